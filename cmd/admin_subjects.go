@@ -0,0 +1,42 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	repo_service "code.gitea.io/gitea/services/repository"
+
+	"github.com/urfave/cli/v3"
+)
+
+var (
+	subcmdSubjects = &cli.Command{
+		Name:  "subjects",
+		Usage: "Maintain subjects and their articles",
+		Commands: []*cli.Command{
+			microcmdSubjectsReconcileRoots,
+		},
+	}
+
+	microcmdSubjectsReconcileRoots = &cli.Command{
+		Name:   "reconcile-roots",
+		Usage:  "Convert all but the oldest root article of each subject to forks of it",
+		Action: runSubjectsReconcileRoots,
+	}
+)
+
+func runSubjectsReconcileRoots(ctx context.Context, _ *cli.Command) error {
+	if err := initDB(ctx); err != nil {
+		return err
+	}
+
+	converted, err := repo_service.ReconcileSubjectRoots(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Converted %d repositories to forks of their subject root\n", converted)
+	return nil
+}
@@ -1,3 +1,7 @@
 module github.com/okTurtles/forkana/custom/services/article-creator
 
 go 1.25.1
+
+require github.com/okTurtles/forkana/custom/services/progress v0.0.0
+
+replace github.com/okTurtles/forkana/custom/services/progress => ../progress
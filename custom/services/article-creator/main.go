@@ -15,6 +15,7 @@ package main
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
 	"flag"
@@ -26,8 +27,25 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/okTurtles/forkana/custom/services/progress"
+)
+
+// defaultCommitMessage is the message of the README.md commit if --commit-message isn't set
+const defaultCommitMessage = "Import article from Wikipedia"
+
+const (
+	// maxRateLimitRetries is how often a request rejected with 429 Too Many Requests is retried
+	maxRateLimitRetries = 5
+	// defaultRateLimitBackoff is the pause after a 429 response without a usable Retry-After header.
+	// It doubles with every retry of the same request, up to maxRateLimitBackoff.
+	defaultRateLimitBackoff = 2 * time.Second
+	maxRateLimitBackoff     = 5 * time.Minute
 )
 
 // Pre-compiled regexes for createSlug (Issue 5: avoid recompiling in hot path)
@@ -42,6 +60,21 @@ type config struct {
 	inputPath string
 	private   bool
 	rateDelay time.Duration
+
+	// branch is the branch README.md is committed to; empty means the instance default
+	branch        string
+	commitMessage string
+
+	// update commits the content of a file to README.md of its repository if it exists already
+	update bool
+
+	// include and exclude filter the Markdown files of an input directory by name
+	include patternList
+	exclude patternList
+
+	progress         bool
+	progressEvery    int
+	progressInterval time.Duration
 }
 
 type stats struct {
@@ -49,6 +82,11 @@ type stats struct {
 	created   int
 	failed    int
 	skipped   int
+	excluded  int
+	// updated and unchanged count the existing repositories whose README.md was, or already was
+	// up to date in --update mode
+	updated   int
+	unchanged int
 }
 
 type giteaClient struct {
@@ -57,17 +95,76 @@ type giteaClient struct {
 	httpClient *http.Client
 	stats      stats
 	rateDelay  time.Duration
+	progress   *progress.Reporter
+
+	branch        string
+	commitMessage string
+	update        bool
+	filter        *fileFilter
+
+	// throttle pauses all requests after the instance rate-limited one of them
+	throttle rateLimiter
+}
+
+// rateLimiter holds back all requests of a client until the time an instance asked to wait
+// for in a Retry-After header has passed. The zero value is ready to use.
+type rateLimiter struct {
+	mu    sync.Mutex
+	until time.Time
+	sleep func(time.Duration) // time.Sleep if nil, replaced in tests
+}
+
+// wait blocks until requests may be sent again
+func (l *rateLimiter) wait() {
+	l.mu.Lock()
+	d := time.Until(l.until)
+	sleep := l.sleep
+	l.mu.Unlock()
+
+	if d <= 0 {
+		return
+	}
+	if sleep == nil {
+		sleep = time.Sleep
+	}
+	sleep(d)
+}
+
+// pause holds back all requests for at least d
+func (l *rateLimiter) pause(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if until := time.Now().Add(d); until.After(l.until) {
+		l.until = until
+	}
+}
+
+// retryAfter returns how long to wait before retrying a rate-limited request, from the value of
+// its Retry-After header (delay in seconds or HTTP date), or an exponential backoff by attempt
+// if the header is missing or invalid
+func retryAfter(header string, attempt int) time.Duration {
+	if header != "" {
+		if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+			return min(time.Duration(seconds)*time.Second, maxRateLimitBackoff)
+		}
+		if t, err := http.ParseTime(header); err == nil {
+			return min(max(time.Until(t), 0), maxRateLimitBackoff)
+		}
+	}
+	return min(defaultRateLimitBackoff<<attempt, maxRateLimitBackoff)
 }
 
 type createRepoRequest struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Subject     string `json:"subject"`
-	Private     bool   `json:"private"`
-	AutoInit    bool   `json:"auto_init"`
-	Gitignores  string `json:"gitignores"`
-	License     string `json:"license"`
-	Readme      string `json:"readme"`
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	Subject       string `json:"subject"`
+	SubjectLang   string `json:"subject_lang,omitempty"`
+	Private       bool   `json:"private"`
+	AutoInit      bool   `json:"auto_init"`
+	Gitignores    string `json:"gitignores"`
+	License       string `json:"license"`
+	Readme        string `json:"readme"`
+	DefaultBranch string `json:"default_branch,omitempty"`
 }
 
 type commitDateOptions struct {
@@ -82,12 +179,30 @@ type createFileRequest struct {
 	Dates   commitDateOptions `json:"dates"`
 }
 
+// updateFileRequest replaces the content of the file whose blob is SHA
+type updateFileRequest struct {
+	createFileRequest
+	SHA string `json:"sha"`
+}
+
+// fileContents is the part of a contents API response needed to compare a file
+type fileContents struct {
+	SHA      string `json:"sha"`
+	Encoding string `json:"encoding"`
+	Content  string `json:"content"`
+}
+
 type userInfo struct {
 	Login string `json:"login"`
 }
 
 type repoInfo struct {
-	HTMLURL string `json:"html_url"`
+	HTMLURL       string `json:"html_url"`
+	DefaultBranch string `json:"default_branch"`
+}
+
+type repoSettings struct {
+	DefaultBranch string `json:"default_branch"`
 }
 
 func main() {
@@ -97,6 +212,14 @@ func main() {
 	flag.StringVar(&cfg.inputPath, "input", os.Getenv("GITEA_INPUT_PATH"), "Path to Markdown file or directory")
 	flag.BoolVar(&cfg.private, "private", os.Getenv("GITEA_PRIVATE") == "true", "Create private repositories")
 	flag.DurationVar(&cfg.rateDelay, "delay", 500*time.Millisecond, "Delay between API calls")
+	flag.StringVar(&cfg.branch, "branch", os.Getenv("GITEA_BRANCH"), "Branch to commit README.md to (default: the instance's default branch)")
+	flag.StringVar(&cfg.commitMessage, "commit-message", defaultCommitMessage, "Commit message of the README.md commit")
+	flag.BoolVar(&cfg.update, "update", false, "Update README.md of existing repositories whose content changed instead of skipping them")
+	flag.Var(&cfg.include, "include", "Only process Markdown files of the input directory whose name matches this glob, or regex if prefixed with 're:' (repeatable)")
+	flag.Var(&cfg.exclude, "exclude", "Skip Markdown files of the input directory whose name matches this glob, or regex if prefixed with 're:' (repeatable)")
+	flag.BoolVar(&cfg.progress, "progress", false, "Print periodic progress lines with ETA to stderr")
+	flag.IntVar(&cfg.progressEvery, "progress-every", 10, "Emit a progress line every N files; 0 disables (requires --progress)")
+	flag.DurationVar(&cfg.progressInterval, "progress-interval", 30*time.Second, "Emit a progress line on this interval; 0 disables (requires --progress)")
 	flag.Parse()
 
 	// Validate required arguments
@@ -109,6 +232,15 @@ func main() {
 	if cfg.inputPath == "" {
 		log.Fatal("Error: --input is required (or set GITEA_INPUT_PATH environment variable)")
 	}
+	if strings.TrimSpace(cfg.commitMessage) == "" {
+		log.Fatal("Error: --commit-message must not be empty")
+	}
+	if cfg.progressEvery < 0 {
+		log.Fatal("Error: --progress-every must not be negative")
+	}
+	if cfg.progressInterval < 0 {
+		log.Fatal("Error: --progress-interval must not be negative")
+	}
 
 	// Parse rate delay from environment if not set via flag
 	if !isFlagSet("delay") {
@@ -135,11 +267,24 @@ func isFlagSet(name string) bool {
 }
 
 func run(cfg config) error {
+	filter, err := newFileFilter(cfg.include, cfg.exclude)
+	if err != nil {
+		return err
+	}
+
 	client := &giteaClient{
 		baseURL:    strings.TrimSuffix(cfg.giteaURL, "/"),
 		apiToken:   cfg.apiToken,
 		httpClient: &http.Client{Timeout: 30 * time.Second},
 		rateDelay:  cfg.rateDelay,
+
+		branch:        cfg.branch,
+		commitMessage: cfg.commitMessage,
+		update:        cfg.update,
+		filter:        filter,
+	}
+	if cfg.progress {
+		client.progress = progress.NewReporter(os.Stderr, cfg.progressEvery, cfg.progressInterval)
 	}
 
 	// Validate connection
@@ -148,6 +293,9 @@ func run(cfg config) error {
 		return fmt.Errorf("connection validation failed: %w", err)
 	}
 	fmt.Printf("✓ Connected to Gitea as user: %s\n", username)
+	if client.branch != "" {
+		fmt.Printf("✓ Committing README.md to branch: %s\n", client.branch)
+	}
 
 	// Determine if input is file or directory
 	info, err := os.Stat(cfg.inputPath)
@@ -179,13 +327,7 @@ func run(cfg config) error {
 }
 
 func (c *giteaClient) validateConnection() (string, error) {
-	req, err := http.NewRequest("GET", c.baseURL+"/api/v1/user", nil)
-	if err != nil {
-		return "", err
-	}
-	c.setAuthHeaders(req)
-
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.apiRequest("GET", c.baseURL+"/api/v1/user", nil)
 	if err != nil {
 		return "", fmt.Errorf("connection error: %w", err)
 	}
@@ -201,12 +343,41 @@ func (c *giteaClient) validateConnection() (string, error) {
 		return "", err
 	}
 
+	if c.branch == "" {
+		// Not fatal: older instances don't report their default branch, in which case
+		// the default branch of each created repository is used
+		branch, err := c.instanceDefaultBranch()
+		if err != nil {
+			fmt.Printf("⚠ Could not fetch the instance's default branch: %v\n", err)
+		}
+		c.branch = branch
+	}
+
 	return user.Login, nil
 }
 
+// instanceDefaultBranch returns the default branch name of new repositories on the instance
+func (c *giteaClient) instanceDefaultBranch() (string, error) {
+	resp, err := c.apiRequest("GET", c.baseURL+"/api/v1/settings/repository", nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var settings repoSettings
+	if err := json.NewDecoder(resp.Body).Decode(&settings); err != nil {
+		return "", err
+	}
+	return settings.DefaultBranch, nil
+}
+
 func (c *giteaClient) processSingleFile(filePath, username string, public bool) (bool, error) {
-	if !strings.HasSuffix(strings.ToLower(filePath), ".md") {
-		return false, fmt.Errorf("file is not a Markdown file: %s", filePath)
+	if !isArticleFile(filePath) {
+		return false, fmt.Errorf("file is not a Markdown, AsciiDoc or Org file: %s", filePath)
 	}
 
 	return c.processFile(filePath, username, public), nil
@@ -220,22 +391,46 @@ func (c *giteaClient) processDirectory(dirPath, username string, public bool) (b
 
 	var mdFiles []string
 	for _, entry := range entries {
-		if !entry.IsDir() && strings.HasSuffix(strings.ToLower(entry.Name()), ".md") {
-			mdFiles = append(mdFiles, filepath.Join(dirPath, entry.Name()))
+		if entry.IsDir() || !isArticleFile(entry.Name()) {
+			continue
+		}
+		if !c.filter.allows(entry.Name()) {
+			c.stats.excluded++
+			continue
 		}
+		mdFiles = append(mdFiles, filepath.Join(dirPath, entry.Name()))
 	}
 
 	if len(mdFiles) == 0 {
+		if c.stats.excluded > 0 {
+			return false, fmt.Errorf("all %d Markdown files in directory %s were excluded", c.stats.excluded, dirPath)
+		}
 		return false, fmt.Errorf("no Markdown files found in directory: %s", dirPath)
 	}
 
-	fmt.Printf("Found %d Markdown files to process\n", len(mdFiles))
+	if c.stats.excluded > 0 {
+		fmt.Printf("Found %d Markdown files to process (%d excluded)\n", len(mdFiles), c.stats.excluded)
+	} else {
+		fmt.Printf("Found %d Markdown files to process\n", len(mdFiles))
+	}
+
+	c.progress.Start(len(mdFiles))
+	defer c.progress.Stop()
 
 	success := false
 	for i, mdFile := range mdFiles {
 		if c.processFile(mdFile, username, public) {
 			success = true
 		}
+		counters := []progress.Counter{
+			{Name: "created", N: c.stats.created},
+			{Name: "skipped", N: c.stats.skipped},
+			{Name: "failed", N: c.stats.failed},
+		}
+		if c.update {
+			counters = append(counters, progress.Counter{Name: "updated", N: c.stats.updated}, progress.Counter{Name: "unchanged", N: c.stats.unchanged})
+		}
+		c.progress.Update(c.stats.processed, counters...)
 
 		if i < len(mdFiles)-1 {
 			time.Sleep(c.rateDelay)
@@ -273,8 +468,8 @@ func (c *giteaClient) processFile(filePath, username string, public bool) bool {
 		return false
 	}
 
-	// Extract title from YAML front matter
-	title := extractYAMLTitle(string(content))
+	// Extract title from the front matter of the file's format
+	title := extractTitle(filePath, string(content))
 	var description string
 	if title != "" {
 		description = title
@@ -285,7 +480,7 @@ func (c *giteaClient) processFile(filePath, username string, public bool) bool {
 		description = strings.TrimSuffix(base, filepath.Ext(base))
 		description = strings.ReplaceAll(description, "_", " ")
 		description = strings.ReplaceAll(description, "-", " ")
-		fmt.Printf("  No YAML title found, using filename as description\n")
+		fmt.Printf("  No title found, using filename as description\n")
 	}
 
 	// Create repository slug
@@ -294,107 +489,176 @@ func (c *giteaClient) processFile(filePath, username string, public bool) bool {
 
 	// Check if repository already exists
 	if c.checkRepoExists(username, repoName) {
+		if c.update {
+			return c.updateArticle(username, repoName, content, fileInfo.ModTime())
+		}
 		fmt.Printf("  ⚠ Repository '%s' already exists, skipping\n", repoName)
 		c.stats.skipped++
 		return false
 	}
 
 	// Create repository
-	repoURL, err := c.createRepository(repoName, description, description, public)
+	repo, err := c.createRepository(repoName, description, description, extractYAMLLang(string(content)), public)
 	if err != nil {
 		fmt.Printf("  ✗ Failed to create repository: %v\n", err)
 		c.stats.failed++
 		return false
 	}
 
+	// The repository's actual default branch wins, so that README.md doesn't end up
+	// on a branch nobody looks at
+	branch := c.branch
+	if repo.DefaultBranch != "" && repo.DefaultBranch != branch {
+		if branch != "" {
+			fmt.Printf("  ⚠ Repository default branch is '%s', committing there instead of '%s'\n", repo.DefaultBranch, branch)
+		}
+		branch = repo.DefaultBranch
+	}
+
 	// Create README.md file with file modification time as commit timestamp.
 	// This reflects when the article was fetched/written to disk.
-	if err := c.createReadmeFile(username, repoName, string(content), fileInfo.ModTime()); err != nil {
+	if err := c.createReadmeFile(username, repoName, branch, string(content), fileInfo.ModTime()); err != nil {
 		fmt.Printf("  ✗ Failed to create README.md: %v\n", err)
 		c.stats.failed++
 		return false
 	}
 
-	fmt.Printf("  ✓ Repository created successfully: %s\n", repoURL)
+	fmt.Printf("  ✓ Repository created successfully: %s\n", repo.HTMLURL)
 	c.stats.created++
 	return true
 }
 
-func (c *giteaClient) checkRepoExists(username, repoName string) bool {
-	apiURL := fmt.Sprintf("%s/api/v1/repos/%s/%s", c.baseURL, url.PathEscape(username), url.PathEscape(repoName))
-	req, err := http.NewRequest("GET", apiURL, nil)
+// updateArticle commits content to README.md of the existing repository repoName, unless
+// README.md has that content already, which would only add an empty commit to its history
+func (c *giteaClient) updateArticle(username, repoName string, content []byte, commitTime time.Time) bool {
+	existing, err := c.getReadmeFile(username, repoName)
 	if err != nil {
+		fmt.Printf("  ✗ Failed to fetch README.md: %v\n", err)
+		c.stats.failed++
 		return false
 	}
-	c.setAuthHeaders(req)
 
-	resp, err := c.httpClient.Do(req)
+	if existing == nil {
+		err = c.createReadmeFile(username, repoName, c.branch, string(content), commitTime)
+	} else {
+		// Content that can't be decoded is treated as changed
+		if existingContent, decodeErr := existing.decode(); decodeErr == nil && sha256.Sum256(existingContent) == sha256.Sum256(content) {
+			fmt.Printf("  ✓ README.md of '%s' is unchanged, skipping\n", repoName)
+			c.stats.unchanged++
+			return true
+		}
+		err = c.updateReadmeFile(username, repoName, existing.SHA, string(content), commitTime)
+	}
 	if err != nil {
+		fmt.Printf("  ✗ Failed to update README.md: %v\n", err)
+		c.stats.failed++
 		return false
 	}
+
+	fmt.Printf("  ✓ README.md of '%s' updated\n", repoName)
+	c.stats.updated++
+	return true
+}
+
+// getReadmeFile returns README.md on the branch of the repository, or nil if it has none
+func (c *giteaClient) getReadmeFile(username, repoName string) (*fileContents, error) {
+	apiURL := fmt.Sprintf("%s/api/v1/repos/%s/%s/contents/README.md", c.baseURL, url.PathEscape(username), url.PathEscape(repoName))
+	if c.branch != "" {
+		apiURL += "?ref=" + url.QueryEscape(c.branch)
+	}
+	resp, err := c.apiRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
 	defer resp.Body.Close()
 
-	return resp.StatusCode == http.StatusOK
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var file fileContents
+	if err := json.NewDecoder(resp.Body).Decode(&file); err != nil {
+		return nil, err
+	}
+	return &file, nil
 }
 
-func (c *giteaClient) createRepository(repoName, description, subject string, public bool) (string, error) {
-	reqData := createRepoRequest{
-		Name:        repoName,
-		Description: description,
-		Subject:     subject,
-		Private:     !public,
-		AutoInit:    false,
-		Gitignores:  "",
-		License:     "",
-		Readme:      "",
+// decode returns the content of the file, which the API encodes in base64
+func (f *fileContents) decode() ([]byte, error) {
+	if f.Encoding != "base64" {
+		return nil, fmt.Errorf("unsupported encoding %q", f.Encoding)
 	}
+	return base64.StdEncoding.DecodeString(f.Content)
+}
 
-	jsonData, err := json.Marshal(reqData)
+func (c *giteaClient) checkRepoExists(username, repoName string) bool {
+	apiURL := fmt.Sprintf("%s/api/v1/repos/%s/%s", c.baseURL, url.PathEscape(username), url.PathEscape(repoName))
+	resp, err := c.apiRequest("GET", apiURL, nil)
 	if err != nil {
-		return "", err
+		return false
 	}
+	defer resp.Body.Close()
 
-	url := fmt.Sprintf("%s/api/v1/user/repos", c.baseURL)
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	return resp.StatusCode == http.StatusOK
+}
+
+func (c *giteaClient) createRepository(repoName, description, subject, lang string, public bool) (*repoInfo, error) {
+	reqData := createRepoRequest{
+		Name:          repoName,
+		Description:   description,
+		Subject:       subject,
+		SubjectLang:   lang,
+		Private:       !public,
+		AutoInit:      false,
+		Gitignores:    "",
+		License:       "",
+		Readme:        "",
+		DefaultBranch: c.branch,
+	}
+
+	jsonData, err := json.Marshal(reqData)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	c.setAuthHeaders(req)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.apiRequest("POST", c.baseURL+"/api/v1/user/repos", jsonData)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusConflict {
-		return "", fmt.Errorf("repository already exists")
+		return nil, fmt.Errorf("repository already exists")
 	}
 
 	if resp.StatusCode != http.StatusCreated {
 		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
 	}
 
 	var repo repoInfo
 	if err := json.NewDecoder(resp.Body).Decode(&repo); err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return repo.HTMLURL, nil
+	return &repo, nil
 }
 
-// createReadmeFile creates the README.md file in the repository.
+// createReadmeFile creates the README.md file on branch of the repository.
 // commitTime is the timestamp to use for the commit (typically the file's modification time).
-func (c *giteaClient) createReadmeFile(username, repoName, content string, commitTime time.Time) error {
+func (c *giteaClient) createReadmeFile(username, repoName, branch, content string, commitTime time.Time) error {
 	contentB64 := base64.StdEncoding.EncodeToString([]byte(content))
 
 	commitTimeStr := commitTime.Format(time.RFC3339)
 
 	reqData := createFileRequest{
-		Message: "Import article from Wikipedia",
+		Message: c.commitMessage,
 		Content: contentB64,
-		Branch:  "main",
+		Branch:  branch,
 		Dates: commitDateOptions{
 			Author:    commitTimeStr,
 			Committer: commitTimeStr,
@@ -407,19 +671,51 @@ func (c *giteaClient) createReadmeFile(username, repoName, content string, commi
 	}
 
 	apiURL := fmt.Sprintf("%s/api/v1/repos/%s/%s/contents/README.md", c.baseURL, url.PathEscape(username), url.PathEscape(repoName))
-	req, err := http.NewRequest("POST", apiURL, bytes.NewBuffer(jsonData))
+	resp, err := c.apiRequest("POST", apiURL, jsonData)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// updateReadmeFile replaces README.md, whose current blob is sha, on the branch of the repository.
+// commitTime is the timestamp to use for the commit (typically the file's modification time).
+func (c *giteaClient) updateReadmeFile(username, repoName, sha, content string, commitTime time.Time) error {
+	commitTimeStr := commitTime.Format(time.RFC3339)
+
+	reqData := updateFileRequest{
+		createFileRequest: createFileRequest{
+			Message: c.commitMessage,
+			Content: base64.StdEncoding.EncodeToString([]byte(content)),
+			Branch:  c.branch,
+			Dates: commitDateOptions{
+				Author:    commitTimeStr,
+				Committer: commitTimeStr,
+			},
+		},
+		SHA: sha,
+	}
+
+	jsonData, err := json.Marshal(reqData)
 	if err != nil {
 		return err
 	}
-	c.setAuthHeaders(req)
 
-	resp, err := c.httpClient.Do(req)
+	apiURL := fmt.Sprintf("%s/api/v1/repos/%s/%s/contents/README.md", c.baseURL, url.PathEscape(username), url.PathEscape(repoName))
+	resp, err := c.apiRequest("PUT", apiURL, jsonData)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusCreated {
+	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
 		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
 	}
@@ -427,6 +723,39 @@ func (c *giteaClient) createReadmeFile(username, repoName, content string, commi
 	return nil
 }
 
+// apiRequest sends a request with body, if not nil, to the Gitea API. A request rejected with
+// 429 Too Many Requests pauses the whole client for the time asked for by the instance and is
+// retried, up to maxRateLimitRetries times, after which the 429 response is returned.
+func (c *giteaClient) apiRequest(method, apiURL string, body []byte) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		c.throttle.wait()
+
+		var reqBody io.Reader
+		if body != nil {
+			reqBody = bytes.NewReader(body)
+		}
+		req, err := http.NewRequest(method, apiURL, reqBody)
+		if err != nil {
+			return nil, err
+		}
+		c.setAuthHeaders(req)
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusTooManyRequests || attempt >= maxRateLimitRetries {
+			return resp, nil
+		}
+
+		delay := retryAfter(resp.Header.Get("Retry-After"), attempt)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		fmt.Printf("  ⚠ Rate limited by the instance, pausing for %s (retry %d/%d)\n", delay, attempt+1, maxRateLimitRetries)
+		c.throttle.pause(delay)
+	}
+}
+
 func (c *giteaClient) setAuthHeaders(req *http.Request) {
 	req.Header.Set("Authorization", "Bearer "+c.apiToken)
 	req.Header.Set("Content-Type", "application/json")
@@ -440,15 +769,188 @@ func (c *giteaClient) printSummary() {
 	fmt.Printf("Files processed: %d\n", c.stats.processed)
 	fmt.Printf("Repositories created: %d\n", c.stats.created)
 	fmt.Printf("Repositories skipped: %d\n", c.stats.skipped)
+	if c.update {
+		fmt.Printf("Repositories updated: %d\n", c.stats.updated)
+		fmt.Printf("Repositories unchanged: %d\n", c.stats.unchanged)
+	}
 	fmt.Printf("Failures: %d\n", c.stats.failed)
+	if c.filter != nil {
+		fmt.Printf("Files excluded: %d\n", c.stats.excluded)
+	}
 
 	if c.stats.processed > 0 {
-		successRate := float64(c.stats.created) / float64(c.stats.processed) * 100
+		succeeded := c.stats.created + c.stats.updated + c.stats.unchanged
+		successRate := float64(succeeded) / float64(c.stats.processed) * 100
 		fmt.Printf("Success rate: %.1f%%\n", successRate)
 	}
 }
 
+// patternList is a flag.Value collecting the values of a repeatable flag
+type patternList []string
+
+func (l *patternList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *patternList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// regexPatternPrefix marks an --include or --exclude pattern as a regular expression
+// instead of a glob
+const regexPatternPrefix = "re:"
+
+// filePattern matches file names against either a glob or a regular expression
+type filePattern struct {
+	glob string
+	re   *regexp.Regexp
+}
+
+func parseFilePattern(pattern string) (filePattern, error) {
+	if expr, ok := strings.CutPrefix(pattern, regexPatternPrefix); ok {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return filePattern{}, fmt.Errorf("invalid regex %q: %w", expr, err)
+		}
+		return filePattern{re: re}, nil
+	}
+	// filepath.Match only reports malformed patterns, validate them before processing starts
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return filePattern{}, fmt.Errorf("invalid glob %q: %w", pattern, err)
+	}
+	return filePattern{glob: pattern}, nil
+}
+
+func (p filePattern) matches(name string) bool {
+	if p.re != nil {
+		return p.re.MatchString(name)
+	}
+	ok, _ := filepath.Match(p.glob, name)
+	return ok
+}
+
+// fileFilter decides which Markdown files of an input directory are processed.
+// A nil fileFilter allows every file.
+type fileFilter struct {
+	include []filePattern
+	exclude []filePattern
+}
+
+// newFileFilter parses the --include and --exclude patterns.
+// Returns nil if no pattern is given.
+func newFileFilter(include, exclude []string) (*fileFilter, error) {
+	if len(include) == 0 && len(exclude) == 0 {
+		return nil, nil
+	}
+	f := &fileFilter{}
+	for _, pattern := range include {
+		p, err := parseFilePattern(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("--include: %w", err)
+		}
+		f.include = append(f.include, p)
+	}
+	for _, pattern := range exclude {
+		p, err := parseFilePattern(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("--exclude: %w", err)
+		}
+		f.exclude = append(f.exclude, p)
+	}
+	return f, nil
+}
+
+// allows reports whether the file named name matches an include pattern, if any are given,
+// and no exclude pattern
+func (f *fileFilter) allows(name string) bool {
+	if f == nil {
+		return true
+	}
+	for _, p := range f.exclude {
+		if p.matches(name) {
+			return false
+		}
+	}
+	if len(f.include) == 0 {
+		return true
+	}
+	for _, p := range f.include {
+		if p.matches(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// articleExtensions are the extensions of the files processed as articles
+var articleExtensions = []string{".md", ".adoc", ".asciidoc", ".org"}
+
+func isArticleFile(name string) bool {
+	return slices.Contains(articleExtensions, strings.ToLower(filepath.Ext(name)))
+}
+
+// extractTitle returns the title of an article, read the way its format (detected by the
+// extension of filePath) declares it. YAML front matter is the default.
+func extractTitle(filePath, content string) string {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".adoc", ".asciidoc":
+		return extractAsciiDocTitle(content)
+	case ".org":
+		return extractOrgTitle(content)
+	default:
+		return extractYAMLTitle(content)
+	}
+}
+
+// asciiDocAttributeRE matches an attribute entry (":name: value") of an AsciiDoc header
+var asciiDocAttributeRE = regexp.MustCompile(`^:!?\w[\w-]*!?:`)
+
+// extractAsciiDocTitle returns the document title ("= Title") of an AsciiDoc header, which
+// may only be preceded by blank lines, comments and attribute entries
+func extractAsciiDocTitle(content string) string {
+	for line := range strings.Lines(content) {
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case strings.TrimSpace(line) == "", strings.HasPrefix(line, "//"), asciiDocAttributeRE.MatchString(line):
+			continue
+		case strings.HasPrefix(line, "= "):
+			return strings.TrimSpace(line[2:])
+		}
+		return ""
+	}
+	return ""
+}
+
+// extractOrgTitle returns the #+TITLE: keyword of an Org document before its first heading.
+// Org joins the values of several #+TITLE: lines, so that long titles can be split.
+func extractOrgTitle(content string) string {
+	var parts []string
+	for line := range strings.Lines(content) {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "* ") || line == "*" {
+			break
+		}
+		if len(line) < len("#+title:") || !strings.EqualFold(line[:len("#+title:")], "#+title:") {
+			continue
+		}
+		if value := strings.TrimSpace(line[len("#+title:"):]); value != "" {
+			parts = append(parts, value)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
 func extractYAMLTitle(content string) string {
+	return extractYAMLField(content, "title")
+}
+
+// extractYAMLLang returns the language code declared in the front matter, if any
+func extractYAMLLang(content string) string {
+	return extractYAMLField(content, "lang")
+}
+
+func extractYAMLField(content, field string) string {
 	if !strings.HasPrefix(content, "---") {
 		return ""
 	}
@@ -463,34 +965,34 @@ func extractYAMLTitle(content string) string {
 
 	yamlContent := content[3 : 3+endIdx]
 
-	// Match title field
-	re := regexp.MustCompile(`(?m)^title:\s*(.+)$`)
+	// Match the field
+	re := regexp.MustCompile(`(?m)^` + regexp.QuoteMeta(field) + `:\s*(.+)$`)
 	matches := re.FindStringSubmatch(yamlContent)
 	if len(matches) < 2 {
 		return ""
 	}
 
-	title := strings.TrimSpace(matches[1])
+	value := strings.TrimSpace(matches[1])
 
 	// Handle quoted strings (must be at least 2 chars to have opening and closing quotes)
-	if len(title) > 1 {
-		if (strings.HasPrefix(title, `"`) && strings.HasSuffix(title, `"`)) ||
-			(strings.HasPrefix(title, `'`) && strings.HasSuffix(title, `'`)) {
-			title = title[1 : len(title)-1]
+	if len(value) > 1 {
+		if (strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`)) ||
+			(strings.HasPrefix(value, `'`) && strings.HasSuffix(value, `'`)) {
+			value = value[1 : len(value)-1]
 			// Unescape quotes
-			title = strings.ReplaceAll(title, `\"`, `"`)
-			title = strings.ReplaceAll(title, `\'`, `'`)
+			value = strings.ReplaceAll(value, `\"`, `"`)
+			value = strings.ReplaceAll(value, `\'`, `'`)
 		}
 	}
 
-	return title
+	return value
 }
 
 func createSlug(filename string) string {
-	// Remove .md extension if present
+	// Remove the article extension if present
 	name := filename
-	if strings.HasSuffix(strings.ToLower(name), ".md") {
-		name = name[:len(name)-3]
+	if isArticleFile(name) {
+		name = strings.TrimSuffix(name, filepath.Ext(name))
 	}
 
 	// Convert to lowercase
@@ -4,7 +4,15 @@
 package main
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestExtractYAMLTitle(t *testing.T) {
@@ -175,6 +183,141 @@ Content`,
 	}
 }
 
+func TestExtractYAMLLang(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "lang field",
+			content:  "---\ntitle: Paris\nlang: fr\n---\n\nContent",
+			expected: "fr",
+		},
+		{
+			name:     "quoted lang field",
+			content:  "---\nlang: \"pt-BR\"\ntitle: Brasil\n---\n",
+			expected: "pt-BR",
+		},
+		{
+			name:     "no lang field",
+			content:  "---\ntitle: Paris\n---\n\nlang: fr",
+			expected: "",
+		},
+		{
+			name:     "no front matter",
+			content:  "lang: fr\n",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := extractYAMLLang(tt.content)
+			if result != tt.expected {
+				t.Errorf("extractYAMLLang() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExtractTitle(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		content  string
+		expected string
+	}{
+		{
+			name:     "markdown YAML title",
+			filePath: "paris.md",
+			content:  "---\ntitle: Paris\n---\n\n# Not the title",
+			expected: "Paris",
+		},
+		{
+			name:     "markdown without front matter",
+			filePath: "paris.md",
+			content:  "# Paris\n",
+			expected: "",
+		},
+		{
+			name:     "asciidoc document title",
+			filePath: "paris.adoc",
+			content:  "= Paris\n:toc:\n\nParis is the capital of France.\n",
+			expected: "Paris",
+		},
+		{
+			name:     "asciidoc title after comments and attributes",
+			filePath: "Paris.ASCIIDOC",
+			content:  "// imported\n:lang: fr\n\n= Paris, France  \r\nBody",
+			expected: "Paris, France",
+		},
+		{
+			name:     "asciidoc keeps the first of several candidates",
+			filePath: "paris.adoc",
+			content:  "= Paris\n\n= Lyon\n",
+			expected: "Paris",
+		},
+		{
+			name:     "asciidoc section heading is not a title",
+			filePath: "paris.adoc",
+			content:  "== History\n\n= Paris\n",
+			expected: "",
+		},
+		{
+			name:     "asciidoc without title",
+			filePath: "paris.adoc",
+			content:  "Paris is the capital of France.\n",
+			expected: "",
+		},
+		{
+			name:     "asciidoc ignores YAML front matter",
+			filePath: "paris.adoc",
+			content:  "---\ntitle: Paris\n---\n",
+			expected: "",
+		},
+		{
+			name:     "org title",
+			filePath: "paris.org",
+			content:  "#+TITLE: Paris\n#+AUTHOR: Someone\n\n* History\n",
+			expected: "Paris",
+		},
+		{
+			name:     "org lowercase keyword",
+			filePath: "paris.org",
+			content:  "#+title:   Paris  \n",
+			expected: "Paris",
+		},
+		{
+			name:     "org joins several title lines",
+			filePath: "paris.org",
+			content:  "#+TITLE: Paris,\n#+TITLE: the capital of France\n",
+			expected: "Paris, the capital of France",
+		},
+		{
+			name:     "org title after the first heading is ignored",
+			filePath: "paris.org",
+			content:  "* History\n#+TITLE: Paris\n",
+			expected: "",
+		},
+		{
+			name:     "org without title",
+			filePath: "paris.org",
+			content:  "#+AUTHOR: Someone\n#+TITLE:\nParis\n",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := extractTitle(tt.filePath, tt.content)
+			if result != tt.expected {
+				t.Errorf("extractTitle(%q) = %q, want %q", tt.filePath, result, tt.expected)
+			}
+		})
+	}
+}
+
 // TestExtractYAMLTitleNoPanic ensures edge cases don't cause panics
 func TestExtractYAMLTitleNoPanic(t *testing.T) {
 	edgeCases := []string{
@@ -206,3 +349,345 @@ title:
 		})
 	}
 }
+
+// fakeGitea is a minimal Gitea API serving the endpoints used to create an article
+type fakeGitea struct {
+	instanceBranch string // reported by /settings/repository
+	repoBranch     string // default branch of created repositories, the requested one if empty
+
+	// rateLimited is the number of repository creations to reject with 429 Too Many Requests
+	rateLimited int
+
+	// readmes is the README.md content of existing repositories by name
+	readmes map[string]string
+
+	createRepo  createRepoRequest
+	createFile  createFileRequest
+	updateFiles map[string]updateFileRequest
+}
+
+func (f *fakeGitea) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v1/user":
+		_ = json.NewEncoder(w).Encode(userInfo{Login: "tester"})
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v1/settings/repository":
+		_ = json.NewEncoder(w).Encode(repoSettings{DefaultBranch: f.instanceBranch})
+	case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/contents/README.md"):
+		readme, ok := f.readmes[strings.Split(r.URL.Path, "/")[5]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(fileContents{
+			SHA:      "sha-" + readme,
+			Encoding: "base64",
+			Content:  base64.StdEncoding.EncodeToString([]byte(readme)),
+		})
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/api/v1/repos/tester/"):
+		if _, ok := f.readmes[strings.TrimPrefix(r.URL.Path, "/api/v1/repos/tester/")]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(repoInfo{})
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v1/user/repos":
+		if f.rateLimited > 0 {
+			f.rateLimited--
+			w.Header().Set("Retry-After", "3")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&f.createRepo)
+		branch := f.repoBranch
+		if branch == "" {
+			branch = f.createRepo.DefaultBranch
+		}
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(repoInfo{HTMLURL: "http://gitea/tester/" + f.createRepo.Name, DefaultBranch: branch})
+	case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/contents/README.md"):
+		_ = json.NewDecoder(r.Body).Decode(&f.createFile)
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "/contents/README.md"):
+		var req updateFileRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if f.updateFiles == nil {
+			f.updateFiles = map[string]updateFileRequest{}
+		}
+		f.updateFiles[strings.Split(r.URL.Path, "/")[5]] = req
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestCreateArticleBranchAndMessage(t *testing.T) {
+	tests := []struct {
+		name           string
+		branch         string
+		commitMessage  string
+		instanceBranch string
+		repoBranch     string
+		expectedBranch string
+	}{
+		{
+			name:           "instance default",
+			commitMessage:  defaultCommitMessage,
+			instanceBranch: "master",
+			expectedBranch: "master",
+		},
+		{
+			name:           "configured branch and message",
+			branch:         "articles",
+			commitMessage:  "Import from the 2025 dump",
+			instanceBranch: "master",
+			expectedBranch: "articles",
+		},
+		{
+			name:           "repository default branch differs",
+			branch:         "articles",
+			commitMessage:  defaultCommitMessage,
+			repoBranch:     "main",
+			expectedBranch: "main",
+		},
+		{
+			name:           "instance doesn't report its default branch",
+			commitMessage:  defaultCommitMessage,
+			repoBranch:     "main",
+			expectedBranch: "main",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &fakeGitea{instanceBranch: tt.instanceBranch, repoBranch: tt.repoBranch}
+			server := httptest.NewServer(api)
+			defer server.Close()
+
+			mdFile := filepath.Join(t.TempDir(), "My_Article.md")
+			if err := os.WriteFile(mdFile, []byte("---\ntitle: My Article\n---\n\nContent\n"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			client := &giteaClient{
+				baseURL:       server.URL,
+				httpClient:    server.Client(),
+				branch:        tt.branch,
+				commitMessage: tt.commitMessage,
+			}
+			username, err := client.validateConnection()
+			if err != nil {
+				t.Fatalf("validateConnection() error = %v", err)
+			}
+			if !client.processFile(mdFile, username, true) {
+				t.Fatalf("processFile() failed, stats = %+v", client.stats)
+			}
+
+			if api.createFile.Branch != tt.expectedBranch {
+				t.Errorf("README.md committed to branch %q, want %q", api.createFile.Branch, tt.expectedBranch)
+			}
+			if api.createFile.Message != tt.commitMessage {
+				t.Errorf("commit message = %q, want %q", api.createFile.Message, tt.commitMessage)
+			}
+		})
+	}
+}
+
+func TestUpdateArticleSkipsUnchanged(t *testing.T) {
+	unchanged := "---\ntitle: Unchanged\n---\n\nContent\n"
+	changed := "---\ntitle: Changed\n---\n\nNew content\n"
+	api := &fakeGitea{
+		instanceBranch: "main",
+		readmes: map[string]string{
+			"unchanged": unchanged,
+			"changed":   "---\ntitle: Changed\n---\n\nOld content\n",
+		},
+	}
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	dir := t.TempDir()
+	for name, content := range map[string]string{"Unchanged.md": unchanged, "Changed.md": changed} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	client := &giteaClient{
+		baseURL:       server.URL,
+		httpClient:    server.Client(),
+		commitMessage: defaultCommitMessage,
+		update:        true,
+	}
+	username, err := client.validateConnection()
+	if err != nil {
+		t.Fatalf("validateConnection() error = %v", err)
+	}
+	if _, err := client.processDirectory(dir, username, true); err != nil {
+		t.Fatalf("processDirectory() error = %v", err)
+	}
+
+	if client.stats.unchanged != 1 || client.stats.updated != 1 || client.stats.failed != 0 {
+		t.Errorf("stats = %+v, want 1 unchanged and 1 updated", client.stats)
+	}
+	if _, ok := api.updateFiles["unchanged"]; ok {
+		t.Error("README.md of the unchanged article was uploaded")
+	}
+	req, ok := api.updateFiles["changed"]
+	if !ok {
+		t.Fatal("README.md of the changed article wasn't uploaded")
+	}
+	if content, _ := base64.StdEncoding.DecodeString(req.Content); string(content) != changed {
+		t.Errorf("uploaded content = %q, want %q", content, changed)
+	}
+	if req.SHA != "sha-"+api.readmes["changed"] {
+		t.Errorf("sha = %q, want the blob of the existing README.md", req.SHA)
+	}
+	if req.Branch != "main" {
+		t.Errorf("branch = %q, want %q", req.Branch, "main")
+	}
+}
+
+func TestCreateRepositoryRateLimited(t *testing.T) {
+	api := &fakeGitea{rateLimited: 2}
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	var pauses []time.Duration
+	client := &giteaClient{
+		baseURL:    server.URL,
+		httpClient: server.Client(),
+	}
+	client.throttle.sleep = func(d time.Duration) {
+		pauses = append(pauses, d)
+		// Let the pause pass without waiting for it
+		client.throttle.until = time.Time{}
+	}
+
+	repo, err := client.createRepository("my-article", "My Article", "My Article", "", true)
+	if err != nil {
+		t.Fatalf("createRepository() error = %v", err)
+	}
+	if repo.HTMLURL != "http://gitea/tester/my-article" {
+		t.Errorf("HTMLURL = %q", repo.HTMLURL)
+	}
+	if api.createRepo.Name != "my-article" {
+		t.Errorf("created repository %q, want %q", api.createRepo.Name, "my-article")
+	}
+	if len(pauses) != 2 {
+		t.Fatalf("paused %d times, want 2", len(pauses))
+	}
+	for _, d := range pauses {
+		if d <= 2*time.Second || d > 3*time.Second {
+			t.Errorf("paused for %s, want the 3s of Retry-After", d)
+		}
+	}
+
+	// The instance keeps rejecting requests: give up after maxRateLimitRetries retries
+	api.rateLimited = maxRateLimitRetries + 1
+	if _, err := client.createRepository("other-article", "Other", "Other", "", true); err == nil {
+		t.Error("createRepository() succeeded while rate limited")
+	}
+	if api.rateLimited != 0 {
+		t.Errorf("%d rate-limited requests left, want 0", api.rateLimited)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		header  string
+		attempt int
+		want    time.Duration
+	}{
+		{header: "7", want: 7 * time.Second},
+		{header: "0", want: 0},
+		{header: "100000", want: maxRateLimitBackoff},
+		{header: "Wed, 21 Oct 2015 07:28:00 GMT", want: 0},
+		{header: "", attempt: 0, want: defaultRateLimitBackoff},
+		{header: "soon", attempt: 2, want: 4 * defaultRateLimitBackoff},
+		{header: "", attempt: 20, want: maxRateLimitBackoff},
+	}
+	for _, tt := range tests {
+		if got := retryAfter(tt.header, tt.attempt); got != tt.want {
+			t.Errorf("retryAfter(%q, %d) = %s, want %s", tt.header, tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestNewFileFilterInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		include []string
+		exclude []string
+	}{
+		{name: "invalid regex", exclude: []string{"re:draft("}},
+		{name: "invalid glob", include: []string{"[a-"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newFileFilter(tt.include, tt.exclude); err == nil {
+				t.Error("newFileFilter() expected an error")
+			}
+		})
+	}
+}
+
+func TestFileFilterAllows(t *testing.T) {
+	tests := []struct {
+		name     string
+		include  []string
+		exclude  []string
+		expected map[string]bool
+	}{
+		{
+			name: "no patterns",
+			expected: map[string]bool{
+				"Physics.md":      true,
+				"CONTRIBUTING.md": true,
+			},
+		},
+		{
+			name:    "exclude globs",
+			exclude: []string{"CONTRIBUTING.md", "TEMPLATE*.md"},
+			expected: map[string]bool{
+				"Physics.md":       true,
+				"CONTRIBUTING.md":  false,
+				"TEMPLATE_stub.md": false,
+				"My_TEMPLATE_x.md": true,
+				"contributing.md":  true,
+			},
+		},
+		{
+			name:    "exclude regex",
+			exclude: []string{"re:(?i)draft"},
+			expected: map[string]bool{
+				"Physics.md":       true,
+				"Physics_draft.md": false,
+				"DRAFT-notes.md":   false,
+			},
+		},
+		{
+			name:    "include with exclude",
+			include: []string{"P*.md", "re:^Chem"},
+			exclude: []string{"*_draft.md"},
+			expected: map[string]bool{
+				"Physics.md":       true,
+				"Physics_draft.md": false,
+				"Chemistry.md":     true,
+				"Biology.md":       false,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := newFileFilter(tt.include, tt.exclude)
+			if err != nil {
+				t.Fatalf("newFileFilter() error = %v", err)
+			}
+			for name, expected := range tt.expected {
+				if got := filter.allows(name); got != expected {
+					t.Errorf("allows(%q) = %v, want %v", name, got, expected)
+				}
+			}
+		})
+	}
+}
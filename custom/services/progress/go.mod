@@ -0,0 +1,3 @@
+module github.com/okTurtles/forkana/custom/services/progress
+
+go 1.25.1
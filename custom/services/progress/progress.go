@@ -0,0 +1,140 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package progress reports the progress of the batch runs of the custom service tools
+// (wiki2md and article-creator) as one-line status reports with counts, rate and ETA.
+package progress
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Counter is a named tally shown on a progress line, e.g. "created=12".
+type Counter struct {
+	Name string
+	N    int
+}
+
+// Reporter writes one-line status reports (counts, rate and ETA) to out
+// every `every` items and, independently, every `interval` from a ticker, so a
+// stalled request still produces output. A zero value for either disables that
+// trigger. A nil *Reporter is valid and reports nothing.
+type Reporter struct {
+	mu       sync.Mutex
+	out      io.Writer
+	every    int
+	interval time.Duration
+	now      func() time.Time
+
+	total    int
+	done     int
+	counters []Counter
+	started  time.Time
+	lastDone int
+
+	stopTicker chan struct{}
+	tickerDone chan struct{}
+}
+
+// NewReporter creates a Reporter writing to out.
+func NewReporter(out io.Writer, every int, interval time.Duration) *Reporter {
+	return &Reporter{
+		out:      out,
+		every:    every,
+		interval: interval,
+		now:      time.Now,
+	}
+}
+
+// Start begins a run of total items and launches the interval ticker.
+func (p *Reporter) Start(total int) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	p.total = total
+	p.done = 0
+	p.lastDone = 0
+	p.counters = nil
+	p.started = p.now()
+	p.mu.Unlock()
+
+	if p.interval <= 0 {
+		return
+	}
+	p.stopTicker = make(chan struct{})
+	p.tickerDone = make(chan struct{})
+	go func() {
+		defer close(p.tickerDone)
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				p.mu.Lock()
+				p.writeLocked()
+				p.mu.Unlock()
+			case <-p.stopTicker:
+				return
+			}
+		}
+	}()
+}
+
+// Update records a snapshot of the counters after an item finishes and emits
+// a line when the item threshold is reached or the run is complete.
+func (p *Reporter) Update(done int, counters ...Counter) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done = done
+	p.counters = counters
+	if done >= p.total || (p.every > 0 && done-p.lastDone >= p.every) {
+		p.lastDone = done
+		p.writeLocked()
+	}
+}
+
+// Stop shuts down the interval ticker. It is safe to call more than once.
+func (p *Reporter) Stop() {
+	if p == nil || p.stopTicker == nil {
+		return
+	}
+	close(p.stopTicker)
+	<-p.tickerDone
+	p.stopTicker = nil
+}
+
+func (p *Reporter) writeLocked() {
+	fmt.Fprintln(p.out, Format(p.done, p.total, p.counters, p.now().Sub(p.started)))
+}
+
+// Format renders a single progress line. The ETA is extrapolated from
+// the average rate so far and is omitted until at least one item has finished.
+func Format(done, total int, counters []Counter, elapsed time.Duration) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[progress] %d/%d", done, total)
+	if total > 0 {
+		fmt.Fprintf(&b, " (%.1f%%)", float64(done)/float64(total)*100)
+	}
+	for _, c := range counters {
+		fmt.Fprintf(&b, " %s=%d", c.Name, c.N)
+	}
+
+	if done > 0 && elapsed > 0 {
+		rate := float64(done) / elapsed.Seconds()
+		fmt.Fprintf(&b, " rate=%.2f/s", rate)
+		if remaining := total - done; remaining > 0 {
+			eta := time.Duration(float64(remaining) / rate * float64(time.Second))
+			fmt.Fprintf(&b, " eta=%s", eta.Round(time.Second))
+		}
+	}
+	fmt.Fprintf(&b, " elapsed=%s", elapsed.Round(time.Second))
+	return b.String()
+}
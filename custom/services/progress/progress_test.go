@@ -0,0 +1,136 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package progress
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFormatProgress(t *testing.T) {
+	tests := []struct {
+		name     string
+		done     int
+		total    int
+		counters []Counter
+		elapsed  time.Duration
+		expected string
+	}{
+		{
+			name:     "nothing done yet",
+			done:     0,
+			total:    10,
+			counters: []Counter{{"converted", 0}, {"skipped", 0}},
+			elapsed:  0,
+			expected: "[progress] 0/10 (0.0%) converted=0 skipped=0 elapsed=0s",
+		},
+		{
+			name:     "halfway",
+			done:     5,
+			total:    10,
+			counters: []Counter{{"converted", 4}, {"skipped", 1}},
+			elapsed:  10 * time.Second,
+			expected: "[progress] 5/10 (50.0%) converted=4 skipped=1 rate=0.50/s eta=10s elapsed=10s",
+		},
+		{
+			name:     "finished has no eta",
+			done:     10,
+			total:    10,
+			counters: []Counter{{"converted", 9}, {"skipped", 1}},
+			elapsed:  20 * time.Second,
+			expected: "[progress] 10/10 (100.0%) converted=9 skipped=1 rate=0.50/s elapsed=20s",
+		},
+		{
+			name:     "zero total has no percentage",
+			done:     0,
+			total:    0,
+			counters: nil,
+			elapsed:  time.Second,
+			expected: "[progress] 0/0 elapsed=1s",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Format(tt.done, tt.total, tt.counters, tt.elapsed)
+			if result != tt.expected {
+				t.Errorf("Format() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestReporterEvery(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewReporter(&buf, 3, 0)
+
+	p.Start(7)
+	for i := 1; i <= 7; i++ {
+		p.Update(i, Counter{"converted", i})
+	}
+	p.Stop()
+
+	// Lines are expected after items 3 and 6 (every=3) and after the final item.
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 progress lines, got %d: %q", len(lines), buf.String())
+	}
+	for i, prefix := range []string{"[progress] 3/7", "[progress] 6/7", "[progress] 7/7"} {
+		if !strings.HasPrefix(lines[i], prefix) {
+			t.Errorf("line %d = %q, want prefix %q", i, lines[i], prefix)
+		}
+	}
+}
+
+func TestReporterInterval(t *testing.T) {
+	var buf syncBuffer
+	p := NewReporter(&buf, 0, 5*time.Millisecond)
+
+	// No item finishes, so any output must come from the ticker.
+	p.Start(100)
+	p.Update(1, Counter{"converted", 1})
+	time.Sleep(50 * time.Millisecond)
+	p.Stop()
+
+	if !strings.HasPrefix(buf.String(), "[progress] 1/100") {
+		t.Errorf("expected interval-triggered line, got %q", buf.String())
+	}
+
+	// Nothing is written after stop.
+	n := len(buf.String())
+	time.Sleep(20 * time.Millisecond)
+	if len(buf.String()) != n {
+		t.Errorf("progress written after stop: %q", buf.String())
+	}
+}
+
+func TestReporterNil(t *testing.T) {
+	var p *Reporter
+	// Must not panic when --progress is disabled.
+	p.Start(10)
+	p.Update(1, Counter{"converted", 1})
+	p.Stop()
+}
+
+// syncBuffer is a bytes.Buffer that is safe to read while the reporter's
+// ticker goroutine writes to it.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
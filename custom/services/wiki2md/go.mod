@@ -2,10 +2,15 @@ module github.com/okTurtles/forkana/custom/services/wiki2md
 
 go 1.25.1
 
-require github.com/JohannesKaufmann/html-to-markdown/v2 v2.4.0
+require (
+	github.com/JohannesKaufmann/html-to-markdown/v2 v2.4.0
+	github.com/okTurtles/forkana/custom/services/progress v0.0.0
+)
 
 require (
 	github.com/JohannesKaufmann/dom v0.2.0 // indirect
 	golang.org/x/net v0.43.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+replace github.com/okTurtles/forkana/custom/services/progress => ../progress
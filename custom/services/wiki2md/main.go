@@ -14,29 +14,106 @@
 package main
 
 import (
+	"compress/gzip"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"hash/fnv"
 	"io"
 	"log"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 	"unicode/utf8"
 
 	htmltomarkdown "github.com/JohannesKaufmann/html-to-markdown/v2"
+	"github.com/okTurtles/forkana/custom/services/progress"
 )
 
 const (
-	wikiAPI  = "https://en.wikipedia.org/w/api.php"
-	wikiREST = "https://en.wikipedia.org/api/rest_v1"
+	defaultWikiAPI  = "https://en.wikipedia.org/w/api.php"
+	defaultWikiREST = "https://en.wikipedia.org/api/rest_v1"
 )
 
-const userAgent = "wiki2md/1.0 (Gitea; +https://github.com/go-gitea/gitea)"
+// wikiAPI and wikiREST are the endpoints of the MediaWiki instance, set by --api-url and --rest-url
+var (
+	wikiAPI  = defaultWikiAPI
+	wikiREST = defaultWikiREST
+)
+
+// Policies for images from hosts outside the allowlist, selected with --image-policy
+const (
+	imagePolicyRewrite = "rewrite"
+	imagePolicyDrop    = "drop"
+	imagePolicyKeep    = "keep"
+)
+
+// version is the wiki2md version, sent in the User-Agent and recorded in run manifests
+const version = "1.0"
+
+const userAgent = "wiki2md/" + version + " (Gitea; +https://github.com/go-gitea/gitea)"
+
+// wikiBaseURL, wikiLang and fullWikiURLRE describe the wiki the articles are fetched from, they
+// are derived from the host of --rest-url by setWikiBaseURL
+var (
+	wikiBaseURL = "https://en.wikipedia.org"
+	// wikiLang is the language of the wiki, taken from the language subdomain of its host, e.g. "en"
+	// for en.wikipedia.org; it is empty if the host has none
+	wikiLang = "en"
+	// fullWikiURLRE matches full URLs of articles of the wiki, including those of its other
+	// language editions, e.g. https://fr.wikipedia.org/wiki/Article for en.wikipedia.org
+	fullWikiURLRE = wikiArticleURLRE("en.wikipedia.org")
+)
+
+// wikiLangRE matches the language subdomain of the host of a wiki, e.g. "en" or "zh-yue"
+var wikiLangRE = regexp.MustCompile(`^[a-z]{2,3}(?:-[a-z]+)*$`)
+
+// setWikiBaseURL points the source URLs, language and internal link rewriting at the wiki at
+// baseURL, the scheme and host of its REST API
+func setWikiBaseURL(baseURL string) error {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return err
+	}
+	host := strings.ToLower(u.Hostname())
+	wikiBaseURL = baseURL
+	wikiLang = wikiLangFromHost(host)
+	fullWikiURLRE = wikiArticleURLRE(host)
+	return nil
+}
+
+// wikiLangFromHost returns the language subdomain of the host of a wiki, or an empty string if it
+// has none, e.g. for wiki.example.org
+func wikiLangFromHost(host string) string {
+	labels := strings.Split(host, ".")
+	if len(labels) < 3 || labels[0] == "www" || !wikiLangRE.MatchString(labels[0]) {
+		return ""
+	}
+	return labels[0]
+}
+
+// wikiArticleURLRE returns a regular expression matching the full URLs of articles on host, in
+// any language edition if host has a language subdomain. It captures the article name.
+func wikiArticleURLRE(host string) *regexp.Regexp {
+	hostPattern := regexp.QuoteMeta(host)
+	if lang := wikiLangFromHost(host); lang != "" {
+		hostPattern = `[a-z]{2,3}(?:-[a-z]+)*\.` + regexp.QuoteMeta(strings.TrimPrefix(host, lang+"."))
+	}
+	return regexp.MustCompile(`(?i)^https?:\/\/` + hostPattern + `\/wiki\/(.+)$`)
+}
+
+// Output formats selected with --format
+const (
+	formatMarkdown = "markdown"
+	formatJSON     = "json"
+)
 
 var (
 	client = &http.Client{Timeout: 30 * time.Second}
@@ -59,15 +136,116 @@ const (
 type skipReason string
 
 const (
-	skipRedirect     skipReason = "redirect"
-	skipEmptyContent skipReason = "empty_content"
+	skipRedirect       skipReason = "redirect"
+	skipEmptyContent   skipReason = "empty_content"
+	skipDisambiguation skipReason = "disambiguation"
+	skipDuplicatePage  skipReason = "duplicate_page"
+)
+
+// processStage names the step of processArticle an article failed or was skipped in
+type processStage string
+
+const (
+	stageRedirectCheck processStage = "redirect_check"
+	stageFetch         processStage = "fetch"
+	stageConvert       processStage = "convert"
+	stageWrite         processStage = "write"
+)
+
+// stage returns the step of processArticle that detects the skip reason
+func (r skipReason) stage() processStage {
+	if r == skipEmptyContent {
+		return stageFetch
+	}
+	return stageRedirectCheck
+}
+
+// articleError is an error returned by processArticle, recording the stage it occurred in
+type articleError struct {
+	stage processStage
+	err   error
+}
+
+func (e *articleError) Error() string { return e.err.Error() }
+func (e *articleError) Unwrap() error { return e.err }
+
+// errorStage returns the stage an error returned by processArticle occurred in, or "" if unknown
+func errorStage(err error) processStage {
+	var articleErr *articleError
+	if errors.As(err, &articleErr) {
+		return articleErr.stage
+	}
+	return ""
+}
+
+// Log formats selected with --log-format
+const (
+	logFormatText = "text"
+	logFormatJSON = "json"
 )
 
+// articleLog writes the entries of errors.log and skipped.log, either as
+// tab-separated lines or, with --log-format json, as one JSON object per line
+type articleLog struct {
+	w      io.Writer
+	format string
+}
+
+// logEntry is a line of an article log written in the JSON format
+type logEntry struct {
+	Title  string       `json:"title"`
+	Error  string       `json:"error,omitempty"`
+	Reason skipReason   `json:"reason,omitempty"`
+	Stage  processStage `json:"stage,omitempty"`
+}
+
+func (l articleLog) logError(title string, err error) {
+	if l.format == logFormatJSON {
+		l.writeJSON(logEntry{Title: title, Error: err.Error(), Stage: errorStage(err)})
+		return
+	}
+	fmt.Fprintf(l.w, "%s\t%v\n", title, err)
+}
+
+func (l articleLog) logSkip(title string, reason skipReason) {
+	if l.format == logFormatJSON {
+		l.writeJSON(logEntry{Title: title, Reason: reason, Stage: reason.stage()})
+		return
+	}
+	fmt.Fprintf(l.w, "%s\t%s\n", title, reason)
+}
+
+func (l articleLog) writeJSON(entry logEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		log.Printf("failed to marshal log entry for %q: %v", entry.Title, err)
+		return
+	}
+	fmt.Fprintf(l.w, "%s\n", data)
+}
+
 type config struct {
 	outputDir     string
 	count         int
 	category      string
+	perCategory   int
 	sleepInterval time.Duration
+	format        string
+	jsonSingle    string
+	gzip          bool
+	leadOnly      bool
+	logFormat     string
+	shard         shard
+	dedupePageID  bool
+
+	apiURL      string
+	restURL     string
+	imageHosts  string
+	imagePolicy string
+
+	progress         bool
+	progressEvery    int
+	progressInterval time.Duration
 }
 
 type articleRecord struct {
@@ -77,20 +255,190 @@ type articleRecord struct {
 	FetchedAt string `json:"fetched_at"`
 }
 
+// articleDocument is an article written in the JSON output format
+type articleDocument struct {
+	Title     string `json:"title"`
+	Source    string `json:"source"`
+	Lang      string `json:"lang"`
+	FetchedAt string `json:"fetched_at"`
+	Revision  int64  `json:"revision,omitempty"`
+	Markdown  string `json:"markdown"`
+}
+
+// output describes where and in which format converted articles are written
+type output struct {
+	dir      string
+	format   string
+	gzip     bool // compress Markdown files
+	leadOnly bool // write only the lead section of articles
+
+	// jsonStream, when set, receives every JSON document as one line instead of
+	// individual .json files; jsonStreamName is recorded as saved_as in the index
+	jsonStream     io.Writer
+	jsonStreamName string
+
+	images imageOptions
+
+	// pages, when set, skips the titles of pages already converted under another title
+	pages pageDeduper
+}
+
+// imageOptions decides what normalizeImageURLs does with the images of an article.
+// The zero value resolves relative URLs against Wikipedia and rewrites all the images.
+type imageOptions struct {
+	// baseURL is the scheme and host relative image URLs are resolved against
+	baseURL string
+	// hosts are the hosts images are expected from, besides the host of baseURL
+	hosts []string
+	// policy is what happens to images from other hosts: they are rewritten like the
+	// others, dropped, or kept as they are
+	policy string
+}
+
 func main() {
 	cfg := config{}
 	flag.StringVar(&cfg.outputDir, "out", "out_md", "Output directory for Markdown files")
 	flag.IntVar(&cfg.count, "count", 1000, "Number of articles to fetch")
-	flag.StringVar(&cfg.category, "category", "", "Wikipedia category to fetch from (e.g., 'Category:Physics')")
+	flag.StringVar(&cfg.category, "category", "", "Wikipedia category to fetch from (e.g., 'Category:Physics'); separate several categories with commas")
+	flag.IntVar(&cfg.perCategory, "per-category-limit", 0, "Maximum number of articles taken from each top-level category, subcategories included; 0 disables")
 	flag.DurationVar(&cfg.sleepInterval, "sleep", 100*time.Millisecond, "Sleep duration between API requests")
+	flag.StringVar(&cfg.format, "format", formatMarkdown, "Output format: 'markdown' or 'json'")
+	flag.BoolVar(&cfg.leadOnly, "lead-only", false, "Write only the lead section (the introduction before the first heading) of each article")
+	flag.BoolVar(&cfg.gzip, "gzip", false, "Write gzip-compressed Markdown files (.md.gz)")
+	flag.StringVar(&cfg.jsonSingle, "json-single", "", "With --format json, append all articles to this JSONL file in the output directory instead of writing one .json file per article")
+	flag.StringVar(&cfg.apiURL, "api-url", defaultWikiAPI, "URL of the Action API (api.php) of the MediaWiki instance")
+	flag.StringVar(&cfg.restURL, "rest-url", defaultWikiREST, "URL of the REST API of the MediaWiki instance, whose host relative image URLs are resolved against")
+	flag.StringVar(&cfg.imageHosts, "image-hosts", "upload.wikimedia.org", "Comma-separated hosts images are expected from, besides the host of --rest-url")
+	flag.StringVar(&cfg.imagePolicy, "image-policy", imagePolicyRewrite, "What to do with images from other hosts: 'rewrite' like the others, 'drop' or 'keep' untouched")
+	flag.Var(&cfg.shard, "shard", "Process only the titles of shard N of M ('N/M', N from 0 to M-1), written to a shard-N-of-M subdirectory of the output directory")
+	flag.BoolVar(&cfg.dedupePageID, "dedupe-pageid", false, "Skip titles resolving to the page ID of an article already converted under another title (e.g. differently capitalized), besides deduplicating titles")
+	flag.StringVar(&cfg.logFormat, "log-format", logFormatText, "Format of errors.log and skipped.log: 'text' (tab-separated) or 'json' (one object per line)")
+	flag.BoolVar(&cfg.progress, "progress", false, "Print periodic progress lines with ETA to stderr")
+	flag.IntVar(&cfg.progressEvery, "progress-every", 25, "Emit a progress line every N articles; 0 disables (requires --progress)")
+	flag.DurationVar(&cfg.progressInterval, "progress-interval", 30*time.Second, "Emit a progress line on this interval; 0 disables (requires --progress)")
 	flag.Parse()
 
+	if cfg.perCategory < 0 {
+		log.Fatal("Error: --per-category-limit must not be negative")
+	}
+	if cfg.progressEvery < 0 {
+		log.Fatal("Error: --progress-every must not be negative")
+	}
+	if cfg.progressInterval < 0 {
+		log.Fatal("Error: --progress-interval must not be negative")
+	}
+	if cfg.format != formatMarkdown && cfg.format != formatJSON {
+		log.Fatalf("Error: --format must be '%s' or '%s'", formatMarkdown, formatJSON)
+	}
+	if cfg.logFormat != logFormatText && cfg.logFormat != logFormatJSON {
+		log.Fatalf("Error: --log-format must be '%s' or '%s'", logFormatText, logFormatJSON)
+	}
+	if cfg.jsonSingle != "" && cfg.format != formatJSON {
+		log.Fatal("Error: --json-single requires --format json")
+	}
+	if cfg.gzip && cfg.format != formatMarkdown {
+		log.Fatal("Error: --gzip is only supported with --format markdown")
+	}
+	if cfg.imagePolicy != imagePolicyRewrite && cfg.imagePolicy != imagePolicyDrop && cfg.imagePolicy != imagePolicyKeep {
+		log.Fatalf("Error: --image-policy must be '%s', '%s' or '%s'", imagePolicyRewrite, imagePolicyDrop, imagePolicyKeep)
+	}
+	for _, flagURL := range []struct{ name, value string }{{"--api-url", cfg.apiURL}, {"--rest-url", cfg.restURL}} {
+		if _, err := parseBaseURL(flagURL.value); err != nil {
+			log.Fatalf("Error: %s: %v", flagURL.name, err)
+		}
+	}
+
 	if err := run(cfg); err != nil {
 		log.Fatalf("Error: %v", err)
 	}
 }
 
+// runStats tallies the outcome of the articles processed by a run
+type runStats struct {
+	Titles     int `json:"titles"`
+	Converted  int `json:"converted"`
+	Skipped    int `json:"skipped"`
+	Errors     int `json:"errors"`
+	Redirects  int `json:"redirects"`
+	Empty      int `json:"empty"`
+	Disambigs  int `json:"disambiguation"`
+	Duplicates int `json:"duplicates"`
+}
+
+// runManifest describes a run: the tool version, the configuration it was
+// started with and its results, making an output directory self-describing
+type runManifest struct {
+	Tool       string         `json:"tool"`
+	Version    string         `json:"version"`
+	Config     manifestConfig `json:"config"`
+	StartedAt  string         `json:"started_at"`
+	FinishedAt string         `json:"finished_at"`
+	Stats      runStats       `json:"stats"`
+}
+
+// manifestConfig is the part of the configuration recorded in a run manifest
+type manifestConfig struct {
+	Lang             string `json:"lang"`
+	Category         string `json:"category,omitempty"`
+	PerCategoryLimit int    `json:"per_category_limit,omitempty"`
+	Count            int    `json:"count"`
+	Sleep            string `json:"sleep"`
+	Format           string `json:"format"`
+	JSONSingle       string `json:"json_single,omitempty"`
+	Gzip             bool   `json:"gzip"`
+	LeadOnly         bool   `json:"lead_only"`
+	Shard            string `json:"shard,omitempty"`
+	DedupePageID     bool   `json:"dedupe_pageid,omitempty"`
+}
+
+func newRunManifest(cfg config, started, finished time.Time, stats runStats) runManifest {
+	return runManifest{
+		Tool:    "wiki2md",
+		Version: version,
+		Config: manifestConfig{
+			Lang:             wikiLang,
+			Category:         cfg.category,
+			PerCategoryLimit: cfg.perCategory,
+			Count:            cfg.count,
+			Sleep:            cfg.sleepInterval.String(),
+			Format:           cfg.format,
+			JSONSingle:       cfg.jsonSingle,
+			Gzip:             cfg.gzip,
+			LeadOnly:         cfg.leadOnly,
+			Shard:            cfg.shard.String(),
+			DedupePageID:     cfg.dedupePageID,
+		},
+		StartedAt:  started.UTC().Format(time.RFC3339),
+		FinishedAt: finished.UTC().Format(time.RFC3339),
+		Stats:      stats,
+	}
+}
+
+// writeManifest writes the manifest to manifest.json in dir, replacing the
+// manifest of any earlier run into the same directory
+func writeManifest(dir string, manifest runManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "manifest.json"), append(data, '\n'), 0o644)
+}
+
 func run(cfg config) error {
+	started := time.Now()
+
+	wikiAPI, wikiREST = cfg.apiURL, cfg.restURL
+	baseURL, err := parseBaseURL(cfg.restURL)
+	if err != nil {
+		return fmt.Errorf("invalid REST API URL: %w", err)
+	}
+	if err := setWikiBaseURL(baseURL); err != nil {
+		return fmt.Errorf("invalid REST API URL: %w", err)
+	}
+
+	// Shards write to their own subdirectory, so that crawls into a shared directory don't collide
+	cfg.outputDir = cfg.shard.outputDir(cfg.outputDir)
+
 	// Create output directory
 	if err := os.MkdirAll(cfg.outputDir, 0o755); err != nil {
 		return fmt.Errorf("failed to create output directory: %w", err)
@@ -98,9 +446,8 @@ func run(cfg config) error {
 
 	// Discover article titles
 	var titles []string
-	var err error
 	if cfg.category != "" {
-		titles, err = getCategoryMembers(cfg.category, cfg.count, cfg.sleepInterval)
+		titles, err = getCategoryMembers(parseCategories(cfg.category), cfg.count, cfg.perCategory, cfg.sleepInterval)
 		if err != nil {
 			return fmt.Errorf("failed to get category members: %w", err)
 		}
@@ -122,6 +469,10 @@ func run(cfg config) error {
 
 	// Deduplicate and filter redirects
 	titles = deduplicateTitles(titles)
+	if cfg.shard.count > 1 {
+		titles = cfg.shard.filter(titles)
+		fmt.Printf("Shard %s: processing %d titles\n", cfg.shard.String(), len(titles))
+	}
 
 	// Open index file
 	indexPath := filepath.Join(cfg.outputDir, "index.jsonl")
@@ -147,34 +498,61 @@ func run(cfg config) error {
 	}
 	defer skipLog.Close()
 
+	errs := articleLog{w: errorLog, format: cfg.logFormat}
+	skips := articleLog{w: skipLog, format: cfg.logFormat}
+
+	out := output{dir: cfg.outputDir, format: cfg.format, gzip: cfg.gzip, leadOnly: cfg.leadOnly}
+	out.images = imageOptions{baseURL: baseURL, hosts: parseImageHosts(cfg.imageHosts), policy: cfg.imagePolicy}
+	if cfg.dedupePageID {
+		out.pages = pageDeduper{}
+	}
+	if cfg.jsonSingle != "" {
+		streamFile, err := os.OpenFile(filepath.Join(cfg.outputDir, cfg.jsonSingle), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return fmt.Errorf("failed to open JSON stream: %w", err)
+		}
+		defer streamFile.Close()
+		out.jsonStream = streamFile
+		out.jsonStreamName = cfg.jsonSingle
+	}
+
 	// Fetch and convert articles with detailed tracking
-	var stats struct {
-		converted int
-		skipped   int
-		errors    int
-		redirects int
-		empty     int
+	stats := runStats{Titles: len(titles)}
+
+	var reporter *progress.Reporter
+	if cfg.progress {
+		reporter = progress.NewReporter(os.Stderr, cfg.progressEvery, cfg.progressInterval)
 	}
+	reporter.Start(len(titles))
+	defer reporter.Stop()
 
 	for i, title := range titles {
-		result, reason, err := processArticle(title, cfg.outputDir, indexFile)
+		result, reason, err := processArticle(title, out, indexFile)
 
 		switch result {
 		case resultSuccess:
-			stats.converted++
+			stats.Converted++
 		case resultSkipped:
-			stats.skipped++
-			fmt.Fprintf(skipLog, "%s\t%s\n", title, reason)
+			stats.Skipped++
+			skips.logSkip(title, reason)
 			switch reason {
 			case skipRedirect:
-				stats.redirects++
+				stats.Redirects++
 			case skipEmptyContent:
-				stats.empty++
+				stats.Empty++
+			case skipDisambiguation:
+				stats.Disambigs++
+			case skipDuplicatePage:
+				stats.Duplicates++
 			}
 		case resultError:
-			stats.errors++
-			fmt.Fprintf(errorLog, "%s\t%v\n", title, err)
+			stats.Errors++
+			errs.logError(title, err)
 		}
+		reporter.Update(i+1,
+			progress.Counter{Name: "converted", N: stats.Converted},
+			progress.Counter{Name: "skipped", N: stats.Skipped},
+			progress.Counter{Name: "errors", N: stats.Errors})
 
 		if i < len(titles)-1 {
 			time.Sleep(cfg.sleepInterval)
@@ -183,30 +561,40 @@ func run(cfg config) error {
 
 	// Print summary
 	fmt.Printf("Done. Processed %d articles in: %s\n", len(titles), cfg.outputDir)
-	fmt.Printf("  Converted: %d\n", stats.converted)
-	fmt.Printf("  Skipped:   %d (redirects: %d, empty: %d)\n", stats.skipped, stats.redirects, stats.empty)
-	if stats.errors > 0 {
-		fmt.Printf("  Errors:    %d (see %s)\n", stats.errors, errorLogPath)
+	fmt.Printf("  Converted: %d\n", stats.Converted)
+	fmt.Printf("  Skipped:   %d (redirects: %d, empty: %d, disambiguation: %d, duplicates: %d)\n", stats.Skipped, stats.Redirects, stats.Empty, stats.Disambigs, stats.Duplicates)
+	if stats.Errors > 0 {
+		fmt.Printf("  Errors:    %d (see %s)\n", stats.Errors, errorLogPath)
+	}
+
+	if err := writeManifest(cfg.outputDir, newRunManifest(cfg, started, time.Now(), stats)); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
 	}
 	return nil
 }
 
 // processArticle fetches and converts a Wikipedia article to Markdown.
 // It returns the processing result and any skip reason or error.
-func processArticle(title, outputDir string, indexFile io.Writer) (processResult, skipReason, error) {
-	// Check if redirect
-	isRedir, err := isRedirect(title)
+func processArticle(title string, out output, indexFile io.Writer) (processResult, skipReason, error) {
+	// Check if redirect or disambiguation page (a single API request)
+	info, err := getPageInfo(title)
 	if err != nil {
-		return resultError, "", fmt.Errorf("redirect check failed: %w", err)
+		return resultError, "", &articleError{stageRedirectCheck, fmt.Errorf("page info check failed: %w", err)}
 	}
-	if isRedir {
+	if info.redirect {
 		return resultSkipped, skipRedirect, nil
 	}
+	if info.disambiguation {
+		return resultSkipped, skipDisambiguation, nil
+	}
+	if out.pages.duplicate(title, info.pageID) {
+		return resultSkipped, skipDuplicatePage, nil
+	}
 
 	// Fetch HTML
-	htmlContent, err := getParsoidHTML(title)
+	htmlContent, revision, err := getParsoidHTML(title)
 	if err != nil {
-		return resultError, "", fmt.Errorf("failed to fetch HTML: %w", err)
+		return resultError, "", &articleError{stageFetch, fmt.Errorf("failed to fetch HTML: %w", err)}
 	}
 	if htmlContent == "" {
 		return resultSkipped, skipEmptyContent, nil
@@ -215,34 +603,58 @@ func processArticle(title, outputDir string, indexFile io.Writer) (processResult
 	// Convert to Markdown
 	md, err := htmlToMarkdown(htmlContent)
 	if err != nil {
-		return resultError, "", fmt.Errorf("failed to convert to markdown: %w", err)
+		return resultError, "", &articleError{stageConvert, fmt.Errorf("failed to convert to markdown: %w", err)}
+	}
+
+	// Keep only the introduction if requested
+	if out.leadOnly {
+		md = leadSection(md)
 	}
 
 	// Normalize list markers (replace hyphen-based markers with asterisks)
 	md = normalizeListMarkers(md)
 
 	// Normalize image URLs
-	md = normalizeImageURLs(md)
+	md = normalizeImageURLs(md, out.images)
 
 	// Normalize internal Wikipedia links to subject-based URLs
 	md = normalizeInternalLinks(md)
 
-	// Generate unique filename
-	filename, err := writeMarkdown(outputDir, title, md)
-	if err != nil {
-		return resultError, "", fmt.Errorf("failed to write markdown: %w", err)
+	source := wikiBaseURL + "/wiki/" + url.PathEscape(strings.ReplaceAll(title, " ", "_"))
+	fetchedAt := time.Now().UTC().Format("2006-01-02T15:04:05Z")
+
+	// Write the article in the requested format
+	var filename string
+	switch out.format {
+	case formatJSON:
+		filename, err = writeJSON(out, articleDocument{
+			Title:     title,
+			Source:    source,
+			Lang:      wikiLang,
+			FetchedAt: fetchedAt,
+			Revision:  revision,
+			Markdown:  md,
+		})
+		if err != nil {
+			return resultError, "", &articleError{stageWrite, fmt.Errorf("failed to write json: %w", err)}
+		}
+	default:
+		filename, err = writeMarkdown(out.dir, title, md, out.gzip)
+		if err != nil {
+			return resultError, "", &articleError{stageWrite, fmt.Errorf("failed to write markdown: %w", err)}
+		}
 	}
 
 	// Write to index
 	record := articleRecord{
 		Title:     title,
-		Source:    fmt.Sprintf("https://en.wikipedia.org/wiki/%s", url.PathEscape(strings.ReplaceAll(title, " ", "_"))),
+		Source:    source,
 		SavedAs:   filename,
-		FetchedAt: time.Now().UTC().Format("2006-01-02T15:04:05Z"),
+		FetchedAt: fetchedAt,
 	}
 	recordJSON, err := json.Marshal(record)
 	if err != nil {
-		return resultError, "", fmt.Errorf("failed to marshal record: %w", err)
+		return resultError, "", &articleError{stageWrite, fmt.Errorf("failed to marshal record: %w", err)}
 	}
 	fmt.Fprintf(indexFile, "%s\n", recordJSON)
 
@@ -291,121 +703,241 @@ func getRandomTitles(count int, sleepInterval time.Duration) ([]string, error) {
 	return titles, nil
 }
 
-func getCategoryMembers(category string, limit int, sleepInterval time.Duration) ([]string, error) {
+// categoryMember is a page listed in a category
+type categoryMember struct {
+	NS    int    `json:"ns"`
+	Title string `json:"title"`
+}
+
+// categoryPageFunc fetches one page of the members of a category, returning the
+// continuation token of the next page or "" when the listing is exhausted
+type categoryPageFunc func(category, cmcontinue string) ([]categoryMember, string, error)
+
+// fetchCategoryPage lists the members of a category with the MediaWiki API
+func fetchCategoryPage(category, cmcontinue string) ([]categoryMember, string, error) {
+	params := url.Values{
+		"action":  {"query"},
+		"list":    {"categorymembers"},
+		"cmtitle": {category},
+		"cmlimit": {"500"},
+		"format":  {"json"},
+	}
+	if cmcontinue != "" {
+		params.Set("cmcontinue", cmcontinue)
+	}
+
+	var result struct {
+		Query struct {
+			CategoryMembers []categoryMember `json:"categorymembers"`
+		} `json:"query"`
+		Continue struct {
+			CMContinue string `json:"cmcontinue"`
+		} `json:"continue"`
+	}
+
+	if err := apiRequest(wikiAPI, params, &result); err != nil {
+		return nil, "", err
+	}
+	return result.Query.CategoryMembers, result.Continue.CMContinue, nil
+}
+
+func getCategoryMembers(categories []string, limit, perCategoryLimit int, sleepInterval time.Duration) ([]string, error) {
+	return traverseCategories(categories, limit, perCategoryLimit, fetchCategoryPage, sleepInterval)
+}
+
+// traverseCategories collects up to limit article titles from the given top-level
+// categories and their subcategories. When perCategoryLimit is positive, each
+// top-level category contributes at most that many titles, so a huge category
+// cannot crowd out the others. Subcategories visited for one top-level category
+// are not traversed again for another, and titles are never collected twice.
+func traverseCategories(categories []string, limit, perCategoryLimit int, fetchPage categoryPageFunc, sleepInterval time.Duration) ([]string, error) {
 	var titles []string
 	visited := make(map[string]bool)
-	stack := []string{category}
-
-	for len(stack) > 0 && len(titles) < limit {
-		cat := stack[len(stack)-1]
-		stack = stack[:len(stack)-1]
+	seen := make(map[string]bool)
 
-		if visited[cat] {
-			continue
+	for _, category := range categories {
+		if len(titles) >= limit {
+			break
+		}
+		quota := limit - len(titles)
+		if perCategoryLimit > 0 {
+			quota = min(quota, perCategoryLimit)
 		}
-		visited[cat] = true
 
-		cmcontinue := ""
-		for {
-			params := url.Values{
-				"action":  {"query"},
-				"list":    {"categorymembers"},
-				"cmtitle": {cat},
-				"cmlimit": {"500"},
-				"format":  {"json"},
-			}
-			if cmcontinue != "" {
-				params.Set("cmcontinue", cmcontinue)
-			}
+		taken := 0
+		stack := []string{category}
+		for len(stack) > 0 && taken < quota {
+			cat := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
 
-			var result struct {
-				Query struct {
-					CategoryMembers []struct {
-						NS    int    `json:"ns"`
-						Title string `json:"title"`
-					} `json:"categorymembers"`
-				} `json:"query"`
-				Continue struct {
-					CMContinue string `json:"cmcontinue"`
-				} `json:"continue"`
+			if visited[cat] {
+				continue
 			}
+			visited[cat] = true
 
-			if err := apiRequest(wikiAPI, params, &result); err != nil {
-				return nil, err
-			}
+			cmcontinue := ""
+			for {
+				members, next, err := fetchPage(cat, cmcontinue)
+				if err != nil {
+					return nil, err
+				}
 
-			for _, m := range result.Query.CategoryMembers {
-				if m.NS == 14 { // Category
-					stack = append(stack, m.Title)
-				} else if m.NS == 0 { // Article
-					if len(titles) < limit {
-						titles = append(titles, m.Title)
+				for _, m := range members {
+					if m.NS == 14 { // Category
+						stack = append(stack, m.Title)
+					} else if m.NS == 0 { // Article
+						if taken < quota && !seen[m.Title] {
+							seen[m.Title] = true
+							titles = append(titles, m.Title)
+							taken++
+						}
 					}
 				}
+
+				cmcontinue = next
+				if cmcontinue == "" || taken >= quota {
+					break
+				}
+				time.Sleep(sleepInterval)
 			}
 
-			cmcontinue = result.Continue.CMContinue
-			if cmcontinue == "" || len(titles) >= limit {
-				break
+			if len(stack) > 0 && taken < quota {
+				time.Sleep(sleepInterval)
 			}
 		}
+	}
 
-		if len(stack) > 0 || len(titles) < limit {
-			time.Sleep(sleepInterval)
+	return titles, nil
+}
+
+// parseCategories splits the comma-separated --category value into category titles
+func parseCategories(value string) []string {
+	var categories []string
+	for _, c := range strings.Split(value, ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			categories = append(categories, c)
+		}
+	}
+	return categories
+}
+
+// parseImageHosts splits the comma-separated --image-hosts value into lowercase host names.
+// A scheme, port or path given with a host is ignored.
+func parseImageHosts(value string) []string {
+	var hosts []string
+	for _, h := range strings.Split(value, ",") {
+		h = strings.TrimSpace(h)
+		if h == "" {
+			continue
+		}
+		if u, err := url.Parse(h); err == nil && u.Host != "" {
+			h = u.Host
+		} else {
+			h, _, _ = strings.Cut(h, "/")
+		}
+		if hostname, _, err := net.SplitHostPort(h); err == nil {
+			h = hostname
 		}
+		hosts = append(hosts, strings.ToLower(h))
 	}
+	return hosts
+}
+
+// pageInfo holds the properties of an article that decide whether it is worth converting
+type pageInfo struct {
+	redirect       bool
+	disambiguation bool
+	// pageID identifies the page the title resolves to; 0 if it is unknown (e.g. missing pages)
+	pageID int64
+}
+
+// pageInfoResponse is the MediaWiki API response to the query made by getPageInfo
+type pageInfoResponse struct {
+	Query struct {
+		Redirects []struct{} `json:"redirects"`
+		Pages     map[string]struct {
+			PageID    int64             `json:"pageid"`
+			PageProps map[string]string `json:"pageprops"`
+		} `json:"pages"`
+	} `json:"query"`
+}
 
-	return titles[:min(len(titles), limit)], nil
+// info interprets the response. Disambiguation pages carry the "disambiguation"
+// page property (with an empty value); pages of a redirect are those of its target.
+func (r *pageInfoResponse) info() pageInfo {
+	info := pageInfo{redirect: len(r.Query.Redirects) > 0}
+	for _, page := range r.Query.Pages {
+		if _, ok := page.PageProps["disambiguation"]; ok {
+			info.disambiguation = true
+		}
+		info.pageID = page.PageID
+	}
+	return info
 }
 
-func isRedirect(title string) (bool, error) {
+// getPageInfo checks whether an article is a redirect or a disambiguation page, and which
+// page its title resolves to. All are answered by the same query, so this costs a single
+// API request per article.
+func getPageInfo(title string) (pageInfo, error) {
 	params := url.Values{
 		"action":    {"query"},
 		"titles":    {title},
 		"redirects": {""},
+		"prop":      {"pageprops"},
+		"ppprop":    {"disambiguation"},
 		"format":    {"json"},
 	}
 
-	var result struct {
-		Query struct {
-			Redirects []struct{} `json:"redirects"`
-		} `json:"query"`
-	}
-
+	var result pageInfoResponse
 	if err := apiRequest(wikiAPI, params, &result); err != nil {
-		return false, err
+		return pageInfo{}, err
 	}
-
-	return len(result.Query.Redirects) > 0, nil
+	return result.info(), nil
 }
 
-func getParsoidHTML(title string) (string, error) {
+// getParsoidHTML fetches the HTML of an article and the revision it was rendered from.
+// A missing article returns empty HTML; an unknown revision is returned as 0.
+func getParsoidHTML(title string) (string, int64, error) {
 	urlPath := fmt.Sprintf("%s/page/html/%s", wikiREST, url.PathEscape(strings.ReplaceAll(title, " ", "_")))
 	req, err := http.NewRequest("GET", urlPath, nil)
 	if err != nil {
-		return "", err
+		return "", 0, err
 	}
 	req.Header.Set("User-Agent", userAgent)
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return "", err
+		return "", 0, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusNotFound {
-		return "", nil
+		return "", 0, nil
 	}
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return "", 0, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", err
+		return "", 0, err
 	}
 
-	return string(body), nil
+	return string(body), revisionFromETag(resp.Header.Get("ETag")), nil
+}
+
+// revisionFromETag extracts the revision ID from the ETag of a Parsoid response,
+// which has the form "<revision>/<render id>", optionally weak (W/"...").
+// It returns 0 if the ETag doesn't contain a revision.
+func revisionFromETag(etag string) int64 {
+	etag = strings.TrimPrefix(etag, "W/")
+	etag = strings.Trim(etag, `"`)
+	revision, _, _ := strings.Cut(etag, "/")
+	id, err := strconv.ParseInt(revision, 10, 64)
+	if err != nil || id < 0 {
+		return 0
+	}
+	return id
 }
 
 func htmlToMarkdown(htmlContent string) (string, error) {
@@ -416,6 +948,32 @@ func htmlToMarkdown(htmlContent string) (string, error) {
 	return md, nil
 }
 
+// sectionHeadingRE matches an ATX heading of level 2 or deeper, which starts the
+// first section after the lead of an article.
+var sectionHeadingRE = regexp.MustCompile(`^#{2,6}(?:[ \t]|$)`)
+
+// leadSection returns the lead section of an article: everything before its first
+// "##" heading, ignoring headings inside fenced code blocks. If the article has no
+// such heading, or nothing but whitespace precedes it, the whole article is returned.
+func leadSection(md string) string {
+	inFence := false
+	offset := 0
+	for line := range strings.SplitAfterSeq(md, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+		} else if !inFence && sectionHeadingRE.MatchString(strings.TrimRight(line, "\r\n")) {
+			lead := strings.TrimRight(md[:offset], " \t\r\n")
+			if lead == "" {
+				return md
+			}
+			return lead + "\n"
+		}
+		offset += len(line)
+	}
+	return md
+}
+
 // listMarkerRE matches unordered list items that start with a hyphen.
 // It captures optional leading whitespace, the hyphen, and ensures it's followed by a space.
 // This pattern only matches at the start of a line to avoid affecting mid-sentence hyphens.
@@ -446,8 +1004,6 @@ var (
 	relativeWikiLinkRE = regexp.MustCompile(`^\.\/(.+)$`)
 	// Matches /wiki/Article_Name (absolute path)
 	absoluteWikiPathRE = regexp.MustCompile(`^\/wiki\/(.+)$`)
-	// Matches full Wikipedia URLs like https://en.wikipedia.org/wiki/Article
-	fullWikiURLRE = regexp.MustCompile(`^https?:\/\/[a-z]{2,3}\.wikipedia\.org\/wiki\/(.+)$`)
 )
 
 // normalizeInternalLinks transforms internal Wikipedia links to subject-based URLs.
@@ -541,7 +1097,7 @@ func extractWikiArticleName(linkURL string) string {
 		return matches[1]
 	}
 
-	// Check for full Wikipedia URLs
+	// Check for full URLs of the wiki, see setWikiBaseURL
 	if matches := fullWikiURLRE.FindStringSubmatch(linkURL); len(matches) == 2 {
 		return matches[1]
 	}
@@ -559,7 +1115,15 @@ func stripLinkTitle(linkURL string) string {
 	return linkURL
 }
 
-func normalizeImageURLs(md string) string {
+// normalizeImageURLs makes the URLs of the images of md absolute, resolving relative URLs against
+// the base URL of opts. Images from a host that is neither the base host nor one of the hosts
+// of opts are handled according to its policy.
+func normalizeImageURLs(md string, opts imageOptions) string {
+	baseURL := opts.baseURL
+	if baseURL == "" {
+		baseURL = wikiBaseURL
+	}
+
 	return imgEmbedRE.ReplaceAllStringFunc(md, func(match string) string {
 		parts := imgEmbedRE.FindStringSubmatch(match)
 		if len(parts) != 3 {
@@ -580,16 +1144,54 @@ func normalizeImageURLs(md string) string {
 			imgURL = "https:" + imgURL
 		} else if !strings.HasPrefix(imgURL, "http://") && !strings.HasPrefix(imgURL, "https://") {
 			if strings.HasPrefix(imgURL, "/") {
-				imgURL = "https://en.wikipedia.org" + imgURL
+				imgURL = baseURL + imgURL
 			} else {
-				imgURL = "https://en.wikipedia.org/" + imgURL
+				imgURL = baseURL + "/" + imgURL
+			}
+		}
+
+		if opts.policy != "" && opts.policy != imagePolicyRewrite && !opts.allowsHost(imgURL, baseURL) {
+			if opts.policy == imagePolicyDrop {
+				return ""
 			}
+			return match
 		}
 
 		return fmt.Sprintf("![%s](%s)", alt, imgURL)
 	})
 }
 
+// allowsHost reports whether the absolute image URL imgURL is on the host of baseURL or on one
+// of the hosts of the options
+func (opts imageOptions) allowsHost(imgURL, baseURL string) bool {
+	u, err := url.Parse(imgURL)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	if base, err := url.Parse(baseURL); err == nil && strings.EqualFold(base.Hostname(), host) {
+		return true
+	}
+	for _, h := range opts.hosts {
+		if strings.EqualFold(h, host) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseBaseURL returns the scheme and host of the absolute URL rawURL, e.g. "https://en.wikipedia.org"
+func parseBaseURL(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("%q is not an absolute http(s) URL", rawURL)
+	}
+	return u.Scheme + "://" + u.Host, nil
+}
+
 // escapeYAMLString escapes a string for use in a double-quoted YAML value.
 // It handles backslashes, quotes, and control characters that could break YAML parsing.
 func escapeYAMLString(s string) string {
@@ -617,6 +1219,17 @@ func truncateToByteLimit(s string, maxBytes int) string {
 	return string(runes)
 }
 
+// maxFilenameBytes is the file name length limit of common filesystems
+const maxFilenameBytes = 255
+
+// maxBaseNameBytes returns the byte limit for the base name of an output file with the
+// given (possibly compound) extension, leaving room for the extension and the "_N"
+// counter added by getUniqueFilename.
+func maxBaseNameBytes(ext string) int {
+	const counterBytes = len("_10000")
+	return min(200, maxFilenameBytes-len(ext)-counterBytes)
+}
+
 func safeFilename(title string, maxLength int) string {
 	// Replace problematic characters with underscores (using pre-compiled regex)
 	name := safeFilenameRE.ReplaceAllString(title, "_")
@@ -664,8 +1277,10 @@ func safeFilename(title string, maxLength int) string {
 	return name
 }
 
-func getUniqueFilename(outputDir, baseName string) string {
-	fname := baseName + ".md"
+// getUniqueFilename returns baseName+ext, or baseName_N+ext if that file already exists
+// in outputDir. ext may be a compound extension such as ".md.gz".
+func getUniqueFilename(outputDir, baseName, ext string) string {
+	fname := baseName + ext
 	path := filepath.Join(outputDir, fname)
 
 	_, err := os.Stat(path)
@@ -680,7 +1295,7 @@ func getUniqueFilename(outputDir, baseName string) string {
 	// File exists or error occurred, add counter with bounds checking
 	const maxAttempts = 10000
 	for counter := 1; counter <= maxAttempts; counter++ {
-		fname = fmt.Sprintf("%s_%d.md", baseName, counter)
+		fname = fmt.Sprintf("%s_%d%s", baseName, counter, ext)
 		path = filepath.Join(outputDir, fname)
 		_, err := os.Stat(path)
 		if err != nil {
@@ -693,18 +1308,68 @@ func getUniqueFilename(outputDir, baseName string) string {
 	}
 
 	// Fallback with timestamp to ensure uniqueness
-	return fmt.Sprintf("%s_%d.md", baseName, time.Now().UnixNano())
+	return fmt.Sprintf("%s_%d%s", baseName, time.Now().UnixNano(), ext)
 }
 
-func writeMarkdown(outputDir, title, md string) (string, error) {
-	baseName := safeFilename(title, 200)
-	filename := getUniqueFilename(outputDir, baseName)
+// writeMarkdown writes an article as a Markdown file, gzip-compressed if compress is set,
+// and returns the name of the written file.
+func writeMarkdown(outputDir, title, md string, compress bool) (string, error) {
+	ext := ".md"
+	if compress {
+		ext = ".md.gz"
+	}
+	baseName := safeFilename(title, maxBaseNameBytes(ext))
+	filename := getUniqueFilename(outputDir, baseName, ext)
 	path := filepath.Join(outputDir, filename)
 
-	if err := os.WriteFile(path, []byte(md), 0o644); err != nil {
+	if !compress {
+		if err := os.WriteFile(path, []byte(md), 0o644); err != nil {
+			return "", err
+		}
+		return filename, nil
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return "", err
+	}
+	zw := gzip.NewWriter(f)
+	zw.Name = baseName + ".md"
+	if _, err := io.WriteString(zw, md); err != nil {
+		zw.Close()
+		f.Close()
 		return "", err
 	}
+	if err := zw.Close(); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+	return filename, nil
+}
+
+// writeJSON writes an article in the JSON format, either as a line of the JSON stream of out
+// or as its own .json file. It returns the name to record in the index.
+func writeJSON(out output, doc articleDocument) (string, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+
+	if out.jsonStream != nil {
+		if _, err := fmt.Fprintf(out.jsonStream, "%s\n", data); err != nil {
+			return "", err
+		}
+		return out.jsonStreamName, nil
+	}
 
+	baseName := safeFilename(doc.Title, maxBaseNameBytes(".json"))
+	filename := getUniqueFilename(out.dir, baseName, ".json")
+	if err := os.WriteFile(filepath.Join(out.dir, filename), data, 0o644); err != nil {
+		return "", err
+	}
 	return filename, nil
 }
 
@@ -722,6 +1387,94 @@ func deduplicateTitles(titles []string) []string {
 	return result
 }
 
+// pageDeduper records the page ID of every title it is asked about, so that a page
+// reachable under several titles (e.g. differently capitalized, which deduplicateTitles
+// doesn't catch) is converted once. A nil pageDeduper reports no duplicates.
+type pageDeduper map[int64]string
+
+// duplicate reports whether pageID was seen under another title before, and records it
+// otherwise. Titles without a page ID are left to the title deduplication.
+func (d pageDeduper) duplicate(title string, pageID int64) bool {
+	if d == nil || pageID == 0 {
+		return false
+	}
+	if first, ok := d[pageID]; ok {
+		return first != title
+	}
+	d[pageID] = title
+	return false
+}
+
+// shard selects the part of the titles an instance processes when a crawl is split across
+// machines. The zero value selects all the titles.
+type shard struct {
+	index int
+	count int
+}
+
+// String returns the shard as "N/M", or "" if it selects all the titles
+func (s *shard) String() string {
+	if s.count == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d", s.index, s.count)
+}
+
+// Set parses a --shard value of the form "N/M"
+func (s *shard) Set(value string) error {
+	n, m, ok := strings.Cut(value, "/")
+	if !ok {
+		return fmt.Errorf("%q must be of the form N/M", value)
+	}
+	index, err := strconv.Atoi(n)
+	if err != nil {
+		return fmt.Errorf("invalid shard number %q", n)
+	}
+	count, err := strconv.Atoi(m)
+	if err != nil {
+		return fmt.Errorf("invalid shard count %q", m)
+	}
+	if count < 1 {
+		return errors.New("the shard count must be at least 1")
+	}
+	if index < 0 || index >= count {
+		return fmt.Errorf("the shard number must be between 0 and %d", count-1)
+	}
+	s.index, s.count = index, count
+	return nil
+}
+
+// includes reports whether title belongs to the shard. The hash doesn't depend on the
+// machine or the order of the titles, so the shards of the same titles are disjoint
+// and together cover all of them.
+func (s shard) includes(title string) bool {
+	if s.count <= 1 {
+		return true
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(title))
+	return int(h.Sum32()%uint32(s.count)) == s.index
+}
+
+// filter returns the titles belonging to the shard
+func (s shard) filter(titles []string) []string {
+	var result []string
+	for _, title := range titles {
+		if s.includes(title) {
+			result = append(result, title)
+		}
+	}
+	return result
+}
+
+// outputDir returns the directory the shard writes to within dir
+func (s shard) outputDir(dir string) string {
+	if s.count <= 1 {
+		return dir
+	}
+	return filepath.Join(dir, fmt.Sprintf("shard-%d-of-%d", s.index, s.count))
+}
+
 func apiRequest(apiURL string, params url.Values, result interface{}) error {
 	req, err := http.NewRequest("GET", apiURL+"?"+params.Encode(), nil)
 	if err != nil {
@@ -4,8 +4,18 @@
 package main
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 	"unicode/utf8"
 )
 
@@ -322,6 +332,7 @@ func TestSkipReasonStrings(t *testing.T) {
 	}{
 		{skipRedirect, "redirect"},
 		{skipEmptyContent, "empty_content"},
+		{skipDisambiguation, "disambiguation"},
 	}
 
 	for _, tt := range tests {
@@ -333,7 +344,7 @@ func TestSkipReasonStrings(t *testing.T) {
 
 func TestSkipReasonLoggable(t *testing.T) {
 	// Verify skip reasons can be formatted for logging
-	reasons := []skipReason{skipRedirect, skipEmptyContent}
+	reasons := []skipReason{skipRedirect, skipEmptyContent, skipDisambiguation}
 
 	for _, reason := range reasons {
 		// Should be non-empty
@@ -490,7 +501,7 @@ func TestNormalizeImageURLs(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := normalizeImageURLs(tt.input)
+			result := normalizeImageURLs(tt.input, imageOptions{})
 			if result != tt.expected {
 				t.Errorf("normalizeImageURLs(%q) = %q, want %q", tt.input, result, tt.expected)
 			}
@@ -498,6 +509,59 @@ func TestNormalizeImageURLs(t *testing.T) {
 	}
 }
 
+func TestNormalizeImageURLsPolicy(t *testing.T) {
+	inputs := []string{
+		"![a](//upload.example.org/a.png)",
+		"![b](/images/b.png)",
+		"![c](https://tracker.example.com/c.png)",
+		"![](//cdn.example.net/d.png)",
+	}
+	// The images from the wiki and upload.example.org are rewritten under every policy
+	allowed := []string{
+		"![a](https://upload.example.org/a.png)",
+		"![b](https://wiki.example.org/images/b.png)",
+	}
+	tests := []struct {
+		policy   string
+		expected []string
+	}{
+		{
+			policy:   imagePolicyRewrite,
+			expected: append(allowed, "![c](https://tracker.example.com/c.png)", "![image](https://cdn.example.net/d.png)"),
+		},
+		{
+			policy:   imagePolicyDrop,
+			expected: append(allowed, "", ""),
+		},
+		{
+			policy:   imagePolicyKeep,
+			expected: append(allowed, "![c](https://tracker.example.com/c.png)", "![](//cdn.example.net/d.png)"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			opts := imageOptions{baseURL: "https://wiki.example.org", hosts: []string{"Upload.Example.org"}, policy: tt.policy}
+			for i, input := range inputs {
+				if result := normalizeImageURLs(input, opts); result != tt.expected[i] {
+					t.Errorf("normalizeImageURLs(%q) = %q, want %q", input, result, tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseBaseURL(t *testing.T) {
+	if got, err := parseBaseURL("https://wiki.example.org/api/rest_v1"); err != nil || got != "https://wiki.example.org" {
+		t.Errorf("parseBaseURL() = %q, %v, want %q", got, err, "https://wiki.example.org")
+	}
+	for _, invalid := range []string{"", "wiki.example.org/w/api.php", "ftp://wiki.example.org"} {
+		if _, err := parseBaseURL(invalid); err == nil {
+			t.Errorf("parseBaseURL(%q) succeeded", invalid)
+		}
+	}
+}
+
 func TestNormalizeInternalLinks(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -731,3 +795,614 @@ func TestExtractWikiArticleName(t *testing.T) {
 		})
 	}
 }
+
+func TestRevisionFromETag(t *testing.T) {
+	tests := []struct {
+		etag     string
+		expected int64
+	}{
+		{`"1234567/0f4b2a10-6c1e-11ef-8a1c-3c9f5f6b2c11"`, 1234567},
+		{`W/"1234567/0f4b2a10-6c1e-11ef-8a1c-3c9f5f6b2c11"`, 1234567},
+		{`"42"`, 42},
+		{"", 0},
+		{`"not-a-revision/abc"`, 0},
+		{`"-5/abc"`, 0},
+	}
+
+	for _, tt := range tests {
+		if got := revisionFromETag(tt.etag); got != tt.expected {
+			t.Errorf("revisionFromETag(%q) = %d, want %d", tt.etag, got, tt.expected)
+		}
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	doc := articleDocument{
+		Title:     "Go (programming language)",
+		Source:    "https://en.wikipedia.org/wiki/Go_(programming_language)",
+		Lang:      wikiLang,
+		FetchedAt: "2025-11-17T16:10:16Z",
+		Revision:  1234567,
+		Markdown:  "# Go\n\nGo is a programming language.\n",
+	}
+
+	t.Run("individual files", func(t *testing.T) {
+		dir := t.TempDir()
+		out := output{dir: dir, format: formatJSON}
+
+		first, err := writeJSON(out, doc)
+		if err != nil {
+			t.Fatalf("writeJSON: %v", err)
+		}
+		if first != "Go_programming_language.json" {
+			t.Errorf("first file = %q, want %q", first, "Go_programming_language.json")
+		}
+		second, err := writeJSON(out, doc)
+		if err != nil {
+			t.Fatalf("writeJSON: %v", err)
+		}
+		if second != "Go_programming_language_1.json" {
+			t.Errorf("second file = %q, want %q", second, "Go_programming_language_1.json")
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, first))
+		if err != nil {
+			t.Fatalf("ReadFile: %v", err)
+		}
+		var got articleDocument
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("Unmarshal: %v", err)
+		}
+		if got != doc {
+			t.Errorf("round trip = %+v, want %+v", got, doc)
+		}
+	})
+
+	t.Run("single stream", func(t *testing.T) {
+		dir := t.TempDir()
+		var stream bytes.Buffer
+		out := output{dir: dir, format: formatJSON, jsonStream: &stream, jsonStreamName: "articles.jsonl"}
+
+		for range 2 {
+			savedAs, err := writeJSON(out, doc)
+			if err != nil {
+				t.Fatalf("writeJSON: %v", err)
+			}
+			if savedAs != "articles.jsonl" {
+				t.Errorf("saved as %q, want %q", savedAs, "articles.jsonl")
+			}
+		}
+
+		lines := strings.Split(strings.TrimSuffix(stream.String(), "\n"), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("stream has %d lines, want 2", len(lines))
+		}
+		for _, line := range lines {
+			var got articleDocument
+			if err := json.Unmarshal([]byte(line), &got); err != nil {
+				t.Fatalf("Unmarshal(%q): %v", line, err)
+			}
+			if got != doc {
+				t.Errorf("stream document = %+v, want %+v", got, doc)
+			}
+		}
+
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatalf("ReadDir: %v", err)
+		}
+		if len(entries) != 0 {
+			t.Errorf("output directory has %d files, want none", len(entries))
+		}
+	})
+}
+
+func TestWriteMarkdownGzip(t *testing.T) {
+	dir := t.TempDir()
+	md := "# Gzip\n\nMarkdown compresses well. " + strings.Repeat("Repeated text. ", 100) + "\n"
+
+	filename, err := writeMarkdown(dir, "Gzip test", md, true)
+	if err != nil {
+		t.Fatalf("writeMarkdown: %v", err)
+	}
+	if filename != "Gzip_test.md.gz" {
+		t.Errorf("filename = %q, want %q", filename, "Gzip_test.md.gz")
+	}
+
+	// A second article with the same title gets a counter before the compound extension
+	second, err := writeMarkdown(dir, "Gzip test", md, true)
+	if err != nil {
+		t.Fatalf("writeMarkdown: %v", err)
+	}
+	if second != "Gzip_test_1.md.gz" {
+		t.Errorf("second filename = %q, want %q", second, "Gzip_test_1.md.gz")
+	}
+
+	f, err := os.Open(filepath.Join(dir, filename))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("gzip.NewReader: %v", err)
+	}
+	defer zr.Close()
+	got, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if string(got) != md {
+		t.Errorf("round trip = %q, want %q", got, md)
+	}
+}
+
+func TestMaxBaseNameBytes(t *testing.T) {
+	title := strings.Repeat("日本語", 200)
+	for _, ext := range []string{".md", ".md.gz", ".json"} {
+		name := safeFilename(title, maxBaseNameBytes(ext)) + "_10000" + ext
+		if len(name) > maxFilenameBytes {
+			t.Errorf("longest %s file name is %d bytes, exceeds %d", ext, len(name), maxFilenameBytes)
+		}
+	}
+}
+
+func TestPageInfoResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		expected pageInfo
+	}{
+		{
+			name:     "regular article",
+			response: `{"batchcomplete":"","query":{"pages":{"736":{"pageid":736,"ns":0,"title":"Albert Einstein"}}}}`,
+			expected: pageInfo{pageID: 736},
+		},
+		{
+			name:     "disambiguation page",
+			response: `{"batchcomplete":"","query":{"pages":{"1099":{"pageid":1099,"ns":0,"title":"Mercury","pageprops":{"disambiguation":""}}}}}`,
+			expected: pageInfo{disambiguation: true, pageID: 1099},
+		},
+		{
+			name:     "other page properties",
+			response: `{"batchcomplete":"","query":{"pages":{"736":{"pageid":736,"ns":0,"title":"Albert Einstein","pageprops":{"wikibase_item":"Q937"}}}}}`,
+			expected: pageInfo{pageID: 736},
+		},
+		{
+			name:     "redirect",
+			response: `{"batchcomplete":"","query":{"redirects":[{"from":"Einstein","to":"Albert Einstein"}],"pages":{"736":{"pageid":736,"ns":0,"title":"Albert Einstein"}}}}`,
+			expected: pageInfo{redirect: true, pageID: 736},
+		},
+		{
+			name:     "redirect to a disambiguation page",
+			response: `{"batchcomplete":"","query":{"redirects":[{"from":"Mercury (disambiguation)","to":"Mercury"}],"pages":{"1099":{"pageid":1099,"ns":0,"title":"Mercury","pageprops":{"disambiguation":""}}}}}`,
+			expected: pageInfo{redirect: true, disambiguation: true, pageID: 1099},
+		},
+		{
+			name:     "normalized title",
+			response: `{"batchcomplete":"","query":{"normalized":[{"from":"albert einstein","to":"Albert einstein"}],"pages":{"736":{"pageid":736,"ns":0,"title":"Albert Einstein"}}}}`,
+			expected: pageInfo{pageID: 736},
+		},
+		{
+			name:     "missing page",
+			response: `{"batchcomplete":"","query":{"pages":{"-1":{"ns":0,"title":"No such article","missing":""}}}}`,
+			expected: pageInfo{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp pageInfoResponse
+			if err := json.Unmarshal([]byte(tt.response), &resp); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+			if got := resp.info(); got != tt.expected {
+				t.Errorf("info() = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPageDeduper(t *testing.T) {
+	pages := pageDeduper{}
+	// "albert einstein" and "Albert Einstein" are different titles resolving to the same page
+	if pages.duplicate("Albert Einstein", 736) {
+		t.Error("first title of a page reported as duplicate")
+	}
+	if !pages.duplicate("albert einstein", 736) {
+		t.Error("second title of the same page not reported as duplicate")
+	}
+	if pages.duplicate("Albert Einstein", 736) {
+		t.Error("title already converted reported as duplicate of itself")
+	}
+	if pages.duplicate("Isaac Newton", 1234) {
+		t.Error("other page reported as duplicate")
+	}
+
+	// Without a page ID, titles are left to deduplicateTitles
+	if pages.duplicate("No such article", 0) || pages.duplicate("no such article", 0) {
+		t.Error("title without page ID reported as duplicate")
+	}
+
+	// Deduplication by page ID is disabled by default
+	var disabled pageDeduper
+	if disabled.duplicate("Albert Einstein", 736) || disabled.duplicate("albert einstein", 736) {
+		t.Error("disabled pageDeduper reported a duplicate")
+	}
+}
+
+func TestLeadSection(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "lead followed by sections",
+			input:    "Physics is a natural science.\n\nIt studies matter.\n\n## History\n\nAncient times.\n\n## See also\n",
+			expected: "Physics is a natural science.\n\nIt studies matter.\n",
+		},
+		{
+			name:     "deeper heading ends the lead too",
+			input:    "Intro.\n\n### Subsection\n\nBody.\n",
+			expected: "Intro.\n",
+		},
+		{
+			name:     "no heading",
+			input:    "A stub article.\n\nWith two paragraphs.\n",
+			expected: "A stub article.\n\nWith two paragraphs.\n",
+		},
+		{
+			name:     "initial heading without lead",
+			input:    "## History\n\nAncient times.\n",
+			expected: "## History\n\nAncient times.\n",
+		},
+		{
+			name:     "top-level title is part of the lead",
+			input:    "# Physics\n\nPhysics is a natural science.\n\n## History\n",
+			expected: "# Physics\n\nPhysics is a natural science.\n",
+		},
+		{
+			name:     "heading inside a code block",
+			input:    "Intro.\n\n```\n## not a heading\n```\n\nMore intro.\n\n## History\n",
+			expected: "Intro.\n\n```\n## not a heading\n```\n\nMore intro.\n",
+		},
+		{
+			name:     "hashes without a space are not a heading",
+			input:    "Intro.\n##hashtag\n",
+			expected: "Intro.\n##hashtag\n",
+		},
+		{
+			name:     "empty document",
+			input:    "",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := leadSection(tt.input); got != tt.expected {
+				t.Errorf("leadSection(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+// fakeCategoryTree serves a category tree from memory, one member per page so
+// that the traversal has to follow continuation tokens
+type fakeCategoryTree map[string][]categoryMember
+
+func (tree fakeCategoryTree) fetchPage(category, cmcontinue string) ([]categoryMember, string, error) {
+	members := tree[category]
+	i := 0
+	if cmcontinue != "" {
+		i, _ = strconv.Atoi(cmcontinue)
+	}
+	if i >= len(members) {
+		return nil, "", nil
+	}
+	next := ""
+	if i+1 < len(members) {
+		next = strconv.Itoa(i + 1)
+	}
+	return members[i : i+1], next, nil
+}
+
+func articles(prefix string, n int) []categoryMember {
+	members := make([]categoryMember, n)
+	for i := range members {
+		members[i] = categoryMember{NS: 0, Title: fmt.Sprintf("%s %d", prefix, i)}
+	}
+	return members
+}
+
+func TestTraverseCategoriesPerCategoryLimit(t *testing.T) {
+	tree := fakeCategoryTree{
+		"Category:Big": append(articles("Big", 5),
+			categoryMember{NS: 14, Title: "Category:Big sub"},
+			categoryMember{NS: 14, Title: "Category:Big tail"}),
+		"Category:Big sub":  articles("Big sub", 20),
+		"Category:Big tail": articles("Big tail", 3),
+		"Category:Small": append(articles("Small", 2),
+			categoryMember{NS: 14, Title: "Category:Big tail"},
+			categoryMember{NS: 0, Title: "Big 0"}),
+		"Category:Other": articles("Other", 10),
+	}
+	categories := []string{"Category:Big", "Category:Small", "Category:Other"}
+
+	countByPrefix := func(titles []string) map[string]int {
+		counts := make(map[string]int)
+		for _, title := range titles {
+			counts[strings.Fields(title)[0]]++
+		}
+		return counts
+	}
+
+	t.Run("per-category cap", func(t *testing.T) {
+		titles, err := traverseCategories(categories, 100, 8, tree.fetchPage, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Big stops at its quota of 8 (5 direct articles and the 3 of the last
+		// pushed subcategory); Small neither revisits Big's subcategories nor
+		// collects Big's articles again
+		counts := countByPrefix(titles)
+		if got := counts["Big"]; got != 8 {
+			t.Errorf("Big contributed %d articles, want 8", got)
+		}
+		if got := counts["Small"]; got != 2 {
+			t.Errorf("Small contributed %d articles, want 2", got)
+		}
+		if got := counts["Other"]; got != 8 {
+			t.Errorf("Other contributed %d articles, want 8", got)
+		}
+		if len(titles) != 18 {
+			t.Errorf("got %d titles, want 18", len(titles))
+		}
+	})
+
+	t.Run("overall count", func(t *testing.T) {
+		titles, err := traverseCategories(categories, 10, 8, tree.fetchPage, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		counts := countByPrefix(titles)
+		if len(titles) != 10 || counts["Big"] != 8 || counts["Small"] != 2 {
+			t.Errorf("got %v, want 8 articles from Big and 2 from Small", titles)
+		}
+	})
+
+	t.Run("no cap", func(t *testing.T) {
+		titles, err := traverseCategories(categories, 25, 0, tree.fetchPage, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		counts := countByPrefix(titles)
+		if len(titles) != 25 || counts["Small"] != 0 || counts["Other"] != 0 {
+			t.Errorf("got %v, want all 25 articles from Big", titles)
+		}
+	})
+}
+
+func TestParseCategories(t *testing.T) {
+	got := parseCategories(" Category:Physics, ,Category:Chemistry,")
+	want := []string{"Category:Physics", "Category:Chemistry"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("parseCategories() = %q, want %q", got, want)
+	}
+}
+
+func TestParseImageHosts(t *testing.T) {
+	got := parseImageHosts(" Upload.Wikimedia.org, ,https://images.example.org/files,cdn.example.org:8443,")
+	want := []string{"upload.wikimedia.org", "images.example.org", "cdn.example.org"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("parseImageHosts() = %q, want %q", got, want)
+	}
+}
+
+func TestSetWikiBaseURL(t *testing.T) {
+	defer func(baseURL string) {
+		if err := setWikiBaseURL(baseURL); err != nil {
+			t.Fatal(err)
+		}
+	}(wikiBaseURL)
+
+	t.Run("other language edition", func(t *testing.T) {
+		if err := setWikiBaseURL("https://de.wikipedia.org"); err != nil {
+			t.Fatal(err)
+		}
+		if wikiLang != "de" {
+			t.Errorf("wikiLang = %q, want de", wikiLang)
+		}
+		if got := extractWikiArticleName("https://de.wikipedia.org/wiki/Ägypten"); got != "Ägypten" {
+			t.Errorf("extractWikiArticleName() = %q, want Ägypten", got)
+		}
+		if got := extractWikiArticleName("https://en.wiktionary.org/wiki/Egypt"); got != "" {
+			t.Errorf("extractWikiArticleName() = %q for another project, want none", got)
+		}
+	})
+
+	t.Run("wiki without language subdomain", func(t *testing.T) {
+		if err := setWikiBaseURL("https://wiki.example.org"); err != nil {
+			t.Fatal(err)
+		}
+		if wikiLang != "" {
+			t.Errorf("wikiLang = %q, want none", wikiLang)
+		}
+		got := normalizeInternalLinks("[Egypt](https://wiki.example.org/wiki/Egypt) [Moon](https://en.wikipedia.org/wiki/Moon)")
+		want := "[Egypt](/:root/subject/Egypt) [Moon](https://en.wikipedia.org/wiki/Moon)"
+		if got != want {
+			t.Errorf("normalizeInternalLinks() = %q, want %q", got, want)
+		}
+		if got := normalizeImageURLs("![a](/images/a.png)", imageOptions{}); got != "![a](https://wiki.example.org/images/a.png)" {
+			t.Errorf("normalizeImageURLs() = %q, want the image on the wiki", got)
+		}
+	})
+}
+
+func TestWriteManifest(t *testing.T) {
+	dir := t.TempDir()
+	cfg := config{
+		outputDir:     dir,
+		count:         10,
+		category:      "Category:Physics,Category:Chemistry",
+		perCategory:   5,
+		sleepInterval: 250 * time.Millisecond,
+		format:        formatMarkdown,
+	}
+	started := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	stats := runStats{Titles: 10, Converted: 6, Skipped: 3, Errors: 1, Redirects: 1, Empty: 1, Disambigs: 1}
+
+	if err := writeManifest(dir, newRunManifest(cfg, started, started.Add(90*time.Second), stats)); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "manifest.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got runManifest
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("manifest is not valid JSON: %v", err)
+	}
+
+	if got.Tool != "wiki2md" || got.Version != version {
+		t.Errorf("tool = %q %q, want wiki2md %q", got.Tool, got.Version, version)
+	}
+	wantConfig := manifestConfig{
+		Lang:             wikiLang,
+		Category:         "Category:Physics,Category:Chemistry",
+		PerCategoryLimit: 5,
+		Count:            10,
+		Sleep:            "250ms",
+		Format:           formatMarkdown,
+	}
+	if got.Config != wantConfig {
+		t.Errorf("config = %+v, want %+v", got.Config, wantConfig)
+	}
+	if got.StartedAt != "2026-01-02T03:04:05Z" || got.FinishedAt != "2026-01-02T03:05:35Z" {
+		t.Errorf("times = %s - %s", got.StartedAt, got.FinishedAt)
+	}
+	if got.Stats != stats {
+		t.Errorf("stats = %+v, want %+v", got.Stats, stats)
+	}
+}
+
+func TestArticleLogJSON(t *testing.T) {
+	var buf bytes.Buffer
+	l := articleLog{w: &buf, format: logFormatJSON}
+	l.logError("Fetched", &articleError{stageFetch, fmt.Errorf("failed to fetch HTML: %w", io.ErrUnexpectedEOF)})
+	l.logError("Written", &articleError{stageWrite, errors.New("failed to write markdown: disk full")})
+	l.logError("Unknown", errors.New("boom"))
+	l.logSkip("Redirect", skipRedirect)
+	l.logSkip("Empty", skipEmptyContent)
+
+	want := []logEntry{
+		{Title: "Fetched", Error: "failed to fetch HTML: unexpected EOF", Stage: stageFetch},
+		{Title: "Written", Error: "failed to write markdown: disk full", Stage: stageWrite},
+		{Title: "Unknown", Error: "boom"},
+		{Title: "Redirect", Reason: skipRedirect, Stage: stageRedirectCheck},
+		{Title: "Empty", Reason: skipEmptyContent, Stage: stageFetch},
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(want), buf.String())
+	}
+	for i, line := range lines {
+		var got logEntry
+		if err := json.Unmarshal([]byte(line), &got); err != nil {
+			t.Fatalf("line %d is not valid JSON: %v\n%s", i, err, line)
+		}
+		if got != want[i] {
+			t.Errorf("line %d = %+v, want %+v", i, got, want[i])
+		}
+	}
+}
+
+func TestArticleLogText(t *testing.T) {
+	var buf bytes.Buffer
+	l := articleLog{w: &buf, format: logFormatText}
+	l.logError("Fetched", &articleError{stageFetch, errors.New("failed to fetch HTML: timeout")})
+	l.logSkip("Redirect", skipRedirect)
+
+	want := "Fetched\tfailed to fetch HTML: timeout\nRedirect\tredirect\n"
+	if buf.String() != want {
+		t.Errorf("text log = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestErrorStage(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", &articleError{stageConvert, errors.New("bad html")})
+	if got := errorStage(err); got != stageConvert {
+		t.Errorf("errorStage() = %q, want %q", got, stageConvert)
+	}
+	if got := errorStage(errors.New("plain")); got != "" {
+		t.Errorf("errorStage() = %q, want empty", got)
+	}
+}
+
+func TestShardSet(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    shard
+		wantErr bool
+	}{
+		{value: "0/4", want: shard{index: 0, count: 4}},
+		{value: "3/4", want: shard{index: 3, count: 4}},
+		{value: "0/1", want: shard{index: 0, count: 1}},
+		{value: "4/4", wantErr: true},
+		{value: "-1/4", wantErr: true},
+		{value: "1/0", wantErr: true},
+		{value: "1", wantErr: true},
+		{value: "a/b", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			var s shard
+			err := s.Set(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Set(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !tt.wantErr && s != tt.want {
+				t.Errorf("Set(%q) = %+v, want %+v", tt.value, s, tt.want)
+			}
+		})
+	}
+}
+
+func TestShardCoverage(t *testing.T) {
+	var titles []string
+	for i := range 1000 {
+		titles = append(titles, fmt.Sprintf("Article %d", i))
+	}
+	titles = append(titles, "Physics", "Émile Durkheim", "C++", "")
+
+	for _, count := range []int{1, 2, 3, 7} {
+		owners := make(map[string]int)
+		for index := range count {
+			s := shard{index: index, count: count}
+			part := s.filter(titles)
+			if count > 1 && len(part) == 0 {
+				t.Errorf("shard %d/%d is empty", index, count)
+			}
+			for _, title := range part {
+				if previous, ok := owners[title]; ok {
+					t.Errorf("%q is in shards %d and %d of %d", title, previous, index, count)
+				}
+				owners[title] = index
+			}
+		}
+		if len(owners) != len(titles) {
+			t.Errorf("%d shards cover %d of %d titles", count, len(owners), len(titles))
+		}
+	}
+}
+
+func TestShardOutputDir(t *testing.T) {
+	if got := (shard{}).outputDir("out"); got != "out" {
+		t.Errorf("outputDir() without shard = %q, want %q", got, "out")
+	}
+	if got, want := (shard{index: 1, count: 4}).outputDir("out"), filepath.Join("out", "shard-1-of-4"); got != want {
+		t.Errorf("outputDir() = %q, want %q", got, want)
+	}
+}
@@ -148,6 +148,26 @@ func CreateRepoTransferNotification(ctx context.Context, doer, newOwner *user_mo
 	})
 }
 
+// CreateArticleChangeNotifications creates a notification of a commit changing the README of the
+// article of a repository for each of the receivers
+func CreateArticleChangeNotifications(ctx context.Context, doerID, repoID int64, commitID string, receiverIDs []int64) error {
+	if len(receiverIDs) == 0 {
+		return nil
+	}
+	notify := make([]*Notification, 0, len(receiverIDs))
+	for _, receiverID := range receiverIDs {
+		notify = append(notify, &Notification{
+			UserID:    receiverID,
+			RepoID:    repoID,
+			Status:    NotificationStatusUnread,
+			UpdatedBy: doerID,
+			Source:    NotificationSourceCommit,
+			CommitID:  commitID,
+		})
+	}
+	return db.Insert(ctx, notify)
+}
+
 func createIssueNotification(ctx context.Context, userID int64, issue *issues_model.Issue, commentID, updatedByID int64) error {
 	notification := &Notification{
 		UserID:    userID,
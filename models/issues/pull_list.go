@@ -14,6 +14,7 @@ import (
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/container"
 	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/timeutil"
 	"code.gitea.io/gitea/modules/util"
 
 	"xorm.io/builder"
@@ -335,6 +336,83 @@ func HasMergedPullRequestInRepo(ctx context.Context, repoID, posterID int64) (bo
 		Get(new(Issue))
 }
 
+// CountOpenPullRequestsByPosterSince returns the number of still-open pull requests
+// the poster has opened in the repo since the given time
+func CountOpenPullRequestsByPosterSince(ctx context.Context, repoID, posterID int64, since timeutil.TimeStamp) (int64, error) {
+	return db.GetEngine(ctx).
+		Where("repo_id=?", repoID).
+		And("poster_id=?", posterID).
+		And("is_pull=?", true).
+		And("is_closed=?", false).
+		And("created_unix>=?", since).
+		Count(new(Issue))
+}
+
+// CountOpenPullRequestsByBaseRepo returns the number of open pull requests targeting the repo
+func CountOpenPullRequestsByBaseRepo(ctx context.Context, repoID int64) (int64, error) {
+	return db.GetEngine(ctx).
+		Where("repo_id=?", repoID).
+		And("is_pull=?", true).
+		And("is_closed=?", false).
+		Count(new(Issue))
+}
+
+// CountOpenPullRequestsByHeadRepo returns the number of open pull requests proposed from the repo
+func CountOpenPullRequestsByHeadRepo(ctx context.Context, repoID int64) (int64, error) {
+	return db.GetEngine(ctx).
+		Join("INNER", "issue", "issue.id = pull_request.issue_id").
+		Where("pull_request.head_repo_id=?", repoID).
+		And("issue.is_closed=?", false).
+		Count(new(PullRequest))
+}
+
+// HasPullRequestActivitySince reports whether the repo is the base or the head of a pull request
+// that is still open or was updated since the given time
+func HasPullRequestActivitySince(ctx context.Context, repoID int64, since timeutil.TimeStamp) (bool, error) {
+	return db.GetEngine(ctx).
+		Join("INNER", "issue", "issue.id = pull_request.issue_id").
+		Where("pull_request.base_repo_id=? OR pull_request.head_repo_id=?", repoID, repoID).
+		And("issue.is_closed=? OR issue.updated_unix>=?", false, since).
+		Exist(new(PullRequest))
+}
+
+// CountOpenPullRequestsToDefaultBranches returns the number of open pull requests, from the same
+// repository or from forks, targeting the default branch of each of repos, keyed by repository id
+func CountOpenPullRequestsToDefaultBranches(ctx context.Context, repos []*repo_model.Repository) (map[int64]int, error) {
+	defaultBranches := make(map[int64]string, len(repos))
+	repoIDs := make([]int64, 0, len(repos))
+	for _, repo := range repos {
+		defaultBranches[repo.ID] = repo.DefaultBranch
+		repoIDs = append(repoIDs, repo.ID)
+	}
+
+	counts := make(map[int64]int, len(repos))
+	for start := 0; start < len(repoIDs); start += MaxQueryParameters {
+		chunk := repoIDs[start:min(start+MaxQueryParameters, len(repoIDs))]
+		countsSlice := make([]*struct {
+			BaseRepoID int64
+			BaseBranch string
+			Count      int
+		}, 0, len(chunk))
+		if err := db.GetEngine(ctx).Table("pull_request").
+			Join("INNER", "issue", "issue.id = pull_request.issue_id").
+			Where("issue.is_closed = ?", false).
+			And("pull_request.has_merged = ?", false).
+			In("pull_request.base_repo_id", chunk).
+			GroupBy("pull_request.base_repo_id, pull_request.base_branch").
+			Select("pull_request.base_repo_id AS base_repo_id, pull_request.base_branch AS base_branch, COUNT(*) AS count").
+			Find(&countsSlice); err != nil {
+			return nil, fmt.Errorf("unable to CountOpenPullRequestsToDefaultBranches: %w", err)
+		}
+		for _, c := range countsSlice {
+			if c.BaseBranch == defaultBranches[c.BaseRepoID] {
+				counts[c.BaseRepoID] += c.Count
+			}
+		}
+	}
+	return counts, nil
+}
+
 // GetPullRequestByIssueIDs returns all pull requests by issue ids
 func GetPullRequestByIssueIDs(ctx context.Context, issueIDs []int64) (PullRequestList, error) {
 	prs := make([]*PullRequest, 0, len(issueIDs))
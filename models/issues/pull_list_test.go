@@ -7,7 +7,9 @@ import (
 	"testing"
 
 	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/timeutil"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -61,3 +63,35 @@ func TestPullRequestList_LoadReviews(t *testing.T) {
 	assert.EqualValues(t, 10, reviewList[4].ID)
 	assert.EqualValues(t, 22, reviewList[5].ID)
 }
+
+func TestCountOpenPullRequestsByPosterSince(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	count, err := issues_model.CountOpenPullRequestsByPosterSince(t.Context(), 1, 1, 0)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, count)
+
+	// only issue 3 was opened at or after this time
+	count, err = issues_model.CountOpenPullRequestsByPosterSince(t.Context(), 1, 1, 946684820)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+
+	count, err = issues_model.CountOpenPullRequestsByPosterSince(t.Context(), 1, 1, timeutil.TimeStampNow())
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, count)
+}
+
+func TestCountOpenPullRequestsToDefaultBranches(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	repos := []*repo_model.Repository{
+		unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1}),
+		unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 10}),
+		unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 11}),
+	}
+	counts, err := issues_model.CountOpenPullRequestsToDefaultBranches(t.Context(), repos)
+	assert.NoError(t, err)
+	// repo1: pull 2 is open into master, pull 1 is merged and pull 5 targets branch2
+	// repo10: pull 3 is open from its fork repo11
+	assert.Equal(t, map[int64]int{1: 1, 10: 1}, counts)
+}
@@ -0,0 +1,42 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25_custom
+
+import (
+	"fmt"
+
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/phonetic"
+
+	"xorm.io/xorm"
+)
+
+// AddSubjectPhoneticKey adds an indexed phonetic_key column to the subject table
+// and backfills it with the phonetic key of each subject name.
+func AddSubjectPhoneticKey(x *xorm.Engine) error {
+	type Subject struct {
+		ID          int64  `xorm:"pk autoincr"`
+		Name        string `xorm:"VARCHAR(255) NOT NULL"`
+		PhoneticKey string `xorm:"VARCHAR(64) INDEX"`
+	}
+
+	if err := x.Sync(new(Subject)); err != nil {
+		return fmt.Errorf("failed to add phonetic_key column: %w", err)
+	}
+
+	var subjects []Subject
+	if err := x.Table("subject").Cols("id", "name").Find(&subjects); err != nil {
+		return fmt.Errorf("failed to fetch existing subjects: %w", err)
+	}
+
+	for i := range subjects {
+		subjects[i].PhoneticKey, _ = phonetic.NameKeys(subjects[i].Name)
+		if _, err := x.ID(subjects[i].ID).Cols("phonetic_key").Update(&subjects[i]); err != nil {
+			return fmt.Errorf("failed to update phonetic key of subject %d: %w", subjects[i].ID, err)
+		}
+	}
+
+	log.Info("Migration v329: Computed phonetic keys for %d subjects", len(subjects))
+	return nil
+}
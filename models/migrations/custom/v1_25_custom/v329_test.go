@@ -0,0 +1,44 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25_custom
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/migrations/base"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AddSubjectPhoneticKey(t *testing.T) {
+	type Subject struct {
+		ID   int64  `xorm:"pk autoincr"`
+		Name string `xorm:"VARCHAR(255) NOT NULL"`
+		Slug string `xorm:"VARCHAR(255) UNIQUE NOT NULL"`
+	}
+
+	x, deferable := base.PrepareTestEnv(t, 0, new(Subject))
+	defer deferable()
+	if x == nil || t.Failed() {
+		return
+	}
+
+	_, err := x.Insert(&Subject{Name: "Tchaikovsky", Slug: "tchaikovsky"}, &Subject{Name: "The Moon", Slug: "the-moon"})
+	assert.NoError(t, err)
+
+	assert.NoError(t, AddSubjectPhoneticKey(x))
+
+	type SubjectResult struct {
+		Name        string
+		PhoneticKey string
+	}
+	var results []SubjectResult
+	assert.NoError(t, x.Table("subject").OrderBy("id").Find(&results))
+	assert.Len(t, results, 2)
+	assert.Equal(t, "XKFSK", results[0].PhoneticKey)
+	assert.Equal(t, "0MN", results[1].PhoneticKey)
+
+	// Running the migration again must not fail
+	assert.NoError(t, AddSubjectPhoneticKey(x))
+}
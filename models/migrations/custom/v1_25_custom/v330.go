@@ -0,0 +1,58 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25_custom
+
+import (
+	"fmt"
+
+	"code.gitea.io/gitea/modules/log"
+
+	"xorm.io/xorm"
+)
+
+// AddSubjectRootRepoID adds a root_repo_id column to the subject table pointing at the
+// canonical root repository of each subject, and backfills it with the oldest
+// non-fork, non-empty repository of the subject.
+func AddSubjectRootRepoID(x *xorm.Engine) error {
+	type Subject struct {
+		ID         int64 `xorm:"pk autoincr"`
+		RootRepoID int64 `xorm:"INDEX"`
+	}
+
+	if err := x.Sync(new(Subject)); err != nil {
+		return fmt.Errorf("failed to add root_repo_id column: %w", err)
+	}
+
+	type Repository struct {
+		ID        int64
+		SubjectID int64
+	}
+
+	var subjectIDs []int64
+	if err := x.Table("subject").Cols("id").Find(&subjectIDs); err != nil {
+		return fmt.Errorf("failed to fetch existing subjects: %w", err)
+	}
+
+	updated := 0
+	for _, subjectID := range subjectIDs {
+		var root Repository
+		has, err := x.Table("repository").
+			Where("subject_id = ? AND is_fork = ? AND is_empty = ?", subjectID, false, false).
+			OrderBy("created_unix ASC").
+			Get(&root)
+		if err != nil {
+			return fmt.Errorf("failed to find root repository of subject %d: %w", subjectID, err)
+		}
+		if !has {
+			continue
+		}
+		if _, err := x.ID(subjectID).Cols("root_repo_id").Update(&Subject{RootRepoID: root.ID}); err != nil {
+			return fmt.Errorf("failed to update root repository of subject %d: %w", subjectID, err)
+		}
+		updated++
+	}
+
+	log.Info("Migration v330: Recorded root repositories for %d of %d subjects", updated, len(subjectIDs))
+	return nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25_custom
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/migrations/base"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AddSubjectRootRepoID(t *testing.T) {
+	type Subject struct {
+		ID   int64  `xorm:"pk autoincr"`
+		Name string `xorm:"VARCHAR(255) NOT NULL"`
+	}
+	type Repository struct {
+		ID          int64 `xorm:"pk autoincr"`
+		SubjectID   int64
+		IsFork      bool
+		IsEmpty     bool
+		CreatedUnix int64
+	}
+
+	x, deferable := base.PrepareTestEnv(t, 0, new(Subject), new(Repository))
+	defer deferable()
+	if x == nil || t.Failed() {
+		return
+	}
+
+	_, err := x.Insert(&Subject{ID: 1, Name: "with-root"}, &Subject{ID: 2, Name: "without-root"})
+	assert.NoError(t, err)
+	_, err = x.Insert(
+		&Repository{ID: 1, SubjectID: 1, IsEmpty: true, CreatedUnix: 100},
+		&Repository{ID: 2, SubjectID: 1, CreatedUnix: 200},
+		&Repository{ID: 3, SubjectID: 1, IsFork: true, CreatedUnix: 50},
+		&Repository{ID: 4, SubjectID: 2, IsFork: true, CreatedUnix: 50},
+	)
+	assert.NoError(t, err)
+
+	assert.NoError(t, AddSubjectRootRepoID(x))
+
+	type SubjectResult struct {
+		ID         int64
+		RootRepoID int64
+	}
+	var results []SubjectResult
+	assert.NoError(t, x.Table("subject").OrderBy("id").Find(&results))
+	assert.Equal(t, []SubjectResult{{ID: 1, RootRepoID: 2}, {ID: 2, RootRepoID: 0}}, results)
+}
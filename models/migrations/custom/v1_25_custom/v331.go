@@ -0,0 +1,19 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25_custom
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+// AddSubjectDeletedUnix adds a deleted_unix column to the subject table so that
+// subjects can be hidden from explore and search without deleting them.
+func AddSubjectDeletedUnix(x *xorm.Engine) error {
+	type Subject struct {
+		DeletedUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+	}
+	return x.Sync(new(Subject))
+}
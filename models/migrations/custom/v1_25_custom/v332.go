@@ -0,0 +1,17 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25_custom
+
+import (
+	"xorm.io/xorm"
+)
+
+// AddSubjectLang adds a lang column to the subject table so that the explore
+// page can filter subjects by the language of their articles.
+func AddSubjectLang(x *xorm.Engine) error {
+	type Subject struct {
+		Lang string `xorm:"VARCHAR(10) INDEX NOT NULL DEFAULT ''"`
+	}
+	return x.Sync(new(Subject))
+}
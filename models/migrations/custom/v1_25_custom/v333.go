@@ -0,0 +1,28 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25_custom
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+type subjectCountV333 struct {
+	SubjectID     int64              `xorm:"pk"`
+	RepoCount     int64              `xorm:"NOT NULL DEFAULT 0"`
+	RootRepoCount int64              `xorm:"NOT NULL DEFAULT 0"`
+	ForkRepoCount int64              `xorm:"NOT NULL DEFAULT 0"`
+	UpdatedUnix   timeutil.TimeStamp `xorm:"INDEX"`
+}
+
+func (*subjectCountV333) TableName() string {
+	return "subject_counts"
+}
+
+// AddSubjectCountsTable adds the subject_counts table holding the precomputed repository
+// counts of each subject. It is filled by the reconcile_subject_counts cron task.
+func AddSubjectCountsTable(x *xorm.Engine) error {
+	return x.Sync(new(subjectCountV333))
+}
@@ -0,0 +1,24 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25_custom
+
+import (
+	"xorm.io/xorm"
+)
+
+type subjectRedirectV334 struct {
+	ID        int64  `xorm:"pk autoincr"`
+	Slug      string `xorm:"VARCHAR(255) UNIQUE NOT NULL"`
+	SubjectID int64  `xorm:"INDEX NOT NULL"`
+}
+
+func (*subjectRedirectV334) TableName() string {
+	return "subject_redirect"
+}
+
+// AddSubjectRedirectTable adds the subject_redirect table mapping former slugs of subjects to
+// the subjects, so that links keep working after the regenerate_subject_slugs task changed them.
+func AddSubjectRedirectTable(x *xorm.Engine) error {
+	return x.Sync(new(subjectRedirectV334))
+}
@@ -0,0 +1,29 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25_custom
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+type rootPromotionV335 struct {
+	ID          int64              `xorm:"pk autoincr"`
+	SubjectID   int64              `xorm:"INDEX NOT NULL"`
+	RepoID      int64              `xorm:"UNIQUE NOT NULL"`
+	RootRepoID  int64              `xorm:"INDEX NOT NULL"`
+	DoerID      int64              `xorm:"NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+}
+
+func (*rootPromotionV335) TableName() string {
+	return "root_promotion"
+}
+
+// AddRootPromotionTable adds the root_promotion table holding forks waiting for an administrator
+// to approve them becoming the root repository of their subject.
+func AddRootPromotionTable(x *xorm.Engine) error {
+	return x.Sync(new(rootPromotionV335))
+}
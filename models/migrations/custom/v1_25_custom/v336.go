@@ -0,0 +1,18 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25_custom
+
+import (
+	"xorm.io/xorm"
+)
+
+// AddSubjectFeatured adds the is_featured and featured_order columns to the subject table
+// so that administrators can curate subjects onto the explore page.
+func AddSubjectFeatured(x *xorm.Engine) error {
+	type Subject struct {
+		IsFeatured    bool `xorm:"INDEX NOT NULL DEFAULT false"`
+		FeaturedOrder int  `xorm:"NOT NULL DEFAULT 0"`
+	}
+	return x.Sync(new(Subject))
+}
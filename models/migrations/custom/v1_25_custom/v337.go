@@ -0,0 +1,36 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25_custom
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+type articleSuggestionV337 struct {
+	ID              int64              `xorm:"pk autoincr"`
+	RepoID          int64              `xorm:"INDEX NOT NULL"`
+	PosterID        int64              `xorm:"INDEX NOT NULL"`
+	CommitID        string             `xorm:"VARCHAR(64) NOT NULL"`
+	TreePath        string             `xorm:"NOT NULL"`
+	LineStart       int                `xorm:"NOT NULL"`
+	LineEnd         int                `xorm:"NOT NULL"`
+	OriginalContent string             `xorm:"LONGTEXT"`
+	Content         string             `xorm:"LONGTEXT"`
+	Comment         string             `xorm:"TEXT"`
+	Status          int                `xorm:"INDEX NOT NULL DEFAULT 0"`
+	CreatedUnix     timeutil.TimeStamp `xorm:"INDEX created"`
+	UpdatedUnix     timeutil.TimeStamp `xorm:"updated"`
+}
+
+func (*articleSuggestionV337) TableName() string {
+	return "article_suggestion"
+}
+
+// AddArticleSuggestionTable adds the article_suggestion table holding readers' proposed
+// corrections of line ranges of article READMEs.
+func AddArticleSuggestionTable(x *xorm.Engine) error {
+	return x.Sync(new(articleSuggestionV337))
+}
@@ -0,0 +1,16 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25_custom
+
+import (
+	"xorm.io/xorm"
+)
+
+// AddSubjectDescription adds the description column to the subject table
+func AddSubjectDescription(x *xorm.Engine) error {
+	type Subject struct {
+		Description string `xorm:"TEXT"`
+	}
+	return x.Sync(new(Subject))
+}
@@ -0,0 +1,27 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25_custom
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+type articleWatchV339 struct {
+	ID          int64              `xorm:"pk autoincr"`
+	UserID      int64              `xorm:"UNIQUE(article_watch)"`
+	RepoID      int64              `xorm:"UNIQUE(article_watch) INDEX"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+func (*articleWatchV339) TableName() string {
+	return "article_watch"
+}
+
+// AddArticleWatchTable adds the article_watch table holding the users watching the changes
+// to a single article.
+func AddArticleWatchTable(x *xorm.Engine) error {
+	return x.Sync(new(articleWatchV339))
+}
@@ -0,0 +1,36 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_25_custom
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+type articleHeadingV340 struct {
+	Level int    `json:"level"`
+	Text  string `json:"text"`
+}
+
+type articleReadingMetadataV340 struct {
+	ID             int64                 `xorm:"pk autoincr"`
+	RepoID         int64                 `xorm:"UNIQUE(article_reading) NOT NULL"`
+	CommitID       string                `xorm:"UNIQUE(article_reading) VARCHAR(64) NOT NULL"`
+	ReadmeBlobID   string                `xorm:"VARCHAR(64) NOT NULL"`
+	WordCount      int                   `xorm:"NOT NULL DEFAULT 0"`
+	ReadingMinutes int                   `xorm:"NOT NULL DEFAULT 0"`
+	Outline        []*articleHeadingV340 `xorm:"TEXT JSON"`
+	CreatedUnix    timeutil.TimeStamp    `xorm:"created"`
+}
+
+func (*articleReadingMetadataV340) TableName() string {
+	return "article_reading_metadata"
+}
+
+// AddArticleReadingMetadataTable adds the article_reading_metadata table holding the precomputed
+// word count, reading time and outline of the README of articles.
+func AddArticleReadingMetadataTable(x *xorm.Engine) error {
+	return x.Sync(new(articleReadingMetadataV340))
+}
@@ -403,6 +403,18 @@ func prepareMigrationTasks() []*migration {
 		newMigration(326, "Forkana: add slug column to subjects table", v1_25_custom.AddSubjectSlugColumn),
 		newMigration(327, "Forkana: add composite indexes for fork-on-edit optimization", v1_25_custom.AddCompositeIndexesForForkOnEdit),
 		newMigration(328, "Forkana: add is_forked and forked_repo_id to pull_request", v1_25_custom.AddIsForkedToPullRequest),
+		newMigration(329, "Forkana: add phonetic_key column to subject table", v1_25_custom.AddSubjectPhoneticKey),
+		newMigration(330, "Forkana: add root_repo_id column to subject table", v1_25_custom.AddSubjectRootRepoID),
+		newMigration(331, "Forkana: add deleted_unix column to subject table", v1_25_custom.AddSubjectDeletedUnix),
+		newMigration(332, "Forkana: add lang column to subject table", v1_25_custom.AddSubjectLang),
+		newMigration(333, "Forkana: add subject_counts table", v1_25_custom.AddSubjectCountsTable),
+		newMigration(334, "Forkana: add subject_redirect table", v1_25_custom.AddSubjectRedirectTable),
+		newMigration(335, "Forkana: add root_promotion table", v1_25_custom.AddRootPromotionTable),
+		newMigration(336, "Forkana: add is_featured and featured_order columns to subject table", v1_25_custom.AddSubjectFeatured),
+		newMigration(337, "Forkana: add article_suggestion table", v1_25_custom.AddArticleSuggestionTable),
+		newMigration(338, "Forkana: add description column to subject table", v1_25_custom.AddSubjectDescription),
+		newMigration(339, "Forkana: add article_watch table", v1_25_custom.AddArticleWatchTable),
+		newMigration(340, "Forkana: add article_reading_metadata table", v1_25_custom.AddArticleReadingMetadataTable),
 	}
 	return preparedMigrations
 }
@@ -0,0 +1,69 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// ArticleHeading is a heading of the README of an article
+type ArticleHeading struct {
+	Level int    `json:"level"`
+	Text  string `json:"text"`
+}
+
+// ArticleReadingMetadata is the reading metadata of the README of an article at a commit. It is
+// computed when the default branch is pushed to, so that the article view doesn't recompute it.
+type ArticleReadingMetadata struct {
+	ID       int64  `xorm:"pk autoincr"`
+	RepoID   int64  `xorm:"UNIQUE(article_reading) NOT NULL"`
+	CommitID string `xorm:"UNIQUE(article_reading) VARCHAR(64) NOT NULL"`
+	// ReadmeBlobID lets the metadata be reused for commits which don't change the README
+	ReadmeBlobID   string             `xorm:"VARCHAR(64) NOT NULL"`
+	WordCount      int                `xorm:"NOT NULL DEFAULT 0"`
+	ReadingMinutes int                `xorm:"NOT NULL DEFAULT 0"`
+	Outline        []*ArticleHeading  `xorm:"TEXT JSON"`
+	CreatedUnix    timeutil.TimeStamp `xorm:"created"`
+}
+
+// TableName returns the table name for ArticleReadingMetadata
+func (ArticleReadingMetadata) TableName() string {
+	return "article_reading_metadata"
+}
+
+func init() {
+	db.RegisterModel(new(ArticleReadingMetadata))
+}
+
+// GetArticleReadingMetadata returns the stored reading metadata of the article of the repository at the commit
+func GetArticleReadingMetadata(ctx context.Context, repoID int64, commitID string) (*ArticleReadingMetadata, bool, error) {
+	return db.Get[ArticleReadingMetadata](ctx, builder.Eq{"repo_id": repoID, "commit_id": commitID})
+}
+
+// GetLatestArticleReadingMetadata returns the most recently stored reading metadata of the article of the repository
+func GetLatestArticleReadingMetadata(ctx context.Context, repoID int64) (*ArticleReadingMetadata, bool, error) {
+	metadata := &ArticleReadingMetadata{}
+	has, err := db.GetEngine(ctx).Where("repo_id = ?", repoID).Desc("id").Get(metadata)
+	if err != nil || !has {
+		return nil, false, err
+	}
+	return metadata, true, nil
+}
+
+// SaveArticleReadingMetadata stores the reading metadata of an article, replacing the metadata of
+// its previous commits: only the latest version of an article is read often enough to be worth it.
+func SaveArticleReadingMetadata(ctx context.Context, metadata *ArticleReadingMetadata) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("repo_id = ?", metadata.RepoID).Delete(&ArticleReadingMetadata{}); err != nil {
+			return err
+		}
+		metadata.ID = 0
+		return db.Insert(ctx, metadata)
+	})
+}
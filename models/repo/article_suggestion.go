@@ -0,0 +1,135 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// ErrArticleSuggestionNotExist represents a "ArticleSuggestionNotExist" kind of error.
+type ErrArticleSuggestionNotExist struct {
+	ID int64
+}
+
+// IsErrArticleSuggestionNotExist checks if an error is an ErrArticleSuggestionNotExist.
+func IsErrArticleSuggestionNotExist(err error) bool {
+	_, ok := err.(ErrArticleSuggestionNotExist)
+	return ok
+}
+
+func (err ErrArticleSuggestionNotExist) Error() string {
+	return fmt.Sprintf("article suggestion does not exist [id: %d]", err.ID)
+}
+
+func (err ErrArticleSuggestionNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// ArticleSuggestionStatus is the state of an article suggestion
+type ArticleSuggestionStatus int
+
+const (
+	// ArticleSuggestionOpen is a suggestion waiting for the owner of the article
+	ArticleSuggestionOpen ArticleSuggestionStatus = iota
+	// ArticleSuggestionAccepted is a suggestion the owner took into the editor
+	ArticleSuggestionAccepted
+	// ArticleSuggestionDismissed is a suggestion the owner turned down
+	ArticleSuggestionDismissed
+)
+
+// ArticleSuggestion is a reader's proposed correction of a range of lines of the README of an
+// article, as it was at a commit. Unlike a change request it has no branch: the owner of the
+// article can accept it into the editor, or dismiss it.
+type ArticleSuggestion struct {
+	ID       int64            `xorm:"pk autoincr"`
+	RepoID   int64            `xorm:"INDEX NOT NULL"`
+	PosterID int64            `xorm:"INDEX NOT NULL"`
+	Poster   *user_model.User `xorm:"-"`
+	CommitID string           `xorm:"VARCHAR(64) NOT NULL"`
+	TreePath string           `xorm:"NOT NULL"`
+	// LineStart and LineEnd are the 1-based, inclusive range of the suggested lines
+	LineStart int `xorm:"NOT NULL"`
+	LineEnd   int `xorm:"NOT NULL"`
+	// OriginalContent holds the suggested lines as they were at CommitID, to find them again
+	// once the article has changed
+	OriginalContent string                  `xorm:"LONGTEXT"`
+	Content         string                  `xorm:"LONGTEXT"`
+	Comment         string                  `xorm:"TEXT"`
+	Status          ArticleSuggestionStatus `xorm:"INDEX NOT NULL DEFAULT 0"`
+	CreatedUnix     timeutil.TimeStamp      `xorm:"INDEX created"`
+	UpdatedUnix     timeutil.TimeStamp      `xorm:"updated"`
+}
+
+// TableName returns the table name for ArticleSuggestion
+func (ArticleSuggestion) TableName() string {
+	return "article_suggestion"
+}
+
+func init() {
+	db.RegisterModel(new(ArticleSuggestion))
+}
+
+// IsOpen returns true if the suggestion has been neither accepted nor dismissed
+func (s *ArticleSuggestion) IsOpen() bool {
+	return s.Status == ArticleSuggestionOpen
+}
+
+// CreateArticleSuggestion records a new open suggestion
+func CreateArticleSuggestion(ctx context.Context, suggestion *ArticleSuggestion) error {
+	suggestion.Status = ArticleSuggestionOpen
+	return db.Insert(ctx, suggestion)
+}
+
+// GetArticleSuggestionByID returns the suggestion with the given ID made on the repository
+func GetArticleSuggestionByID(ctx context.Context, repoID, id int64) (*ArticleSuggestion, error) {
+	suggestion := new(ArticleSuggestion)
+	if has, err := db.GetEngine(ctx).ID(id).And("repo_id = ?", repoID).Get(suggestion); err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrArticleSuggestionNotExist{ID: id}
+	}
+	return suggestion, nil
+}
+
+// FindOpenArticleSuggestions returns the open suggestions made on the repository, oldest first,
+// with their posters loaded
+func FindOpenArticleSuggestions(ctx context.Context, repoID int64) ([]*ArticleSuggestion, error) {
+	suggestions := make([]*ArticleSuggestion, 0, 10)
+	if err := db.GetEngine(ctx).
+		Where("repo_id = ? AND status = ?", repoID, ArticleSuggestionOpen).
+		Asc("created_unix", "id").
+		Find(&suggestions); err != nil {
+		return nil, err
+	}
+
+	posterIDs := make([]int64, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		posterIDs = append(posterIDs, suggestion.PosterID)
+	}
+	posters, err := user_model.GetUsersMapByIDs(ctx, posterIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, suggestion := range suggestions {
+		if poster, ok := posters[suggestion.PosterID]; ok {
+			suggestion.Poster = poster
+		} else {
+			suggestion.Poster = user_model.NewGhostUser()
+		}
+	}
+	return suggestions, nil
+}
+
+// UpdateArticleSuggestionStatus sets the status of a suggestion
+func UpdateArticleSuggestionStatus(ctx context.Context, suggestion *ArticleSuggestion, status ArticleSuggestionStatus) error {
+	suggestion.Status = status
+	_, err := db.GetEngine(ctx).ID(suggestion.ID).Cols("status").Update(suggestion)
+	return err
+}
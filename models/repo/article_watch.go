@@ -0,0 +1,56 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// ArticleWatch is a user watching the changes to one article. Unlike Watch, which notifies of all
+// the activity of a repository, it only notifies of changes to the README and of change requests.
+type ArticleWatch struct {
+	ID          int64              `xorm:"pk autoincr"`
+	UserID      int64              `xorm:"UNIQUE(article_watch)"`
+	RepoID      int64              `xorm:"UNIQUE(article_watch) INDEX"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+// TableName returns the table name for ArticleWatch
+func (ArticleWatch) TableName() string {
+	return "article_watch"
+}
+
+func init() {
+	db.RegisterModel(new(ArticleWatch))
+}
+
+// IsWatchingArticle checks if the user watches the changes to the article of the repository
+func IsWatchingArticle(ctx context.Context, userID, repoID int64) bool {
+	has, _ := db.GetEngine(ctx).Exist(&ArticleWatch{UserID: userID, RepoID: repoID})
+	return has
+}
+
+// WatchArticle starts or stops watching the changes to the article of the repository
+func WatchArticle(ctx context.Context, userID, repoID int64, doWatch bool) error {
+	if !doWatch {
+		_, err := db.GetEngine(ctx).Delete(&ArticleWatch{UserID: userID, RepoID: repoID})
+		return err
+	}
+	if IsWatchingArticle(ctx, userID, repoID) {
+		return nil
+	}
+	return db.Insert(ctx, &ArticleWatch{UserID: userID, RepoID: repoID})
+}
+
+// GetArticleWatcherIDs returns the IDs of the users watching the changes to the article of the repository
+func GetArticleWatcherIDs(ctx context.Context, repoID int64) ([]int64, error) {
+	ids := make([]int64, 0, 10)
+	return ids, db.GetEngine(ctx).Table("article_watch").
+		Where("repo_id = ?", repoID).
+		Cols("user_id").
+		Find(&ids)
+}
@@ -39,6 +39,27 @@ func GetForkedRepo(ctx context.Context, ownerID, repoID int64) (*Repository, err
 	return nil, nil
 }
 
+// GetForkedRepos returns the forks the given user owns of the repositories with the given IDs in
+// a single query, keyed by the ID of the forked repository. Repositories the user hasn't forked
+// are missing from the map.
+func GetForkedRepos(ctx context.Context, ownerID int64, repoIDs []int64) (map[int64]*Repository, error) {
+	forks := make(map[int64]*Repository, len(repoIDs))
+	if len(repoIDs) == 0 {
+		return forks, nil
+	}
+	repos := make([]*Repository, 0, len(repoIDs))
+	if err := db.GetEngine(ctx).
+		Where("owner_id=?", ownerID).
+		In("fork_id", repoIDs).
+		Find(&repos); err != nil {
+		return nil, err
+	}
+	for _, repo := range repos {
+		forks[repo.ForkID] = repo
+	}
+	return forks, nil
+}
+
 // HasForkedRepo checks if given user has already forked a repository with given ID.
 func HasForkedRepo(ctx context.Context, ownerID, repoID int64) bool {
 	has, _ := db.GetEngine(ctx).
@@ -73,6 +94,25 @@ func DecrementRepoForkNum(ctx context.Context, repoID int64) error {
 	return err
 }
 
+// FindOrphanedForks returns forks whose ForkID references a repository that no longer exists
+func FindOrphanedForks(ctx context.Context) ([]*Repository, error) {
+	repos := make([]*Repository, 0, 10)
+	return repos, db.GetEngine(ctx).
+		Where(builder.Eq{"is_fork": true}).
+		And(builder.NotIn("fork_id", builder.Select("id").From("repository"))).
+		OrderBy("id ASC").
+		Find(&repos)
+}
+
+// FindRepoIDsWithForkCountDrift returns the IDs of the repositories whose stored NumForks
+// differs from the number of repositories that reference them as their fork
+func FindRepoIDsWithForkCountDrift(ctx context.Context) ([]int64, error) {
+	repoIDs := make([]int64, 0, 10)
+	return repoIDs, db.GetEngine(ctx).
+		SQL("SELECT repo.id FROM `repository` repo WHERE repo.num_forks != (SELECT COUNT(*) FROM `repository` WHERE fork_id = repo.id) ORDER BY repo.id ASC").
+		Find(&repoIDs)
+}
+
 // FindUserOrgForks returns the forked repositories for one user from a repository
 func FindUserOrgForks(ctx context.Context, repoID, userID int64) ([]*Repository, error) {
 	cond := builder.And(
@@ -6,6 +6,7 @@ package repo_test
 import (
 	"testing"
 
+	"code.gitea.io/gitea/models/db"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unittest"
 
@@ -30,3 +31,49 @@ func TestGetUserFork(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Nil(t, repo)
 }
+
+func TestGetForkedRepos(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	// User20 has repo 29 forked from repo27 and repo 30 forked from repo28, but no fork of repo10
+	forks, err := repo_model.GetForkedRepos(t.Context(), 20, []int64{10, 27, 28, 999})
+	assert.NoError(t, err)
+	assert.Len(t, forks, 2)
+	if assert.Contains(t, forks, int64(27)) {
+		assert.EqualValues(t, 29, forks[27].ID)
+	}
+	if assert.Contains(t, forks, int64(28)) {
+		assert.EqualValues(t, 30, forks[28].ID)
+	}
+
+	// Forks of other users are not returned
+	forks, err = repo_model.GetForkedRepos(t.Context(), 13, []int64{10, 27, 28})
+	assert.NoError(t, err)
+	assert.Len(t, forks, 1)
+	if assert.Contains(t, forks, int64(10)) {
+		assert.EqualValues(t, 11, forks[10].ID)
+	}
+
+	forks, err = repo_model.GetForkedRepos(t.Context(), 20, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, forks)
+}
+
+func TestFindOrphanedForks(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	orphans, err := repo_model.FindOrphanedForks(t.Context())
+	assert.NoError(t, err)
+	assert.Empty(t, orphans)
+
+	// Delete repo10 out from under its fork repo11
+	_, err = db.DeleteByID[repo_model.Repository](t.Context(), 10)
+	assert.NoError(t, err)
+
+	orphans, err = repo_model.FindOrphanedForks(t.Context())
+	assert.NoError(t, err)
+	if assert.Len(t, orphans, 1) {
+		assert.EqualValues(t, 11, orphans[0].ID)
+		assert.EqualValues(t, 10, orphans[0].ForkID)
+	}
+}
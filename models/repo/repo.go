@@ -974,6 +974,7 @@ func GetPublicRepositoryBySubject(ctx context.Context, subjectName string) (*Rep
 // This function finds the first non-fork, non-empty repository created for the subject, ordered by creation time.
 // Only non-empty repositories are considered as roots because the first-article-becomes-root logic
 // should only trigger when a user commits content, not when they create an empty repository.
+// The subject's RootRepoID pointer is used directly while it still references such a repository.
 // Returns ErrRepoNotExist if no root repository exists for the subject.
 func GetSubjectRootRepository(ctx context.Context, subjectID int64) (*Repository, error) {
 	return GetSubjectRootRepositoryExcluding(ctx, subjectID, 0)
@@ -986,6 +987,13 @@ func GetSubjectRootRepository(ctx context.Context, subjectID int64) (*Repository
 // if another repository was the first to have content committed.
 // Returns ErrRepoNotExist if no root repository exists for the subject (excluding the specified repo).
 func GetSubjectRootRepositoryExcluding(ctx context.Context, subjectID, excludeRepoID int64) (*Repository, error) {
+	// Short-circuit to the subject's root pointer while it is still valid
+	if rootRepo, err := getSubjectRootRepositoryByPointer(ctx, subjectID, excludeRepoID); err != nil {
+		return nil, err
+	} else if rootRepo != nil {
+		return rootRepo, nil
+	}
+
 	var repo Repository
 	sess := db.GetEngine(ctx).
 		Where("subject_id = ?", subjectID).
@@ -1007,6 +1015,56 @@ func GetSubjectRootRepositoryExcluding(ctx context.Context, subjectID, excludeRe
 	return &repo, nil
 }
 
+// getSubjectRootRepositoryByPointer returns the repository referenced by Subject.RootRepoID,
+// or nil if the pointer is unset, references the excluded repository or is stale.
+func getSubjectRootRepositoryByPointer(ctx context.Context, subjectID, excludeRepoID int64) (*Repository, error) {
+	var subject Subject
+	has, err := db.GetEngine(ctx).ID(subjectID).Cols("root_repo_id").Get(&subject)
+	if err != nil || !has || subject.RootRepoID == 0 || subject.RootRepoID == excludeRepoID {
+		return nil, err
+	}
+
+	repo, err := GetRepositoryByID(ctx, subject.RootRepoID)
+	if err != nil {
+		if IsErrRepoNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if repo.SubjectID != subjectID || repo.IsFork || repo.IsEmpty {
+		return nil, nil
+	}
+	return repo, nil
+}
+
+// GetOldestNonEmptyRepositoryBySubject returns the oldest non-empty repository of a subject,
+// whether or not it is a fork. Returns ErrRepoNotExist if every repository of the subject is empty.
+func GetOldestNonEmptyRepositoryBySubject(ctx context.Context, subjectID int64) (*Repository, error) {
+	var repo Repository
+	has, err := db.GetEngine(ctx).
+		Where("subject_id = ?", subjectID).
+		And("is_empty = ?", false).
+		OrderBy("created_unix ASC, id ASC").
+		Get(&repo)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrRepoNotExist{ID: 0, UID: 0, OwnerName: "", Name: ""}
+	}
+	return &repo, nil
+}
+
+// FindNonEmptyRepositoriesBySubject returns all non-empty repositories of a subject, oldest first
+func FindNonEmptyRepositoriesBySubject(ctx context.Context, subjectID int64) (RepositoryList, error) {
+	repos := make(RepositoryList, 0, 10)
+	return repos, db.GetEngine(ctx).
+		Where("subject_id = ?", subjectID).
+		And("is_empty = ?", false).
+		OrderBy("created_unix ASC, id ASC").
+		Find(&repos)
+}
+
 // GetRepositoryByOwnerAndSubject returns a repository by owner name and subject name.
 // This function returns the specific user's repository (whether it's a root or fork).
 func GetRepositoryByOwnerAndSubject(ctx context.Context, ownerName, subjectName string) (*Repository, error) {
@@ -1037,21 +1095,35 @@ func GetRepositoryByOwnerAndSubject(ctx context.Context, ownerName, subjectName
 	return &repo, nil
 }
 
-// GetRepositoryByOwnerIDAndSubjectID returns a repository by owner ID and subject ID.
-// Returns nil if no such repository exists (without error).
-func GetRepositoryByOwnerIDAndSubjectID(ctx context.Context, ownerID, subjectID int64) (*Repository, error) {
-	var repo Repository
-	has, err := db.GetEngine(ctx).
+// GetRepositoriesByOwnerIDAndSubjectID returns the repositories of a subject owned by ownerID, oldest first
+func GetRepositoriesByOwnerIDAndSubjectID(ctx context.Context, ownerID, subjectID int64) (RepositoryList, error) {
+	repos := make(RepositoryList, 0, 1)
+	return repos, db.GetEngine(ctx).
 		Where("owner_id = ?", ownerID).
 		And("subject_id = ?", subjectID).
-		Get(&repo)
-	if err != nil {
-		return nil, err
+		OrderBy("id ASC").
+		Find(&repos)
+}
+
+// FindSubjectRepositoriesByOwnerID returns the repositories owned by ownerID that belong to a subject which
+// isn't deleted, ordered by subject, like GetRepositoriesByOwnerIDAndSubjectID for all the subjects at once.
+// Private repositories are left out unless includePrivate is set.
+func FindSubjectRepositoriesByOwnerID(ctx context.Context, ownerID int64, includePrivate bool, listOptions db.ListOptions) (RepositoryList, int64, error) {
+	cond := builder.And(
+		builder.Eq{"owner_id": ownerID},
+		builder.In("subject_id", builder.Select("id").From("subject").Where(builder.Eq{"deleted_unix": 0})),
+	)
+	if !includePrivate {
+		cond = cond.And(builder.Eq{"is_private": false})
 	}
-	if !has {
-		return nil, nil
+
+	sess := db.GetEngine(ctx).Where(cond).OrderBy("subject_id ASC, id ASC")
+	if listOptions.Page > 0 {
+		sess = db.SetSessionPagination(sess, &listOptions)
 	}
-	return &repo, nil
+	repos := make(RepositoryList, 0, listOptions.PageSize)
+	count, err := sess.FindAndCount(&repos)
+	return repos, count, err
 }
 
 // GetRepositoriesBySubjectIDAndOwners returns repositories for the given subject ID and owner names.
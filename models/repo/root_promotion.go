@@ -0,0 +1,105 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// ErrRootPromotionNotExist represents a "RootPromotionNotExist" kind of error.
+type ErrRootPromotionNotExist struct {
+	ID int64
+}
+
+// IsErrRootPromotionNotExist checks if an error is an ErrRootPromotionNotExist.
+func IsErrRootPromotionNotExist(err error) bool {
+	_, ok := err.(ErrRootPromotionNotExist)
+	return ok
+}
+
+func (err ErrRootPromotionNotExist) Error() string {
+	return fmt.Sprintf("root promotion does not exist [id: %d]", err.ID)
+}
+
+func (err ErrRootPromotionNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// RootPromotion is a pending request to make a fork the root repository of its subject
+// in place of the current root, waiting for the approval of a site administrator.
+// It is recorded instead of swapping the repositories immediately when
+// [repository] REQUIRE_ROOT_PROMOTION_APPROVAL is enabled.
+type RootPromotion struct {
+	ID          int64              `xorm:"pk autoincr"`
+	SubjectID   int64              `xorm:"INDEX NOT NULL"`
+	RepoID      int64              `xorm:"UNIQUE NOT NULL"`
+	Repo        *Repository        `xorm:"-"`
+	RootRepoID  int64              `xorm:"INDEX NOT NULL"`
+	RootRepo    *Repository        `xorm:"-"`
+	DoerID      int64              `xorm:"NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+}
+
+// TableName returns the table name for RootPromotion
+func (RootPromotion) TableName() string {
+	return "root_promotion"
+}
+
+func init() {
+	db.RegisterModel(new(RootPromotion))
+}
+
+// CreateRootPromotion records a pending root promotion, unless one already exists for the repository
+func CreateRootPromotion(ctx context.Context, promotion *RootPromotion) error {
+	has, err := db.GetEngine(ctx).Exist(&RootPromotion{RepoID: promotion.RepoID})
+	if err != nil || has {
+		return err
+	}
+	return db.Insert(ctx, promotion)
+}
+
+// GetRootPromotionByID returns the pending root promotion with the given ID
+func GetRootPromotionByID(ctx context.Context, id int64) (*RootPromotion, error) {
+	promotion := new(RootPromotion)
+	if has, err := db.GetEngine(ctx).ID(id).Get(promotion); err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrRootPromotionNotExist{ID: id}
+	}
+	return promotion, nil
+}
+
+// FindPendingRootPromotions returns all pending root promotions, oldest first,
+// with their repositories loaded
+func FindPendingRootPromotions(ctx context.Context) ([]*RootPromotion, error) {
+	promotions := make([]*RootPromotion, 0, 10)
+	if err := db.GetEngine(ctx).Asc("created_unix", "id").Find(&promotions); err != nil {
+		return nil, err
+	}
+
+	repoIDs := make([]int64, 0, len(promotions)*2)
+	for _, promotion := range promotions {
+		repoIDs = append(repoIDs, promotion.RepoID, promotion.RootRepoID)
+	}
+	repos, err := GetRepositoriesMapByIDs(ctx, repoIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, promotion := range promotions {
+		promotion.Repo = repos[promotion.RepoID]
+		promotion.RootRepo = repos[promotion.RootRepoID]
+	}
+	return promotions, nil
+}
+
+// DeleteRootPromotion removes a pending root promotion once it has been approved or rejected
+func DeleteRootPromotion(ctx context.Context, id int64) error {
+	_, err := db.GetEngine(ctx).ID(id).Delete(new(RootPromotion))
+	return err
+}
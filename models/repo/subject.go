@@ -10,10 +10,15 @@ import (
 	"regexp"
 	"slices"
 	"strings"
+	"time"
 	"unicode"
 
 	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/phonetic"
+	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
 
 	"golang.org/x/text/runes"
 	"golang.org/x/text/transform"
@@ -30,8 +35,26 @@ type Subject struct {
 	ID          int64              `xorm:"pk autoincr"`
 	Name        string             `xorm:"VARCHAR(255) NOT NULL"`        // Display name (can contain special chars)
 	Slug        string             `xorm:"VARCHAR(255) UNIQUE NOT NULL"` // URL-safe slug (globally unique)
+	PhoneticKey string             `xorm:"VARCHAR(64) INDEX"`            // Double Metaphone key of the name, see EnablePhoneticSubjectSearch
+	RootRepoID  int64              `xorm:"INDEX"`                        // Canonical root repository, 0 if unknown (may be stale, see GetSubjectRootRepository)
 	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
 	UpdatedUnix timeutil.TimeStamp `xorm:"INDEX updated"`
+	DeletedUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`              // Soft-delete time, 0 if the subject is visible
+	Lang        string             `xorm:"VARCHAR(10) INDEX NOT NULL DEFAULT ''"` // Language code of the articles (e.g. "en"), empty if unknown
+	Description string             `xorm:"TEXT"`                                  // Short summary of what the subject covers, empty if none
+
+	IsFeatured    bool `xorm:"INDEX NOT NULL DEFAULT false"` // Curated by an administrator onto the explore page
+	FeaturedOrder int  `xorm:"NOT NULL DEFAULT 0"`           // Position among the featured subjects, lowest first
+}
+
+// IsDeleted reports whether the subject has been soft-deleted
+func (s *Subject) IsDeleted() bool {
+	return s.DeletedUnix > 0
+}
+
+// HTMLURL returns the absolute URL of the subject view
+func (s *Subject) HTMLURL() string {
+	return setting.AppURL + "subject/" + util.PathEscapeSegments(s.Name)
 }
 
 func init() {
@@ -88,18 +111,57 @@ func GenerateSlugFromName(name string) string {
 	return slug
 }
 
+// subjectPhoneticKey returns the phonetic key stored for a subject name,
+// or an empty string when phonetic subject search is disabled
+func subjectPhoneticKey(name string) string {
+	if !setting.Repository.EnablePhoneticSubjectSearch {
+		return ""
+	}
+	key, _ := phonetic.NameKeys(name)
+	return key
+}
+
+// reservedSubjectSlugs can't be taken by subjects created on purpose: "subject" is the slug of
+// names without any letter or digit, the others are used by the subject routes
+var reservedSubjectSlugs = []string{"subject", "search", "sitemap", "new", "compare"}
+
+// IsUsableSubjectSlug returns an error if the slug can't be taken by a subject created on purpose
+func IsUsableSubjectSlug(slug string) error {
+	return db.IsUsableName(reservedSubjectSlugs, nil, slug)
+}
+
+// CreateSubjectOptions holds the fields of a subject to create
+type CreateSubjectOptions struct {
+	Name        string
+	Description string
+	Lang        string
+}
+
 // CreateSubject creates a new subject with the given name
 // Returns ErrSubjectSlugAlreadyExists if a subject with the same slug already exists
 func CreateSubject(ctx context.Context, name string) (*Subject, error) {
+	return CreateSubjectWithOptions(ctx, CreateSubjectOptions{Name: name})
+}
+
+// CreateSubjectWithOptions creates a new subject with a description and language
+// Returns ErrSubjectSlugAlreadyExists if a subject with the same slug already exists
+func CreateSubjectWithOptions(ctx context.Context, opts CreateSubjectOptions) (*Subject, error) {
+	name := opts.Name
 	if name == "" {
 		return nil, errors.New("subject name cannot be empty")
 	}
+	if len(opts.Lang) > MaxSubjectLangLength {
+		return nil, fmt.Errorf("subject language %q is too long (maximum %d characters)", opts.Lang, MaxSubjectLangLength)
+	}
 
 	slug := GenerateSlugFromName(name)
 
 	subject := &Subject{
-		Name: name,
-		Slug: slug,
+		Name:        name,
+		Slug:        slug,
+		PhoneticKey: subjectPhoneticKey(name),
+		Description: opts.Description,
+		Lang:        opts.Lang,
 	}
 
 	// Use transaction to prevent race conditions
@@ -132,7 +194,104 @@ func CreateSubject(ctx context.Context, name string) (*Subject, error) {
 	return subject, nil
 }
 
+// BulkCreateSubjectsResult reports the outcome of BulkCreateSubjects
+type BulkCreateSubjectsResult struct {
+	Created []*Subject // subjects that were inserted
+	Skipped []string   // names skipped because their slug already exists or repeats within the batch
+}
+
+// BulkCreateSubjects creates many subjects at once, e.g. when seeding an instance from a taxonomy.
+// Names whose slug is already taken, either by an existing subject or by an earlier name in the
+// same list, are skipped. Existing slugs are looked up with one query per batch and the remaining
+// subjects are inserted in batches inside a single transaction.
+func BulkCreateSubjects(ctx context.Context, names []string) (*BulkCreateSubjectsResult, error) {
+	result := &BulkCreateSubjectsResult{}
+
+	// Deduplicate within the list, keeping the first name for each slug
+	pending := make([]*Subject, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if len(name) > MaxSubjectNameLength {
+			return nil, fmt.Errorf("subject name %q is too long (maximum %d characters)", name, MaxSubjectNameLength)
+		}
+		slug := GenerateSlugFromName(name)
+		if seen[slug] {
+			result.Skipped = append(result.Skipped, name)
+			continue
+		}
+		seen[slug] = true
+		pending = append(pending, &Subject{Name: name, Slug: slug, PhoneticKey: subjectPhoneticKey(name)})
+	}
+
+	err := db.WithTx(ctx, func(ctx context.Context) error {
+		batchSize := db.MaxBatchInsertSize(new(Subject))
+		for start := 0; start < len(pending); start += batchSize {
+			batch := pending[start:min(start+batchSize, len(pending))]
+
+			slugs := make([]string, 0, len(batch))
+			for _, subject := range batch {
+				slugs = append(slugs, subject.Slug)
+			}
+			var existing []string
+			if err := db.GetEngine(ctx).Table("subject").In("slug", slugs).Cols("slug").Find(&existing); err != nil {
+				return err
+			}
+			existingSlugs := make(map[string]bool, len(existing))
+			for _, slug := range existing {
+				existingSlugs[slug] = true
+			}
+
+			toInsert := make([]*Subject, 0, len(batch))
+			for _, subject := range batch {
+				if existingSlugs[subject.Slug] {
+					result.Skipped = append(result.Skipped, subject.Name)
+					continue
+				}
+				toInsert = append(toInsert, subject)
+			}
+			if len(toInsert) == 0 {
+				continue
+			}
+			if err := db.Insert(ctx, toInsert); err != nil {
+				return err
+			}
+
+			// A multi-row insert doesn't fill in the autoincrement IDs, load the inserted rows back
+			insertedSlugs := make([]string, 0, len(toInsert))
+			for _, subject := range toInsert {
+				insertedSlugs = append(insertedSlugs, subject.Slug)
+			}
+			inserted := make([]*Subject, 0, len(toInsert))
+			if err := db.GetEngine(ctx).In("slug", insertedSlugs).Find(&inserted); err != nil {
+				return err
+			}
+			insertedBySlug := make(map[string]*Subject, len(inserted))
+			for _, subject := range inserted {
+				insertedBySlug[subject.Slug] = subject
+			}
+			for _, subject := range toInsert {
+				loaded, ok := insertedBySlug[subject.Slug]
+				if !ok {
+					return fmt.Errorf("inserted subject %q not found", subject.Slug)
+				}
+				result.Created = append(result.Created, loaded)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // GetOrCreateSubject gets an existing subject by slug or creates a new one if it doesn't exist
+// Returns ErrSubjectDeleted if the subject with the slug has been soft-deleted, only RestoreSubject
+// can bring it back.
 // This function is idempotent and safe for concurrent use
 func GetOrCreateSubject(ctx context.Context, name string) (*Subject, error) {
 	// Validate subject name
@@ -152,13 +311,14 @@ func GetOrCreateSubject(ctx context.Context, name string) (*Subject, error) {
 		return nil, err
 	}
 	if has {
-		return subject, nil
+		return checkReusedSubject(subject)
 	}
 
 	// Create new subject
 	subject = &Subject{
-		Name: name,
-		Slug: slug,
+		Name:        name,
+		Slug:        slug,
+		PhoneticKey: subjectPhoneticKey(name),
 	}
 
 	if err := db.Insert(ctx, subject); err != nil {
@@ -170,7 +330,7 @@ func GetOrCreateSubject(ctx context.Context, name string) (*Subject, error) {
 			return nil, err
 		}
 		if has {
-			return subject, nil
+			return checkReusedSubject(subject)
 		}
 		return nil, fmt.Errorf("failed to create subject: %w", err)
 	}
@@ -178,7 +338,17 @@ func GetOrCreateSubject(ctx context.Context, name string) (*Subject, error) {
 	return subject, nil
 }
 
-// GetSubjectByID gets a subject by its ID
+// checkReusedSubject refuses to reuse a soft-deleted subject for a new article, the article would
+// either be hidden with it or undo the deletion of a moderator
+func checkReusedSubject(subject *Subject) (*Subject, error) {
+	if subject.IsDeleted() {
+		return nil, ErrSubjectDeleted{ID: subject.ID, Slug: subject.Slug}
+	}
+	return subject, nil
+}
+
+// GetSubjectByID gets a subject by its ID, including a soft-deleted one since its articles still
+// reference it. Callers showing the subject must check IsDeleted.
 func GetSubjectByID(ctx context.Context, id int64) (*Subject, error) {
 	subject := &Subject{ID: id}
 	has, err := db.GetEngine(ctx).Get(subject)
@@ -191,10 +361,10 @@ func GetSubjectByID(ctx context.Context, id int64) (*Subject, error) {
 	return subject, nil
 }
 
-// GetSubjectByName gets a subject by its name (exact match)
+// GetSubjectByName gets a subject by its name (exact match), ignoring soft-deleted subjects
 func GetSubjectByName(ctx context.Context, name string) (*Subject, error) {
 	subject := &Subject{Name: name}
-	has, err := db.GetEngine(ctx).Get(subject)
+	has, err := db.GetEngine(ctx).Where("deleted_unix = ?", 0).Get(subject)
 	if err != nil {
 		return nil, err
 	}
@@ -204,10 +374,10 @@ func GetSubjectByName(ctx context.Context, name string) (*Subject, error) {
 	return subject, nil
 }
 
-// GetSubjectBySlug gets a subject by its slug
+// GetSubjectBySlug gets a subject by its slug, ignoring soft-deleted subjects
 func GetSubjectBySlug(ctx context.Context, slug string) (*Subject, error) {
 	subject := &Subject{Slug: slug}
-	has, err := db.GetEngine(ctx).Get(subject)
+	has, err := db.GetEngine(ctx).Where("deleted_unix = ?", 0).Get(subject)
 	if err != nil {
 		return nil, err
 	}
@@ -219,11 +389,87 @@ func GetSubjectBySlug(ctx context.Context, slug string) (*Subject, error) {
 
 // UpdateSubject updates a subject's properties
 func UpdateSubject(ctx context.Context, subject *Subject) error {
+	subject.PhoneticKey = subjectPhoneticKey(subject.Name)
 	_, err := db.GetEngine(ctx).ID(subject.ID).AllCols().Update(subject)
 	return err
 }
 
-// DeleteSubject deletes a subject (only if no repositories reference it)
+// SubjectTouchInterval is the minimum time between two activity bumps of a subject's UpdatedUnix
+const SubjectTouchInterval = time.Minute
+
+// TouchSubject bumps a subject's UpdatedUnix after activity in one of its repositories.
+// It is skipped if the subject was updated within SubjectTouchInterval to avoid write
+// amplification on busy subjects.
+func TouchSubject(ctx context.Context, subjectID int64, updateTime time.Time) error {
+	_, err := db.GetEngine(ctx).Exec("UPDATE subject SET updated_unix = ? WHERE id = ? AND updated_unix < ?",
+		updateTime.Unix(), subjectID, updateTime.Add(-SubjectTouchInterval).Unix())
+	return err
+}
+
+// SetSubjectRootRepoID records repoID as the canonical root repository of a subject.
+// Passing 0 clears the pointer so that the root is computed again.
+func SetSubjectRootRepoID(ctx context.Context, subjectID, repoID int64) error {
+	_, err := db.GetEngine(ctx).ID(subjectID).Cols("root_repo_id").NoAutoTime().Update(&Subject{RootRepoID: repoID})
+	return err
+}
+
+// staleSubjectRootCond matches subjects whose root pointer does not reference
+// a non-fork, non-empty repository of the same subject
+func staleSubjectRootCond() builder.Cond {
+	return builder.Gt{"subject.root_repo_id": 0}.And(builder.NotIn("subject.root_repo_id",
+		builder.Select("repository.id").From("repository").
+			Where(builder.Eq{"repository.is_fork": false, "repository.is_empty": false}.
+				And(builder.Expr("repository.subject_id = subject.id")))))
+}
+
+// CountSubjectsWithStaleRoot counts subjects whose root pointer is stale
+func CountSubjectsWithStaleRoot(ctx context.Context) (int64, error) {
+	return db.GetEngine(ctx).Where(staleSubjectRootCond()).Count(new(Subject))
+}
+
+// ClearStaleSubjectRoots clears stale root pointers so the root is computed again
+func ClearStaleSubjectRoots(ctx context.Context) (int64, error) {
+	return db.GetEngine(ctx).Where(staleSubjectRootCond()).Cols("root_repo_id").NoAutoTime().Update(&Subject{RootRepoID: 0})
+}
+
+// SoftDeleteSubject hides a subject from explore and search without deleting any data
+func SoftDeleteSubject(ctx context.Context, id int64) error {
+	_, err := db.GetEngine(ctx).ID(id).Cols("deleted_unix").NoAutoTime().Update(&Subject{DeletedUnix: timeutil.TimeStampNow()})
+	return err
+}
+
+// RestoreSubject makes a soft-deleted subject visible again
+func RestoreSubject(ctx context.Context, id int64) error {
+	_, err := db.GetEngine(ctx).ID(id).Cols("deleted_unix").NoAutoTime().Update(&Subject{DeletedUnix: 0})
+	return err
+}
+
+// MaxSubjectLangLength is the maximum length of a subject language code
+const MaxSubjectLangLength = 10
+
+// SetSubjectLangIfEmpty records the language of a subject unless one is already set,
+// so that the first import to declare a language wins
+func SetSubjectLangIfEmpty(ctx context.Context, subjectID int64, lang string) error {
+	if lang == "" {
+		return nil
+	}
+	if len(lang) > MaxSubjectLangLength {
+		return fmt.Errorf("subject language %q is too long (maximum %d characters)", lang, MaxSubjectLangLength)
+	}
+	_, err := db.GetEngine(ctx).ID(subjectID).Where(builder.Eq{"lang": ""}).
+		Cols("lang").NoAutoTime().Update(&Subject{Lang: lang})
+	return err
+}
+
+// FindSubjectLangs returns the distinct languages of visible subjects, sorted
+func FindSubjectLangs(ctx context.Context) ([]string, error) {
+	langs := make([]string, 0, 5)
+	return langs, db.GetEngine(ctx).Table("subject").
+		Where(builder.Neq{"lang": ""}.And(builder.Eq{"deleted_unix": 0})).
+		Distinct("lang").Asc("lang").Find(&langs)
+}
+
+// DeleteSubject permanently deletes a subject (only if no repositories reference it)
 func DeleteSubject(ctx context.Context, id int64) error {
 	// Check if any repositories reference this subject
 	count, err := db.GetEngine(ctx).Where("subject_id = ?", id).Count(new(Repository))
@@ -238,6 +484,17 @@ func DeleteSubject(ctx context.Context, id int64) error {
 	return err
 }
 
+// SetSubjectFeatured features the subject on the explore page at the given position among
+// the featured subjects, or stops featuring it
+func SetSubjectFeatured(ctx context.Context, subjectID int64, featured bool, order int) error {
+	if !featured {
+		order = 0
+	}
+	_, err := db.GetEngine(ctx).ID(subjectID).Cols("is_featured", "featured_order").NoAutoTime().
+		Update(&Subject{IsFeatured: featured, FeaturedOrder: order})
+	return err
+}
+
 // FindSubjects finds subjects based on options
 func FindSubjects(ctx context.Context, opts FindSubjectsOptions) ([]*Subject, int64, error) {
 	sess := db.GetEngine(ctx).Where(opts.ToConds())
@@ -266,6 +523,12 @@ type FindSubjectsOptions struct {
 	OrderBy        string
 	ExcludeIDs     []int64 // IDs to exclude from results
 	ExactMatchOnly bool    // Only find exact matches
+	IncludeDeleted bool    // Also find soft-deleted subjects
+	MinRepos       int64   // Only find subjects with at least this many repositories
+	Lang           string  // Only find subjects in this language, empty for all
+	FeaturedOnly   bool    // Only find featured subjects
+	HasForks       bool    // Only find subjects with a non-empty fork, see SubjectRepoCounts.ForkRepoCount
+	HasPublicRepo  bool    // Only find subjects with a public non-empty repository, which the subject view shows
 }
 
 // ToConds converts options to database conditions
@@ -280,35 +543,87 @@ func (opts FindSubjectsOptions) ToConds() builder.Cond {
 			cond = cond.And(builder.Like{"LOWER(name)", strings.ToLower(opts.Keyword)})
 		}
 	}
+	return cond.And(opts.filterConds())
+}
+
+// filterConds converts the options other than the keyword to database conditions
+func (opts FindSubjectsOptions) filterConds() builder.Cond {
+	cond := builder.NewCond()
 	if len(opts.ExcludeIDs) > 0 {
 		cond = cond.And(builder.NotIn("id", opts.ExcludeIDs))
 	}
+	if !opts.IncludeDeleted {
+		cond = cond.And(builder.Eq{"deleted_unix": 0})
+	}
+	if opts.Lang != "" {
+		cond = cond.And(builder.Eq{"lang": opts.Lang})
+	}
+	if opts.FeaturedOnly {
+		cond = cond.And(builder.Eq{"is_featured": true})
+	}
+	if opts.MinRepos > 0 {
+		// Filter in the database so that the total count and pagination stay correct
+		cond = cond.And(builder.In("id",
+			builder.Select("subject_id").From("repository").
+				GroupBy("subject_id").
+				Having(builder.Expr("COUNT(*) >= ?", opts.MinRepos)),
+		))
+	}
+	if opts.HasForks {
+		cond = cond.And(builder.In("id",
+			builder.Select("subject_id").From("repository").
+				Where(builder.Eq{"is_fork": true, "is_empty": false}),
+		))
+	}
+	if opts.HasPublicRepo {
+		cond = cond.And(builder.In("id",
+			builder.Select("subject_id").From("repository").
+				Where(builder.Eq{"is_private": false, "is_empty": false}),
+		))
+	}
 	return cond
 }
 
-// FindSimilarSubjects finds subjects similar to the given keyword
-// It returns subjects that partially match the keyword, excluding exact matches
-func FindSimilarSubjects(ctx context.Context, keyword string, limit int, excludeIDs []int64) ([]*Subject, error) {
-	if keyword == "" {
-		return nil, nil
+// similarSubjectsWindow is the number of most recently updated candidates FindSimilarSubjects
+// scores. The window doesn't depend on the requested page, so the order is the same on every page.
+const similarSubjectsWindow = 200
+
+// FindSimilarSubjects finds subjects similar to opts.Keyword
+// It returns a page of the subjects that partially match the keyword, excluding exact matches,
+// ordered by relevance, and the number of similar subjects on all pages. The other options filter
+// the candidates in the database, before they are scored and paginated.
+func FindSimilarSubjects(ctx context.Context, opts FindSubjectsOptions) ([]*Subject, int64, error) {
+	if opts.Keyword == "" {
+		return nil, 0, nil
 	}
 
-	keyword = strings.ToLower(strings.TrimSpace(keyword))
+	keyword := strings.ToLower(strings.TrimSpace(opts.Keyword))
 
 	// Find subjects that contain the keyword but are not exact matches
-	// Fetch more results than needed for better scoring, then trim to limit after sorting
-	fetchLimit := limit * 2
-	subjects := make([]*Subject, 0, fetchLimit)
-	sess := db.GetEngine(ctx).
-		Where("LOWER(name) LIKE ? AND LOWER(name) != ?", "%"+keyword+"%", keyword)
-	if len(excludeIDs) > 0 {
-		sess = sess.NotIn("id", excludeIDs)
-	}
-	err := sess.OrderBy("updated_unix DESC").
-		Limit(fetchLimit).
+	// Fetch a fixed window of candidates for scoring, then slice the requested page after sorting
+	subjects := make([]*Subject, 0, similarSubjectsWindow)
+	var cond builder.Cond = builder.Like{"LOWER(name)", keyword}
+	if tokens := subjectSearchTokens(keyword); len(tokens) > 1 {
+		// Multi-word keywords also match subjects containing any of the words,
+		// e.g. "moon apollo" should find "Apollo Moon Landing"
+		for _, token := range tokens {
+			cond = builder.Or(cond, builder.Like{"LOWER(name)", token})
+		}
+	}
+	if setting.Repository.EnablePhoneticSubjectSearch {
+		// Also include subjects that sound like the keyword
+		primary, alternate := phonetic.NameKeys(keyword)
+		if primary != "" {
+			cond = builder.Or(cond, builder.In("phonetic_key", primary, alternate))
+		}
+	}
+	cond = builder.And(cond, builder.Neq{"LOWER(name)": keyword}, opts.filterConds())
+	err := db.GetEngine(ctx).Where(cond).
+		OrderBy("updated_unix DESC, id DESC").
+		Limit(similarSubjectsWindow).
 		Find(&subjects)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	// Calculate similarity scores and sort by relevance
@@ -323,43 +638,144 @@ func FindSimilarSubjects(ctx context.Context, keyword string, limit int, exclude
 		scoredSubjects = append(scoredSubjects, subjectWithScore{subject, score})
 	}
 
-	// Sort by score (lower is better) using O(n log n) algorithm
-	slices.SortFunc(scoredSubjects, func(a, b subjectWithScore) int {
+	// Sort by score (lower is better), keeping the most recently updated first among equal scores
+	slices.SortStableFunc(scoredSubjects, func(a, b subjectWithScore) int {
 		return a.score - b.score
 	})
 
-	// Extract sorted subjects, trimmed to original limit
-	resultLimit := min(len(scoredSubjects), limit)
-	result := make([]*Subject, 0, resultLimit)
-	for i := range resultLimit {
-		result = append(result, scoredSubjects[i].subject)
+	// Extract the sorted subjects of the requested page
+	skip, take := opts.GetSkipTake()
+	start := min(skip, len(scoredSubjects))
+	end := min(start+take, len(scoredSubjects))
+	result := make([]*Subject, 0, end-start)
+	for _, scored := range scoredSubjects[start:end] {
+		result = append(result, scored.subject)
 	}
 
-	return result, nil
+	return result, int64(len(scoredSubjects)), nil
+}
+
+// FindSubjectsSharingNameTokens returns up to limit subjects, other than the given one, whose
+// names share a word with its name. They are ordered by how many words they share.
+func FindSubjectsSharingNameTokens(ctx context.Context, subject *Subject, limit int) ([]*Subject, error) {
+	tokens := subjectSearchTokens(strings.ToLower(subject.Name))
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	var cond builder.Cond = builder.NewCond()
+	for _, token := range tokens {
+		cond = cond.Or(builder.Like{"LOWER(name)", token})
+	}
+	candidates := make([]*Subject, 0, limit*2)
+	if err := db.GetEngine(ctx).
+		Where(cond).
+		And("id != ?", subject.ID).
+		And("deleted_unix = ?", 0).
+		OrderBy("updated_unix DESC").
+		Limit(limit * 2).
+		Find(&candidates); err != nil {
+		return nil, err
+	}
+
+	// LIKE also matches words that merely contain a token, keep only whole shared words
+	subjects := make([]*Subject, 0, len(candidates))
+	for _, candidate := range candidates {
+		if CountSharedNameTokens(subject.Name, candidate.Name) > 0 {
+			subjects = append(subjects, candidate)
+		}
+	}
+	slices.SortStableFunc(subjects, func(a, b *Subject) int {
+		return CountSharedNameTokens(subject.Name, b.Name) - CountSharedNameTokens(subject.Name, a.Name)
+	})
+	return subjects[:min(len(subjects), limit)], nil
+}
+
+// CountSharedNameTokens returns how many distinct words the names of two subjects share
+func CountSharedNameTokens(a, b string) int {
+	bTokens := subjectSearchTokens(strings.ToLower(b))
+	shared := 0
+	for _, token := range subjectSearchTokens(strings.ToLower(a)) {
+		if slices.Contains(bTokens, token) {
+			shared++
+		}
+	}
+	return shared
+}
+
+// minSubjectSearchTokenLength is the shortest word of a multi-word keyword that is
+// matched on its own, so that "a" or "of" don't match almost every subject
+const minSubjectSearchTokenLength = 2
+
+// subjectSearchTokens splits a lowercased keyword or subject name into distinct words
+func subjectSearchTokens(s string) []string {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	tokens := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if len(field) >= minSubjectSearchTokenLength && !slices.Contains(tokens, field) {
+			tokens = append(tokens, field)
+		}
+	}
+	return tokens
+}
+
+// tokenOverlapScore returns how many tokens of the keyword match a token of the subject
+// name, scaled by the Jaccard index of both token sets into the range [0, 9].
+// A keyword token matches a name token it is a prefix of, so "apol" matches "apollo".
+func tokenOverlapScore(keywordTokens, nameTokens []string) int {
+	if len(keywordTokens) == 0 || len(nameTokens) == 0 {
+		return 0
+	}
+	shared := 0
+	for _, kt := range keywordTokens {
+		for _, nt := range nameTokens {
+			if strings.HasPrefix(nt, kt) {
+				shared++
+				break
+			}
+		}
+	}
+	union := len(keywordTokens) + len(nameTokens) - shared
+	return shared * 9 / union
 }
 
-// calculateSimilarityScore calculates a similarity score between keyword and subject name
-// Lower score means more similar
-// 1 = starts with keyword, 2 = contains keyword at word boundary, 3 = contains keyword anywhere
+// Similarity buckets used by calculateSimilarityScore, lower is more similar.
+// Buckets are spaced by 10 so that the token overlap score can rank subjects within a bucket.
+const (
+	similarityPrefix       = 10 // name starts with the keyword
+	similarityWordBoundary = 20 // keyword starts a word of the name
+	similarityContains     = 30 // keyword appears anywhere in the name
+	similarityTokens       = 40 // some words of a multi-word keyword appear in the name
+	similarityPhonetic     = 50 // name only sounds like the keyword
+)
+
+// calculateSimilarityScore calculates a similarity score between keyword and subject name.
+// Lower score means more similar: the bucket the match falls in, minus how many words
+// keyword and name share (see tokenOverlapScore).
 func calculateSimilarityScore(keyword, subjectName string) int {
 	keyword = strings.ToLower(keyword)
 	subjectName = strings.ToLower(subjectName)
 
-	// Check if subject name starts with keyword
+	bucket := similarityPhonetic
 	if strings.HasPrefix(subjectName, keyword) {
-		return 1
-	}
-
-	// Check if keyword appears at word boundary
-	words := strings.FieldsSeq(subjectName)
-	for word := range words {
-		if strings.HasPrefix(word, keyword) {
-			return 2
+		bucket = similarityPrefix
+	} else if strings.Contains(subjectName, keyword) {
+		bucket = similarityContains
+		for word := range strings.FieldsSeq(subjectName) {
+			if strings.HasPrefix(word, keyword) {
+				bucket = similarityWordBoundary
+				break
+			}
 		}
 	}
 
-	// Keyword is contained somewhere in the name
-	return 3
+	overlap := tokenOverlapScore(subjectSearchTokens(keyword), subjectSearchTokens(subjectName))
+	if bucket == similarityPhonetic && overlap > 0 {
+		bucket = similarityTokens
+	}
+	return bucket - overlap
 }
 
 // SubjectSortType represents the sort type for subjects
@@ -372,6 +788,7 @@ const (
 	SubjectSortOldest         SubjectSortType = "oldest"
 	SubjectSortRecentUpdate   SubjectSortType = "recentupdate"
 	SubjectSortLeastUpdate    SubjectSortType = "leastupdate"
+	SubjectSortFeatured       SubjectSortType = "featured"
 )
 
 // SubjectOrderByMap maps sort types to database ORDER BY clauses
@@ -382,6 +799,7 @@ var SubjectOrderByMap = map[SubjectSortType]string{
 	SubjectSortOldest:         "created_unix ASC",
 	SubjectSortRecentUpdate:   "updated_unix DESC",
 	SubjectSortLeastUpdate:    "updated_unix ASC",
+	SubjectSortFeatured:       "is_featured DESC, featured_order ASC, updated_unix DESC",
 }
 
 // CountRepositoriesBySubject counts the number of repositories for a given subject
@@ -396,16 +814,34 @@ func CountRootRepositoriesBySubject(ctx context.Context, subjectID int64) (int64
 	return db.GetEngine(ctx).Where("subject_id = ? AND is_fork = ? AND is_empty = ?", subjectID, false, false).Count(new(Repository))
 }
 
+// GetSubjectRepositoriesLastUpdate returns the number of repositories of a subject and the
+// latest time one of them was updated
+func GetSubjectRepositoriesLastUpdate(ctx context.Context, subjectID int64) (int64, timeutil.TimeStamp, error) {
+	var result struct {
+		RepoCount   int64              `xorm:"repo_count"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated_unix"`
+	}
+	if _, err := db.GetEngine(ctx).
+		Table("repository").
+		Select("COUNT(*) AS repo_count, MAX(updated_unix) AS updated_unix").
+		Where("subject_id = ?", subjectID).
+		Get(&result); err != nil {
+		return 0, 0, err
+	}
+	return result.RepoCount, result.UpdatedUnix, nil
+}
+
 // SubjectRepoCounts holds repository counts for a subject
 type SubjectRepoCounts struct {
 	SubjectID     int64
 	RepoCount     int64
 	RootRepoCount int64
+	ForkRepoCount int64 // non-empty forks, shown as "viewpoints"
 }
 
 // BatchCountRepositoriesBySubjects counts repositories for multiple subjects in a single query.
-// It returns a map of subject ID to SubjectRepoCounts containing both total repository count
-// and root (non-fork, non-empty) repository count for each subject.
+// It returns a map of subject ID to SubjectRepoCounts containing the total repository count,
+// the root (non-fork) repository count and the non-empty fork count for each subject.
 //
 // Note: If a subject ID doesn't exist in the database or has no repositories, the returned
 // SubjectRepoCounts will have zero values for RepoCount and RootRepoCount. This is intentional
@@ -465,9 +901,114 @@ func BatchCountRepositoriesBySubjects(ctx context.Context, subjectIDs []int64) (
 		}
 	}
 
+	// Count non-empty forks per subject
+	var forkCounts []countResult
+	err = db.GetEngine(ctx).
+		Table("repository").
+		Select("subject_id, COUNT(*) as count").
+		In("subject_id", subjectIDs).
+		And("is_fork = ? AND is_empty = ?", true, false).
+		GroupBy("subject_id").
+		Find(&forkCounts)
+	if err != nil {
+		return nil, fmt.Errorf("count fork repositories: %w", err)
+	}
+
+	for _, c := range forkCounts {
+		if counts, ok := result[c.SubjectID]; ok {
+			counts.ForkRepoCount = c.Count
+		}
+	}
+
 	return result, nil
 }
 
+// FindSubjectsWithoutRoot returns the subjects that have repositories but no root repository,
+// e.g. because every repository is still empty or the root was deleted.
+func FindSubjectsWithoutRoot(ctx context.Context) ([]*Subject, error) {
+	// A root is a non-fork, non-empty repository, see CountRootRepositoriesBySubject
+	cond := builder.In("id", builder.Select("subject_id").From("repository").Where(builder.Gt{"subject_id": 0})).
+		And(builder.NotIn("id", builder.Select("subject_id").From("repository").
+			Where(builder.Gt{"subject_id": 0}.And(builder.Eq{"is_fork": false, "is_empty": false}))))
+
+	subjects := make([]*Subject, 0, 10)
+	return subjects, db.GetEngine(ctx).Where(cond).OrderBy("name ASC").Find(&subjects)
+}
+
+// emptySubjectCond matches the subjects no repository references
+func emptySubjectCond() builder.Cond {
+	return builder.NotIn("id", builder.Select("subject_id").From("repository").Where(builder.Gt{"subject_id": 0}))
+}
+
+// FindEmptySubjects returns the subjects no repository references (CountRepositoriesBySubject is 0),
+// e.g. because all their repositories were deleted, oldest first
+func FindEmptySubjects(ctx context.Context) ([]*Subject, error) {
+	subjects := make([]*Subject, 0, 10)
+	return subjects, db.GetEngine(ctx).Where(emptySubjectCond()).Asc("created_unix", "id").Find(&subjects)
+}
+
+// PruneEmptySubjectsResult reports the outcome of PruneEmptySubjects
+type PruneEmptySubjectsResult struct {
+	Pruned int64 // empty subjects that were deleted
+	Kept   int64 // empty subjects kept because they are featured or have redirects
+}
+
+// PruneEmptySubjects deletes the subjects no repository references that were created more than
+// olderThan ago, so that they stop showing in search. Featured subjects and subjects that former
+// slugs redirect to are kept, they were curated or linked to on purpose.
+func PruneEmptySubjects(ctx context.Context, olderThan time.Duration) (*PruneEmptySubjectsResult, error) {
+	result := &PruneEmptySubjectsResult{}
+	cutoff := timeutil.TimeStamp(time.Now().Add(-olderThan).Unix())
+
+	subjects := make([]*Subject, 0, 10)
+	if err := db.GetEngine(ctx).Where(emptySubjectCond().And(builder.Lt{"created_unix": cutoff})).
+		OrderBy("id").Find(&subjects); err != nil {
+		return result, fmt.Errorf("find empty subjects: %w", err)
+	}
+
+	for _, subject := range subjects {
+		hasRedirect, err := db.GetEngine(ctx).Exist(&SubjectRedirect{SubjectID: subject.ID})
+		if err != nil {
+			return result, err
+		}
+		if subject.IsFeatured || hasRedirect {
+			result.Kept++
+			continue
+		}
+
+		err = db.WithTx(ctx, func(ctx context.Context) error {
+			// DeleteSubject refuses if a repository was attached to the subject meanwhile
+			if err := DeleteSubject(ctx, subject.ID); err != nil {
+				return err
+			}
+			_, err := db.GetEngine(ctx).Delete(&SubjectCount{SubjectID: subject.ID})
+			return err
+		})
+		if IsErrSubjectInUse(err) {
+			continue
+		} else if err != nil {
+			return result, fmt.Errorf("delete subject %d: %w", subject.ID, err)
+		}
+		log.Trace("Pruned empty subject %d (%s)", subject.ID, subject.Slug)
+		result.Pruned++
+	}
+	return result, nil
+}
+
+// FindSubjectIDsWithMultipleRoots returns the IDs of the subjects that have more than one root
+// (non-fork, non-empty) repository, e.g. because their articles were imported in bulk.
+func FindSubjectIDsWithMultipleRoots(ctx context.Context) ([]int64, error) {
+	subjectIDs := make([]int64, 0, 10)
+	return subjectIDs, db.GetEngine(ctx).
+		Table("repository").
+		Where("subject_id > 0 AND is_fork = ? AND is_empty = ?", false, false).
+		GroupBy("subject_id").
+		Having("COUNT(*) > 1").
+		OrderBy("subject_id").
+		Cols("subject_id").
+		Find(&subjectIDs)
+}
+
 // ErrSubjectNotExist represents a "SubjectNotExist" error
 type ErrSubjectNotExist struct {
 	ID   int64
@@ -487,6 +1028,26 @@ func (err ErrSubjectNotExist) Error() string {
 	return fmt.Sprintf("subject does not exist [id: %d]", err.ID)
 }
 
+// ErrSubjectDeleted represents a "SubjectDeleted" error
+type ErrSubjectDeleted struct {
+	ID   int64
+	Slug string
+}
+
+// IsErrSubjectDeleted checks if an error is ErrSubjectDeleted
+func IsErrSubjectDeleted(err error) bool {
+	var e ErrSubjectDeleted
+	return errors.As(err, &e)
+}
+
+func (err ErrSubjectDeleted) Error() string {
+	return fmt.Sprintf("subject has been deleted [id: %d, slug: %s]", err.ID, err.Slug)
+}
+
+func (err ErrSubjectDeleted) Unwrap() error {
+	return util.ErrPermissionDenied
+}
+
 // ErrSubjectInUse represents a "SubjectInUse" error
 type ErrSubjectInUse struct {
 	ID        int64
@@ -0,0 +1,201 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// SubjectCount stores the precomputed repository counts of a subject, so that listing subjects
+// doesn't have to count repositories on every request. Rows are refreshed whenever a repository
+// of the subject is created, deleted, forked or converted (see RefreshSubjectCounts), and
+// periodically reconciled against the live counts (see ReconcileSubjectCounts).
+type SubjectCount struct {
+	SubjectID     int64              `xorm:"pk"`
+	RepoCount     int64              `xorm:"NOT NULL DEFAULT 0"`
+	RootRepoCount int64              `xorm:"NOT NULL DEFAULT 0"`
+	ForkRepoCount int64              `xorm:"NOT NULL DEFAULT 0"` // non-empty forks
+	UpdatedUnix   timeutil.TimeStamp `xorm:"INDEX"`
+}
+
+func init() {
+	db.RegisterModel(new(SubjectCount))
+}
+
+// TableName returns the table name for SubjectCount
+func (sc *SubjectCount) TableName() string {
+	return "subject_counts"
+}
+
+func (sc *SubjectCount) equalCounts(counts *SubjectRepoCounts) bool {
+	return sc.RepoCount == counts.RepoCount &&
+		sc.RootRepoCount == counts.RootRepoCount &&
+		sc.ForkRepoCount == counts.ForkRepoCount
+}
+
+// saveSubjectCounts stores the given counts, updating the row of the subject or inserting it
+// when the subject has none yet. Repositories of the same subject can be created concurrently,
+// so the row is never deleted and re-inserted.
+func saveSubjectCounts(ctx context.Context, counts *SubjectRepoCounts) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		e := db.GetEngine(ctx)
+
+		// UPDATE first to acquire the write lock of an existing row
+		rows, err := e.Where("subject_id = ?", counts.SubjectID).
+			Cols("repo_count", "root_repo_count", "fork_repo_count", "updated_unix").
+			Update(&SubjectCount{
+				RepoCount:     counts.RepoCount,
+				RootRepoCount: counts.RootRepoCount,
+				ForkRepoCount: counts.ForkRepoCount,
+				UpdatedUnix:   timeutil.TimeStampNow(),
+			})
+		if err != nil {
+			return err
+		}
+		if rows > 0 {
+			return nil
+		}
+
+		// Some databases report 0 affected rows when the values didn't change
+		has, err := e.Exist(&SubjectCount{SubjectID: counts.SubjectID})
+		if err != nil {
+			return err
+		}
+		if has {
+			return nil
+		}
+		return db.Insert(ctx, &SubjectCount{
+			SubjectID:     counts.SubjectID,
+			RepoCount:     counts.RepoCount,
+			RootRepoCount: counts.RootRepoCount,
+			ForkRepoCount: counts.ForkRepoCount,
+			UpdatedUnix:   timeutil.TimeStampNow(),
+		})
+	})
+}
+
+// RefreshSubjectCounts recomputes the stored repository counts of the given subjects.
+// It must be called whenever repositories are added to or removed from a subject, or
+// change between fork, root and empty state.
+func RefreshSubjectCounts(ctx context.Context, subjectIDs ...int64) error {
+	ids := make([]int64, 0, len(subjectIDs))
+	for _, id := range subjectIDs {
+		if id > 0 {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	countsMap, err := BatchCountRepositoriesBySubjects(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, counts := range countsMap {
+		if err := saveSubjectCounts(ctx, counts); err != nil {
+			return fmt.Errorf("save counts of subject %d: %w", counts.SubjectID, err)
+		}
+	}
+	return nil
+}
+
+// GetSubjectRepoCounts returns the repository counts of the given subjects from the
+// precomputed subject_counts table. Subjects without a stored row fall back to the live
+// counts of BatchCountRepositoriesBySubjects.
+func GetSubjectRepoCounts(ctx context.Context, subjectIDs []int64) (map[int64]*SubjectRepoCounts, error) {
+	result := make(map[int64]*SubjectRepoCounts, len(subjectIDs))
+	if len(subjectIDs) == 0 {
+		return result, nil
+	}
+
+	rows := make([]*SubjectCount, 0, len(subjectIDs))
+	if err := db.GetEngine(ctx).In("subject_id", subjectIDs).Find(&rows); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		result[row.SubjectID] = &SubjectRepoCounts{
+			SubjectID:     row.SubjectID,
+			RepoCount:     row.RepoCount,
+			RootRepoCount: row.RootRepoCount,
+			ForkRepoCount: row.ForkRepoCount,
+		}
+	}
+
+	missing := make([]int64, 0, len(subjectIDs)-len(rows))
+	for _, id := range subjectIDs {
+		if _, ok := result[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	liveCounts, err := BatchCountRepositoriesBySubjects(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	for id, counts := range liveCounts {
+		result[id] = counts
+	}
+	return result, nil
+}
+
+// ReconcileSubjectCounts compares the stored counts of every subject with the live counts,
+// fixing rows that are wrong or missing and removing rows of subjects that no longer exist.
+// It returns the number of subjects whose counts were fixed.
+func ReconcileSubjectCounts(ctx context.Context) (int64, error) {
+	const batchSize = 100
+
+	var fixed int64
+	var lastID int64
+	for {
+		var subjectIDs []int64
+		if err := db.GetEngine(ctx).Table("subject").Where("id > ?", lastID).
+			OrderBy("id").Limit(batchSize).Cols("id").Find(&subjectIDs); err != nil {
+			return fixed, fmt.Errorf("find subjects: %w", err)
+		}
+		if len(subjectIDs) == 0 {
+			break
+		}
+		lastID = subjectIDs[len(subjectIDs)-1]
+
+		liveCounts, err := BatchCountRepositoriesBySubjects(ctx, subjectIDs)
+		if err != nil {
+			return fixed, err
+		}
+
+		rows := make([]*SubjectCount, 0, len(subjectIDs))
+		if err := db.GetEngine(ctx).In("subject_id", subjectIDs).Find(&rows); err != nil {
+			return fixed, err
+		}
+		stored := make(map[int64]*SubjectCount, len(rows))
+		for _, row := range rows {
+			stored[row.SubjectID] = row
+		}
+
+		for _, id := range subjectIDs {
+			counts := liveCounts[id]
+			if row, ok := stored[id]; ok && row.equalCounts(counts) {
+				continue
+			}
+			log.Trace("Fixing repository counts of subject %d", id)
+			if err := saveSubjectCounts(ctx, counts); err != nil {
+				return fixed, fmt.Errorf("save counts of subject %d: %w", id, err)
+			}
+			fixed++
+		}
+	}
+
+	if _, err := db.GetEngine(ctx).Exec("DELETE FROM subject_counts WHERE subject_id NOT IN (SELECT id FROM subject)"); err != nil {
+		return fixed, fmt.Errorf("delete stale subject counts: %w", err)
+	}
+	return fixed, nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSubjectRepoCounts(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	// Without stored rows the live counts are used
+	counts, err := repo_model.GetSubjectRepoCounts(ctx, []int64{1, 2})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, counts[1].RepoCount)
+	assert.Zero(t, counts[2].RepoCount)
+
+	// Stored rows take precedence
+	require.NoError(t, db.Insert(ctx, &repo_model.SubjectCount{SubjectID: 2, RepoCount: 5}))
+	counts, err = repo_model.GetSubjectRepoCounts(ctx, []int64{1, 2})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, counts[1].RepoCount)
+	assert.EqualValues(t, 5, counts[2].RepoCount)
+}
+
+func TestRefreshSubjectCounts(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	require.NoError(t, repo_model.RefreshSubjectCounts(ctx, 1, 0))
+	row := unittest.AssertExistsAndLoadBean(t, &repo_model.SubjectCount{SubjectID: 1})
+	assert.EqualValues(t, 1, row.RepoCount)
+	assert.EqualValues(t, 1, row.RootRepoCount)
+
+	// Moving a repository into the subject is picked up by the next refresh
+	repo2 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 2})
+	repo2.SubjectID = 1
+	require.NoError(t, repo_model.UpdateRepositoryColsNoAutoTime(ctx, repo2, "subject_id"))
+	require.NoError(t, repo_model.RefreshSubjectCounts(ctx, 1))
+	row = unittest.AssertExistsAndLoadBean(t, &repo_model.SubjectCount{SubjectID: 1})
+	assert.EqualValues(t, 2, row.RepoCount)
+
+	// Refreshing unchanged counts updates the existing row in place
+	require.NoError(t, repo_model.RefreshSubjectCounts(ctx, 1))
+	unittest.AssertCount(t, &repo_model.SubjectCount{SubjectID: 1}, 1)
+	row = unittest.AssertExistsAndLoadBean(t, &repo_model.SubjectCount{SubjectID: 1})
+	assert.EqualValues(t, 2, row.RepoCount)
+}
+
+func TestReconcileSubjectCounts(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	// A deliberately wrong count for subject 1, and a row of a subject that doesn't exist
+	require.NoError(t, db.Insert(ctx, &repo_model.SubjectCount{SubjectID: 1, RepoCount: 42, RootRepoCount: 7}))
+	require.NoError(t, db.Insert(ctx, &repo_model.SubjectCount{SubjectID: 9999, RepoCount: 3}))
+
+	fixed, err := repo_model.ReconcileSubjectCounts(ctx)
+	require.NoError(t, err)
+	// subject 1 was wrong, the other subjects had no rows yet
+	assert.EqualValues(t, unittest.GetCount(t, &repo_model.Subject{}), fixed)
+
+	row := unittest.AssertExistsAndLoadBean(t, &repo_model.SubjectCount{SubjectID: 1})
+	assert.EqualValues(t, 1, row.RepoCount)
+	assert.EqualValues(t, 1, row.RootRepoCount)
+	unittest.AssertNotExistsBean(t, &repo_model.SubjectCount{SubjectID: 9999})
+
+	// Everything is consistent now
+	fixed, err = repo_model.ReconcileSubjectCounts(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, fixed)
+}
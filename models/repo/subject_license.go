@@ -0,0 +1,82 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+
+	"xorm.io/builder"
+)
+
+// InheritRootLicense copies the license of rootRepo to repo if repo has none. A license of its
+// own (detected from its LICENSE file) overrides the license of the root.
+func InheritRootLicense(ctx context.Context, rootRepo, repo *Repository) error {
+	if rootRepo.ID == repo.ID {
+		return nil
+	}
+	has, err := db.GetEngine(ctx).Exist(&RepoLicense{RepoID: repo.ID})
+	if err != nil || has {
+		return err
+	}
+	return CopyLicense(ctx, rootRepo, repo)
+}
+
+// InheritSubjectRootLicense copies the license of the root repository of repo's subject to repo
+// if repo has none, so that the articles of a subject share the license of its root
+func InheritSubjectRootLicense(ctx context.Context, repo *Repository) error {
+	if repo.SubjectID == 0 {
+		return nil
+	}
+	rootRepo, err := GetSubjectRootRepository(ctx, repo.SubjectID)
+	if IsErrRepoNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return InheritRootLicense(ctx, rootRepo, repo)
+}
+
+// subjectReposWithoutRootLicenseCond matches the repositories, joined with their subject, that
+// have no license while the root repository of their subject has one
+func subjectReposWithoutRootLicenseCond() builder.Cond {
+	return builder.Expr("subject.root_repo_id > 0 AND repository.id != subject.root_repo_id").
+		And(builder.NotIn("repository.id", builder.Select("repo_id").From("repo_license"))).
+		And(builder.In("subject.root_repo_id", builder.Select("repo_id").From("repo_license")))
+}
+
+// CountSubjectReposWithoutRootLicense returns the number of repositories that have no license
+// while the root repository of their subject has one
+func CountSubjectReposWithoutRootLicense(ctx context.Context) (int64, error) {
+	return db.GetEngine(ctx).Table("repository").
+		Join("INNER", "subject", "subject.id = repository.subject_id").
+		Where(subjectReposWithoutRootLicenseCond()).
+		Count()
+}
+
+// FixSubjectReposWithoutRootLicense copies the license of the root repository of their subject
+// to the repositories that have none, it returns the number of repositories that were fixed
+func FixSubjectReposWithoutRootLicense(ctx context.Context) (int64, error) {
+	var pairs []struct {
+		RepoID     int64
+		RootRepoID int64
+	}
+	if err := db.GetEngine(ctx).Table("repository").
+		Join("INNER", "subject", "subject.id = repository.subject_id").
+		Where(subjectReposWithoutRootLicenseCond()).
+		Select("repository.id AS repo_id, subject.root_repo_id AS root_repo_id").
+		Find(&pairs); err != nil {
+		return 0, err
+	}
+
+	var fixed int64
+	for _, pair := range pairs {
+		if err := InheritRootLicense(ctx, &Repository{ID: pair.RootRepoID}, &Repository{ID: pair.RepoID}); err != nil {
+			return fixed, err
+		}
+		fixed++
+	}
+	return fixed, nil
+}
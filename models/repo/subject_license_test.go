@@ -0,0 +1,66 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInheritSubjectRootLicense(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// repo1 is the root of subject 1
+	require.NoError(t, db.Insert(t.Context(), &repo_model.RepoLicense{RepoID: 1, License: "CC-BY-SA-4.0"}))
+
+	// repo3 and repo4 join subject 1, repo4 has a license of its own
+	_, err := db.GetEngine(t.Context()).Exec("UPDATE `repository` SET subject_id = 1 WHERE id IN (3, 4)")
+	require.NoError(t, err)
+	require.NoError(t, db.Insert(t.Context(), &repo_model.RepoLicense{RepoID: 4, License: "MIT"}))
+
+	count, err := repo_model.CountSubjectReposWithoutRootLicense(t.Context())
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+
+	repo3 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3})
+	require.NoError(t, repo_model.InheritSubjectRootLicense(t.Context(), repo3))
+	licenses, err := repo_model.GetRepoLicenses(t.Context(), repo3)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"CC-BY-SA-4.0"}, licenses.StringList())
+
+	// A license of its own overrides the license of the root
+	repo4 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 4})
+	require.NoError(t, repo_model.InheritSubjectRootLicense(t.Context(), repo4))
+	licenses, err = repo_model.GetRepoLicenses(t.Context(), repo4)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"MIT"}, licenses.StringList())
+
+	count, err = repo_model.CountSubjectReposWithoutRootLicense(t.Context())
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, count)
+}
+
+func TestFixSubjectReposWithoutRootLicense(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	require.NoError(t, db.Insert(t.Context(), &repo_model.RepoLicense{RepoID: 1, License: "CC-BY-SA-4.0"}))
+	_, err := db.GetEngine(t.Context()).Exec("UPDATE `repository` SET subject_id = 1 WHERE id IN (3, 4)")
+	require.NoError(t, err)
+
+	fixed, err := repo_model.FixSubjectReposWithoutRootLicense(t.Context())
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, fixed)
+	unittest.AssertExistsAndLoadBean(t, &repo_model.RepoLicense{RepoID: 3, License: "CC-BY-SA-4.0"})
+	unittest.AssertExistsAndLoadBean(t, &repo_model.RepoLicense{RepoID: 4, License: "CC-BY-SA-4.0"})
+
+	count, err := repo_model.CountSubjectReposWithoutRootLicense(t.Context())
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, count)
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// ErrSubjectRedirectNotExist represents a "SubjectRedirectNotExist" kind of error.
+type ErrSubjectRedirectNotExist struct {
+	Slug string
+}
+
+// IsErrSubjectRedirectNotExist checks if an error is an ErrSubjectRedirectNotExist.
+func IsErrSubjectRedirectNotExist(err error) bool {
+	_, ok := err.(ErrSubjectRedirectNotExist)
+	return ok
+}
+
+func (err ErrSubjectRedirectNotExist) Error() string {
+	return fmt.Sprintf("subject redirect does not exist [slug: %s]", err.Slug)
+}
+
+func (err ErrSubjectRedirectNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// SubjectRedirect represents that a former slug of a subject should be redirected to the subject
+type SubjectRedirect struct {
+	ID        int64  `xorm:"pk autoincr"`
+	Slug      string `xorm:"VARCHAR(255) UNIQUE NOT NULL"`
+	SubjectID int64  `xorm:"INDEX NOT NULL"`
+}
+
+// TableName returns the table name for SubjectRedirect
+func (SubjectRedirect) TableName() string {
+	return "subject_redirect"
+}
+
+func init() {
+	db.RegisterModel(new(SubjectRedirect))
+}
+
+// LookupSubjectRedirect returns the ID of the subject that formerly had the slug
+func LookupSubjectRedirect(ctx context.Context, slug string) (int64, error) {
+	redirect := &SubjectRedirect{Slug: slug}
+	if has, err := db.GetEngine(ctx).Get(redirect); err != nil {
+		return 0, err
+	} else if !has {
+		return 0, ErrSubjectRedirectNotExist{Slug: slug}
+	}
+	return redirect.SubjectID, nil
+}
+
+// newSubjectRedirect redirects the old slug of a subject to it. A redirect of the new slug is
+// removed because the slug is in use again.
+func newSubjectRedirect(ctx context.Context, subjectID int64, oldSlug, newSlug string) error {
+	if _, err := db.GetEngine(ctx).Where("slug = ? OR slug = ?", oldSlug, newSlug).Delete(new(SubjectRedirect)); err != nil {
+		return err
+	}
+	return db.Insert(ctx, &SubjectRedirect{Slug: oldSlug, SubjectID: subjectID})
+}
+
+// availableSubjectSlug returns the slug for a subject: the slug generated from its name, or
+// the first of slug-2, slug-3, … that no other subject uses
+func availableSubjectSlug(ctx context.Context, subject *Subject) (string, error) {
+	base := GenerateSlugFromName(subject.Name)
+	slug := base
+	for i := 2; ; i++ {
+		taken, err := db.GetEngine(ctx).Where("slug = ? AND id <> ?", slug, subject.ID).Exist(new(Subject))
+		if err != nil {
+			return "", err
+		} else if !taken {
+			return slug, nil
+		}
+		slug = base + "-" + strconv.Itoa(i)
+	}
+}
+
+// RegenerateSubjectSlugs recomputes the slug of every subject from its name, for example after
+// GenerateSlugFromName has changed. Subjects are processed in ID order so that collisions are
+// resolved the same way every time. The old slug of a changed subject is redirected to it.
+// Each batch of subjects is updated in a transaction of its own. It returns the number of
+// subjects whose slug changed.
+func RegenerateSubjectSlugs(ctx context.Context) (int64, error) {
+	const batchSize = 100
+
+	var changed int64
+	var lastID int64
+	for {
+		var subjects []*Subject
+		var batchChanged int64
+		err := db.WithTx(ctx, func(ctx context.Context) error {
+			if err := db.GetEngine(ctx).Where("id > ?", lastID).OrderBy("id").Limit(batchSize).Find(&subjects); err != nil {
+				return fmt.Errorf("find subjects: %w", err)
+			}
+			for _, subject := range subjects {
+				slug, err := availableSubjectSlug(ctx, subject)
+				if err != nil {
+					return err
+				}
+				if slug == subject.Slug {
+					continue
+				}
+				log.Trace("Changing the slug of subject %d from %q to %q", subject.ID, subject.Slug, slug)
+				if _, err := db.GetEngine(ctx).ID(subject.ID).Cols("slug").NoAutoTime().Update(&Subject{Slug: slug}); err != nil {
+					return fmt.Errorf("update slug of subject %d: %w", subject.ID, err)
+				}
+				if err := newSubjectRedirect(ctx, subject.ID, subject.Slug, slug); err != nil {
+					return fmt.Errorf("redirect slug of subject %d: %w", subject.ID, err)
+				}
+				batchChanged++
+			}
+			return nil
+		})
+		if err != nil {
+			return changed, err
+		}
+		changed += batchChanged
+		if len(subjects) == 0 {
+			return changed, nil
+		}
+		lastID = subjects[len(subjects)-1].ID
+	}
+}
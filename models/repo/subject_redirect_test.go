@@ -0,0 +1,48 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegenerateSubjectSlugs(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	// Slugs generated by an older GenerateSlugFromName
+	_, err := db.GetEngine(ctx).ID(2).Cols("slug").Update(&repo_model.Subject{Slug: "another_subject"})
+	require.NoError(t, err)
+	require.NoError(t, db.Insert(ctx, &repo_model.Subject{ID: 3, Name: "Another Subject!", Slug: "another-subject!"}))
+
+	changed, err := repo_model.RegenerateSubjectSlugs(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, changed)
+
+	// Collisions are resolved in ID order with a numeric suffix
+	unittest.AssertExistsAndLoadBean(t, &repo_model.Subject{ID: 1, Slug: "example-subject"})
+	unittest.AssertExistsAndLoadBean(t, &repo_model.Subject{ID: 2, Slug: "another-subject"})
+	unittest.AssertExistsAndLoadBean(t, &repo_model.Subject{ID: 3, Slug: "another-subject-2"})
+
+	subjectID, err := repo_model.LookupSubjectRedirect(ctx, "another_subject")
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, subjectID)
+	subjectID, err = repo_model.LookupSubjectRedirect(ctx, "another-subject!")
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, subjectID)
+	_, err = repo_model.LookupSubjectRedirect(ctx, "example-subject")
+	assert.True(t, repo_model.IsErrSubjectRedirectNotExist(err))
+
+	// Consistent slugs are left alone
+	changed, err = repo_model.RegenerateSubjectSlugs(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, changed)
+}
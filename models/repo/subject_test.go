@@ -4,12 +4,17 @@
 package repo_test
 
 import (
+	"fmt"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
+	"code.gitea.io/gitea/models/db"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -457,3 +462,527 @@ func TestMultipleRepositoriesSameSubject(t *testing.T) {
 	assert.NoError(t, err)
 	assert.GreaterOrEqual(t, count, int64(2), "At least 2 repositories should have this subject")
 }
+
+func TestFindSubjectsWithoutRoot(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	// The fixture subject has repo1 as its root
+	subjects, err := repo_model.FindSubjectsWithoutRoot(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, subjects)
+
+	subject, err := repo_model.GetOrCreateSubject(ctx, "Rootless Subject")
+	assert.NoError(t, err)
+
+	// An empty non-fork repository does not count as a root...
+	emptyRepo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 2})
+	emptyRepo.SubjectID = subject.ID
+	emptyRepo.IsEmpty = true
+	assert.NoError(t, repo_model.UpdateRepositoryColsNoAutoTime(ctx, emptyRepo, "subject_id", "is_empty"))
+
+	// ...and neither does a non-empty fork
+	forkRepo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 4})
+	forkRepo.SubjectID = subject.ID
+	forkRepo.IsFork = true
+	forkRepo.ForkID = emptyRepo.ID
+	assert.NoError(t, repo_model.UpdateRepositoryColsNoAutoTime(ctx, forkRepo, "subject_id", "is_fork", "fork_id"))
+
+	subjects, err = repo_model.FindSubjectsWithoutRoot(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, subjects, 1) {
+		assert.Equal(t, subject.ID, subjects[0].ID)
+	}
+
+	// Once the fork becomes a normal repository the subject has a root again
+	forkRepo.IsFork = false
+	forkRepo.ForkID = 0
+	assert.NoError(t, repo_model.UpdateRepositoryColsNoAutoTime(ctx, forkRepo, "is_fork", "fork_id"))
+
+	subjects, err = repo_model.FindSubjectsWithoutRoot(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, subjects)
+}
+
+func TestFindSimilarSubjects_Phonetic(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&setting.Repository.EnablePhoneticSubjectSearch, true)()
+
+	subject, err := repo_model.CreateSubject(t.Context(), "Tchaikovsky")
+	assert.NoError(t, err)
+	assert.Equal(t, "XKFSK", subject.PhoneticKey)
+
+	// A phonetically-similar misspelling surfaces the intended subject
+	subjects, _, err := repo_model.FindSimilarSubjects(t.Context(), repo_model.FindSubjectsOptions{Keyword: "Chaikovski", ListOptions: db.ListOptions{PageSize: 10}})
+	assert.NoError(t, err)
+	if assert.Len(t, subjects, 1) {
+		assert.Equal(t, subject.ID, subjects[0].ID)
+	}
+
+	// The stored key and the keyword key ignore case and diacritics alike
+	accented, err := repo_model.CreateSubject(t.Context(), "Émile")
+	assert.NoError(t, err)
+	subjects, _, err = repo_model.FindSimilarSubjects(t.Context(), repo_model.FindSubjectsOptions{Keyword: "EMILE", ListOptions: db.ListOptions{PageSize: 10}})
+	assert.NoError(t, err)
+	if assert.Len(t, subjects, 1) {
+		assert.Equal(t, accented.ID, subjects[0].ID)
+	}
+
+	// Without phonetic matching only substring matches are returned
+	defer test.MockVariableValue(&setting.Repository.EnablePhoneticSubjectSearch, false)()
+	subjects, _, err = repo_model.FindSimilarSubjects(t.Context(), repo_model.FindSubjectsOptions{Keyword: "Chaikovski", ListOptions: db.ListOptions{PageSize: 10}})
+	assert.NoError(t, err)
+	assert.Empty(t, subjects)
+}
+
+func TestFindSimilarSubjects_MultiWord(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	for _, name := range []string{"Moon Base", "Apollo Moon Landing", "Apollo Program", "Apollo Moon", "Sun"} {
+		_, err := repo_model.CreateSubject(ctx, name)
+		assert.NoError(t, err)
+	}
+
+	subjects, _, err := repo_model.FindSimilarSubjects(ctx, repo_model.FindSubjectsOptions{Keyword: "moon apollo", ListOptions: db.ListOptions{PageSize: 10}})
+	assert.NoError(t, err)
+	names := make([]string, 0, len(subjects))
+	for _, subject := range subjects {
+		names = append(names, subject.Name)
+	}
+	// Subjects sharing more words with the keyword rank first; subjects sharing none are not found
+	if assert.Len(t, names, 4) {
+		assert.Equal(t, []string{"Apollo Moon", "Apollo Moon Landing"}, names[:2])
+		assert.ElementsMatch(t, []string{"Moon Base", "Apollo Program"}, names[2:])
+	}
+
+	// Among names starting with a single-word keyword, shorter names rank first
+	subjects, _, err = repo_model.FindSimilarSubjects(ctx, repo_model.FindSubjectsOptions{Keyword: "apollo", ListOptions: db.ListOptions{PageSize: 10}})
+	assert.NoError(t, err)
+	if assert.Len(t, subjects, 3) {
+		assert.Equal(t, "Apollo Moon Landing", subjects[2].Name)
+	}
+}
+
+func TestFindSimilarSubjects_Paging(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	_, err := repo_model.CreateSubject(ctx, "Comet")
+	assert.NoError(t, err)
+	for i := range 25 {
+		_, err := repo_model.CreateSubject(ctx, fmt.Sprintf("Comet %c%d", 'a'+rune(i%3), i))
+		assert.NoError(t, err)
+	}
+
+	all, total, err := repo_model.FindSimilarSubjects(ctx, repo_model.FindSubjectsOptions{Keyword: "comet", ListOptions: db.ListOptions{Page: 1, PageSize: 50}})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 25, total)
+	assert.Len(t, all, 25)
+
+	// Paging through the results yields every similar subject once, in the same order
+	var paged []*repo_model.Subject
+	for page := 1; page <= 3; page++ {
+		subjects, total, err := repo_model.FindSimilarSubjects(ctx, repo_model.FindSubjectsOptions{Keyword: "comet", ListOptions: db.ListOptions{Page: page, PageSize: 10}})
+		assert.NoError(t, err)
+		assert.EqualValues(t, 25, total)
+		paged = append(paged, subjects...)
+	}
+	if assert.Len(t, paged, 25) {
+		for i := range all {
+			assert.Equal(t, all[i].ID, paged[i].ID, "position %d", i)
+			assert.NotEqual(t, "Comet", paged[i].Name, "the exact match is not a similar subject")
+		}
+	}
+
+	// The same page is returned on every request
+	first, _, err := repo_model.FindSimilarSubjects(ctx, repo_model.FindSubjectsOptions{Keyword: "comet", ListOptions: db.ListOptions{Page: 2, PageSize: 10}})
+	assert.NoError(t, err)
+	second, _, err := repo_model.FindSimilarSubjects(ctx, repo_model.FindSubjectsOptions{Keyword: "comet", ListOptions: db.ListOptions{Page: 2, PageSize: 10}})
+	assert.NoError(t, err)
+	assert.Equal(t, first, second)
+
+	// Pages past the end are empty
+	subjects, _, err := repo_model.FindSimilarSubjects(ctx, repo_model.FindSubjectsOptions{Keyword: "comet", ListOptions: db.ListOptions{Page: 4, PageSize: 10}})
+	assert.NoError(t, err)
+	assert.Empty(t, subjects)
+}
+
+func TestFindSimilarSubjects_Filters(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	var french []int64
+	for i := range 12 {
+		subject, err := repo_model.CreateSubject(ctx, fmt.Sprintf("Nebula %d", i))
+		assert.NoError(t, err)
+		if i%4 == 0 {
+			assert.NoError(t, repo_model.SetSubjectLangIfEmpty(ctx, subject.ID, "fr"))
+			french = append(french, subject.ID)
+		}
+	}
+
+	// The filters apply before pagination, so the count and the first page only hold matching subjects
+	subjects, total, err := repo_model.FindSimilarSubjects(ctx, repo_model.FindSubjectsOptions{
+		Keyword:     "nebula",
+		Lang:        "fr",
+		ListOptions: db.ListOptions{Page: 1, PageSize: 2},
+	})
+	assert.NoError(t, err)
+	assert.EqualValues(t, len(french), total)
+	assert.Len(t, subjects, 2)
+	for _, subject := range subjects {
+		assert.Contains(t, french, subject.ID)
+	}
+
+	// The subjects without any repository don't match a minimum repository count
+	subjects, total, err = repo_model.FindSimilarSubjects(ctx, repo_model.FindSubjectsOptions{
+		Keyword:     "nebula",
+		MinRepos:    1,
+		ListOptions: db.ListOptions{Page: 1, PageSize: 10},
+	})
+	assert.NoError(t, err)
+	assert.Zero(t, total)
+	assert.Empty(t, subjects)
+}
+
+func TestSubjectRootRepoPointer(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	// The fixtures are consistent
+	count, err := repo_model.CountSubjectsWithStaleRoot(ctx)
+	assert.NoError(t, err)
+	assert.Zero(t, count)
+
+	root, err := repo_model.GetSubjectRootRepository(ctx, 1)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, root.ID)
+
+	// Point the subject at a repository of another subject
+	assert.NoError(t, repo_model.SetSubjectRootRepoID(ctx, 1, 2))
+	count, err = repo_model.CountSubjectsWithStaleRoot(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+
+	// The stale pointer is ignored and the root is computed instead
+	root, err = repo_model.GetSubjectRootRepository(ctx, 1)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, root.ID)
+
+	fixed, err := repo_model.ClearStaleSubjectRoots(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, fixed)
+	subject := unittest.AssertExistsAndLoadBean(t, &repo_model.Subject{ID: 1})
+	assert.Zero(t, subject.RootRepoID)
+
+	count, err = repo_model.CountSubjectsWithStaleRoot(ctx)
+	assert.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestBatchCountRepositoriesBySubjects_Forks(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	// Subject 1 has repo1 as its root; add two non-empty forks of it
+	for _, id := range []int64{2, 4} {
+		fork := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: id})
+		fork.SubjectID = 1
+		fork.IsFork = true
+		fork.ForkID = 1
+		fork.IsEmpty = false
+		assert.NoError(t, repo_model.UpdateRepositoryColsNoAutoTime(ctx, fork, "subject_id", "is_fork", "fork_id", "is_empty"))
+	}
+
+	counts, err := repo_model.BatchCountRepositoriesBySubjects(ctx, []int64{1, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, &repo_model.SubjectRepoCounts{SubjectID: 1, RepoCount: 3, RootRepoCount: 1, ForkRepoCount: 2}, counts[1])
+
+	// Subjects without repositories still get zeroed counts
+	assert.Equal(t, &repo_model.SubjectRepoCounts{SubjectID: 2}, counts[2])
+}
+
+func TestSoftDeleteSubject(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	subject, err := repo_model.CreateSubject(ctx, "Hidden Moon")
+	assert.NoError(t, err)
+	assert.NoError(t, repo_model.SoftDeleteSubject(ctx, subject.ID))
+
+	deleted := unittest.AssertExistsAndLoadBean(t, &repo_model.Subject{ID: subject.ID})
+	assert.True(t, deleted.IsDeleted())
+
+	// Soft-deleted subjects are excluded from search and slug lookups
+	subjects, _, err := repo_model.FindSubjects(ctx, repo_model.FindSubjectsOptions{Keyword: "hidden moon", ExactMatchOnly: true})
+	assert.NoError(t, err)
+	assert.Empty(t, subjects)
+
+	similar, _, err := repo_model.FindSimilarSubjects(ctx, repo_model.FindSubjectsOptions{Keyword: "hidden", ListOptions: db.ListOptions{PageSize: 10}})
+	assert.NoError(t, err)
+	assert.Empty(t, similar)
+
+	_, err = repo_model.GetSubjectBySlug(ctx, subject.Slug)
+	assert.True(t, repo_model.IsErrSubjectNotExist(err))
+	_, err = repo_model.GetSubjectByName(ctx, subject.Name)
+	assert.True(t, repo_model.IsErrSubjectNotExist(err))
+
+	// ...unless explicitly requested
+	subjects, _, err = repo_model.FindSubjects(ctx, repo_model.FindSubjectsOptions{Keyword: "hidden moon", ExactMatchOnly: true, IncludeDeleted: true})
+	assert.NoError(t, err)
+	assert.Len(t, subjects, 1)
+
+	// Restoring makes the subject visible again
+	assert.NoError(t, repo_model.RestoreSubject(ctx, subject.ID))
+
+	similar, _, err = repo_model.FindSimilarSubjects(ctx, repo_model.FindSubjectsOptions{Keyword: "hidden", ListOptions: db.ListOptions{PageSize: 10}})
+	assert.NoError(t, err)
+	if assert.Len(t, similar, 1) {
+		assert.Equal(t, subject.ID, similar[0].ID)
+	}
+
+	restored, err := repo_model.GetSubjectBySlug(ctx, subject.Slug)
+	assert.NoError(t, err)
+	assert.False(t, restored.IsDeleted())
+
+	// A new article for a soft-deleted subject neither attaches to it nor undoes the deletion
+	assert.NoError(t, repo_model.SoftDeleteSubject(ctx, subject.ID))
+	_, err = repo_model.GetOrCreateSubject(ctx, "Hidden Moon")
+	assert.True(t, repo_model.IsErrSubjectDeleted(err))
+	deleted := unittest.AssertExistsAndLoadBean(t, &repo_model.Subject{ID: subject.ID})
+	assert.True(t, deleted.IsDeleted())
+}
+
+func TestTouchSubject(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	subject := unittest.AssertExistsAndLoadBean(t, &repo_model.Subject{ID: 1})
+	now := subject.UpdatedUnix.AsTime().Add(time.Hour)
+
+	assert.NoError(t, repo_model.TouchSubject(ctx, subject.ID, now))
+	subject = unittest.AssertExistsAndLoadBean(t, &repo_model.Subject{ID: 1})
+	assert.Equal(t, now.Unix(), int64(subject.UpdatedUnix))
+
+	// A second touch within the debounce interval is skipped
+	assert.NoError(t, repo_model.TouchSubject(ctx, subject.ID, now.Add(repo_model.SubjectTouchInterval/2)))
+	subject = unittest.AssertExistsAndLoadBean(t, &repo_model.Subject{ID: 1})
+	assert.Equal(t, now.Unix(), int64(subject.UpdatedUnix))
+
+	later := now.Add(2 * repo_model.SubjectTouchInterval)
+	assert.NoError(t, repo_model.TouchSubject(ctx, subject.ID, later))
+	subject = unittest.AssertExistsAndLoadBean(t, &repo_model.Subject{ID: 1})
+	assert.Equal(t, later.Unix(), int64(subject.UpdatedUnix))
+}
+
+func TestBulkCreateSubjects(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	result, err := repo_model.BulkCreateSubjects(ctx, []string{
+		"Bulk Alpha",
+		"Bulk Beta",
+		"bulk alpha!",     // same slug as "Bulk Alpha" within the batch
+		"Example Subject", // same slug as the example-subject fixture
+		"  ",
+		"Bulk Gamma",
+	})
+	assert.NoError(t, err)
+
+	created := make([]string, 0, len(result.Created))
+	ids := make(map[int64]bool, len(result.Created))
+	for _, subject := range result.Created {
+		created = append(created, subject.Name)
+		assert.NotZero(t, subject.ID)
+		ids[subject.ID] = true
+	}
+	assert.Equal(t, []string{"Bulk Alpha", "Bulk Beta", "Bulk Gamma"}, created)
+	assert.Len(t, ids, 3)
+	assert.ElementsMatch(t, []string{"bulk alpha!", "Example Subject"}, result.Skipped)
+
+	for i, slug := range []string{"bulk-alpha", "bulk-beta", "bulk-gamma"} {
+		subject, err := repo_model.GetSubjectBySlug(ctx, slug)
+		assert.NoError(t, err)
+		assert.NotZero(t, subject.CreatedUnix)
+		assert.Equal(t, subject.ID, result.Created[i].ID)
+	}
+
+	// Importing the same list again creates nothing
+	result, err = repo_model.BulkCreateSubjects(ctx, []string{"Bulk Alpha", "Bulk Beta"})
+	assert.NoError(t, err)
+	assert.Empty(t, result.Created)
+	assert.Equal(t, []string{"Bulk Alpha", "Bulk Beta"}, result.Skipped)
+}
+
+func TestFindSubjects_MinRepos(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	// Only subject 1 has a repository (repo1) in the fixtures
+	subjects, count, err := repo_model.FindSubjects(ctx, repo_model.FindSubjectsOptions{MinRepos: 1})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+	if assert.Len(t, subjects, 1) {
+		assert.EqualValues(t, 1, subjects[0].ID)
+	}
+
+	subjects, count, err = repo_model.FindSubjects(ctx, repo_model.FindSubjectsOptions{MinRepos: 2})
+	assert.NoError(t, err)
+	assert.Zero(t, count)
+	assert.Empty(t, subjects)
+
+	// A second repository brings subject 1 over the threshold
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 2})
+	repo.SubjectID = 1
+	assert.NoError(t, repo_model.UpdateRepositoryColsNoAutoTime(ctx, repo, "subject_id"))
+
+	subjects, count, err = repo_model.FindSubjects(ctx, repo_model.FindSubjectsOptions{MinRepos: 2})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+	if assert.Len(t, subjects, 1) {
+		assert.EqualValues(t, 1, subjects[0].ID)
+	}
+
+	// Without the filter, subjects without repositories are listed too
+	_, count, err = repo_model.FindSubjects(ctx, repo_model.FindSubjectsOptions{})
+	assert.NoError(t, err)
+	assert.Greater(t, count, int64(1))
+}
+
+func TestFindSubjects_HasForks(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	// Subject 1 only has its root article (repo1) in the fixtures
+	subjects, count, err := repo_model.FindSubjects(ctx, repo_model.FindSubjectsOptions{HasForks: true})
+	assert.NoError(t, err)
+	assert.Zero(t, count)
+	assert.Empty(t, subjects)
+
+	// An empty fork doesn't count
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 29})
+	repo.SubjectID = 2
+	assert.NoError(t, repo_model.UpdateRepositoryColsNoAutoTime(ctx, repo, "subject_id"))
+
+	_, count, err = repo_model.FindSubjects(ctx, repo_model.FindSubjectsOptions{HasForks: true})
+	assert.NoError(t, err)
+	assert.Zero(t, count)
+
+	repo = unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 11}) // a non-empty fork
+	repo.SubjectID = 1
+	assert.NoError(t, repo_model.UpdateRepositoryColsNoAutoTime(ctx, repo, "subject_id"))
+
+	subjects, count, err = repo_model.FindSubjects(ctx, repo_model.FindSubjectsOptions{HasForks: true})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+	if assert.Len(t, subjects, 1) {
+		assert.EqualValues(t, 1, subjects[0].ID)
+	}
+}
+
+func TestFindSubjects_Lang(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	assert.NoError(t, repo_model.SetSubjectLangIfEmpty(ctx, 1, "en"))
+	assert.NoError(t, repo_model.SetSubjectLangIfEmpty(ctx, 2, "fr"))
+	// The first language recorded wins
+	assert.NoError(t, repo_model.SetSubjectLangIfEmpty(ctx, 2, "de"))
+	unittest.AssertExistsAndLoadBean(t, &repo_model.Subject{ID: 2, Lang: "fr"})
+
+	untagged, err := repo_model.CreateSubject(ctx, "Untagged Subject")
+	assert.NoError(t, err)
+
+	subjects, count, err := repo_model.FindSubjects(ctx, repo_model.FindSubjectsOptions{Lang: "fr"})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+	if assert.Len(t, subjects, 1) {
+		assert.EqualValues(t, 2, subjects[0].ID)
+	}
+
+	// Subjects without a language are still listed when no language is selected
+	subjects, _, err = repo_model.FindSubjects(ctx, repo_model.FindSubjectsOptions{})
+	assert.NoError(t, err)
+	ids := make([]int64, 0, len(subjects))
+	for _, subject := range subjects {
+		ids = append(ids, subject.ID)
+	}
+	assert.Contains(t, ids, int64(1))
+	assert.Contains(t, ids, int64(2))
+	assert.Contains(t, ids, untagged.ID)
+
+	langs, err := repo_model.FindSubjectLangs(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"en", "fr"}, langs)
+}
+
+func TestFindSubjects_Featured(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	assert.NoError(t, repo_model.SetSubjectFeatured(ctx, 3, true, 2))
+	assert.NoError(t, repo_model.SetSubjectFeatured(ctx, 1, true, 1))
+	assert.NoError(t, repo_model.SetSubjectFeatured(ctx, 2, true, 3))
+	// Unfeaturing clears the position
+	assert.NoError(t, repo_model.SetSubjectFeatured(ctx, 2, false, 3))
+	subject2 := unittest.AssertExistsAndLoadBean(t, &repo_model.Subject{ID: 2})
+	assert.False(t, subject2.IsFeatured)
+	assert.Zero(t, subject2.FeaturedOrder)
+
+	subjects, count, err := repo_model.FindSubjects(ctx, repo_model.FindSubjectsOptions{
+		OrderBy:      repo_model.SubjectOrderByMap[repo_model.SubjectSortFeatured],
+		FeaturedOnly: true,
+	})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, count)
+	if assert.Len(t, subjects, 2) {
+		assert.EqualValues(t, 1, subjects[0].ID)
+		assert.EqualValues(t, 3, subjects[1].ID)
+	}
+
+	subject3 := unittest.AssertExistsAndLoadBean(t, &repo_model.Subject{ID: 3})
+	subjects, _, err = repo_model.FindSubjects(ctx, repo_model.FindSubjectsOptions{
+		Keyword:      subject3.Name,
+		FeaturedOnly: true,
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, subjects, 1) {
+		assert.EqualValues(t, 3, subjects[0].ID)
+	}
+}
+
+func TestPruneEmptySubjects(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	// Subject 2 has no repositories, subject 1 is referenced by repo1
+	empty, err := repo_model.FindEmptySubjects(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, empty, 1) {
+		assert.EqualValues(t, 2, empty[0].ID)
+	}
+
+	fresh, err := repo_model.CreateSubject(ctx, "Fresh Subject")
+	assert.NoError(t, err)
+	featured, err := repo_model.CreateSubject(ctx, "Featured Subject")
+	assert.NoError(t, err)
+	assert.NoError(t, repo_model.SetSubjectFeatured(ctx, featured.ID, true, 1))
+	redirected, err := repo_model.CreateSubject(ctx, "Redirected Subject")
+	assert.NoError(t, err)
+	assert.NoError(t, db.Insert(ctx, &repo_model.SubjectRedirect{Slug: "old-redirected-subject", SubjectID: redirected.ID}))
+	_, err = db.GetEngine(ctx).In("id", featured.ID, redirected.ID).Cols("created_unix").NoAutoTime().
+		Update(&repo_model.Subject{CreatedUnix: 1588800000})
+	assert.NoError(t, err)
+
+	result, err := repo_model.PruneEmptySubjects(ctx, 24*time.Hour)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, result.Pruned)
+	assert.EqualValues(t, 2, result.Kept)
+
+	unittest.AssertNotExistsBean(t, &repo_model.Subject{ID: 2})
+	unittest.AssertExistsAndLoadBean(t, &repo_model.Subject{ID: 1})
+	unittest.AssertExistsAndLoadBean(t, &repo_model.Subject{ID: fresh.ID})
+	unittest.AssertExistsAndLoadBean(t, &repo_model.Subject{ID: featured.ID})
+	unittest.AssertExistsAndLoadBean(t, &repo_model.Subject{ID: redirected.ID})
+}
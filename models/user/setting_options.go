@@ -26,4 +26,11 @@ const (
 	SettingEmailNotificationGiteaActionsAll         = "all"
 	SettingEmailNotificationGiteaActionsFailureOnly = "failure-only" // Default for actions email preference
 	SettingEmailNotificationGiteaActionsDisabled    = "disabled"
+
+	// SettingsKeyForkAfterMerge is the setting key for what happens to a user's fork once
+	// its change request has been merged into the repository it was forked from
+	SettingsKeyForkAfterMerge    = "fork.after_merge"
+	SettingForkAfterMergeKeep    = "keep" // Default: the fork is left alone
+	SettingForkAfterMergeConvert = "convert"
+	SettingForkAfterMergeDelete  = "delete"
 )
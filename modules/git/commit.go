@@ -157,6 +157,8 @@ type CommitsCountOptions struct {
 	RelPath  []string
 	Since    string
 	Until    string
+	// Authors limits the count to commits whose author identity ("Name <email>") contains any of them
+	Authors []string
 }
 
 // CommitsCount returns number of total commits of until given revision.
@@ -169,6 +171,8 @@ func CommitsCount(ctx context.Context, opts CommitsCountOptions) (int64, error)
 		cmd.AddOptionValues("--not", opts.Not)
 	}
 
+	addAuthorFilter(cmd, opts.Authors)
+
 	if len(opts.RelPath) > 0 {
 		cmd.AddDashesAndList(opts.RelPath...)
 	}
@@ -181,6 +185,17 @@ func CommitsCount(ctx context.Context, opts CommitsCountOptions) (int64, error)
 	return strconv.ParseInt(strings.TrimSpace(stdout), 10, 64)
 }
 
+// addAuthorFilter limits a rev-list command to commits by any of the authors, matched as fixed strings
+func addAuthorFilter(cmd *gitcmd.Command, authors []string) {
+	if len(authors) == 0 {
+		return
+	}
+	cmd.AddArguments("--fixed-strings")
+	for _, author := range authors {
+		cmd.AddOptionFormat("--author=%s", author)
+	}
+}
+
 // CommitsCount returns number of total commits of until current revision.
 func (c *Commit) CommitsCount() (int64, error) {
 	return CommitsCount(c.repo.Ctx, CommitsCountOptions{
@@ -222,6 +222,8 @@ type CommitsByFileAndRangeOptions struct {
 	Page     int
 	Since    string
 	Until    string
+	// Authors limits the commits to those whose author identity ("Name <email>") contains any of them
+	Authors []string
 }
 
 // CommitsByFileAndRange return the commits according revision file and the page
@@ -247,6 +249,7 @@ func (repo *Repository) CommitsByFileAndRange(opts CommitsByFileAndRangeOptions)
 		if opts.Until != "" {
 			gitCmd.AddOptionFormat("--until=%s", opts.Until)
 		}
+		addAuthorFilter(gitCmd, opts.Authors)
 
 		gitCmd.AddDashesAndList(opts.File)
 		err := gitCmd.Run(repo.Ctx, &gitcmd.RunOpts{
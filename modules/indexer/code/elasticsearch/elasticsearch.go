@@ -32,7 +32,7 @@ import (
 )
 
 const (
-	esRepoIndexerLatestVersion = 3
+	esRepoIndexerLatestVersion = 4
 	// multi-match-types, currently only 2 types are used
 	// Reference: https://www.elastic.co/guide/en/elasticsearch/reference/7.0/query-dsl-multi-match-query.html#multi-match-types
 	esMultiMatchTypeBestFields   = "best_fields"
@@ -100,6 +100,10 @@ const (
 					"type": "long",
 					"index": true
 				},
+				"subject_id": {
+					"type": "long",
+					"index": true
+				},
 				"filename": {
 					"type": "text",
 					"term_vector": "with_positions_offsets",
@@ -189,6 +193,7 @@ func (b *Indexer) addUpdate(ctx context.Context, batchWriter git.WriteCloserErro
 			Id(id).
 			Doc(map[string]any{
 				"repo_id":    repo.ID,
+				"subject_id": repo.SubjectID,
 				"filename":   update.Filename,
 				"content":    string(charset.ToUTF8DropErrors(fileContents, charset.ConvertOpts{})),
 				"commit_id":  sha,
@@ -382,6 +387,9 @@ func (b *Indexer) Search(ctx context.Context, opts *internal.SearchOptions) (int
 		repoQuery := elastic.NewTermsQuery("repo_id", repoStrs...)
 		query = query.Must(repoQuery)
 	}
+	if opts.SubjectID > 0 {
+		query = query.Must(elastic.NewTermQuery("subject_id", opts.SubjectID))
+	}
 
 	var (
 		start, pageSize = opts.GetSkipTake()
@@ -23,9 +23,11 @@ type Indexer interface {
 }
 
 type SearchOptions struct {
-	RepoIDs  []int64
-	Keyword  string
-	Language string
+	RepoIDs []int64
+	// SubjectID limits the search to the articles of a subject if it's set
+	SubjectID int64
+	Keyword   string
+	Language  string
 
 	SearchMode indexer.SearchModeType
 
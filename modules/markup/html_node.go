@@ -23,13 +23,13 @@ func isAnchorHrefFootnote(s string) bool {
 	return strings.HasPrefix(s, "#fnref:user-content-") || strings.HasPrefix(s, "#fn:user-content-")
 }
 
-func processNodeAttrID(node *html.Node) {
+func processNodeAttrID(ctx *RenderContext, node *html.Node) {
 	// Add user-content- to IDs and "#" links if they don't already have them,
 	// and convert the link href to a relative link to the host root
 	for idx, attr := range node.Attr {
 		if attr.Key == "id" {
 			if !isAnchorIDUserContent(attr.Val) {
-				node.Attr[idx].Val = "user-content-" + attr.Val
+				node.Attr[idx].Val = "user-content-" + ctx.AnchorIDPrefix() + attr.Val
 			}
 		}
 	}
@@ -52,11 +52,16 @@ func processNodeA(ctx *RenderContext, node *html.Node) {
 		if attr.Key == "href" {
 			if anchorID, ok := strings.CutPrefix(attr.Val, "#"); ok {
 				if !isAnchorIDUserContent(attr.Val) {
-					node.Attr[idx].Val = "#user-content-" + anchorID
+					node.Attr[idx].Val = "#user-content-" + ctx.AnchorIDPrefix() + anchorID
 				}
 			} else {
 				node.Attr[idx].Val = ctx.RenderHelper.ResolveLink(attr.Val, LinkTypeDefault)
 			}
+		} else if attr.Key == "name" && ctx.AnchorIDPrefix() != "" {
+			// prefix the named anchors like the "#" links to them, the frontend adds the user-content- part
+			if !isAnchorIDUserContent(attr.Val) {
+				node.Attr[idx].Val = ctx.AnchorIDPrefix() + attr.Val
+			}
 		}
 	}
 }
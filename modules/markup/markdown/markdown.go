@@ -59,7 +59,7 @@ func (l *limitWriter) Write(data []byte) (int, error) {
 
 // newParserContext creates a parser.Context with the render context set
 func newParserContext(ctx *markup.RenderContext) parser.Context {
-	pc := parser.NewContext(parser.WithIDs(newPrefixedIDs()))
+	pc := parser.NewContext(parser.WithIDs(newPrefixedIDs(ctx.AnchorIDPrefix())))
 	pc.Set(renderContextKey, ctx)
 	return pc
 }
@@ -6,6 +6,7 @@ package markdown_test
 import (
 	"context"
 	"html/template"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -547,3 +548,60 @@ func TestMarkdownLink(t *testing.T) {
 <a href="#user-content-foo" rel="nofollow">link3</a></p>
 `, string(result))
 }
+
+func TestMarkdownAnchorIDPrefix(t *testing.T) {
+	input := `---
+include_toc: true
+---
+# Introduction
+
+See the [details](#details).
+
+## Details
+`
+	idRegexp := regexp.MustCompile(` id="([^"]+)"`)
+	renderFragment := func(prefix string) (string, []string) {
+		result, err := markdown.RenderString(markup.NewTestRenderContext().WithAnchorIDPrefix(prefix), input)
+		assert.NoError(t, err)
+		var ids []string
+		for _, match := range idRegexp.FindAllStringSubmatch(string(result), -1) {
+			ids = append(ids, match[1])
+		}
+		return string(result), ids
+	}
+
+	// Two articles embedded in one page have no heading id in common
+	result1, ids1 := renderFragment("article-1")
+	result2, ids2 := renderFragment("article-2")
+	assert.ElementsMatch(t, []string{"user-content-article-1-introduction", "user-content-article-1-details"}, ids1)
+	assert.ElementsMatch(t, []string{"user-content-article-2-introduction", "user-content-article-2-details"}, ids2)
+	for _, id := range ids1 {
+		assert.NotContains(t, ids2, id)
+	}
+
+	// The table of contents and the links within the article use the same prefix
+	assert.Equal(t, 2, strings.Count(result1, `href="#user-content-article-1-details"`))
+	assert.Contains(t, result1, `href="#user-content-article-1-introduction"`)
+	assert.Equal(t, 2, strings.Count(result2, `href="#user-content-article-2-details"`))
+
+	// Without a prefix the ids are unchanged
+	_, ids := renderFragment("")
+	assert.ElementsMatch(t, []string{"user-content-introduction", "user-content-details"}, ids)
+}
+
+func TestMarkdownAnchorIDPrefixNamedAnchor(t *testing.T) {
+	input := `[jump](#notes)
+
+<a name="notes"></a>Notes
+`
+	// The frontend adds user-content- to the name, so it matches the link once the name has the prefix too
+	result, err := markdown.RenderString(markup.NewTestRenderContext().WithAnchorIDPrefix("article-1"), input)
+	assert.NoError(t, err)
+	assert.Contains(t, string(result), `href="#user-content-article-1-notes"`)
+	assert.Contains(t, string(result), `name="article-1-notes"`)
+
+	result, err = markdown.RenderString(markup.NewTestRenderContext(), input)
+	assert.NoError(t, err)
+	assert.Contains(t, string(result), `href="#user-content-notes"`)
+	assert.Contains(t, string(result), `name="notes"`)
+}
@@ -16,6 +16,8 @@ import (
 
 type prefixedIDs struct {
 	values container.Set[string]
+	// prefix follows "user-content-" in the generated ids, see markup.RenderContext.AnchorIDPrefix
+	prefix string
 }
 
 // Generate generates a new element id.
@@ -34,7 +36,7 @@ func (p *prefixedIDs) GenerateWithDefault(value, dft []byte) []byte {
 		result = dft
 	}
 	if !bytes.HasPrefix(result, []byte("user-content-")) {
-		result = append([]byte("user-content-"), result...)
+		result = append([]byte("user-content-"+p.prefix), result...)
 	}
 	if p.values.Add(util.UnsafeBytesToString(result)) {
 		return result
@@ -52,8 +54,9 @@ func (p *prefixedIDs) Put(value []byte) {
 	p.values.Add(util.UnsafeBytesToString(value))
 }
 
-func newPrefixedIDs() *prefixedIDs {
+func newPrefixedIDs(prefix string) *prefixedIDs {
 	return &prefixedIDs{
 		values: make(container.Set[string]),
+		prefix: prefix,
 	}
 }
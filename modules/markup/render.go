@@ -52,6 +52,10 @@ type RenderOptions struct {
 
 	// used by external render. the router "/org/repo/render/..." will output the rendered content in a standalone page
 	InStandalonePage bool
+
+	// AnchorIDPrefix is added to the ids of headings and other anchors, and to the links to them,
+	// so that several documents rendered in one page don't have colliding ids
+	AnchorIDPrefix string
 }
 
 // RenderContext represents a render context
@@ -115,6 +119,19 @@ func (ctx *RenderContext) WithUseAbsoluteLink(v bool) *RenderContext {
 	return ctx
 }
 
+func (ctx *RenderContext) WithAnchorIDPrefix(prefix string) *RenderContext {
+	ctx.RenderOptions.AnchorIDPrefix = prefix
+	return ctx
+}
+
+// AnchorIDPrefix returns the prefix of the anchor ids after "user-content-", empty if the ids aren't prefixed
+func (ctx *RenderContext) AnchorIDPrefix() string {
+	if ctx.RenderOptions.AnchorIDPrefix == "" {
+		return ""
+	}
+	return ctx.RenderOptions.AnchorIDPrefix + "-"
+}
+
 func (ctx *RenderContext) WithHelper(helper RenderHelper) *RenderContext {
 	ctx.RenderHelper = helper
 	return ctx
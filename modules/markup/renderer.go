@@ -59,6 +59,11 @@ func GetRendererByFileName(filename string) Renderer {
 	return extRenderers[extension]
 }
 
+// GetRendererByType returns the renderer of a markup type, or nil if there is none
+func GetRendererByType(typ string) Renderer {
+	return renderers[typ]
+}
+
 // DetectRendererType detects the markup type of the content
 func DetectRendererType(filename string, sniffedType typesniffer.SniffedType, prefetchBuf []byte) string {
 	for _, renderer := range renderers {
@@ -0,0 +1,572 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package phonetic implements phonetic encodings used to match names that sound alike.
+package phonetic
+
+import (
+	"strings"
+)
+
+// DefaultMaxLength is the default length of the keys returned by DoubleMetaphone
+const DefaultMaxLength = 6
+
+// DoubleMetaphone returns the primary and alternate Double Metaphone keys of value,
+// each truncated to maxLength characters. Words that sound alike, such as
+// "Tchaikovsky" and "Chaikovski", share a key.
+// This is a port of Lawrence Philips' original algorithm.
+func DoubleMetaphone(value string, maxLength int) (primary, alternate string) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	if value == "" || maxLength <= 0 {
+		return "", ""
+	}
+
+	m := &metaphone{
+		value:     []rune(value),
+		maxLength: maxLength,
+	}
+	m.slavoGermanic = strings.ContainsAny(value, "WK") || strings.Contains(value, "CZ") || strings.Contains(value, "WITZ")
+	m.encode()
+	return m.primary.String(), m.alternate.String()
+}
+
+type metaphone struct {
+	value         []rune
+	slavoGermanic bool
+	maxLength     int
+	primary       strings.Builder
+	alternate     strings.Builder
+}
+
+func (m *metaphone) encode() {
+	index := 0
+	if m.contains(0, 2, "GN", "KN", "PN", "WR", "PS") {
+		index = 1
+	}
+
+	for !m.isComplete() && index < len(m.value) {
+		switch m.value[index] {
+		case 'A', 'E', 'I', 'O', 'U', 'Y':
+			if index == 0 {
+				m.add("A")
+			}
+			index++
+		case 'B':
+			m.add("P")
+			index = m.skipDouble(index, 'B')
+		case 'Ç':
+			m.add("S")
+			index++
+		case 'C':
+			index = m.handleC(index)
+		case 'D':
+			index = m.handleD(index)
+		case 'F':
+			m.add("F")
+			index = m.skipDouble(index, 'F')
+		case 'G':
+			index = m.handleG(index)
+		case 'H':
+			index = m.handleH(index)
+		case 'J':
+			index = m.handleJ(index)
+		case 'K':
+			m.add("K")
+			index = m.skipDouble(index, 'K')
+		case 'L':
+			index = m.handleL(index)
+		case 'M':
+			m.add("M")
+			if m.conditionM0(index) {
+				index += 2
+			} else {
+				index++
+			}
+		case 'N':
+			m.add("N")
+			index = m.skipDouble(index, 'N')
+		case 'Ñ':
+			m.add("N")
+			index++
+		case 'P':
+			index = m.handleP(index)
+		case 'Q':
+			m.add("K")
+			index = m.skipDouble(index, 'Q')
+		case 'R':
+			index = m.handleR(index)
+		case 'S':
+			index = m.handleS(index)
+		case 'T':
+			index = m.handleT(index)
+		case 'V':
+			m.add("F")
+			index = m.skipDouble(index, 'V')
+		case 'W':
+			index = m.handleW(index)
+		case 'X':
+			index = m.handleX(index)
+		case 'Z':
+			index = m.handleZ(index)
+		default:
+			index++
+		}
+	}
+}
+
+func (m *metaphone) handleC(index int) int {
+	switch {
+	case m.conditionC0(index):
+		m.add("K")
+		return index + 2
+	case index == 0 && m.contains(index, 6, "CAESAR"):
+		m.add("S")
+		return index + 2
+	case m.contains(index, 2, "CH"):
+		return m.handleCH(index)
+	case m.contains(index, 2, "CZ") && !m.contains(index-2, 4, "WICZ"):
+		m.addAlt("S", "X")
+		return index + 2
+	case m.contains(index+1, 3, "CIA"):
+		m.add("X")
+		return index + 3
+	case m.contains(index, 2, "CC") && !(index == 1 && m.charAt(0) == 'M'):
+		return m.handleCC(index)
+	case m.contains(index, 2, "CK", "CG", "CQ"):
+		m.add("K")
+		return index + 2
+	case m.contains(index, 2, "CI", "CE", "CY"):
+		if m.contains(index, 3, "CIO", "CIE", "CIA") {
+			m.addAlt("S", "X")
+		} else {
+			m.add("S")
+		}
+		return index + 2
+	}
+
+	m.add("K")
+	switch {
+	case m.contains(index+1, 2, " C", " Q", " G"):
+		return index + 3
+	case m.contains(index+1, 1, "C", "K", "Q") && !m.contains(index+1, 2, "CE", "CI"):
+		return index + 2
+	}
+	return index + 1
+}
+
+func (m *metaphone) handleCC(index int) int {
+	if m.contains(index+2, 1, "I", "E", "H") && !m.contains(index+2, 2, "HU") {
+		if (index == 1 && m.charAt(index-1) == 'A') || m.contains(index-1, 5, "UCCEE", "UCCES") {
+			m.add("KS")
+		} else {
+			m.add("X")
+		}
+		return index + 3
+	}
+	m.add("K")
+	return index + 2
+}
+
+func (m *metaphone) handleCH(index int) int {
+	switch {
+	case index > 0 && m.contains(index, 4, "CHAE"):
+		m.addAlt("K", "X")
+	case m.conditionCH0(index), m.conditionCH1(index):
+		m.add("K")
+	case index > 0 && m.contains(0, 2, "MC"):
+		m.add("K")
+	case index > 0:
+		m.addAlt("X", "K")
+	default:
+		m.add("X")
+	}
+	return index + 2
+}
+
+func (m *metaphone) handleD(index int) int {
+	switch {
+	case m.contains(index, 2, "DG"):
+		if m.contains(index+2, 1, "I", "E", "Y") {
+			m.add("J")
+			return index + 3
+		}
+		m.add("TK")
+		return index + 2
+	case m.contains(index, 2, "DT", "DD"):
+		m.add("T")
+		return index + 2
+	}
+	m.add("T")
+	return index + 1
+}
+
+func (m *metaphone) handleG(index int) int {
+	switch {
+	case m.charAt(index+1) == 'H':
+		return m.handleGH(index)
+	case m.charAt(index+1) == 'N':
+		switch {
+		case index == 1 && isVowel(m.charAt(0)) && !m.slavoGermanic:
+			m.addAlt("KN", "N")
+		case !m.contains(index+2, 2, "EY") && m.charAt(index+1) != 'Y' && !m.slavoGermanic:
+			m.addAlt("N", "KN")
+		default:
+			m.add("KN")
+		}
+		return index + 2
+	case m.contains(index+1, 2, "LI") && !m.slavoGermanic:
+		m.addAlt("KL", "L")
+		return index + 2
+	case index == 0 && (m.charAt(index+1) == 'Y' || m.contains(index+1, 2, "ES", "EP", "EB", "EL", "EY", "IB", "IL", "IN", "IE", "EI", "ER")):
+		m.addAlt("K", "J")
+		return index + 2
+	case (m.contains(index+1, 2, "ER") || m.charAt(index+1) == 'Y') &&
+		!m.contains(0, 6, "DANGER", "RANGER", "MANGER") &&
+		!m.contains(index-1, 1, "E", "I") &&
+		!m.contains(index-1, 3, "RGY", "OGY"):
+		m.addAlt("K", "J")
+		return index + 2
+	case m.contains(index+1, 1, "E", "I", "Y") || m.contains(index-1, 4, "AGGI", "OGGI"):
+		switch {
+		case m.contains(0, 4, "VAN ", "VON ") || m.contains(0, 3, "SCH") || m.contains(index+1, 2, "ET"):
+			m.add("K")
+		case m.contains(index+1, 3, "IER"):
+			m.add("J")
+		default:
+			m.addAlt("J", "K")
+		}
+		return index + 2
+	case m.charAt(index+1) == 'G':
+		m.add("K")
+		return index + 2
+	}
+	m.add("K")
+	return index + 1
+}
+
+func (m *metaphone) handleGH(index int) int {
+	switch {
+	case index > 0 && !isVowel(m.charAt(index-1)):
+		m.add("K")
+	case index == 0:
+		if m.charAt(index+2) == 'I' {
+			m.add("J")
+		} else {
+			m.add("K")
+		}
+	case (index > 1 && m.contains(index-2, 1, "B", "H", "D")) ||
+		(index > 2 && m.contains(index-3, 1, "B", "H", "D")) ||
+		(index > 3 && m.contains(index-4, 1, "B", "H")):
+		// silent, as in "bough" or "broughton"
+	case index > 2 && m.charAt(index-1) == 'U' && m.contains(index-3, 1, "C", "G", "L", "R", "T"):
+		m.add("F")
+	case index > 0 && m.charAt(index-1) != 'I':
+		m.add("K")
+	}
+	return index + 2
+}
+
+func (m *metaphone) handleH(index int) int {
+	if (index == 0 || isVowel(m.charAt(index-1))) && isVowel(m.charAt(index+1)) {
+		m.add("H")
+		return index + 2
+	}
+	return index + 1
+}
+
+func (m *metaphone) handleJ(index int) int {
+	if m.contains(index, 4, "JOSE") || m.contains(0, 4, "SAN ") {
+		if (index == 0 && m.charAt(index+4) == ' ') || len(m.value) == 4 || m.contains(0, 4, "SAN ") {
+			m.add("H")
+		} else {
+			m.addAlt("J", "H")
+		}
+		return index + 1
+	}
+
+	switch {
+	case index == 0:
+		m.addAlt("J", "A")
+	case isVowel(m.charAt(index-1)) && !m.slavoGermanic && (m.charAt(index+1) == 'A' || m.charAt(index+1) == 'O'):
+		m.addAlt("J", "H")
+	case index == len(m.value)-1:
+		m.addAlt("J", " ")
+	case !m.contains(index+1, 1, "L", "T", "K", "S", "N", "M", "B", "Z") && !m.contains(index-1, 1, "S", "K", "L"):
+		m.add("J")
+	}
+	return m.skipDouble(index, 'J')
+}
+
+func (m *metaphone) handleL(index int) int {
+	if m.charAt(index+1) == 'L' {
+		if m.conditionL0(index) {
+			m.primary.WriteString(m.truncate(&m.primary, "L"))
+		} else {
+			m.add("L")
+		}
+		return index + 2
+	}
+	m.add("L")
+	return index + 1
+}
+
+func (m *metaphone) handleP(index int) int {
+	if m.charAt(index+1) == 'H' {
+		m.add("F")
+		return index + 2
+	}
+	m.add("P")
+	if m.contains(index+1, 1, "P", "B") {
+		return index + 2
+	}
+	return index + 1
+}
+
+func (m *metaphone) handleR(index int) int {
+	if index == len(m.value)-1 && !m.slavoGermanic &&
+		m.contains(index-2, 2, "IE") && !m.contains(index-4, 2, "ME", "MA") {
+		m.alternate.WriteString(m.truncate(&m.alternate, "R"))
+	} else {
+		m.add("R")
+	}
+	return m.skipDouble(index, 'R')
+}
+
+func (m *metaphone) handleS(index int) int {
+	switch {
+	case m.contains(index-1, 3, "ISL", "YSL"):
+		// silent, as in "island" or "carlisle"
+		return index + 1
+	case index == 0 && m.contains(index, 5, "SUGAR"):
+		m.addAlt("X", "S")
+		return index + 1
+	case m.contains(index, 2, "SH"):
+		if m.contains(index+1, 4, "HEIM", "HOEK", "HOLM", "HOLZ") {
+			m.add("S")
+		} else {
+			m.add("X")
+		}
+		return index + 2
+	case m.contains(index, 3, "SIO", "SIA") || m.contains(index, 4, "SIAN"):
+		if m.slavoGermanic {
+			m.add("S")
+		} else {
+			m.addAlt("S", "X")
+		}
+		return index + 3
+	case (index == 0 && m.contains(index+1, 1, "M", "N", "L", "W")) || m.contains(index+1, 1, "Z"):
+		m.addAlt("S", "X")
+		if m.contains(index+1, 1, "Z") {
+			return index + 2
+		}
+		return index + 1
+	case m.contains(index, 2, "SC"):
+		return m.handleSC(index)
+	}
+
+	if index == len(m.value)-1 && m.contains(index-2, 2, "AI", "OI") {
+		m.alternate.WriteString(m.truncate(&m.alternate, "S"))
+	} else {
+		m.add("S")
+	}
+	if m.contains(index+1, 1, "S", "Z") {
+		return index + 2
+	}
+	return index + 1
+}
+
+func (m *metaphone) handleSC(index int) int {
+	switch {
+	case m.charAt(index+2) == 'H':
+		switch {
+		case m.contains(index+3, 2, "ER", "EN"):
+			m.addAlt("X", "SK")
+		case m.contains(index+3, 2, "OO", "UY", "ED", "EM"):
+			m.add("SK")
+		case index == 0 && !isVowel(m.charAt(3)) && m.charAt(3) != 'W':
+			m.addAlt("X", "S")
+		default:
+			m.add("X")
+		}
+	case m.contains(index+2, 1, "I", "E", "Y"):
+		m.add("S")
+	default:
+		m.add("SK")
+	}
+	return index + 3
+}
+
+func (m *metaphone) handleT(index int) int {
+	switch {
+	case m.contains(index, 4, "TION"), m.contains(index, 3, "TIA", "TCH"):
+		m.add("X")
+		return index + 3
+	case m.contains(index, 2, "TH") || m.contains(index, 3, "TTH"):
+		if m.contains(index+2, 2, "OM", "AM") || m.contains(0, 4, "VAN ", "VON ") || m.contains(0, 3, "SCH") {
+			m.add("T")
+		} else {
+			m.addAlt("0", "T")
+		}
+		return index + 2
+	}
+	m.add("T")
+	if m.contains(index+1, 1, "T", "D") {
+		return index + 2
+	}
+	return index + 1
+}
+
+func (m *metaphone) handleW(index int) int {
+	switch {
+	case m.contains(index, 2, "WR"):
+		m.add("R")
+		return index + 2
+	case index == 0 && (isVowel(m.charAt(index+1)) || m.contains(index, 2, "WH")):
+		if isVowel(m.charAt(index + 1)) {
+			m.addAlt("A", "F")
+		} else {
+			m.add("A")
+		}
+	case (index == len(m.value)-1 && isVowel(m.charAt(index-1))) ||
+		m.contains(index-1, 5, "EWSKI", "EWSKY", "OWSKI", "OWSKY") ||
+		m.contains(0, 3, "SCH"):
+		m.alternate.WriteString(m.truncate(&m.alternate, "F"))
+	case m.contains(index, 4, "WICZ", "WITZ"):
+		m.addAlt("TS", "FX")
+		return index + 4
+	}
+	return index + 1
+}
+
+func (m *metaphone) handleX(index int) int {
+	if index == 0 {
+		m.add("S")
+		return index + 1
+	}
+	// silent at the end of French words such as "breaux"
+	if !(index == len(m.value)-1 && (m.contains(index-3, 3, "IAU", "EAU") || m.contains(index-2, 2, "AU", "OU"))) {
+		m.add("KS")
+	}
+	if m.contains(index+1, 1, "C", "X") {
+		return index + 2
+	}
+	return index + 1
+}
+
+func (m *metaphone) handleZ(index int) int {
+	if m.charAt(index+1) == 'H' {
+		m.add("J")
+		return index + 2
+	}
+	if m.contains(index+1, 2, "ZO", "ZI", "ZA") || (m.slavoGermanic && index > 0 && m.charAt(index-1) != 'T') {
+		m.addAlt("S", "TS")
+	} else {
+		m.add("S")
+	}
+	return m.skipDouble(index, 'Z')
+}
+
+// conditionC0 matches the Germanic "ACH" as in "bacher" and "macher"
+func (m *metaphone) conditionC0(index int) bool {
+	switch {
+	case m.contains(index, 4, "CHIA"):
+		return true
+	case index <= 1, isVowel(m.charAt(index - 2)), !m.contains(index-1, 3, "ACH"):
+		return false
+	}
+	c := m.charAt(index + 2)
+	return (c != 'I' && c != 'E') || m.contains(index-2, 6, "BACHER", "MACHER")
+}
+
+// conditionCH0 matches the Greek roots of "character", "chorus", "chemistry" and similar
+func (m *metaphone) conditionCH0(index int) bool {
+	if index != 0 {
+		return false
+	}
+	if !m.contains(index+1, 5, "HARAC", "HARIS") && !m.contains(index+1, 3, "HOR", "HYM", "HIA", "HEM") {
+		return false
+	}
+	return !m.contains(0, 5, "CHORE")
+}
+
+// conditionCH1 matches Germanic and Greek words where "CH" sounds like "K"
+func (m *metaphone) conditionCH1(index int) bool {
+	return m.contains(0, 4, "VAN ", "VON ") || m.contains(0, 3, "SCH") ||
+		m.contains(index-2, 6, "ORCHES", "ARCHIT", "ORCHID") ||
+		m.contains(index+2, 1, "T", "S") ||
+		((m.contains(index-1, 1, "A", "O", "U", "E") || index == 0) &&
+			(m.contains(index+2, 1, "L", "R", "N", "M", "B", "H", "F", "V", "W", " ") || index+1 == len(m.value)-1))
+}
+
+// conditionL0 matches Spanish words such as "cabrillo" and "gallegos" where "LL" is not pronounced as "L"
+func (m *metaphone) conditionL0(index int) bool {
+	n := len(m.value)
+	if index == n-3 && m.contains(index-1, 4, "ILLO", "ILLA", "ALLE") {
+		return true
+	}
+	return (m.contains(n-2, 2, "AS", "OS") || m.contains(n-1, 1, "A", "O")) && m.contains(index-1, 4, "ALLE")
+}
+
+// conditionM0 matches a double "M" or the silent "B" in words such as "dumb" and "thumbelina"
+func (m *metaphone) conditionM0(index int) bool {
+	if m.charAt(index+1) == 'M' {
+		return true
+	}
+	return m.contains(index-1, 3, "UMB") && (index+1 == len(m.value)-1 || m.contains(index+2, 2, "ER"))
+}
+
+func (m *metaphone) skipDouble(index int, c rune) int {
+	if m.charAt(index+1) == c {
+		return index + 2
+	}
+	return index + 1
+}
+
+func (m *metaphone) charAt(index int) rune {
+	if index < 0 || index >= len(m.value) {
+		return 0
+	}
+	return m.value[index]
+}
+
+// contains reports whether the length characters starting at start equal one of the criteria
+func (m *metaphone) contains(start, length int, criteria ...string) bool {
+	if start < 0 || start+length > len(m.value) {
+		return false
+	}
+	target := string(m.value[start : start+length])
+	for _, c := range criteria {
+		if target == c {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *metaphone) add(s string) {
+	m.addAlt(s, s)
+}
+
+func (m *metaphone) addAlt(primary, alternate string) {
+	m.primary.WriteString(m.truncate(&m.primary, primary))
+	m.alternate.WriteString(m.truncate(&m.alternate, alternate))
+}
+
+func (m *metaphone) truncate(b *strings.Builder, s string) string {
+	remaining := m.maxLength - b.Len()
+	if remaining <= 0 {
+		return ""
+	}
+	if len(s) > remaining {
+		return s[:remaining]
+	}
+	return s
+}
+
+func (m *metaphone) isComplete() bool {
+	return m.primary.Len() >= m.maxLength && m.alternate.Len() >= m.maxLength
+}
+
+func isVowel(c rune) bool {
+	return strings.ContainsRune("AEIOUY", c)
+}
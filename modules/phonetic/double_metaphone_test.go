@@ -0,0 +1,59 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package phonetic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoubleMetaphone(t *testing.T) {
+	cases := []struct {
+		value     string
+		primary   string
+		alternate string
+	}{
+		{"", "", ""},
+		{"Thomas", "TMS", "TMS"},
+		{"Smith", "SM0", "XMT"},
+		{"Schmidt", "XMT", "SMT"},
+		{"Jose", "HS", "HS"},
+		{"Xavier", "SF", "SFR"},
+		{"Knight", "NT", "NT"},
+		{"Tchaikovsky", "XKFSK", "XKFSK"},
+		{"Chaikovski", "XKFSK", "XKFSK"},
+		{"character", "KRKTR", "KRKTR"},
+		{"Caesar", "SSR", "SSR"},
+	}
+	for _, c := range cases {
+		t.Run(c.value, func(t *testing.T) {
+			primary, alternate := DoubleMetaphone(c.value, DefaultMaxLength)
+			assert.Equal(t, c.primary, primary)
+			assert.Equal(t, c.alternate, alternate)
+		})
+	}
+}
+
+func TestDoubleMetaphoneMaxLength(t *testing.T) {
+	primary, alternate := DoubleMetaphone("Tchaikovsky", 4)
+	assert.Equal(t, "XKFS", primary)
+	assert.Equal(t, "XKFS", alternate)
+
+	primary, alternate = DoubleMetaphone("Tchaikovsky", 0)
+	assert.Empty(t, primary)
+	assert.Empty(t, alternate)
+}
+
+func TestNameKeys(t *testing.T) {
+	primary, alternate := NameKeys("Emile Zola")
+	assert.Equal(t, "AMLSL", primary)
+	assert.Equal(t, "AMLSL", alternate)
+
+	for _, name := range []string{"Émile Zola", "EMILE ZOLA", "émile zola", " Emile Zola "} {
+		p, a := NameKeys(name)
+		assert.Equal(t, primary, p, name)
+		assert.Equal(t, alternate, a, name)
+	}
+}
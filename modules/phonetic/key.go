@@ -0,0 +1,25 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package phonetic
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// NameKeys returns the primary and alternate keys of a name, used both to store the key of a name
+// and to look up names that sound like a keyword. The name is case folded and stripped of its
+// diacritics first, so "Émile", "emile" and "EMILE" share their keys.
+func NameKeys(name string) (primary, alternate string) {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	normalized, _, err := transform.String(t, name)
+	if err != nil {
+		normalized = name
+	}
+	return DoubleMetaphone(strings.ToLower(normalized), DefaultMaxLength)
+}
@@ -7,6 +7,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"code.gitea.io/gitea/modules/log"
 )
@@ -21,6 +22,16 @@ const (
 // ItemsPerPage maximum items per page in forks, watchers and stars of a repo
 const ItemsPerPage = 40
 
+// MinForkContributorStatsCacheTTL is the shortest time fork contributor stats can be cached for
+const MinForkContributorStatsCacheTTL = time.Minute
+
+// enumerates the checks of the first README of a subject, see Repository.Article.FirstReadmeCheck
+const (
+	ArticleReadmeCheckOff    = "off"
+	ArticleReadmeCheckFix    = "fix"
+	ArticleReadmeCheckReject = "reject"
+)
+
 // Repository settings
 var (
 	Repository = struct {
@@ -53,7 +64,15 @@ var (
 		DisableDownloadSourceArchives           bool
 		AllowForkWithoutMaximumLimit            bool
 		AllowForkIntoSameOwner                  bool
+		DisableForkOnEdit                       bool
+		RequireRootPromotionApproval            bool
 		MaxForkTreeNodes                        int
+		EnablePhoneticSubjectSearch             bool
+		ContributorStatsWindowDays              int
+		ForkContributorStatsCacheTTL            time.Duration
+		ForkGraphMaxNodes                       int
+		ForkGraphTimeout                        time.Duration
+		ArticleEmbedOrigins                     []string
 
 		// StreamArchives makes Gitea stream git archive files to the client directly instead of creating an archive first.
 		// Ideally all users should use this streaming method. However, at the moment we don't know whether there are
@@ -74,6 +93,17 @@ var (
 			MaxFiles     int
 		} `ini:"-"`
 
+		// Article settings, for repositories of a subject
+		Article struct {
+			DefaultEditMessage        string
+			DefaultCreateMessage      string
+			FirstReadmeCheck          string
+			MaxRenderSize             int64
+			RootPromotionMinCommits   int
+			RootPromotionMinAge       time.Duration
+			MaxReposPerUserPerSubject int
+		} `ini:"repository.article"`
+
 		// Pull request settings
 		PullRequest struct {
 			WorkInProgressPrefixes                   []string
@@ -90,6 +120,8 @@ var (
 			TestConflictingPatchesWithGitApply       bool
 			RetargetChildrenOnMerge                  bool
 			DelayCheckForInactiveDays                int
+			ChangeRequestRateLimit                   int
+			ChangeRequestRateLimitWindow             time.Duration
 		} `ini:"repository.pull-request"`
 
 		// Issue Setting
@@ -174,7 +206,14 @@ var (
 		DisableStars:                            false,
 		DefaultBranch:                           "main",
 		AllowForkWithoutMaximumLimit:            true,
+		DisableForkOnEdit:                       false,
+		RequireRootPromotionApproval:            false,
 		MaxForkTreeNodes:                        300,
+		ContributorStatsWindowDays:              90,
+		ForkContributorStatsCacheTTL:            5 * time.Minute,
+		ForkGraphMaxNodes:                       10000,
+		ForkGraphTimeout:                        30 * time.Second,
+		ArticleEmbedOrigins:                     []string{},
 		StreamArchives:                          true,
 
 		// Repository editor settings
@@ -197,6 +236,25 @@ var (
 			MaxFiles:     5,
 		},
 
+		// Article settings
+		Article: struct {
+			DefaultEditMessage        string
+			DefaultCreateMessage      string
+			FirstReadmeCheck          string
+			MaxRenderSize             int64
+			RootPromotionMinCommits   int
+			RootPromotionMinAge       time.Duration
+			MaxReposPerUserPerSubject int
+		}{
+			DefaultEditMessage:        "",
+			DefaultCreateMessage:      "",
+			FirstReadmeCheck:          ArticleReadmeCheckOff,
+			MaxRenderSize:             0,
+			RootPromotionMinCommits:   0,
+			RootPromotionMinAge:       0,
+			MaxReposPerUserPerSubject: 1,
+		},
+
 		// Pull request settings
 		PullRequest: struct {
 			WorkInProgressPrefixes                   []string
@@ -213,6 +271,8 @@ var (
 			TestConflictingPatchesWithGitApply       bool
 			RetargetChildrenOnMerge                  bool
 			DelayCheckForInactiveDays                int
+			ChangeRequestRateLimit                   int
+			ChangeRequestRateLimitWindow             time.Duration
 		}{
 			WorkInProgressPrefixes: []string{"WIP:", "[WIP]"},
 			// Same as GitHub. See
@@ -229,6 +289,8 @@ var (
 			AddCoCommitterTrailers:                   true,
 			RetargetChildrenOnMerge:                  true,
 			DelayCheckForInactiveDays:                7,
+			ChangeRequestRateLimit:                   5,
+			ChangeRequestRateLimitWindow:             time.Hour,
 		},
 
 		// Issue settings
@@ -277,6 +339,15 @@ var (
 	ScriptType   = "bash"
 )
 
+// ArticleMaxRenderSize returns the size from which the README of an article is too large to be
+// rendered or edited in the article view, UI.MaxDisplayFileSize unless Repository.Article.MaxRenderSize is set
+func ArticleMaxRenderSize() int64 {
+	if Repository.Article.MaxRenderSize > 0 {
+		return Repository.Article.MaxRenderSize
+	}
+	return UI.MaxDisplayFileSize
+}
+
 func loadRepositoryFrom(rootCfg ConfigProvider) {
 	var err error
 	// Determine and create root git repository path.
@@ -313,6 +384,39 @@ func loadRepositoryFrom(rootCfg ConfigProvider) {
 		log.Fatal("Failed to map Repository.Upload settings: %v", err)
 	} else if err = rootCfg.Section("repository.pull-request").MapTo(&Repository.PullRequest); err != nil {
 		log.Fatal("Failed to map Repository.PullRequest settings: %v", err)
+	} else if err = rootCfg.Section("repository.article").MapTo(&Repository.Article); err != nil {
+		log.Fatal("Failed to map Repository.Article settings: %v", err)
+	}
+
+	switch Repository.Article.FirstReadmeCheck {
+	case ArticleReadmeCheckOff, ArticleReadmeCheckFix, ArticleReadmeCheckReject:
+	default:
+		log.Warn("[repository.article] FIRST_README_CHECK must be one of %q, %q or %q, got %q. Falling back to %q.",
+			ArticleReadmeCheckOff, ArticleReadmeCheckFix, ArticleReadmeCheckReject, Repository.Article.FirstReadmeCheck, ArticleReadmeCheckOff)
+		Repository.Article.FirstReadmeCheck = ArticleReadmeCheckOff
+	}
+	if Repository.Article.MaxReposPerUserPerSubject < 1 {
+		log.Warn("[repository.article] MAX_REPOS_PER_USER_PER_SUBJECT must be at least 1, got %d. Falling back to 1.", Repository.Article.MaxReposPerUserPerSubject)
+		Repository.Article.MaxReposPerUserPerSubject = 1
+	}
+
+	if Repository.ContributorStatsWindowDays < 1 || Repository.ContributorStatsWindowDays > 365 {
+		log.Warn("CONTRIBUTOR_STATS_WINDOW_DAYS must be between 1 and 365, got %d. Falling back to 90.", Repository.ContributorStatsWindowDays)
+		Repository.ContributorStatsWindowDays = 90
+	}
+
+	if Repository.ForkContributorStatsCacheTTL < MinForkContributorStatsCacheTTL {
+		log.Warn("FORK_CONTRIBUTOR_STATS_CACHE_TTL must be at least %s, got %s. Using %s.", MinForkContributorStatsCacheTTL, Repository.ForkContributorStatsCacheTTL, MinForkContributorStatsCacheTTL)
+		Repository.ForkContributorStatsCacheTTL = MinForkContributorStatsCacheTTL
+	}
+
+	if Repository.ForkGraphMaxNodes < 1 {
+		log.Warn("FORK_GRAPH_MAX_NODES must be positive, got %d. Falling back to 10000.", Repository.ForkGraphMaxNodes)
+		Repository.ForkGraphMaxNodes = 10000
+	}
+	if Repository.ForkGraphTimeout <= 0 {
+		log.Warn("FORK_GRAPH_TIMEOUT must be positive, got %s. Falling back to 30s.", Repository.ForkGraphTimeout)
+		Repository.ForkGraphTimeout = 30 * time.Second
 	}
 
 	if !rootCfg.Section("packages").Key("ENABLED").MustBool(Packages.Enabled) {
@@ -0,0 +1,18 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+// RenderedArticle represents the rendered README of an article
+type RenderedArticle struct {
+	// the commit SHA of the rendered version of the article
+	Version string `json:"version"`
+	// path of the README in the repository
+	Path string `json:"path"`
+	// markup type of the README, empty if it was rendered as plain text
+	MarkupType string `json:"markup_type"`
+	// the rendered HTML, with control characters escaped
+	HTML string `json:"html"`
+	// fields of the YAML front matter of the README, which is left out of the HTML
+	FrontMatter map[string]any `json:"front_matter"`
+}
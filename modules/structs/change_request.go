@@ -0,0 +1,31 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+// ChangeRequestConflictPreview is the outcome of a test merge of a change request onto the current default branch
+type ChangeRequestConflictPreview struct {
+	// the commit SHA of the default branch the change request was merged onto
+	BaseCommitID string `json:"base_commit_id"`
+	// the commit SHA of the head of the change request
+	HeadCommitID string `json:"head_commit_id"`
+	// whether the change request merges without conflicts
+	Clean bool `json:"clean"`
+	// paths of the conflicting files, empty if the merge is clean
+	ConflictedFiles []string `json:"conflicted_files"`
+}
+
+// CreateChangeRequestOption options for submitting a change request to an article
+type CreateChangeRequestOption struct {
+	// new content of the file
+	// required: true
+	Content string `json:"content" binding:"Required"`
+	// path of the file to change, defaults to README.md
+	TreePath string `json:"tree_path"`
+	// title of the change request, also used as the commit message. Longer titles are cut at 255 characters
+	Title string `json:"title"`
+	// description of the change request
+	Description string `json:"description"`
+	// SHA of the commit the content is based on, the change is rejected if the file changed since
+	LastCommit string `json:"last_commit"`
+}
@@ -10,3 +10,13 @@ type CreateForkOption struct {
 	// name of the forked repository
 	Name *string `json:"name"`
 }
+
+// ForkDivergence represents how far the default branch of a fork diverges from its parent
+type ForkDivergence struct {
+	// Number of commits of the fork that its parent doesn't have
+	Ahead int `json:"ahead"`
+	// Number of commits of the parent that the fork doesn't have
+	Behind int `json:"behind"`
+	// Number of people who committed to the fork since it was created
+	ContributorCount int `json:"contributor_count"`
+}
@@ -0,0 +1,26 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+// IntegrityIssue summarizes one kind of inconsistency found in the subject/fork data model
+type IntegrityIssue struct {
+	// Number of records with this inconsistency
+	Count int64 `json:"count"`
+	// IDs of some of the affected records, at most ten
+	SampleIDs []int64 `json:"sample_ids"`
+}
+
+// ForkanaIntegrity reports the consistency of subjects and forks, without repairing anything
+type ForkanaIntegrity struct {
+	// Subjects that have repositories but no root repository
+	SubjectsWithoutRoot IntegrityIssue `json:"subjects_without_root"`
+	// Subjects that have more than one root repository
+	SubjectsWithMultipleRoots IntegrityIssue `json:"subjects_with_multiple_roots"`
+	// Forks whose parent repository no longer exists
+	OrphanedForks IntegrityIssue `json:"orphaned_forks"`
+	// Repositories whose stored fork count differs from their actual number of forks
+	ForkCountDrift IntegrityIssue `json:"fork_count_drift"`
+	// Whether no inconsistency was found
+	Healthy bool `json:"healthy"`
+}
@@ -120,6 +120,8 @@ type Repository struct {
 	RepoTransfer  *RepoTransfer `json:"repo_transfer,omitempty"`
 	Topics        []string      `json:"topics"`
 	Licenses      []string      `json:"licenses"`
+	// how far the fork diverges from its parent, only set when listing forks with divergence
+	Divergence *ForkDivergence `json:"divergence,omitempty"`
 }
 
 // CreateRepoOption options when creating repository
@@ -132,6 +134,8 @@ type CreateRepoOption struct {
 	Name string `json:"name" binding:"Required;AlphaDashDot;MaxSize(100)"`
 	// Subject of the repository to create
 	Subject string `json:"subject" binding:"MaxSize(255)"`
+	// Language code of the subject (e.g. "en"), only recorded if the subject has none yet
+	SubjectLang string `json:"subject_lang" binding:"MaxSize(10)"`
 	// Description of the repository to create
 	Description string `json:"description" binding:"MaxSize(2048)"`
 	// Whether the repository is private
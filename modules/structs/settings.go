@@ -17,6 +17,8 @@ type GeneralRepoSettings struct {
 	TimeTrackingDisabled bool `json:"time_tracking_disabled"`
 	// LFSDisabled indicates if Git LFS support is disabled
 	LFSDisabled bool `json:"lfs_disabled"`
+	// DefaultBranch is the default branch name of new repositories
+	DefaultBranch string `json:"default_branch"`
 }
 
 // GeneralUISettings contains global ui settings exposed by API
@@ -0,0 +1,86 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import (
+	"time"
+)
+
+// Subject represents a subject, the topic shared by the articles of its repositories
+type Subject struct {
+	// The unique identifier of the subject
+	ID int64 `json:"id"`
+	// The display name of the subject
+	Name string `json:"name"`
+	// The URL-safe slug of the subject, unique across subjects
+	Slug string `json:"slug"`
+	// A short summary of what the subject covers
+	Description string `json:"description"`
+	// The language code of the articles of the subject, empty if unknown
+	Lang string `json:"lang"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// UserSubject is a subject in which a user owns a repository, listed once per repository of the user
+type UserSubject struct {
+	Subject *Subject `json:"subject"`
+	// The repository of the user for the subject
+	Repository *Repository `json:"repository"`
+}
+
+// CreateSubjectOption options for creating a subject
+type CreateSubjectOption struct {
+	// Display name of the subject
+	//
+	// required: true
+	Name string `json:"name" binding:"Required;MaxSize(255)"`
+	// A short summary of what the subject covers
+	Description string `json:"description"`
+	// Language code of the articles of the subject (e.g. "en")
+	Lang string `json:"lang" binding:"MaxSize(10)"`
+}
+
+// ArticleDiffSide is one of the two articles of a subject compared by a SubjectReadmeDiff
+type ArticleDiffSide struct {
+	// The full name of the repository of the article
+	FullName string `json:"full_name"`
+	// The path of the README, empty if the article has none
+	Path string `json:"path"`
+	// The latest commit of the default branch of the repository, null if it is empty
+	LastCommit *PayloadCommit `json:"last_commit"`
+}
+
+// ArticleDiffLine is a line of an ArticleDiffHunk
+type ArticleDiffLine struct {
+	// "context" for an unchanged line, "add" or "delete"
+	Type string `json:"type"`
+	// The line number in the README of from, 0 for an added line
+	OldNumber int `json:"old_number"`
+	// The line number in the README of to, 0 for a deleted line
+	NewNumber int    `json:"new_number"`
+	Content   string `json:"content"`
+}
+
+// ArticleDiffHunk is a run of changed lines along with the unchanged lines around them
+type ArticleDiffHunk struct {
+	OldStart int                `json:"old_start"`
+	OldLines int                `json:"old_lines"`
+	NewStart int                `json:"new_start"`
+	NewLines int                `json:"new_lines"`
+	Lines    []*ArticleDiffLine `json:"lines"`
+}
+
+// SubjectReadmeDiff is the diff between the READMEs of two articles of a subject
+type SubjectReadmeDiff struct {
+	From *ArticleDiffSide `json:"from"`
+	To   *ArticleDiffSide `json:"to"`
+	// The number of lines added to the README of from to get the one of to
+	Additions int `json:"additions"`
+	// The number of lines of the README of from removed from the one of to
+	Deletions int                `json:"deletions"`
+	Hunks     []*ArticleDiffHunk `json:"hunks"`
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"net/http"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/services/context"
+)
+
+// integritySampleSize is the maximum number of IDs reported for each inconsistency
+const integritySampleSize = 10
+
+func newIntegrityIssue(ids []int64) api.IntegrityIssue {
+	issue := api.IntegrityIssue{Count: int64(len(ids)), SampleIDs: ids}
+	if len(ids) > integritySampleSize {
+		issue.SampleIDs = ids[:integritySampleSize]
+	}
+	return issue
+}
+
+// GetForkanaIntegrity api for reporting the consistency of subjects and forks
+func GetForkanaIntegrity(ctx *context.APIContext) {
+	// swagger:operation GET /admin/forkana/integrity admin adminForkanaIntegrity
+	// ---
+	// summary: Report inconsistencies between subjects and forks, without repairing them
+	// produces:
+	// - application/json
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ForkanaIntegrity"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+
+	subjects, err := repo_model.FindSubjectsWithoutRoot(ctx)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	subjectIDs := make([]int64, 0, len(subjects))
+	for _, subject := range subjects {
+		subjectIDs = append(subjectIDs, subject.ID)
+	}
+
+	multipleRootIDs, err := repo_model.FindSubjectIDsWithMultipleRoots(ctx)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	orphans, err := repo_model.FindOrphanedForks(ctx)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	orphanIDs := make([]int64, 0, len(orphans))
+	for _, orphan := range orphans {
+		orphanIDs = append(orphanIDs, orphan.ID)
+	}
+
+	driftIDs, err := repo_model.FindRepoIDsWithForkCountDrift(ctx)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	report := &api.ForkanaIntegrity{
+		SubjectsWithoutRoot:       newIntegrityIssue(subjectIDs),
+		SubjectsWithMultipleRoots: newIntegrityIssue(multipleRootIDs),
+		OrphanedForks:             newIntegrityIssue(orphanIDs),
+		ForkCountDrift:            newIntegrityIssue(driftIDs),
+	}
+	report.Healthy = report.SubjectsWithoutRoot.Count == 0 && report.SubjectsWithMultipleRoots.Count == 0 &&
+		report.OrphanedForks.Count == 0 && report.ForkCountDrift.Count == 0
+
+	ctx.JSON(http.StatusOK, report)
+}
@@ -1015,6 +1015,7 @@ func Routes() *web.Router {
 				}
 
 				m.Get("/repos", tokenRequiresScopes(auth_model.AccessTokenScopeCategoryRepository), reqExploreSignIn(), user.ListUserRepos)
+				m.Get("/subjects", tokenRequiresScopes(auth_model.AccessTokenScopeCategoryRepository), reqExploreSignIn(), user.ListUserSubjects)
 				m.Group("/tokens", func() {
 					m.Combo("").Get(user.ListAccessTokens).
 						Post(bind(api.CreateAccessTokenOption{}), reqToken(), user.CreateAccessToken)
@@ -1251,6 +1252,7 @@ func Routes() *web.Router {
 				m.Combo("/forks").Get(repo.ListForks).
 					Post(reqToken(), reqRepoReader(unit.TypeCode), bind(api.CreateForkOption{}), repo.CreateFork)
 				m.Get("/forks/graph", repo.GetForkGraph)
+				m.Get("/article/rendered", context.ReferencesGitRepo(), repo.GetRenderedArticle)
 				m.Post("/merge-upstream", reqToken(), mustNotBeArchived, reqRepoWriter(unit.TypeCode), bind(api.MergeUpstreamRequest{}), repo.MergeUpstream)
 				m.Group("/branches", func() {
 					m.Get("", repo.ListBranches)
@@ -1398,6 +1400,8 @@ func Routes() *web.Router {
 					})
 					m.Get("/{base}/*", repo.GetPullRequestByBaseHead)
 				}, mustAllowPulls, reqRepoReader(unit.TypeCode), context.ReferencesGitRepo())
+				m.Post("/change-requests", reqToken(), mustNotBeArchived, mustAllowPulls, reqRepoReader(unit.TypeCode), bind(api.CreateChangeRequestOption{}), repo.CreateChangeRequest)
+				m.Get("/change-requests/{index}/conflict-preview", mustAllowPulls, reqRepoReader(unit.TypeCode), context.ReferencesGitRepo(), repo.GetChangeRequestConflictPreview)
 				m.Group("/statuses", func() {
 					m.Combo("/{sha}").Get(repo.GetCommitStatuses).
 						Post(reqToken(), reqRepoWriter(unit.TypeCode), bind(api.CreateStatusOption{}), repo.NewCommitStatus)
@@ -1715,6 +1719,7 @@ func Routes() *web.Router {
 				m.Get("", admin.ListCronTasks)
 				m.Post("/{task}", admin.PostCronTask)
 			})
+			m.Get("/forkana/integrity", admin.GetForkanaIntegrity)
 			m.Get("/orgs", admin.GetAllOrgs)
 			m.Group("/users", func() {
 				m.Get("", admin.SearchUsers)
@@ -1769,6 +1774,11 @@ func Routes() *web.Router {
 		m.Group("/topics", func() {
 			m.Get("/search", repo.TopicSearch)
 		}, tokenRequiresScopes(auth_model.AccessTokenScopeCategoryRepository))
+
+		m.Post("/subjects", tokenRequiresScopes(auth_model.AccessTokenScopeCategoryAdmin), reqToken(), reqSiteAdmin(), bind(api.CreateSubjectOption{}), repo.CreateSubject)
+		m.Get("/subjects/{slug}/repos", tokenRequiresScopes(auth_model.AccessTokenScopeCategoryRepository), repo.ListSubjectRepos)
+		m.Get("/subjects/{slug}/readme", tokenRequiresScopes(auth_model.AccessTokenScopeCategoryRepository), repo.GetSubjectReadme)
+		m.Get("/subjects/{slug}/diff", tokenRequiresScopes(auth_model.AccessTokenScopeCategoryRepository), repo.GetSubjectReadmeDiff)
 	}, sudo())
 
 	return m
@@ -0,0 +1,147 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"bytes"
+	"html/template"
+	"io"
+	"net/http"
+
+	"code.gitea.io/gitea/models/renderhelper"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/modules/charset"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/markup"
+	"code.gitea.io/gitea/modules/markup/markdown"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/routers/common"
+	"code.gitea.io/gitea/services/context"
+)
+
+// GetRenderedArticle renders the README of an article the same way as the article view
+func GetRenderedArticle(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/article/rendered repository repoGetRenderedArticle
+	// ---
+	// summary: Get the article of a repository rendered as HTML
+	// description: The README is rendered as in the article view. A YAML front matter is returned
+	//   as separate fields instead of being rendered.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: query
+	//   description: commit SHA of the version of the article to render, defaults to the latest version
+	//   type: string
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RenderedArticle"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	// Like the article view, hide the article from those who can't read the code
+	if !ctx.Repo.CanRead(unit.TypeCode) || ctx.Repo.Repository.IsEmpty {
+		ctx.APIErrorNotFound()
+		return
+	}
+
+	var commit *git.Commit
+	var refPath string
+	var err error
+	if version := ctx.FormString("version"); version != "" {
+		if !git.IsStringLikelyCommitID(ctx.Repo.GetObjectFormat(), version, 7) {
+			ctx.APIErrorNotFound()
+			return
+		}
+		commit, err = ctx.Repo.GitRepo.GetCommit(version)
+		refPath = "commit/" + version
+	} else {
+		commit, err = ctx.Repo.GitRepo.GetBranchCommit(ctx.Repo.Repository.DefaultBranch)
+		refPath = "branch/" + ctx.Repo.Repository.DefaultBranch
+	}
+	if err != nil {
+		if git.IsErrNotExist(err) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	entries, err := commit.ListEntries()
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	readme := common.FindArticleReadme(entries)
+	if readme == nil {
+		ctx.APIErrorNotFound()
+		return
+	}
+	blob := readme.Blob()
+	if blob.Size() >= setting.ArticleMaxRenderSize() {
+		ctx.APIError(http.StatusUnprocessableEntity, "the article is too large to be rendered")
+		return
+	}
+	content, err := blob.GetBlobBytes(blob.Size())
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	article := &api.RenderedArticle{
+		Version:     commit.ID.String(),
+		Path:        readme.Name(),
+		FrontMatter: map[string]any{},
+	}
+	// The front matter can choose another markup than the file name, it is left out of the content either way
+	article.MarkupType, _ = common.ArticleMarkupType(readme.Name(), content)
+	if markup.DetectMarkupTypeByFileName(readme.Name()) == markdown.MarkupName {
+		// Without a front matter the whole README is rendered
+		if body, err := markdown.ExtractMetadataBytes(content, &article.FrontMatter); err == nil {
+			content = body
+		} else {
+			article.FrontMatter = map[string]any{}
+		}
+	}
+
+	var html template.HTML
+	if article.MarkupType != "" {
+		rctx := renderhelper.NewRenderContextRepoFile(ctx, ctx.Repo.Repository, renderhelper.RepoFileOptions{
+			CurrentRefPath: refPath,
+		}).
+			WithMarkupType(article.MarkupType).
+			WithRelativePath(readme.Name())
+		rd := charset.ToUTF8WithFallbackReader(bytes.NewReader(content), charset.ConvertOpts{})
+		if _, html, err = common.RenderArticleMarkup(ctx.Base, rctx, rd); err != nil {
+			log.Error("Render failed for %s in %-v: %v", readme.Name(), ctx.Repo.Repository, err)
+			article.MarkupType = ""
+		}
+	}
+	if article.MarkupType == "" {
+		plain, err := io.ReadAll(charset.ToUTF8WithFallbackReader(bytes.NewReader(content), charset.ConvertOpts{}))
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		_, html = charset.EscapeControlHTML(template.HTML(template.HTMLEscapeString(string(plain))), ctx.Locale)
+	}
+	article.HTML = string(html)
+
+	ctx.JSON(http.StatusOK, article)
+}
@@ -0,0 +1,184 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	git_model "code.gitea.io/gitea/models/git"
+	issues_model "code.gitea.io/gitea/models/issues"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	pull_service "code.gitea.io/gitea/services/pull"
+	repo_service "code.gitea.io/gitea/services/repository"
+	files_service "code.gitea.io/gitea/services/repository/files"
+)
+
+// CreateChangeRequest submits an edit of an article as a change request, like the editor does for
+// users who can't edit the article directly
+func CreateChangeRequest(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/change-requests repository repoCreateChangeRequest
+	// ---
+	// summary: Submit a change request to an article
+	// description: Commits the content to a new patch branch of the repository and opens a change
+	//   request from it to the default branch.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CreateChangeRequestOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/PullRequest"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "429":
+	//     "$ref": "#/responses/error"
+
+	form := web.GetForm(ctx).(*api.CreateChangeRequestOption)
+	repo := ctx.Repo.Repository
+
+	if err := repo_service.CheckCanSubmitChangeRequest(ctx, ctx.Doer, repo); err != nil {
+		switch {
+		case errors.Is(err, util.ErrPermissionDenied):
+			ctx.APIError(http.StatusForbidden, err)
+		case errors.Is(err, repo_service.ErrChangeRequestRateLimitExceeded):
+			ctx.APIError(http.StatusTooManyRequests, err)
+		default:
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	if repo_service.IsBlankChangeRequestContent(form.Content) {
+		ctx.APIError(http.StatusUnprocessableEntity, "content is required")
+		return
+	}
+	treePath := util.IfZero(strings.TrimSpace(form.TreePath), "README.md")
+	title := util.TruncateRunes(util.IfZero(strings.TrimSpace(form.Title), ctx.Locale.TrString("repo.editor.update_article")), 255)
+
+	branchName := repo_service.GetUniquePatchBranchName(ctx, ctx.Doer.LowerName, repo)
+	if branchName == "" {
+		ctx.APIErrorInternal(errors.New("no patch branch name is available"))
+		return
+	}
+
+	// The patch branch is created from the default branch with an internal push, the
+	// permission to submit change requests has been checked above
+	if _, err := files_service.ChangeRepoFiles(ctx, repo, ctx.Doer, &files_service.ChangeRepoFilesOptions{
+		LastCommitID: form.LastCommit,
+		OldBranch:    repo.DefaultBranch,
+		NewBranch:    branchName,
+		Message:      title,
+		Files: []*files_service.ChangeRepoFile{
+			{
+				Operation:     "update",
+				FromTreePath:  treePath,
+				TreePath:      treePath,
+				ContentReader: strings.NewReader(strings.ReplaceAll(form.Content, "\r", "")),
+			},
+		},
+		InternalPush: true,
+	}); err != nil {
+		handleChangeRepoFilesError(ctx, err)
+		return
+	}
+
+	pr, err := repo_service.CreatePatchBranchChangeRequest(ctx, ctx.Doer, repo, branchName, title, form.Description)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToAPIPullRequest(ctx, pr, ctx.Doer))
+}
+
+// GetChangeRequestConflictPreview reports whether a change request would merge cleanly onto the default branch
+func GetChangeRequestConflictPreview(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/change-requests/{index}/conflict-preview repository repoGetChangeRequestConflictPreview
+	// ---
+	// summary: Preview the merge conflicts of a change request
+	// description: Attempts a test merge of the change request onto the current default branch of the
+	//   repository, without changing the change request, and returns the conflicting files.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the change request
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ChangeRequestConflictPreview"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/error"
+
+	pr, err := issues_model.GetPullRequestByIndex(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("index"))
+	if err != nil {
+		if issues_model.IsErrPullRequestNotExist(err) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	if pr.HasMerged {
+		ctx.APIError(http.StatusConflict, "the change request has already been merged")
+		return
+	}
+
+	preview, err := pull_service.PreviewMergeConflicts(ctx, pr, ctx.Repo.GitRepo)
+	if err != nil {
+		if git_model.IsErrBranchNotExist(err) {
+			ctx.APIErrorNotFound(err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &api.ChangeRequestConflictPreview{
+		BaseCommitID:    preview.BaseCommitID,
+		HeadCommitID:    preview.HeadCommitID,
+		Clean:           preview.Clean,
+		ConflictedFiles: preview.ConflictedFiles,
+	})
+}
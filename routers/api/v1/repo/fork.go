@@ -49,6 +49,10 @@ func ListForks(ctx *context.APIContext) {
 	//   in: query
 	//   description: page size of results
 	//   type: integer
+	// - name: with_divergence
+	//   in: query
+	//   description: include how far each fork diverges from the default branch of the repository, and its number of contributors
+	//   type: boolean
 	// responses:
 	//   "200":
 	//     "$ref": "#/responses/RepositoryList"
@@ -79,6 +83,24 @@ func ListForks(ctx *context.APIContext) {
 		apiForks[i] = convert.ToRepo(ctx, fork, permission)
 	}
 
+	// Computing the divergence needs git operations, the forks are only compared if asked to
+	if ctx.FormBool("with_divergence") {
+		divergences, err := repo_service.GetForksDivergence(ctx, ctx.Repo.Repository, forks)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		for i, fork := range forks {
+			if divergence, ok := divergences[fork.ID]; ok {
+				apiForks[i].Divergence = &api.ForkDivergence{
+					Ahead:            divergence.Ahead,
+					Behind:           divergence.Behind,
+					ContributorCount: divergence.ContributorCount,
+				}
+			}
+		}
+	}
+
 	ctx.SetTotalCountHeader(total)
 	ctx.JSON(http.StatusOK, apiForks)
 }
@@ -160,7 +182,8 @@ func CreateFork(ctx *context.APIContext) {
 	if err != nil {
 		// Check IsErrUserOwnsSubjectRepo BEFORE errors.Is(err, util.ErrAlreadyExist)
 		// because ErrUserOwnsSubjectRepo.Unwrap() returns util.ErrAlreadyExist
-		if errors.Is(err, user_model.ErrBlockedUser) || repo_model.IsErrForkTreeTooLarge(err) || repo_service.IsErrUserOwnsSubjectRepo(err) {
+		if errors.Is(err, user_model.ErrBlockedUser) || repo_model.IsErrForkTreeTooLarge(err) || repo_service.IsErrUserOwnsSubjectRepo(err) ||
+			repo_service.IsErrForkArchivedRepo(err) {
 			ctx.APIError(http.StatusForbidden, err)
 		} else if errors.Is(err, util.ErrAlreadyExist) || repo_model.IsErrReachLimitOfRepo(err) {
 			ctx.APIError(http.StatusConflict, err)
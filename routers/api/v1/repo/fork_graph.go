@@ -11,7 +11,9 @@ import (
 	"net/http"
 	"time"
 
+	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/modules/cache"
+	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/repository"
 )
@@ -24,23 +26,27 @@ import (
 // - v1: Initial implementation with basic fork graph traversal
 // - v2: Added cycle detection error handling (ErrCycleDetected)
 // - v3: Changed GetPublicRepositoryBySubject to prioritize non-empty repositories
-const forkGraphCacheVersion = "v3"
+// - v4: Added the flattened graph and the original parent of its nodes
+const forkGraphCacheVersion = "v4"
 
 // ForkGraphParams represents the query parameters for fork graph endpoint
 type ForkGraphParams struct {
-	IncludeContributors bool   `form:"include_contributors"`
-	ContributorDays     int    `form:"contributor_days"`
-	MaxDepth            int    `form:"max_depth"`
-	IncludePrivate      bool   `form:"include_private"`
-	Sort                string `form:"sort"`
-	Page                int    `form:"page"`
-	Limit               int    `form:"limit"`
+	IncludeContributors   bool   `form:"include_contributors"`
+	IncludeChangeRequests bool   `form:"include_change_requests"`
+	ContributorDays       int    `form:"contributor_days"`
+	MaxDepth              int    `form:"max_depth"`
+	IncludePrivate        bool   `form:"include_private"`
+	Sort                  string `form:"sort"`
+	Page                  int    `form:"page"`
+	Limit                 int    `form:"limit"`
+	Flatten               bool   `form:"flatten"`
+	Scope                 string `form:"scope"`
 }
 
 // setDefaults sets default values for parameters
 func (p *ForkGraphParams) setDefaults() {
 	if p.ContributorDays == 0 {
-		p.ContributorDays = 90
+		p.ContributorDays = setting.Repository.ContributorStatsWindowDays
 	}
 	if p.MaxDepth == 0 {
 		p.MaxDepth = 10
@@ -54,6 +60,9 @@ func (p *ForkGraphParams) setDefaults() {
 	if p.Limit == 0 {
 		p.Limit = 50
 	}
+	if p.Scope == "" {
+		p.Scope = "all"
+	}
 }
 
 // validate validates the parameters
@@ -74,6 +83,9 @@ func (p *ForkGraphParams) validate() error {
 	if !validSorts[p.Sort] {
 		return errors.New("sort must be one of: updated, created, stars, forks")
 	}
+	if p.Scope != "all" && p.Scope != "user" {
+		return errors.New("scope must be one of: all, user")
+	}
 	return nil
 }
 
@@ -97,19 +109,21 @@ func getCacheKey(repoID int64, isEmpty bool, numForks int, params ForkGraphParam
 
 // hashParams creates a hash of the parameters
 func hashParams(params ForkGraphParams) string {
-	data := fmt.Sprintf("%t:%d:%d:%t:%s:%d:%d",
+	data := fmt.Sprintf("%t:%d:%d:%t:%s:%d:%d:%t:%t:%s",
 		params.IncludeContributors, params.ContributorDays, params.MaxDepth,
-		params.IncludePrivate, params.Sort, params.Page, params.Limit)
+		params.IncludePrivate, params.Sort, params.Page, params.Limit, params.IncludeChangeRequests, params.Flatten,
+		params.Scope)
 	hash := sha256.Sum256([]byte(data))
 	return hex.EncodeToString(hash[:8]) // First 8 bytes for brevity
 }
 
-// getCacheTTL returns the cache TTL based on repository and parameters
-func getCacheTTL(isPrivate, includeContributors bool) time.Duration {
+// getCacheTTL returns the cache TTL based on repository and parameters,
+// includeActivity is true if contributor or change request counts are included
+func getCacheTTL(isPrivate, includeActivity bool) time.Duration {
 	if isPrivate {
 		return 5 * time.Minute
 	}
-	if includeContributors {
+	if includeActivity {
 		return 15 * time.Minute
 	}
 	return 30 * time.Minute
@@ -120,7 +134,8 @@ func GetForkGraph(ctx *context.APIContext) {
 	// swagger:operation GET /repos/{owner}/{repo}/forks/graph repository getForkGraph
 	// ---
 	// summary: Get repository fork graph
-	// description: Returns a hierarchical tree structure of all forks with optional contributor statistics
+	// description: Returns a hierarchical tree structure of all forks with optional contributor statistics.
+	//   With format=flat, a list of all forks with their path from the root is returned instead.
 	// produces:
 	// - application/json
 	// parameters:
@@ -139,11 +154,15 @@ func GetForkGraph(ctx *context.APIContext) {
 	//   description: Include contributor count for each fork
 	//   type: boolean
 	//   default: false
+	// - name: include_change_requests
+	//   in: query
+	//   description: Include the number of open change requests targeting the default branch of each fork
+	//   type: boolean
+	//   default: false
 	// - name: contributor_days
 	//   in: query
-	//   description: Days to look back for contributor activity (1-365)
+	//   description: Days to look back for contributor activity (1-365), defaults to the instance's contributor stats window (90 days unless configured)
 	//   type: integer
-	//   default: 90
 	// - name: max_depth
 	//   in: query
 	//   description: Maximum depth of fork tree traversal (1-20)
@@ -169,6 +188,23 @@ func GetForkGraph(ctx *context.APIContext) {
 	//   description: Number of forks per level per page (1-100)
 	//   type: integer
 	//   default: 50
+	// - name: flatten
+	//   in: query
+	//   description: Attach all forks directly to the root, each with the ID of the node it is a fork of as original_parent_id
+	//   type: boolean
+	//   default: false
+	// - name: scope
+	//   in: query
+	//   description: Start the graph at the root of the fork tree, or at the fork of the signed-in user, whose graph is empty if they have none
+	//   type: string
+	//   enum: [all, user]
+	//   default: all
+	// - name: format
+	//   in: query
+	//   description: Representation of the graph, a nested tree or a flat list of forks in depth-first order
+	//   type: string
+	//   enum: [tree, flat]
+	//   default: tree
 	// responses:
 	//   "200":
 	//     "$ref": "#/responses/ForkGraph"
@@ -181,13 +217,16 @@ func GetForkGraph(ctx *context.APIContext) {
 
 	// Parse query parameters with defaults
 	params := ForkGraphParams{
-		IncludeContributors: ctx.FormBool("include_contributors"),
-		ContributorDays:     90, // default
-		MaxDepth:            10, // default
-		IncludePrivate:      ctx.FormBool("include_private"),
-		Sort:                "updated", // default
-		Page:                1,         // default
-		Limit:               50,        // default
+		IncludeContributors:   ctx.FormBool("include_contributors"),
+		IncludeChangeRequests: ctx.FormBool("include_change_requests"),
+		ContributorDays:       setting.Repository.ContributorStatsWindowDays,
+		MaxDepth:              10, // default
+		IncludePrivate:        ctx.FormBool("include_private"),
+		Sort:                  "updated", // default
+		Page:                  1,         // default
+		Limit:                 50,        // default
+		Flatten:               ctx.FormBool("flatten"),
+		Scope:                 "all", // default
 	}
 
 	// Override defaults if parameters are explicitly provided
@@ -206,18 +245,42 @@ func GetForkGraph(ctx *context.APIContext) {
 	if ctx.FormString("limit") != "" {
 		params.Limit = ctx.FormInt("limit")
 	}
+	if ctx.FormString("scope") != "" {
+		params.Scope = ctx.FormString("scope")
+	}
 
 	if err := params.validate(); err != nil {
 		ctx.APIError(http.StatusBadRequest, err)
 		return
 	}
 
+	format := ctx.FormString("format")
+	if format != "" && format != "tree" && format != "flat" {
+		ctx.APIError(http.StatusBadRequest, errors.New("format must be one of: tree, flat"))
+		return
+	}
+
 	// Check repository access
 	if !ctx.Repo.Permission.HasAnyUnitAccessOrPublicAccess() {
 		ctx.APIErrorNotFound()
 		return
 	}
 
+	// The user scope starts the graph at the fork of the signed-in user
+	var rootRepoID int64
+	if params.Scope == "user" {
+		userRepo, err := findUserForkForGraph(ctx)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		if userRepo == nil {
+			respondForkGraph(ctx, repository.NewEmptyForkGraph(), format)
+			return
+		}
+		rootRepoID = userRepo.ID
+	}
+
 	// Get user ID for cache key
 	var userID int64
 	if ctx.Doer != nil {
@@ -232,20 +295,23 @@ func GetForkGraph(ctx *context.APIContext) {
 		found, err := c.GetJSON(cacheKey, &cachedResponse)
 		if err == nil && found {
 			cachedResponse.Metadata.CacheStatus = "hit"
-			ctx.JSON(http.StatusOK, cachedResponse)
+			respondForkGraph(ctx, &cachedResponse, format)
 			return
 		}
 	}
 
 	// Convert params to service params
 	serviceParams := repository.ForkGraphParams{
-		IncludeContributors: params.IncludeContributors,
-		ContributorDays:     params.ContributorDays,
-		MaxDepth:            params.MaxDepth,
-		IncludePrivate:      params.IncludePrivate,
-		Sort:                params.Sort,
-		Page:                params.Page,
-		Limit:               params.Limit,
+		IncludeContributors:   params.IncludeContributors,
+		IncludeChangeRequests: params.IncludeChangeRequests,
+		ContributorDays:       params.ContributorDays,
+		MaxDepth:              params.MaxDepth,
+		IncludePrivate:        params.IncludePrivate,
+		Sort:                  params.Sort,
+		Page:                  params.Page,
+		Limit:                 params.Limit,
+		Flatten:               params.Flatten,
+		RootRepoID:            rootRepoID,
 	}
 
 	// Generate graph
@@ -260,10 +326,40 @@ func GetForkGraph(ctx *context.APIContext) {
 
 	// Cache result
 	if c != nil {
-		ttl := getCacheTTL(ctx.Repo.Repository.IsPrivate, params.IncludeContributors)
+		ttl := getCacheTTL(ctx.Repo.Repository.IsPrivate, params.IncludeContributors || params.IncludeChangeRequests)
 		_ = c.PutJSON(cacheKey, graph, int64(ttl.Seconds()))
 	}
 
+	respondForkGraph(ctx, graph, format)
+}
+
+// findUserForkForGraph returns the repository of the signed-in user in the fork tree of the
+// current repository: their oldest repository of its subject, or their fork of it if it has
+// no subject. It returns nil if there is no signed-in user or they have no such repository.
+func findUserForkForGraph(ctx *context.APIContext) (*repo_model.Repository, error) {
+	if ctx.Doer == nil {
+		return nil, nil
+	}
+	repo := ctx.Repo.Repository
+	if repo.SubjectID > 0 {
+		userRepos, err := repo_model.GetRepositoriesByOwnerIDAndSubjectID(ctx, ctx.Doer.ID, repo.SubjectID)
+		if err != nil || len(userRepos) == 0 {
+			return nil, err
+		}
+		return userRepos[0], nil
+	}
+	if repo.OwnerID == ctx.Doer.ID {
+		return repo, nil
+	}
+	return repo_model.GetUserFork(ctx, repo.ID, ctx.Doer.ID)
+}
+
+// respondForkGraph writes graph as a tree, or as a list of nodes if format is "flat"
+func respondForkGraph(ctx *context.APIContext, graph *repository.ForkGraphResponse, format string) {
+	if format == "flat" {
+		ctx.JSON(http.StatusOK, graph.Flatten())
+		return
+	}
 	ctx.JSON(http.StatusOK, graph)
 }
 
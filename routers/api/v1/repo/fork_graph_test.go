@@ -8,7 +8,11 @@ import (
 	"testing"
 
 	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
 	"code.gitea.io/gitea/services/contexttest"
+	"code.gitea.io/gitea/services/repository"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -232,4 +236,25 @@ func TestForkGraphDefaults(t *testing.T) {
 	assert.Equal(t, "updated", params.Sort)
 	assert.Equal(t, 1, params.Page)
 	assert.Equal(t, 50, params.Limit)
+
+	defer test.MockVariableValue(&setting.Repository.ContributorStatsWindowDays, 30)()
+	params = ForkGraphParams{}
+	params.setDefaults()
+	assert.Equal(t, 30, params.ContributorDays)
+}
+
+func TestAPIForkGraphDefaultContributorWindow(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+	defer test.MockVariableValue(&setting.Repository.ContributorStatsWindowDays, 45)()
+
+	ctx, resp := contexttest.MockAPIContext(t, "GET /user2/repo1/forks/graph?include_contributors=true")
+	contexttest.LoadRepo(t, ctx, 1)
+	contexttest.LoadUser(t, ctx, 2)
+
+	GetForkGraph(ctx)
+	assert.Equal(t, http.StatusOK, ctx.Resp.WrittenStatus())
+
+	var graph repository.ForkGraphResponse
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &graph))
+	assert.Equal(t, 45, graph.Metadata.ContributorWindowDays)
 }
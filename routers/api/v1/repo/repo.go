@@ -261,6 +261,7 @@ func CreateUserRepo(ctx *context.APIContext, owner *user_model.User, opt api.Cre
 	repo, err := repo_service.CreateRepository(ctx, ctx.Doer, owner, repo_service.CreateRepoOptions{
 		Name:             opt.Name,
 		Subject:          opt.Subject,
+		SubjectLang:      opt.SubjectLang,
 		Description:      opt.Description,
 		IssueLabels:      opt.IssueLabels,
 		Gitignores:       opt.Gitignores,
@@ -278,7 +279,8 @@ func CreateUserRepo(ctx *context.APIContext, owner *user_model.User, opt api.Cre
 			ctx.APIError(http.StatusConflict, "The repository with the same name already exists.")
 		} else if db.IsErrNameReserved(err) ||
 			db.IsErrNamePatternNotAllowed(err) ||
-			label.IsErrTemplateLoad(err) {
+			label.IsErrTemplateLoad(err) ||
+			repo_model.IsErrSubjectDeleted(err) {
 			ctx.APIError(http.StatusUnprocessableEntity, err)
 		} else {
 			ctx.APIErrorInternal(err)
@@ -441,7 +443,8 @@ func Generate(ctx *context.APIContext) {
 		if repo_model.IsErrRepoAlreadyExist(err) {
 			ctx.APIError(http.StatusConflict, "The repository with the same name already exists.")
 		} else if db.IsErrNameReserved(err) ||
-			db.IsErrNamePatternNotAllowed(err) {
+			db.IsErrNamePatternNotAllowed(err) ||
+			repo_model.IsErrSubjectDeleted(err) {
 			ctx.APIError(http.StatusUnprocessableEntity, err)
 		} else {
 			ctx.APIErrorInternal(err)
@@ -0,0 +1,457 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/routers/common"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	"code.gitea.io/gitea/services/gitdiff"
+	repo_service "code.gitea.io/gitea/services/repository"
+)
+
+// ListSubjectRepos lists the repositories of a subject that are visible to the doer
+func ListSubjectRepos(ctx *context.APIContext) {
+	// swagger:operation GET /subjects/{slug}/repos repository listSubjectRepos
+	// ---
+	// summary: List the repositories of a subject, the root article first
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: slug
+	//   in: path
+	//   description: slug of the subject
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RepositoryList"
+	//   "301":
+	//     description: the subject has a new slug
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	subject, err := repo_model.GetSubjectBySlug(ctx, ctx.PathParam("slug"))
+	if err != nil {
+		if repo_model.IsErrSubjectNotExist(err) {
+			redirectToSubjectSlug(ctx)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	repos, total, err := repo_service.FindRepositoriesBySubject(ctx, subject.ID, ctx.Doer, utils.GetListOptions(ctx))
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	if err := repo_model.RepositoryList(repos).LoadOwners(ctx); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	if err := repo_model.RepositoryList(repos).LoadUnits(ctx); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiRepos := make([]*api.Repository, len(repos))
+	for i, repo := range repos {
+		permission, err := access_model.GetUserRepoPermission(ctx, repo, ctx.Doer)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		apiRepos[i] = convert.ToRepo(ctx, repo, permission)
+	}
+
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, apiRepos)
+}
+
+// GetSubjectReadme serves the raw README of the root article of a subject
+func GetSubjectReadme(ctx *context.APIContext) {
+	// swagger:operation GET /subjects/{slug}/readme repository getSubjectReadme
+	// ---
+	// summary: Get the raw README of the root article of a subject
+	// produces:
+	// - application/octet-stream
+	// parameters:
+	// - name: slug
+	//   in: path
+	//   description: slug of the subject
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: query
+	//   description: commit SHA of the version of the article to get, defaults to the latest version
+	//   type: string
+	// responses:
+	//   "200":
+	//     description: Returns the raw README.
+	//     schema:
+	//       type: file
+	//   "301":
+	//     description: the subject has a new slug
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	subject, err := repo_model.GetSubjectBySlug(ctx, ctx.PathParam("slug"))
+	if err != nil {
+		if repo_model.IsErrSubjectNotExist(err) {
+			redirectToSubjectSlug(ctx)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	// Like the fork graph, a subject whose articles are all empty has no root
+	root, err := repo_model.GetSubjectRootRepository(ctx, subject.ID)
+	if err != nil {
+		if repo_model.IsErrRepoNotExist(err) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	permission, err := access_model.GetUserRepoPermission(ctx, root, ctx.Doer)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	if !permission.CanRead(unit.TypeCode) {
+		ctx.APIErrorNotFound()
+		return
+	}
+
+	gitRepo, err := gitrepo.OpenRepository(ctx, root)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	defer gitRepo.Close()
+
+	var commit *git.Commit
+	if version := ctx.FormString("version"); version != "" {
+		var objectFormat git.ObjectFormat
+		if objectFormat, err = gitRepo.GetObjectFormat(); err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		if !git.IsStringLikelyCommitID(objectFormat, version, 7) {
+			ctx.APIErrorNotFound()
+			return
+		}
+		commit, err = gitRepo.GetCommit(version)
+	} else {
+		commit, err = gitRepo.GetBranchCommit(root.DefaultBranch)
+	}
+	if err != nil {
+		if git.IsErrNotExist(err) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	entries, err := commit.ListEntries()
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	readme := common.FindArticleReadme(entries)
+	if readme == nil {
+		ctx.APIErrorNotFound()
+		return
+	}
+	if err := common.ServeBlob(ctx.Base, root, readme.Name(), readme.Blob(), nil); err != nil {
+		ctx.APIErrorInternal(err)
+	}
+}
+
+// subjectDiffContextLines is the number of unchanged lines kept around the changes of a README diff
+const subjectDiffContextLines = 3
+
+// GetSubjectReadmeDiff compares the READMEs of two articles of a subject
+func GetSubjectReadmeDiff(ctx *context.APIContext) {
+	// swagger:operation GET /subjects/{slug}/diff repository getSubjectReadmeDiff
+	// ---
+	// summary: Compare the READMEs of two articles of a subject
+	// description: The READMEs of the default branches are compared line by line. A repository
+	//   without a README compares as an empty README.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: slug
+	//   in: path
+	//   description: slug of the subject
+	//   type: string
+	//   required: true
+	// - name: from
+	//   in: query
+	//   description: full name ("owner/repo") of the repository of the article to compare from
+	//   type: string
+	//   required: true
+	// - name: to
+	//   in: query
+	//   description: full name ("owner/repo") of the repository of the article to compare to
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SubjectReadmeDiff"
+	//   "301":
+	//     description: the subject has a new slug
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	subject, err := repo_model.GetSubjectBySlug(ctx, ctx.PathParam("slug"))
+	if err != nil {
+		if repo_model.IsErrSubjectNotExist(err) {
+			redirectToSubjectSlug(ctx)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	from, fromContent := loadArticleDiffSide(ctx, subject, ctx.FormString("from"))
+	if from == nil {
+		return
+	}
+	to, toContent := loadArticleDiffSide(ctx, subject, ctx.FormString("to"))
+	if to == nil {
+		return
+	}
+
+	diffLines := repo_service.BuildReadmeDiffLines(strings.Split(fromContent, "\n"), strings.Split(toContent, "\n"))
+	diff := &api.SubjectReadmeDiff{From: from, To: to}
+	for _, line := range diffLines {
+		switch line.Type {
+		case gitdiff.DiffLineAdd:
+			diff.Additions++
+		case gitdiff.DiffLineDel:
+			diff.Deletions++
+		}
+	}
+	hunks := repo_service.SplitReadmeDiffHunks(diffLines, subjectDiffContextLines)
+	diff.Hunks = make([]*api.ArticleDiffHunk, 0, len(hunks))
+	for _, hunk := range hunks {
+		apiHunk := &api.ArticleDiffHunk{
+			OldStart: hunk.OldStart,
+			OldLines: hunk.OldLines,
+			NewStart: hunk.NewStart,
+			NewLines: hunk.NewLines,
+			Lines:    make([]*api.ArticleDiffLine, 0, len(hunk.Lines)),
+		}
+		for _, line := range hunk.Lines {
+			lineType := "context"
+			switch line.Type {
+			case gitdiff.DiffLineAdd:
+				lineType = "add"
+			case gitdiff.DiffLineDel:
+				lineType = "delete"
+			}
+			apiHunk.Lines = append(apiHunk.Lines, &api.ArticleDiffLine{
+				Type:      lineType,
+				OldNumber: line.LeftIdx,
+				NewNumber: line.RightIdx,
+				// Drop the " ", "+" or "-" prefix of the line
+				Content: line.Content[1:],
+			})
+		}
+		diff.Hunks = append(diff.Hunks, apiHunk)
+	}
+
+	ctx.JSON(http.StatusOK, diff)
+}
+
+// loadArticleDiffSide loads the README of the default branch of the repository named fullName, which
+// must be an article of the subject. Returns nil if an error response has been written.
+func loadArticleDiffSide(ctx *context.APIContext, subject *repo_model.Subject, fullName string) (*api.ArticleDiffSide, string) {
+	ownerName, repoName, ok := strings.Cut(fullName, "/")
+	if !ok || ownerName == "" || repoName == "" {
+		ctx.APIError(http.StatusUnprocessableEntity, fmt.Sprintf("%q is not the full name of a repository", fullName))
+		return nil, ""
+	}
+	repo, err := repo_model.GetRepositoryByOwnerAndName(ctx, ownerName, repoName)
+	if err != nil {
+		if repo_model.IsErrRepoNotExist(err) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return nil, ""
+	}
+	permission, err := access_model.GetUserRepoPermission(ctx, repo, ctx.Doer)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return nil, ""
+	}
+	if !permission.CanRead(unit.TypeCode) {
+		ctx.APIErrorNotFound()
+		return nil, ""
+	}
+	if repo.SubjectID != subject.ID {
+		ctx.APIError(http.StatusUnprocessableEntity, fmt.Sprintf("%s is not an article of the subject %q", repo.FullName(), subject.Name))
+		return nil, ""
+	}
+
+	side := &api.ArticleDiffSide{FullName: repo.FullName()}
+	if repo.IsEmpty {
+		return side, ""
+	}
+	gitRepo, err := gitrepo.OpenRepository(ctx, repo)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return nil, ""
+	}
+	defer gitRepo.Close()
+	commit, err := gitRepo.GetBranchCommit(repo.DefaultBranch)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return nil, ""
+	}
+	side.LastCommit = convert.ToPayloadCommit(ctx, repo, commit)
+
+	entries, err := commit.ListEntries()
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return nil, ""
+	}
+	readme := common.FindArticleReadme(entries)
+	if readme == nil {
+		return side, ""
+	}
+	blob := readme.Blob()
+	if blob.Size() >= setting.ArticleMaxRenderSize() {
+		ctx.APIError(http.StatusUnprocessableEntity, fmt.Sprintf("the README of %s is too large to be compared", repo.FullName()))
+		return nil, ""
+	}
+	content, err := blob.GetBlobContent(blob.Size())
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return nil, ""
+	}
+	side.Path = readme.Name()
+	return side, content
+}
+
+// CreateSubject creates a subject ahead of the repositories that will attach to it
+func CreateSubject(ctx *context.APIContext) {
+	// swagger:operation POST /subjects repository createSubject
+	// ---
+	// summary: Create a subject
+	// description: Only site administrators can create subjects this way, e.g. to prepare an import.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateSubjectOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/Subject"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "409":
+	//     description: A subject with the same slug already exists.
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreateSubjectOption)
+	name := strings.TrimSpace(form.Name)
+	if name == "" {
+		ctx.APIError(http.StatusUnprocessableEntity, errors.New("subject name cannot be empty"))
+		return
+	}
+	if err := repo_model.IsUsableSubjectSlug(repo_model.GenerateSlugFromName(name)); err != nil {
+		ctx.APIError(http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	subject, err := repo_model.CreateSubjectWithOptions(ctx, repo_model.CreateSubjectOptions{
+		Name:        name,
+		Description: strings.TrimSpace(form.Description),
+		Lang:        strings.ToLower(strings.TrimSpace(form.Lang)),
+	})
+	if err != nil {
+		if repo_model.IsErrSubjectSlugAlreadyExists(err) {
+			ctx.APIError(http.StatusConflict, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	log.Trace("Subject %d (%s) created by %s through the API", subject.ID, subject.Slug, ctx.Doer.Name)
+
+	ctx.JSON(http.StatusCreated, convert.ToSubject(subject))
+}
+
+// redirectToSubjectSlug redirects a request for a former slug of a subject to its current slug,
+// or responds with not found if the slug never belonged to a subject
+func redirectToSubjectSlug(ctx *context.APIContext) {
+	slug := ctx.PathParam("slug")
+	subjectID, err := repo_model.LookupSubjectRedirect(ctx, slug)
+	if err != nil {
+		if repo_model.IsErrSubjectRedirectNotExist(err) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	subject, err := repo_model.GetSubjectByID(ctx, subjectID)
+	if err != nil {
+		if repo_model.IsErrSubjectNotExist(err) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	if subject.IsDeleted() {
+		ctx.APIErrorNotFound()
+		return
+	}
+
+	redirectPath := strings.Replace(ctx.Req.URL.EscapedPath(), "/subjects/"+url.PathEscape(slug)+"/", "/subjects/"+url.PathEscape(subject.Slug)+"/", 1)
+	if ctx.Req.URL.RawQuery != "" {
+		redirectPath += "?" + ctx.Req.URL.RawQuery
+	}
+	ctx.Redirect(path.Join(setting.AppSubURL, redirectPath), http.StatusMovedPermanently)
+}
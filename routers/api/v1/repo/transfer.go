@@ -111,7 +111,7 @@ func Transfer(ctx *context.APIContext) {
 		switch {
 		case repo_model.IsErrRepoTransferInProgress(err):
 			ctx.APIError(http.StatusConflict, err)
-		case repo_model.IsErrRepoAlreadyExist(err):
+		case repo_model.IsErrRepoAlreadyExist(err), repo_service.IsErrUserOwnsSubjectRepo(err), repo_service.IsErrForkAlreadyExist(err):
 			ctx.APIError(http.StatusUnprocessableEntity, err)
 		case repo_service.IsRepositoryLimitReached(err):
 			ctx.APIError(http.StatusForbidden, err)
@@ -158,6 +158,8 @@ func AcceptTransfer(ctx *context.APIContext) {
 	//     "$ref": "#/responses/forbidden"
 	//   "404":
 	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
 
 	err := repo_service.AcceptTransferOwnership(ctx, ctx.Repo.Repository, ctx.Doer)
 	if err != nil {
@@ -168,6 +170,8 @@ func AcceptTransfer(ctx *context.APIContext) {
 			ctx.APIError(http.StatusForbidden, err)
 		case repo_service.IsRepositoryLimitReached(err):
 			ctx.APIError(http.StatusForbidden, err)
+		case repo_service.IsErrUserOwnsSubjectRepo(err), repo_service.IsErrForkAlreadyExist(err):
+			ctx.APIError(http.StatusUnprocessableEntity, err)
 		default:
 			ctx.APIErrorInternal(err)
 		}
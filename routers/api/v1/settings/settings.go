@@ -64,6 +64,7 @@ func GetGeneralRepoSettings(ctx *context.APIContext) {
 		StarsDisabled:        setting.Repository.DisableStars,
 		TimeTrackingDisabled: !setting.Service.EnableTimetracking,
 		LFSDisabled:          !setting.LFS.StartServer,
+		DefaultBranch:        setting.Repository.DefaultBranch,
 	})
 }
 
@@ -13,3 +13,10 @@ type swaggerResponseCronList struct {
 	// in:body
 	Body []api.Cron `json:"body"`
 }
+
+// ForkanaIntegrity
+// swagger:response ForkanaIntegrity
+type swaggerResponseForkanaIntegrity struct {
+	// in:body
+	Body api.ForkanaIntegrity `json:"body"`
+}
@@ -76,6 +76,8 @@ type swaggerParameterBodies struct {
 	// in:body
 	CreatePullRequestOption api.CreatePullRequestOption
 	// in:body
+	CreateChangeRequestOption api.CreateChangeRequestOption
+	// in:body
 	EditPullRequestOption api.EditPullRequestOption
 	// in:body
 	MergePullRequestOption forms.MergePullRequestForm
@@ -222,4 +224,7 @@ type swaggerParameterBodies struct {
 
 	// in:body
 	LockIssueOption api.LockIssueOption
+
+	// in:body
+	CreateSubjectOption api.CreateSubjectOption
 }
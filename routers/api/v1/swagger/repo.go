@@ -422,6 +422,41 @@ type swaggerForkGraph struct {
 	Body repository.ForkGraphResponse `json:"body"`
 }
 
+// Subject
+// swagger:response Subject
+type swaggerSubject struct {
+	// in:body
+	Body api.Subject `json:"body"`
+}
+
+// SubjectReadmeDiff
+// swagger:response SubjectReadmeDiff
+type swaggerSubjectReadmeDiff struct {
+	// in:body
+	Body api.SubjectReadmeDiff `json:"body"`
+}
+
+// UserSubjectList
+// swagger:response UserSubjectList
+type swaggerUserSubjectList struct {
+	// in:body
+	Body []api.UserSubject `json:"body"`
+}
+
+// ChangeRequestConflictPreview
+// swagger:response ChangeRequestConflictPreview
+type swaggerChangeRequestConflictPreview struct {
+	// in:body
+	Body api.ChangeRequestConflictPreview `json:"body"`
+}
+
+// RenderedArticle
+// swagger:response RenderedArticle
+type swaggerRenderedArticle struct {
+	// in:body
+	Body api.RenderedArticle `json:"body"`
+}
+
 // RepoCollaboratorPermission
 // swagger:response RepoCollaboratorPermission
 type swaggerRepoCollaboratorPermission struct {
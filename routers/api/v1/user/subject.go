@@ -0,0 +1,76 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package user
+
+import (
+	"net/http"
+
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// ListUserSubjects lists the subjects in which the given user owns a repository, along with each of these repositories
+func ListUserSubjects(ctx *context.APIContext) {
+	// swagger:operation GET /users/{username}/subjects user userListSubjects
+	// ---
+	// summary: List the subjects in which the given user owns a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: username
+	//   in: path
+	//   description: username of the user whose subjects are to be listed
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/UserSubjectList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	opts := utils.GetListOptions(ctx)
+	repos, count, err := repo_model.FindSubjectRepositoriesByOwnerID(ctx, ctx.ContextUser.ID, ctx.IsSigned, opts)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	if err := repos.LoadAttributes(ctx); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	userSubjects := make([]*api.UserSubject, 0, len(repos))
+	for _, repo := range repos {
+		if repo.SubjectRelation == nil {
+			continue
+		}
+		permission, err := access_model.GetUserRepoPermission(ctx, repo, ctx.Doer)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		if ctx.IsSigned && ctx.Doer.IsAdmin || permission.HasAnyUnitAccess() {
+			userSubjects = append(userSubjects, &api.UserSubject{
+				Subject:    convert.ToSubject(repo.SubjectRelation),
+				Repository: convert.ToRepo(ctx, repo, permission),
+			})
+		}
+	}
+
+	ctx.SetLinkHeader(int(count), opts.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, &userSubjects)
+}
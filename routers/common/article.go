@@ -0,0 +1,70 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package common
+
+import (
+	"bytes"
+	"html/template"
+	"io"
+	"strings"
+
+	"code.gitea.io/gitea/modules/charset"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/markup"
+	"code.gitea.io/gitea/modules/markup/markdown"
+	"code.gitea.io/gitea/services/context"
+)
+
+// FindArticleReadme finds the README of an article in the entries of its root tree
+func FindArticleReadme(entries []*git.TreeEntry) *git.TreeEntry {
+	// Look for readme.md (case insensitive)
+	for _, entry := range entries {
+		if entry.IsRegular() || entry.IsExecutable() {
+			name := strings.ToLower(entry.Name())
+			if name == "readme.md" || name == "readme" || name == "readme.txt" {
+				return entry
+			}
+		}
+	}
+	return nil
+}
+
+// ArticleMarkupType returns the markup type the README of an article at treePath is rendered with, along with
+// the content to render. The "format" key of the YAML front matter of a markdown README overrides the type
+// detected from its name, so that articles can be written in another markup, like "orgmode". It must name a
+// renderer which doesn't display in an iframe, otherwise it is ignored. The front matter is left out of the
+// content rendered with another markup, which wouldn't understand it.
+func ArticleMarkupType(treePath string, content []byte) (string, []byte) {
+	markupType := markup.DetectMarkupTypeByFileName(treePath)
+	if markupType != markdown.MarkupName {
+		return markupType, content
+	}
+
+	var frontMatter struct {
+		Format string `yaml:"format"`
+	}
+	body, err := markdown.ExtractMetadataBytes(content, &frontMatter)
+	if err != nil || frontMatter.Format == "" || frontMatter.Format == markupType {
+		return markupType, content
+	}
+	renderer := markup.GetRendererByType(frontMatter.Format)
+	if renderer == nil {
+		return markupType, content
+	}
+	if external, ok := renderer.(markup.ExternalRenderer); ok && external.DisplayInIFrame() {
+		return markupType, content
+	}
+	return frontMatter.Format, body
+}
+
+// RenderArticleMarkup renders the markup of an article and escapes the control characters
+// in the rendered HTML. It is shared by the article view and the article API.
+func RenderArticleMarkup(ctx *context.Base, rctx *markup.RenderContext, rd io.Reader) (*charset.EscapeStatus, template.HTML, error) {
+	var buf bytes.Buffer
+	if err := markup.Render(rctx, rd, &buf); err != nil {
+		return nil, "", err
+	}
+	escapeStatus, content := charset.EscapeControlHTML(template.HTML(buf.String()), ctx.Locale)
+	return escapeStatus, content, nil
+}
@@ -0,0 +1,27 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package common
+
+import (
+	"testing"
+
+	_ "code.gitea.io/gitea/modules/markup/orgmode"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArticleMarkupType(t *testing.T) {
+	test := func(treePath, content, expectedType, expectedContent string) {
+		markupType, body := ArticleMarkupType(treePath, []byte(content))
+		assert.Equal(t, expectedType, markupType)
+		assert.Equal(t, expectedContent, string(body))
+	}
+
+	test("README.md", "# Rivers\n", "markdown", "# Rivers\n")
+	test("README.md", "---\nformat: orgmode\n---\n* Rivers\n", "orgmode", "* Rivers\n")
+	test("README.md", "---\nformat: markdown\n---\n# Rivers\n", "markdown", "---\nformat: markdown\n---\n# Rivers\n")
+	test("README.md", "---\nformat: no-such-markup\n---\n# Rivers\n", "markdown", "---\nformat: no-such-markup\n---\n# Rivers\n")
+	// only markdown READMEs have a front matter
+	test("README.txt", "---\nformat: orgmode\n---\n* Rivers\n", "", "---\nformat: orgmode\n---\n* Rivers\n")
+}
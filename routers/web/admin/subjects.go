@@ -0,0 +1,211 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"net/http"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/services/context"
+	repo_service "code.gitea.io/gitea/services/repository"
+)
+
+const tplSubjectHealth templates.TplName = "admin/subject/health"
+
+// SubjectHealth lists subjects and forks whose fork tree is in an inconsistent state
+func SubjectHealth(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("admin.subjects.health")
+	ctx.Data["PageIsAdminSubjects"] = true
+
+	subjects, err := repo_model.FindSubjectsWithoutRoot(ctx)
+	if err != nil {
+		ctx.ServerError("FindSubjectsWithoutRoot", err)
+		return
+	}
+	ctx.Data["SubjectsWithoutRoot"] = subjects
+
+	orphans, err := repo_model.FindOrphanedForks(ctx)
+	if err != nil {
+		ctx.ServerError("FindOrphanedForks", err)
+		return
+	}
+	ctx.Data["OrphanedForks"] = orphans
+
+	promotions, err := repo_model.FindPendingRootPromotions(ctx)
+	if err != nil {
+		ctx.ServerError("FindPendingRootPromotions", err)
+		return
+	}
+	ctx.Data["RootPromotions"] = promotions
+
+	featured, _, err := repo_model.FindSubjects(ctx, repo_model.FindSubjectsOptions{
+		OrderBy:      repo_model.SubjectOrderByMap[repo_model.SubjectSortFeatured],
+		FeaturedOnly: true,
+	})
+	if err != nil {
+		ctx.ServerError("FindSubjects", err)
+		return
+	}
+	ctx.Data["FeaturedSubjects"] = featured
+
+	ctx.HTML(http.StatusOK, tplSubjectHealth)
+}
+
+// PromoteSubjectRoot promotes the oldest non-empty article of a subject to be its root
+func PromoteSubjectRoot(ctx *context.Context) {
+	subject, err := repo_model.GetSubjectByID(ctx, ctx.FormInt64("id"))
+	if err != nil {
+		if repo_model.IsErrSubjectNotExist(err) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetSubjectByID", err)
+		}
+		return
+	}
+
+	repo, err := repo_service.PromoteOldestRepositoryToRoot(ctx, subject.ID)
+	switch {
+	case repo_model.IsErrRepoNotExist(err):
+		ctx.Flash.Error(ctx.Tr("admin.subjects.promote_root.no_content", subject.Name))
+	case repo_model.IsErrRootArticleAlreadyExists(err):
+		ctx.Flash.Info(ctx.Tr("admin.subjects.promote_root.has_root", subject.Name))
+	case err != nil:
+		ctx.ServerError("PromoteOldestRepositoryToRoot", err)
+		return
+	default:
+		log.Info("Admin %s promoted repository %-v to root of subject %d", ctx.Doer.Name, repo, subject.ID)
+		ctx.Flash.Success(ctx.Tr("admin.subjects.promote_root.success", repo.FullName(), subject.Name))
+	}
+	ctx.Redirect(setting.AppSubURL + "/-/admin/subjects")
+}
+
+// RepairOrphanedForks reparents or converts every fork whose parent repository no longer exists
+func RepairOrphanedForks(ctx *context.Context) {
+	orphans, err := repo_model.FindOrphanedForks(ctx)
+	if err != nil {
+		ctx.ServerError("FindOrphanedForks", err)
+		return
+	}
+
+	for _, repo := range orphans {
+		parent, err := repo_service.RepairOrphanedFork(ctx, repo)
+		if err != nil {
+			ctx.ServerError("RepairOrphanedFork", err)
+			return
+		}
+		if parent != nil {
+			log.Info("Admin %s reparented orphaned fork %-v to %-v", ctx.Doer.Name, repo, parent)
+		} else {
+			log.Info("Admin %s converted orphaned fork %-v to a normal repository", ctx.Doer.Name, repo)
+		}
+	}
+
+	ctx.Flash.Success(ctx.Tr("admin.subjects.orphaned_forks.repaired", len(orphans)))
+	ctx.Redirect(setting.AppSubURL + "/-/admin/subjects")
+}
+
+// ApproveRootPromotion makes the fork of a pending root promotion the root of its subject
+func ApproveRootPromotion(ctx *context.Context) {
+	promotion := getRootPromotion(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	err := repo_service.ApproveRootPromotion(ctx, promotion)
+	switch {
+	case repo_service.IsErrRootPromotionOutdated(err):
+		if err := repo_model.DeleteRootPromotion(ctx, promotion.ID); err != nil {
+			ctx.ServerError("DeleteRootPromotion", err)
+			return
+		}
+		ctx.Flash.Warning(ctx.Tr("admin.subjects.root_promotions.outdated"))
+	case err != nil:
+		ctx.ServerError("ApproveRootPromotion", err)
+		return
+	default:
+		repo, err := repo_model.GetRepositoryByID(ctx, promotion.RepoID)
+		if err != nil {
+			ctx.ServerError("GetRepositoryByID", err)
+			return
+		}
+		log.Info("Admin %s approved the promotion of %-v to root of subject %d", ctx.Doer.Name, repo, promotion.SubjectID)
+		ctx.Flash.Success(ctx.Tr("admin.subjects.root_promotions.approved", repo.FullName()))
+	}
+	ctx.Redirect(setting.AppSubURL + "/-/admin/subjects")
+}
+
+// RejectRootPromotion removes a pending root promotion, leaving the current root in place
+func RejectRootPromotion(ctx *context.Context) {
+	promotion := getRootPromotion(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := repo_model.DeleteRootPromotion(ctx, promotion.ID); err != nil {
+		ctx.ServerError("DeleteRootPromotion", err)
+		return
+	}
+	log.Info("Admin %s rejected the promotion of repository %d to root of subject %d", ctx.Doer.Name, promotion.RepoID, promotion.SubjectID)
+	ctx.Flash.Success(ctx.Tr("admin.subjects.root_promotions.rejected"))
+	ctx.Redirect(setting.AppSubURL + "/-/admin/subjects")
+}
+
+// FeatureSubject features a subject, found by name, on the explore page at the given position
+func FeatureSubject(ctx *context.Context) {
+	subject, err := repo_model.GetSubjectByName(ctx, ctx.FormTrim("name"))
+	if err != nil {
+		if repo_model.IsErrSubjectNotExist(err) {
+			ctx.Flash.Error(ctx.Tr("admin.subjects.featured.not_found", ctx.FormTrim("name")))
+			ctx.Redirect(setting.AppSubURL + "/-/admin/subjects")
+		} else {
+			ctx.ServerError("GetSubjectByName", err)
+		}
+		return
+	}
+
+	if err := repo_model.SetSubjectFeatured(ctx, subject.ID, true, ctx.FormInt("order")); err != nil {
+		ctx.ServerError("SetSubjectFeatured", err)
+		return
+	}
+	log.Info("Admin %s featured subject %d", ctx.Doer.Name, subject.ID)
+	ctx.Flash.Success(ctx.Tr("admin.subjects.featured.added", subject.Name))
+	ctx.Redirect(setting.AppSubURL + "/-/admin/subjects")
+}
+
+// UnfeatureSubject stops featuring a subject on the explore page
+func UnfeatureSubject(ctx *context.Context) {
+	subject, err := repo_model.GetSubjectByID(ctx, ctx.FormInt64("id"))
+	if err != nil {
+		if repo_model.IsErrSubjectNotExist(err) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetSubjectByID", err)
+		}
+		return
+	}
+
+	if err := repo_model.SetSubjectFeatured(ctx, subject.ID, false, 0); err != nil {
+		ctx.ServerError("SetSubjectFeatured", err)
+		return
+	}
+	log.Info("Admin %s unfeatured subject %d", ctx.Doer.Name, subject.ID)
+	ctx.Flash.Success(ctx.Tr("admin.subjects.featured.removed", subject.Name))
+	ctx.Redirect(setting.AppSubURL + "/-/admin/subjects")
+}
+
+func getRootPromotion(ctx *context.Context) *repo_model.RootPromotion {
+	promotion, err := repo_model.GetRootPromotionByID(ctx, ctx.FormInt64("id"))
+	if err != nil {
+		if repo_model.IsErrRootPromotionNotExist(err) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetRootPromotionByID", err)
+		}
+		return nil
+	}
+	return promotion
+}
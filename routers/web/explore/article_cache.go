@@ -0,0 +1,81 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package explore
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/httpcache"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/routers/common"
+	"code.gitea.io/gitea/services/context"
+)
+
+// articleVersionCacheMaxAge is how long browsers may reuse the read view of an article pinned to a
+// commit without revalidating it. The version never changes, but the page also shows the other
+// articles of the subject, so it isn't cached for good.
+const articleVersionCacheMaxAge = 24 * time.Hour
+
+// handleArticleReadCache sets the ETag and Cache-Control headers of the read view of the article at
+// commit, whose tree has entries, and responds with 304 Not Modified if the browser has it already.
+// Only the page of anonymous visitors is cached: signed in users also see their watch state, change
+// suggestions and a CSRF token, which the ETag can't cover. The edit and history views, and pages
+// showing a flash message, aren't cached either.
+// It returns true if the response has been written.
+func handleArticleReadCache(ctx *context.Context, commit *git.Commit, entries []*git.TreeEntry) bool {
+	if ctx.IsSigned {
+		return false
+	}
+	if mode := ctx.FormString("mode"); (mode != "" && mode != "read") || ctx.Data["Flash"] != nil {
+		return false
+	}
+	readme := common.FindArticleReadme(entries)
+	if readme == nil {
+		return false
+	}
+
+	// The page also shows the repository settings, e.g. its description, and the other articles of
+	// the subject, e.g. the forks and the contributor leaderboard, which change whenever one of its
+	// repositories is created or updated. No modification time covers all of them, only the ETag does.
+	repo := ctx.Repo.Repository
+	var subjectRepoCount int64
+	var subjectUpdated timeutil.TimeStamp
+	if repo.SubjectID > 0 {
+		var err error
+		subjectRepoCount, subjectUpdated, err = repo_model.GetSubjectRepositoriesLastUpdate(ctx, repo.SubjectID)
+		if err != nil {
+			log.Error("GetSubjectRepositoriesLastUpdate(%d): %v", repo.SubjectID, err)
+			return false
+		}
+	}
+	hash := sha256.Sum256(fmt.Appendf(nil, "%s:%s:%s:%d:%d:%d:%s", commit.ID, readme.ID, ctx.Locale.Language(),
+		repo.UpdatedUnix, subjectRepoCount, subjectUpdated, setting.AppVer))
+	etag := `"` + hex.EncodeToString(hash[:16]) + `"`
+
+	setArticleCacheControl(ctx, commit)
+	if httpcache.HandleGenericETagCache(ctx.Req, ctx.Resp, etag) {
+		return true
+	}
+	// HandleGenericETagCache replaces the Cache-Control of a response it doesn't handle
+	setArticleCacheControl(ctx, commit)
+	return false
+}
+
+// setArticleCacheControl lets browsers reuse the read view of an article pinned to the full ID of
+// commit for a while. Any other URL, like the latest version of the article, can show another
+// version at any time and is revalidated on every visit.
+func setArticleCacheControl(ctx *context.Context, commit *git.Commit) {
+	if ctx.FormString("version") == commit.ID.String() {
+		httpcache.SetCacheControlInHeader(ctx.Resp.Header(), &httpcache.CacheControlOptions{MaxAge: articleVersionCacheMaxAge})
+		return
+	}
+	ctx.Resp.Header().Set("Cache-Control", "private, no-cache")
+}
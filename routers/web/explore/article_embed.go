@@ -0,0 +1,98 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package explore
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/routers/common"
+	"code.gitea.io/gitea/services/context"
+)
+
+const tplArticleEmbed templates.TplName = "explore/article_embed"
+
+// RenderArticleEmbed renders the README of an article without the site chrome, to be shown in an
+// iframe on other sites. The article is rendered at ctx.Repo.Commit if it is set (pinned embeds),
+// otherwise at the head of the default branch.
+func RenderArticleEmbed(ctx *context.Context) {
+	repo := ctx.Repo.Repository
+	if repo.IsEmpty || repo.IsBroken() {
+		ctx.NotFound(errors.New("article has no content"))
+		return
+	}
+
+	var refPath, version string
+	commit := ctx.Repo.Commit
+	if commit != nil {
+		refPath = path.Join("commit", commit.ID.String())
+		version = commit.ID.String()
+	} else {
+		var err error
+		commit, err = ctx.Repo.GitRepo.GetBranchCommit(repo.DefaultBranch)
+		if err != nil {
+			ctx.ServerError("GetBranchCommit", err)
+			return
+		}
+		refPath = path.Join("branch", util.PathEscapeSegments(repo.DefaultBranch))
+	}
+
+	entries, err := commit.ListEntries()
+	if err != nil {
+		ctx.ServerError("Commit.ListEntries", err)
+		return
+	}
+	readmeFile := common.FindArticleReadme(entries)
+	if readmeFile == nil {
+		ctx.NotFound(errors.New("article has no README"))
+		return
+	}
+
+	renderArticleReadme(ctx, readmeFile, refPath, "")
+	if ctx.Written() {
+		return
+	}
+
+	repoLicenses, err := repo_model.GetRepoLicenses(ctx, repo)
+	if err != nil {
+		ctx.ServerError("GetRepoLicenses", err)
+		return
+	}
+	ctx.Data["DetectedRepoLicenses"] = repoLicenses.StringList()
+
+	ctx.Data["Title"] = repo.GetSubject(ctx)
+	ctx.Data["ArticleEmbedLink"] = articleURL(ctx, version)
+	ctx.Data["ArticleEmbedCommit"] = commit
+
+	setArticleEmbedHeaders(ctx)
+	ctx.HTML(http.StatusOK, tplArticleEmbed)
+}
+
+// articleURL returns the absolute URL of the article of ctx.Repo, pinned to version if it isn't empty
+func articleURL(ctx *context.Context, version string) string {
+	repo := ctx.Repo.Repository
+	link := setting.AppURL + "article/" + url.PathEscape(repo.OwnerName) + "/" + url.PathEscape(repo.GetSubject(ctx))
+	if version != "" {
+		link += "?version=" + url.QueryEscape(version)
+	}
+	return link
+}
+
+// setArticleEmbedHeaders replaces the site-wide X-Frame-Options header, which only allows
+// same-origin framing, with a frame-ancestors policy built from [repository] ARTICLE_EMBED_ORIGINS
+func setArticleEmbedHeaders(ctx *context.Context) {
+	ancestors := "*"
+	if len(setting.Repository.ArticleEmbedOrigins) > 0 {
+		ancestors = "'self' " + strings.Join(setting.Repository.ArticleEmbedOrigins, " ")
+	}
+	ctx.Resp.Header().Del("X-Frame-Options")
+	ctx.Resp.Header().Set("Content-Security-Policy", "frame-ancestors "+ancestors)
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package explore
+
+import (
+	"net/http"
+	"strings"
+
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/services/context"
+	repo_service "code.gitea.io/gitea/services/repository"
+)
+
+const (
+	historyGraphFormatJSON    = "json"
+	historyGraphFormatDOT     = "dot"
+	historyGraphFormatGraphML = "graphml"
+	// historyGraphFormatUnsupported is returned for an Accept header that allows no supported format
+	historyGraphFormatUnsupported = "unsupported"
+
+	historyGraphMediaTypeDOT     = "text/vnd.graphviz"
+	historyGraphMediaTypeGraphML = "application/graphml+xml"
+)
+
+// historyGraphFormat returns the format the fork graph is requested in, from the format
+// parameter or else the Accept header. It returns "" if the HTML page is requested.
+func historyGraphFormat(ctx *context.Context) string {
+	if format := ctx.FormString("format"); format != "" {
+		return strings.ToLower(format)
+	}
+
+	accept := ctx.Req.Header.Get("Accept")
+	if accept == "" {
+		return ""
+	}
+	switch {
+	case strings.Contains(accept, historyGraphMediaTypeDOT):
+		return historyGraphFormatDOT
+	case strings.Contains(accept, historyGraphMediaTypeGraphML):
+		return historyGraphFormatGraphML
+	case strings.Contains(accept, "application/json") && !strings.Contains(accept, "text/html"):
+		return historyGraphFormatJSON
+	}
+
+	// The HTML page is only served to clients that accept it
+	for mediaRange := range strings.SplitSeq(accept, ",") {
+		mediaType, _, _ := strings.Cut(mediaRange, ";")
+		switch strings.ToLower(strings.TrimSpace(mediaType)) {
+		case "text/html", "application/xhtml+xml", "text/*", "*/*":
+			return ""
+		}
+	}
+	return historyGraphFormatUnsupported
+}
+
+// renderHistoryGraph writes the fork graph of ctx.Repo in format, the same graph the bubble view
+// shows, so that it can be used by scripts without a separate endpoint
+func renderHistoryGraph(ctx *context.Context, format string) {
+	switch format {
+	case historyGraphFormatJSON, historyGraphFormatDOT, historyGraphFormatGraphML:
+	default:
+		ctx.HTTPError(http.StatusNotAcceptable, "unsupported format, supported formats are json, dot and graphml")
+		return
+	}
+
+	graph, err := repo_service.BuildForkGraph(ctx, ctx.Repo.Repository, repo_service.ForkGraphParams{
+		ContributorDays: setting.Repository.ContributorStatsWindowDays,
+		MaxDepth:        10,
+		Sort:            "updated",
+		Page:            1,
+		Limit:           50,
+	}, ctx.Doer)
+	if err != nil {
+		switch {
+		case repo_service.IsErrMaxDepthExceeded(err), repo_service.IsErrTooManyNodes(err):
+			ctx.HTTPError(http.StatusBadRequest, err.Error())
+		case repo_service.IsErrProcessingTimeout(err):
+			ctx.HTTPError(http.StatusRequestTimeout, err.Error())
+		default:
+			ctx.ServerError("BuildForkGraph", err)
+		}
+		return
+	}
+
+	switch format {
+	case historyGraphFormatJSON:
+		ctx.JSON(http.StatusOK, graph)
+	case historyGraphFormatDOT:
+		ctx.Resp.Header().Set("Content-Type", historyGraphMediaTypeDOT+"; charset=utf-8")
+		if err := repo_service.WriteForkGraphDOT(ctx.Resp, graph); err != nil {
+			log.Error("WriteForkGraphDOT: %v", err)
+		}
+	case historyGraphFormatGraphML:
+		ctx.Resp.Header().Set("Content-Type", historyGraphMediaTypeGraphML+"; charset=utf-8")
+		if err := repo_service.WriteForkGraphGraphML(ctx.Resp, graph); err != nil {
+			log.Error("WriteForkGraphGraphML: %v", err)
+		}
+	}
+}
@@ -10,10 +10,12 @@ import (
 	"io"
 	"net/http"
 	"path"
+	"strconv"
 	"strings"
 	"time"
 
 	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
 	"code.gitea.io/gitea/models/renderhelper"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unit"
@@ -23,12 +25,14 @@ import (
 	"code.gitea.io/gitea/modules/git/gitcmd"
 	"code.gitea.io/gitea/modules/gitrepo"
 	"code.gitea.io/gitea/modules/log"
-	"code.gitea.io/gitea/modules/markup"
+	"code.gitea.io/gitea/modules/markup/markdown"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/sitemap"
 	"code.gitea.io/gitea/modules/templates"
 	"code.gitea.io/gitea/modules/timeutil"
 	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/routers/common"
+	asymkey_service "code.gitea.io/gitea/services/asymkey"
 	"code.gitea.io/gitea/services/context"
 	repo_service "code.gitea.io/gitea/services/repository"
 )
@@ -37,8 +41,19 @@ const (
 	// tplExploreRepos explore repositories page template
 	tplExploreRepos templates.TplName = "explore/repos"
 	// tplExploreSubjects explore subjects page template
-	tplExploreSubjects     templates.TplName = "explore/subjects"
+	tplExploreSubjects templates.TplName = "explore/subjects"
+	// tplArticlePrint print-friendly article page template
+	tplArticlePrint        templates.TplName = "explore/article_print"
 	relevantReposOnlyParam string            = "only_show_relevant"
+
+	// trendingSubjectsWindow is how far back the activity of the trending subjects is counted
+	trendingSubjectsWindow = 7 * 24 * time.Hour
+	// relatedSubjectsLimit is how many related subjects are suggested on the subject view
+	relatedSubjectsLimit = 5
+	// subjectLeaderboardLimit is how many top contributors are shown on the subject view
+	subjectLeaderboardLimit = 10
+	// featuredSubjectsLimit is how many featured subjects are shown above the subject list
+	featuredSubjectsLimit = 6
 )
 
 // RepoSearchOptions when calling search repositories
@@ -229,11 +244,35 @@ func Subjects(ctx *context.Context) {
 	keyword := ctx.FormTrim("q")
 	ctx.Data["Keyword"] = keyword
 
+	// Get minimum repository count filter
+	minRepos := max(ctx.FormInt64("min_repos"), 0)
+	ctx.Data["MinRepos"] = minRepos
+
+	// Only show the subjects with forks, where more than one viewpoint exists
+	hasForks := ctx.FormBool("has_forks")
+	ctx.Data["HasForks"] = hasForks
+
+	// Get language filter, empty means all languages
+	lang := ctx.FormTrim("lang")
+	ctx.Data["SubjectLang"] = lang
+	subjectLangs, err := repo_model.FindSubjectLangs(ctx)
+	if err != nil {
+		ctx.ServerError("FindSubjectLangs", err)
+		return
+	}
+	ctx.Data["SubjectLangs"] = subjectLangs
+
+	// The trending tab ranks subjects by their recent activity instead of the sort order
+	isTrendingTab := ctx.FormString("tab") == "trending"
+	ctx.Data["IsTrendingTab"] = isTrendingTab
+
 	// Helper type for subjects with counts
 	type SubjectWithCount struct {
 		*repo_model.Subject
 		RepoCount     int64
 		RootRepoCount int64
+		ForkRepoCount int64
+		Activity      int64
 	}
 
 	var exactMatch *SubjectWithCount
@@ -252,6 +291,9 @@ func Subjects(ctx *context.Context) {
 			Keyword:        keyword,
 			OrderBy:        orderBy,
 			ExactMatchOnly: true,
+			MinRepos:       minRepos,
+			Lang:           lang,
+			HasForks:       hasForks,
 		})
 		if err != nil {
 			ctx.ServerError("FindSubjects (exact)", err)
@@ -264,8 +306,19 @@ func Subjects(ctx *context.Context) {
 			excludeIDs = append(excludeIDs, exactSubjects[0].ID)
 		}
 
-		// Find similar subjects (excluding the exact match)
-		similarResults, err := repo_model.FindSimilarSubjects(ctx, keyword, 20, excludeIDs)
+		// Find a page of similar subjects (excluding the exact match, which is shown on every page)
+		// The filters apply in the database, so the count and the pages only cover the matching subjects
+		similarResults, similarCount, err := repo_model.FindSimilarSubjects(ctx, repo_model.FindSubjectsOptions{
+			ListOptions: db.ListOptions{
+				Page:     page,
+				PageSize: setting.UI.ExplorePagingNum,
+			},
+			Keyword:    keyword,
+			ExcludeIDs: excludeIDs,
+			MinRepos:   minRepos,
+			Lang:       lang,
+			HasForks:   hasForks,
+		})
 		if err != nil {
 			ctx.ServerError("FindSimilarSubjects", err)
 			return
@@ -281,9 +334,9 @@ func Subjects(ctx *context.Context) {
 		}
 
 		// Batch load counts for all subjects
-		countsMap, err := repo_model.BatchCountRepositoriesBySubjects(ctx, allSubjectIDs)
+		countsMap, err := repo_model.GetSubjectRepoCounts(ctx, allSubjectIDs)
 		if err != nil {
-			ctx.ServerError("BatchCountRepositoriesBySubjects", err)
+			ctx.ServerError("GetSubjectRepoCounts", err)
 			return
 		}
 
@@ -295,6 +348,7 @@ func Subjects(ctx *context.Context) {
 				Subject:       subject,
 				RepoCount:     counts.RepoCount,
 				RootRepoCount: counts.RootRepoCount,
+				ForkRepoCount: counts.ForkRepoCount,
 			}
 		}
 
@@ -306,14 +360,45 @@ func Subjects(ctx *context.Context) {
 				Subject:       subject,
 				RepoCount:     counts.RepoCount,
 				RootRepoCount: counts.RootRepoCount,
+				ForkRepoCount: counts.ForkRepoCount,
 			})
 		}
 
-		// For pagination total, we count exact + similar
-		count = int64(len(similarSubjects))
-		if exactMatch != nil {
-			count++
+		// Only the similar subjects are paged, the exact match is pinned above them
+		count = similarCount
+	} else if isTrendingTab {
+		// No search keyword - show the most active subjects without pagination
+		trending, err := repo_service.FindTrendingSubjects(ctx, trendingSubjectsWindow, setting.UI.ExplorePagingNum)
+		if err != nil {
+			ctx.ServerError("FindTrendingSubjects", err)
+			return
 		}
+
+		subjectIDs := make([]int64, 0, len(trending))
+		for _, s := range trending {
+			subjectIDs = append(subjectIDs, s.ID)
+		}
+		countsMap, err := repo_model.GetSubjectRepoCounts(ctx, subjectIDs)
+		if err != nil {
+			ctx.ServerError("GetSubjectRepoCounts", err)
+			return
+		}
+
+		allSubjects = make([]*SubjectWithCount, 0, len(trending))
+		for _, s := range trending {
+			counts := countsMap[s.ID]
+			if counts.RepoCount < minRepos || (lang != "" && s.Lang != lang) || (hasForks && counts.ForkRepoCount == 0) {
+				continue
+			}
+			allSubjects = append(allSubjects, &SubjectWithCount{
+				Subject:       s.Subject,
+				RepoCount:     counts.RepoCount,
+				RootRepoCount: counts.RootRepoCount,
+				ForkRepoCount: counts.ForkRepoCount,
+				Activity:      s.Activity,
+			})
+		}
+		count = int64(len(allSubjects))
 	} else {
 		// No search keyword - show all subjects with pagination
 		subjects, totalCount, err := repo_model.FindSubjects(ctx, repo_model.FindSubjectsOptions{
@@ -321,8 +406,11 @@ func Subjects(ctx *context.Context) {
 				Page:     page,
 				PageSize: setting.UI.ExplorePagingNum,
 			},
-			Keyword: keyword,
-			OrderBy: orderBy,
+			Keyword:  keyword,
+			OrderBy:  orderBy,
+			MinRepos: minRepos,
+			Lang:     lang,
+			HasForks: hasForks,
 		})
 		if err != nil {
 			ctx.ServerError("FindSubjects", err)
@@ -336,9 +424,9 @@ func Subjects(ctx *context.Context) {
 		}
 
 		// Batch load counts for all subjects
-		countsMap, err := repo_model.BatchCountRepositoriesBySubjects(ctx, subjectIDs)
+		countsMap, err := repo_model.GetSubjectRepoCounts(ctx, subjectIDs)
 		if err != nil {
-			ctx.ServerError("BatchCountRepositoriesBySubjects", err)
+			ctx.ServerError("GetSubjectRepoCounts", err)
 			return
 		}
 
@@ -349,11 +437,54 @@ func Subjects(ctx *context.Context) {
 				Subject:       subject,
 				RepoCount:     counts.RepoCount,
 				RootRepoCount: counts.RootRepoCount,
+				ForkRepoCount: counts.ForkRepoCount,
 			})
 		}
 		count = totalCount
 	}
 
+	// Featured subjects are shown above the list on its first page, narrowed by the same search and filters
+	if !isTrendingTab && page == 1 {
+		featured, _, err := repo_model.FindSubjects(ctx, repo_model.FindSubjectsOptions{
+			ListOptions: db.ListOptions{
+				Page:     1,
+				PageSize: featuredSubjectsLimit,
+			},
+			Keyword:      keyword,
+			OrderBy:      repo_model.SubjectOrderByMap[repo_model.SubjectSortFeatured],
+			MinRepos:     minRepos,
+			Lang:         lang,
+			FeaturedOnly: true,
+			HasForks:     hasForks,
+		})
+		if err != nil {
+			ctx.ServerError("FindSubjects (featured)", err)
+			return
+		}
+
+		featuredIDs := make([]int64, 0, len(featured))
+		for _, s := range featured {
+			featuredIDs = append(featuredIDs, s.ID)
+		}
+		countsMap, err := repo_model.GetSubjectRepoCounts(ctx, featuredIDs)
+		if err != nil {
+			ctx.ServerError("GetSubjectRepoCounts", err)
+			return
+		}
+
+		featuredSubjects := make([]*SubjectWithCount, 0, len(featured))
+		for _, subject := range featured {
+			counts := countsMap[subject.ID]
+			featuredSubjects = append(featuredSubjects, &SubjectWithCount{
+				Subject:       subject,
+				RepoCount:     counts.RepoCount,
+				RootRepoCount: counts.RootRepoCount,
+				ForkRepoCount: counts.ForkRepoCount,
+			})
+		}
+		ctx.Data["FeaturedSubjects"] = featuredSubjects
+	}
+
 	ctx.Data["Total"] = count
 	ctx.Data["Subjects"] = allSubjects
 	ctx.Data["ExactMatch"] = exactMatch
@@ -361,21 +492,55 @@ func Subjects(ctx *context.Context) {
 	ctx.Data["HasSearchKeyword"] = keyword != ""
 
 	pager := context.NewPagination(int(count), setting.UI.ExplorePagingNum, page, 5)
-	pager.AddParamFromRequest(ctx.Req)
+	pager.AddParamFromRequest(ctx.Req) // keeps q, sort, min_repos and has_forks across pages
 	ctx.Data["Page"] = pager
 
 	ctx.HTML(http.StatusOK, tplExploreSubjects)
 }
 
+// SubjectsSitemap renders a page of the sitemap of the subjects with a public article
+func SubjectsSitemap(ctx *context.Context) {
+	page := max(int(ctx.PathParamInt64("idx")), 1)
+	subjects, _, err := repo_model.FindSubjects(ctx, repo_model.FindSubjectsOptions{
+		ListOptions: db.ListOptions{
+			Page:     page,
+			PageSize: setting.UI.SitemapPagingNum,
+		},
+		// The oldest subjects first, so that new subjects don't move the others to the next page
+		OrderBy:       "id ASC",
+		HasPublicRepo: true,
+	})
+	if err != nil {
+		ctx.ServerError("FindSubjects", err)
+		return
+	}
+
+	m := sitemap.NewSitemap()
+	for _, subject := range subjects {
+		m.Add(sitemap.URL{URL: subject.HTMLURL(), LastMod: subject.UpdatedUnix.AsTimePtr()})
+	}
+	ctx.Resp.Header().Set("Content-Type", "text/xml")
+	if _, err := m.WriteTo(ctx.Resp); err != nil {
+		log.Error("Failed writing sitemap: %v", err)
+	}
+}
+
 // RepoHistory renders repository history page - an alternative interface to repo home
 func RepoHistory(ctx *context.Context) {
+	// Scripts can request the fork graph instead of the HTML page, so caches must key on the Accept header
+	ctx.Resp.Header().Add("Vary", "Accept")
+	if format := historyGraphFormat(ctx); format != "" {
+		renderHistoryGraph(ctx, format)
+		return
+	}
+
 	// Set page metadata
 	ctx.Data["Title"] = ctx.Repo.Repository.FullName() + " - History View"
 	ctx.Data["PageIsExploreRepositories"] = true
 	ctx.Data["PageIsRepoHistory"] = true
 	ctx.Data["IsRepoHistoryView"] = true
 
-	// Determine which sub-view to render (bubble | table | article)
+	// Determine which sub-view to render (bubble | table | article | changes)
 	view := ctx.FormString("view")
 	if view == "" {
 		// Default to bubble view per UX requirement
@@ -385,6 +550,50 @@ func RepoHistory(ctx *context.Context) {
 	ctx.Data["IsBubbleView"] = view == "bubble"
 	ctx.Data["IsTableView"] = view == "table"
 	ctx.Data["IsArticleView"] = view == "article"
+	ctx.Data["IsChangesView"] = view == "changes"
+
+	// Summarize the activity across all articles of the subject
+	if subjectID := ctx.Repo.Repository.SubjectID; subjectID > 0 {
+		subjectStats, err := repo_service.GetSubjectStats(ctx, subjectID)
+		if err != nil {
+			log.Error("GetSubjectStats(%d): %v", subjectID, err)
+		} else {
+			ctx.Data["SubjectStats"] = subjectStats
+			if subjectStats.MostDivergedRepoID > 0 {
+				if mostDiverged, err := repo_model.GetRepositoryByID(ctx, subjectStats.MostDivergedRepoID); err == nil {
+					ctx.Data["MostDivergedRepo"] = mostDiverged
+				}
+			}
+		}
+
+		leaderboard, err := repo_service.GetSubjectContributorLeaderboard(ctx, subjectID, subjectLeaderboardLimit)
+		if err != nil {
+			log.Error("GetSubjectContributorLeaderboard(%d): %v", subjectID, err)
+		} else {
+			ctx.Data["SubjectLeaderboard"] = leaderboard
+		}
+
+		relatedSubjects, err := repo_service.FindRelatedSubjects(ctx, subjectID, relatedSubjectsLimit)
+		if err != nil {
+			log.Error("FindRelatedSubjects(%d): %v", subjectID, err)
+		} else {
+			ctx.Data["RelatedSubjects"] = relatedSubjects
+		}
+
+		if view == "changes" {
+			state := ctx.FormString("state")
+			if state != "closed" && state != "all" {
+				state = "open"
+			}
+			changeRequests, err := repo_service.FindSubjectChangeRequests(ctx, subjectID, ctx.Doer, state)
+			if err != nil {
+				ctx.ServerError("FindSubjectChangeRequests", err)
+				return
+			}
+			ctx.Data["ChangeRequests"] = changeRequests
+			ctx.Data["ChangeRequestsState"] = state
+		}
+	}
 
 	// Call the main repository home logic
 	// This duplicates the functionality of repo.Home but in the explore context
@@ -413,6 +622,7 @@ func RenderRepositoryHistory(ctx *context.Context) {
 	ctx.Data["Title"] = title
 	ctx.Data["PageIsViewCode"] = true
 	ctx.Data["RepositoryUploadEnabled"] = false // Disable uploads in history view
+	ctx.Data["ContributorStatsWindowDays"] = setting.Repository.ContributorStatsWindowDays
 
 	// For empty/broken repositories, render the history view which will show a "Create first article" bubble
 	if ctx.Repo.Repository.IsEmpty || ctx.Repo.Repository.IsBroken() {
@@ -466,6 +676,11 @@ func RenderRepositoryHistory(ctx *context.Context) {
 		return
 	}
 
+	// An article only changes with its version, let browsers revalidate it before the page is built
+	if ctx.Data["IsArticleView"] == true && handleArticleReadCache(ctx, commit, entries) {
+		return
+	}
+
 	// Set up template data
 	ctx.Data["BranchName"] = defaultBranch
 	ctx.Data["CommitID"] = commit.ID.String()
@@ -484,6 +699,14 @@ func RenderRepositoryHistory(ctx *context.Context) {
 		ContributorCount int64
 		Updated          timeutil.TimeStamp
 		Description      string
+		// Ahead and Behind count the commits of a fork relative to the root's default branch,
+		// HasDivergence is false for the root and when they couldn't be computed
+		Ahead         int
+		Behind        int
+		HasDivergence bool
+		// CommitsSinceFork counts the commits to the README of a fork since it was created,
+		// all commits to the README for the root
+		CommitsSinceFork int64
 	}
 
 	tableEntries := make([]*historyTableEntry, 0, 1)
@@ -513,12 +736,26 @@ func RenderRepositoryHistory(ctx *context.Context) {
 			log.Warn("GetContributorCount for %s: %v", rootRepo.FullName(), err)
 		}
 	}
+	// Only commits to the README are counted, to keep the counts cheap
+	readmeTreePath := ""
+	if readme := common.FindArticleReadme(entries); readme != nil {
+		readmeTreePath = readme.Name()
+		if count, err := getFileCommitsCount(gitRepo, defaultBranch, readmeTreePath, time.Time{}); err == nil {
+			rootEntry.CommitsSinceFork = count
+		} else {
+			log.Warn("getFileCommitsCount for %s: %v", rootRepo.FullName(), err)
+		}
+	}
 	tableEntries = append(tableEntries, rootEntry)
 
 	forks, _, err := repo_service.FindForks(ctx, rootRepo, ctx.Doer, db.ListOptions{Page: 1, PageSize: 100})
 	if err != nil {
 		log.Warn("FindForks for %s: %v", rootRepo.FullName(), err)
 	} else if len(forks) > 0 {
+		rootCommitID, err := gitRepo.GetBranchCommitID(defaultBranch)
+		if err != nil {
+			log.Warn("GetBranchCommitID for %s: %v", rootRepo.FullName(), err)
+		}
 		if err := repo_model.RepositoryList(forks).LoadAttributes(ctx); err != nil {
 			log.Warn("LoadAttributes for forks of %s: %v", rootRepo.FullName(), err)
 		}
@@ -550,6 +787,20 @@ func RenderRepositoryHistory(ctx *context.Context) {
 				} else {
 					log.Warn("GetContributorCount for fork %s: %v", fork.FullName(), err)
 				}
+				if readmeTreePath != "" && !fork.IsEmpty {
+					if count, err := getFileCommitsCount(forkGitRepo, branch, readmeTreePath, forkSince); err == nil {
+						entry.CommitsSinceFork = count
+					} else {
+						log.Warn("getFileCommitsCount for fork %s: %v", fork.FullName(), err)
+					}
+				}
+				if rootCommitID != "" && fork.ID != rootRepo.ID && !fork.IsEmpty {
+					if divergence, err := repo_service.GetForkDivergence(ctx, rootRepo, rootCommitID, fork, forkGitRepo); err == nil {
+						entry.Ahead, entry.Behind, entry.HasDivergence = divergence.Ahead, divergence.Behind, true
+					} else {
+						log.Warn("GetForkDivergence for fork %s: %v", fork.FullName(), err)
+					}
+				}
 				forkGitRepo.Close()
 			}
 			tableEntries = append(tableEntries, entry)
@@ -573,6 +824,12 @@ func RenderRepositoryHistory(ctx *context.Context) {
 		if ctx.Written() {
 			return
 		}
+		if ctx.Data["PrintMode"] == true {
+			version, _ := ctx.Data["ArticleVersion"].(string)
+			ctx.Data["ArticleSourceLink"] = articleURL(ctx, version)
+			ctx.HTML(http.StatusOK, tplArticlePrint)
+			return
+		}
 	}
 
 	// Render the history view template
@@ -602,6 +859,16 @@ func prepareArticleView(ctx *context.Context, gitRepo *git.Repository, entries [
 	if mode == "" {
 		mode = "read"
 	}
+	// The print view is a stripped down read mode
+	printMode := ctx.FormBool("print")
+	if printMode {
+		mode = "read"
+	}
+	ctx.Data["PrintMode"] = printMode
+	// BranchName is empty when a specific version of the article is viewed
+	if ctx.Repo.BranchName == "" {
+		ctx.Data["ArticleVersion"] = ctx.Repo.CommitID
+	}
 	ctx.Data["ArticleMode"] = mode
 	ctx.Data["IsArticleModeRead"] = mode == "read"
 	ctx.Data["IsArticleModeEdit"] = mode == "edit"
@@ -609,7 +876,7 @@ func prepareArticleView(ctx *context.Context, gitRepo *git.Repository, entries [
 	ctx.Data["ReadmeRequested"] = true
 
 	// Find README.md file
-	readmeFile := findReadmeInEntries(entries)
+	readmeFile := common.FindArticleReadme(entries)
 	if readmeFile == nil {
 		ctx.Data["ReadmeError"] = "No README.md file found in repository"
 		return
@@ -651,56 +918,35 @@ func prepareArticleView(ctx *context.Context, gitRepo *git.Repository, entries [
 	// Handle different modes
 	switch mode {
 	case "read":
-		// For read mode, render the README content
-		buf, dataRc, err := getReadmeContent(blob)
-		if err != nil {
-			ctx.ServerError("getReadmeContent", err)
-			return
+		// For read mode, render the README content. The subject view requests the articles of other forks
+		// as fragments to swap them in next to the article of the page, so the ids of their headings must
+		// not collide. Articles alone on their page keep their plain ids, links to them keep working.
+		anchorIDPrefix := ""
+		if ctx.FormBool("fragment") {
+			anchorIDPrefix = articleAnchorIDPrefix(ctx.Repo.Repository)
 		}
-		defer dataRc.Close()
-
-		// Check file size
-		fileSize := blob.Size()
-		if fileSize >= setting.UI.MaxDisplayFileSize {
-			ctx.Data["IsFileTooLarge"] = true
+		renderArticleReadme(ctx, readmeFile, refPath, anchorIDPrefix)
+		if ctx.Written() {
 			return
 		}
-
-		// Detect if this is markup
-		if markupType := markup.DetectMarkupTypeByFileName(readmeFile.Name()); markupType != "" {
-			ctx.Data["IsMarkup"] = true
-			ctx.Data["MarkupType"] = markupType
-
-			rctx := renderhelper.NewRenderContextRepoFile(ctx, ctx.Repo.Repository, renderhelper.RepoFileOptions{
-				CurrentRefPath:  refPath,
-				CurrentTreePath: "",
-			}).
-				WithMarkupType(markupType).
-				WithRelativePath(readmeTreePath)
-
-			rd := charset.ToUTF8WithFallbackReader(io.MultiReader(bytes.NewReader(buf), dataRc), charset.ConvertOpts{})
-			var escapeStatus *charset.EscapeStatus
-			escapeStatus, ctx.Data["FileContent"], err = markupRender(ctx, rctx, rd)
-			if err != nil {
-				log.Error("Render failed for %s in %-v: %v", readmeFile.Name(), ctx.Repo.Repository, err)
-				ctx.Data["IsMarkup"] = false
-			}
-			ctx.Data["EscapeStatus"] = escapeStatus
+		// One click shows what changed most recently
+		if lastCommit, ok := ctx.Data["ReadmeLastCommit"].(*git.Commit); ok {
+			ctx.Data["ReadmeDiffURL"] = articleReadmeDiffURL(ctx.Repo.RepoLink, lastCommit, readmeTreePath)
 		}
-
-		if ctx.Data["IsMarkup"] != true {
-			ctx.Data["IsPlainText"] = true
-			rd := charset.ToUTF8WithFallbackReader(io.MultiReader(bytes.NewReader(buf), dataRc), charset.ConvertOpts{})
-			content, err := io.ReadAll(rd)
-			if err != nil {
-				log.Error("Read readme content failed: %v", err)
-			}
-			contentEscaped := template.HTMLEscapeString(util.UnsafeBytesToString(content))
-			ctx.Data["EscapeStatus"], ctx.Data["FileContent"] = charset.EscapeControlHTML(template.HTML(contentEscaped), ctx.Locale)
+		// Precomputed when the default branch was pushed to, older versions are computed here
+		if reading, err := repo_service.GetArticleReadingMetadata(ctx, ctx.Repo.Repository, ctx.Repo.CommitID, readmeFile); err != nil {
+			log.Warn("Failed to get the reading metadata of %-v: %v", ctx.Repo.Repository, err)
+		} else {
+			ctx.Data["ArticleReading"] = reading
+		}
+		ctx.Data["CanEditReadmeFile"] = !printMode && ctx.Repo.Repository.CanEnableEditor()
+		// Readers can suggest corrections of lines of the version they are reading
+		ctx.Data["CanSuggestArticleEdit"] = !printMode && ctx.Doer != nil && ctx.Doer.ID != ctx.Repo.Repository.OwnerID && !ctx.Repo.Repository.IsArchived
+		ctx.Data["ArticleSuggestionCommitID"] = ctx.Repo.CommitID
+		if ctx.Doer != nil && !printMode {
+			ctx.Data["CanWatchArticle"] = true
+			ctx.Data["IsWatchingArticle"] = repo_model.IsWatchingArticle(ctx, ctx.Doer.ID, ctx.Repo.Repository.ID)
 		}
-
-		ctx.Data["FileSize"] = fileSize
-		ctx.Data["CanEditReadmeFile"] = ctx.Repo.Repository.CanEnableEditor()
 	case "edit":
 		// For edit mode, load raw content
 		buf, dataRc, err := getReadmeContent(blob)
@@ -711,7 +957,7 @@ func prepareArticleView(ctx *context.Context, gitRepo *git.Repository, entries [
 		defer dataRc.Close()
 
 		fileSize := blob.Size()
-		if fileSize >= setting.UI.MaxDisplayFileSize {
+		if fileSize >= setting.ArticleMaxRenderSize() {
 			ctx.Data["NotEditableReason"] = ctx.Tr("repo.editor.cannot_edit_too_large_file")
 		} else {
 			allContent, err := io.ReadAll(io.MultiReader(bytes.NewReader(buf), dataRc))
@@ -727,13 +973,35 @@ func prepareArticleView(ctx *context.Context, gitRepo *git.Repository, entries [
 		}
 		ctx.Data["FileSize"] = fileSize
 
+		// The branch tip the edit is based on, to detect edits by others when it's submitted
+		ctx.Data["LastCommitID"] = ctx.Repo.CommitID
+
 		// Set up fork-on-edit context data
 		prepareArticleForkOnEditData(ctx)
+		if ctx.Written() {
+			return
+		}
+		prepareArticleSigningData(ctx)
+		if ctx.Written() {
+			return
+		}
+		prepareArticleSuggestionsData(ctx)
 	case "history":
+		// The history can be limited to the edits of one user, given by the "author" query parameter
+		authors, ok := articleHistoryAuthors(ctx)
+		if !ok {
+			return
+		}
+
 		// For history mode, get file commit history
-		commitsCount, err := gitRepo.FileCommitsCount(defaultBranch, readmeTreePath)
+		commitsCount, err := git.CommitsCount(ctx, git.CommitsCountOptions{
+			RepoPath: gitRepo.Path,
+			Revision: []string{defaultBranch},
+			RelPath:  []string{readmeTreePath},
+			Authors:  authors,
+		})
 		if err != nil {
-			ctx.ServerError("FileCommitsCount", err)
+			ctx.ServerError("CommitsCount", err)
 			return
 		}
 
@@ -747,6 +1015,7 @@ func prepareArticleView(ctx *context.Context, gitRepo *git.Repository, entries [
 				Revision: defaultBranch,
 				File:     readmeTreePath,
 				Page:     page,
+				Authors:  authors,
 			})
 		if err != nil {
 			ctx.ServerError("CommitsByFileAndRange", err)
@@ -767,21 +1036,121 @@ func prepareArticleView(ctx *context.Context, gitRepo *git.Repository, entries [
 		pager := context.NewPagination(int(commitsCount), setting.Git.CommitsRangeSize, page, 5)
 		pager.AddParamFromRequest(ctx.Req)
 		ctx.Data["Page"] = pager
+
+		prepareArticleRevertData(ctx)
+	}
+}
+
+// articleHistoryAuthors returns the author identities the article history is filtered by: the
+// "<email>" of every activated email address of the user named by the "author" query parameter.
+// It returns no identities if the parameter is missing, and false if a response has been written.
+func articleHistoryAuthors(ctx *context.Context) ([]string, bool) {
+	authorName := ctx.FormString("author")
+	if authorName == "" {
+		return nil, true
+	}
+	author, err := user_model.GetUserByName(ctx, authorName)
+	if err != nil {
+		if user_model.IsErrUserNotExist(err) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetUserByName", err)
+		}
+		return nil, false
+	}
+	emails, err := user_model.GetActivatedEmailAddresses(ctx, author.ID)
+	if err != nil {
+		ctx.ServerError("GetActivatedEmailAddresses", err)
+		return nil, false
 	}
+	emails = append(emails, author.GetPlaceholderEmail())
+	ctx.Data["HistoryAuthor"] = author
+
+	// The angle brackets make sure that only whole email addresses match
+	authors := make([]string, 0, len(emails))
+	for _, email := range emails {
+		authors = append(authors, "<"+email+">")
+	}
+	return authors, true
+}
+
+// articleAnchorIDPrefix returns the prefix of the anchor ids of the article of repo, for article
+// fragments which are combined with the articles of other repositories on one page
+func articleAnchorIDPrefix(repo *repo_model.Repository) string {
+	return "article-" + strconv.FormatInt(repo.ID, 10)
 }
 
-// findReadmeInEntries finds a README file in the given entries
-func findReadmeInEntries(entries []*git.TreeEntry) *git.TreeEntry {
-	// Look for readme.md (case insensitive)
-	for _, entry := range entries {
-		if entry.IsRegular() || entry.IsExecutable() {
-			name := strings.ToLower(entry.Name())
-			if name == "readme.md" || name == "readme" || name == "readme.txt" {
-				return entry
+// renderArticleReadme renders the README of an article for reading into ctx.Data["FileContent"].
+// It is shared by the article read mode and the embeddable article view so that they can't diverge.
+// anchorIDPrefix scopes the ids of the headings, and of the table of contents linking to them, to
+// the article, see articleAnchorIDPrefix; it is empty for articles that are alone on their page.
+func renderArticleReadme(ctx *context.Context, readmeFile *git.TreeEntry, refPath, anchorIDPrefix string) {
+	readmeTreePath := readmeFile.Name()
+	blob := readmeFile.Blob()
+	buf, dataRc, err := getReadmeContent(blob)
+	if err != nil {
+		ctx.ServerError("getReadmeContent", err)
+		return
+	}
+	defer dataRc.Close()
+
+	// Check file size
+	fileSize := blob.Size()
+	if fileSize >= setting.ArticleMaxRenderSize() {
+		ctx.Data["IsFileTooLarge"] = true
+		return
+	}
+
+	content, err := io.ReadAll(io.MultiReader(bytes.NewReader(buf), dataRc))
+	if err != nil {
+		ctx.ServerError("ReadAll", err)
+		return
+	}
+
+	// Detect if this is markup, the front matter can choose another markup than the file name
+	if markupType, body := common.ArticleMarkupType(readmeTreePath, content); markupType != "" {
+		ctx.Data["IsMarkup"] = true
+		ctx.Data["MarkupType"] = markupType
+
+		rctx := renderhelper.NewRenderContextRepoFile(ctx, ctx.Repo.Repository, renderhelper.RepoFileOptions{
+			CurrentRefPath:  refPath,
+			CurrentTreePath: "",
+		}).
+			WithMarkupType(markupType).
+			WithRelativePath(readmeTreePath).
+			WithAnchorIDPrefix(anchorIDPrefix)
+
+		rd := charset.ToUTF8WithFallbackReader(bytes.NewReader(body), charset.ConvertOpts{})
+		var escapeStatus *charset.EscapeStatus
+		escapeStatus, ctx.Data["FileContent"], err = common.RenderArticleMarkup(ctx.Base, rctx, rd)
+		if err != nil {
+			log.Error("Render failed for %s in %-v: %v", readmeTreePath, ctx.Repo.Repository, err)
+			ctx.Data["IsMarkup"] = false
+		}
+		ctx.Data["EscapeStatus"] = escapeStatus
+
+		// Printed pages have no sidebar, the table of contents is shown above the article instead
+		if ctx.Data["PrintMode"] == true && rctx.SidebarTocNode != nil {
+			var toc strings.Builder
+			if err := markdown.SpecializedMarkdown(rctx).Renderer().Render(&toc, nil, rctx.SidebarTocNode); err != nil {
+				log.Error("Failed to render the table of contents of %s in %-v: %v", readmeTreePath, ctx.Repo.Repository, err)
+			} else {
+				ctx.Data["ArticleTocHTML"] = templates.SanitizeHTML(toc.String())
 			}
 		}
 	}
-	return nil
+
+	if ctx.Data["IsMarkup"] != true {
+		ctx.Data["IsPlainText"] = true
+		plain, err := io.ReadAll(charset.ToUTF8WithFallbackReader(bytes.NewReader(content), charset.ConvertOpts{}))
+		if err != nil {
+			log.Error("Read readme content failed: %v", err)
+		}
+		contentEscaped := template.HTMLEscapeString(util.UnsafeBytesToString(plain))
+		ctx.Data["EscapeStatus"], ctx.Data["FileContent"] = charset.EscapeControlHTML(template.HTML(contentEscaped), ctx.Locale)
+	}
+
+	ctx.Data["FileSize"] = fileSize
 }
 
 // getReadmeContent reads content from a blob
@@ -798,17 +1167,6 @@ func getReadmeContent(blob *git.Blob) ([]byte, io.ReadCloser, error) {
 	return buf, dataRc, nil
 }
 
-// markupRender renders markup content
-func markupRender(ctx *context.Context, rctx *markup.RenderContext, rd io.Reader) (*charset.EscapeStatus, template.HTML, error) {
-	var buf bytes.Buffer
-	err := markup.Render(rctx, rd, &buf)
-	if err != nil {
-		return nil, "", err
-	}
-	escapeStatus, content := charset.EscapeControlHTML(template.HTML(buf.String()), ctx.Locale)
-	return escapeStatus, content, nil
-}
-
 // processGitCommits processes git commits to attach user information
 func processGitCommits(ctx *context.Context, commits []*git.Commit) ([]*user_model.UserCommit, error) {
 	// Validate commits with emails to attach user information
@@ -847,6 +1205,111 @@ func getFileContributorCount(gitRepo *git.Repository, branch, filePath string, s
 	return int64(len(lines)), nil
 }
 
+// getFileCommitsCount counts the commits of branch that changed filePath, only those after since if it isn't zero
+func getFileCommitsCount(gitRepo *git.Repository, branch, filePath string, since time.Time) (int64, error) {
+	opts := git.CommitsCountOptions{
+		RepoPath: gitRepo.Path,
+		Revision: []string{branch},
+		RelPath:  []string{filePath},
+	}
+	if !since.IsZero() {
+		opts.Since = since.Format(time.RFC3339)
+	}
+	return git.CommitsCount(gitRepo.Ctx, opts)
+}
+
+// articleReadmeDiffURL returns the link to the latest change of the README: the diff of its last
+// commit against the parent, or the whole README as of that commit if it is the initial commit
+func articleReadmeDiffURL(repoLink string, lastCommit *git.Commit, readmeTreePath string) string {
+	if lastCommit.ParentCount() > 0 {
+		if parentID, err := lastCommit.ParentID(0); err == nil {
+			return repoLink + "/compare/" + parentID.String() + "..." + lastCommit.ID.String()
+		}
+	}
+	return repoLink + "/src/commit/" + lastCommit.ID.String() + "/" + util.PathEscapeSegments(readmeTreePath)
+}
+
+// prepareArticleSigningData tells the editor whether the edit will be signed and whether the doer
+// may leave it unsigned, which isn't possible when the signing policy or the branch requires signing
+func prepareArticleSigningData(ctx *context.Context) {
+	ctx.Data["ArticleCanSign"] = false
+	ctx.Data["ArticleRequireSigned"] = false
+	if ctx.Doer == nil {
+		return
+	}
+
+	repo := ctx.Repo.Repository
+	willSign, _, _, err := asymkey_service.SignCRUDAction(ctx, repo.RepoPath(), ctx.Doer, repo.RepoPath(), git.BranchPrefix+repo.DefaultBranch)
+	if err != nil && !asymkey_service.IsErrWontSign(err) {
+		ctx.ServerError("SignCRUDAction", err)
+		return
+	}
+	protectedBranch, err := git_model.GetFirstMatchProtectedBranchRule(ctx, repo.ID, repo.DefaultBranch)
+	if err != nil {
+		ctx.ServerError("GetFirstMatchProtectedBranchRule", err)
+		return
+	}
+	ctx.Data["ArticleCanSign"] = willSign
+	ctx.Data["ArticleRequireSigned"] = asymkey_service.CRUDActionsAlwaysSign() ||
+		(protectedBranch != nil && protectedBranch.RequireSignedCommits)
+}
+
+// prepareArticleSuggestionsData lists the open suggestions on the article next to the editor and,
+// for its owner, fills the editor with the accepted suggestion given by the "suggestion" query parameter
+func prepareArticleSuggestionsData(ctx *context.Context) {
+	repo := ctx.Repo.Repository
+	suggestions, err := repo_model.FindOpenArticleSuggestions(ctx, repo.ID)
+	if err != nil {
+		ctx.ServerError("FindOpenArticleSuggestions", err)
+		return
+	}
+	ctx.Data["ArticleSuggestions"] = suggestions
+
+	suggestionID := ctx.FormInt64("suggestion")
+	if suggestionID == 0 || ctx.Doer == nil || ctx.Doer.ID != repo.OwnerID {
+		return
+	}
+	content, ok := ctx.Data["FileContent"].(string)
+	if !ok {
+		return
+	}
+	suggestion, err := repo_model.GetArticleSuggestionByID(ctx, repo.ID, suggestionID)
+	if err != nil {
+		if !repo_model.IsErrArticleSuggestionNotExist(err) {
+			ctx.ServerError("GetArticleSuggestionByID", err)
+		}
+		return
+	}
+	if suggestion.Status != repo_model.ArticleSuggestionAccepted {
+		return
+	}
+	applied, err := repo_service.ApplyArticleSuggestion(content, suggestion)
+	if err != nil {
+		ctx.Data["ArticleSuggestionOutdated"] = true
+		return
+	}
+	ctx.Data["FileContent"] = applied
+	ctx.Data["AppliedArticleSuggestion"] = suggestion
+}
+
+// prepareArticleRevertData tells the history view whether the doer can restore versions of the
+// article, directly as its owner or else by submitting a change request
+func prepareArticleRevertData(ctx *context.Context) {
+	ctx.Data["CanRevertArticleVersion"] = false
+	if ctx.Doer == nil {
+		return
+	}
+
+	perms, err := repo_service.CheckForkOnEditPermissions(ctx, ctx.Doer, ctx.Repo.Repository)
+	if err != nil {
+		ctx.ServerError("CheckForkOnEditPermissions", err)
+		return
+	}
+	ctx.Data["IsRepoOwner"] = perms.IsRepoOwner
+	ctx.Data["CanRevertArticleVersion"] = !ctx.Repo.Repository.IsArchived &&
+		(perms.IsRepoOwner || (perms.CanSubmitChangeRequest && ctx.Repo.Repository.AllowsPulls(ctx)))
+}
+
 // prepareArticleForkOnEditData sets up context data for fork-on-edit workflow
 // This determines whether the user can edit directly, needs to fork, or already has a fork
 func prepareArticleForkOnEditData(ctx *context.Context) {
@@ -858,6 +1321,9 @@ func prepareArticleForkOnEditData(ctx *context.Context) {
 	ctx.Data["BlockedByOwnArticle"] = false
 	ctx.Data["OwnRepoForSubject"] = nil
 	ctx.Data["CanSubmitChangeRequest"] = false
+	ctx.Data["ArticleArchived"] = false
+	ctx.Data["IsArticleImageUploadEnabled"] = false
+	ctx.Data["PatchBranchName"] = ""
 
 	perms, err := repo_service.CheckForkOnEditPermissions(ctx, ctx.Doer, ctx.Repo.Repository)
 	if err != nil {
@@ -871,6 +1337,42 @@ func prepareArticleForkOnEditData(ctx *context.Context) {
 	ctx.Data["OwnRepoForSubject"] = perms.OwnRepoForSubject
 	ctx.Data["HasExistingFork"] = perms.HasExistingFork
 	ctx.Data["ExistingFork"] = perms.ExistingFork
-	ctx.Data["NeedsFork"] = perms.NeedsFork
+	// With fork-on-edit disabled, edits can only be proposed through change requests
+	ctx.Data["NeedsFork"] = perms.NeedsFork && !setting.Repository.DisableForkOnEdit
 	ctx.Data["CanSubmitChangeRequest"] = perms.CanSubmitChangeRequest
+	ctx.Data["ArticleArchived"] = perms.IsArchived
+	// Images are uploaded to the article directly or, for other users, to a change request branch
+	ctx.Data["IsArticleImageUploadEnabled"] = setting.Repository.Upload.Enabled && (perms.IsRepoOwner || perms.CanSubmitChangeRequest)
+	// Optional fork of the same subject to route the change request to (validated on submit)
+	targetRepoID := ctx.FormInt64("target_repo_id")
+	ctx.Data["ChangeRequestTargetRepoID"] = targetRepoID
+
+	// The patch branch of this editing session in the repository the change request goes to:
+	// images uploaded while editing are committed to it and the change request is made from it
+	if perms.CanSubmitChangeRequest && !perms.IsRepoOwner {
+		targetRepo := ctx.Repo.Repository
+		if targetRepoID > 0 && targetRepoID != targetRepo.ID {
+			repo, err := repo_model.GetRepositoryByID(ctx, targetRepoID)
+			if err != nil && !repo_model.IsErrRepoNotExist(err) {
+				ctx.ServerError("GetRepositoryByID", err)
+				return
+			}
+			if repo != nil && repo.SubjectID == targetRepo.SubjectID {
+				targetRepo = repo
+			}
+		}
+		ctx.Data["PatchBranchName"] = repo_service.GetUniquePatchBranchName(ctx, ctx.Doer.LowerName, targetRepo)
+	}
+
+	// Tell fork owners when the original article has changed since they forked it
+	if perms.IsRepoOwner && ctx.Repo.Repository.IsFork {
+		divergingInfo, err := repo_service.GetUpstreamDivergingInfo(ctx, ctx.Repo.Repository, ctx.Repo.Repository.DefaultBranch)
+		if err != nil {
+			if !errors.Is(err, util.ErrNotExist) && !errors.Is(err, util.ErrInvalidArgument) {
+				log.Error("GetUpstreamDivergingInfo: %v", err)
+			}
+			return
+		}
+		ctx.Data["UpstreamDivergingInfo"] = divergingInfo
+	}
 }
@@ -0,0 +1,90 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package explore
+
+import (
+	"errors"
+	"net/http"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	repo_service "code.gitea.io/gitea/services/repository"
+)
+
+const tplSubjectArticleSearch templates.TplName = "explore/subject_search"
+
+// SubjectSearch returns subject name suggestions in the OpenSearch suggestions format:
+// the query followed by the list of matching subject names
+func SubjectSearch(ctx *context.Context) {
+	keyword := ctx.FormTrim("q")
+	names := make([]string, 0)
+
+	if keyword != "" {
+		subjects, _, err := repo_model.FindSubjects(ctx, repo_model.FindSubjectsOptions{
+			ListOptions: db.ListOptions{
+				Page:     1,
+				PageSize: convert.ToCorrectPageSize(ctx.FormInt("limit")),
+			},
+			Keyword: keyword,
+			OrderBy: repo_model.SubjectOrderByMap[repo_model.SubjectSortAlphabetically],
+		})
+		if err != nil {
+			ctx.ServerError("FindSubjects", err)
+			return
+		}
+		for _, subject := range subjects {
+			names = append(names, subject.Name)
+		}
+	}
+
+	ctx.JSON(http.StatusOK, []any{keyword, names})
+}
+
+// SubjectArticleSearch searches the content of the articles of the subject of ctx.Repo
+func SubjectArticleSearch(ctx *context.Context) {
+	repo := ctx.Repo.Repository
+	if err := repo.LoadSubject(ctx); err != nil {
+		ctx.ServerError("LoadSubject", err)
+		return
+	}
+	if repo.SubjectRelation == nil {
+		ctx.NotFound(errors.New("repository has no subject"))
+		return
+	}
+
+	keyword := ctx.FormTrim("q")
+	ctx.Data["Title"] = ctx.Locale.TrString("repo.subject_search.title", repo.SubjectRelation.Name)
+	ctx.Data["Subject"] = repo.SubjectRelation
+	ctx.Data["SubjectLink"] = setting.AppSubURL + "/subject/" + util.PathEscapeSegments(repo.SubjectRelation.Name)
+	ctx.Data["Keyword"] = keyword
+	ctx.Data["MaxSearchedArticles"] = repo_service.MaxSubjectSearchRepos
+
+	if keyword != "" {
+		results, err := repo_service.SearchSubjectArticles(ctx, repo.SubjectID, keyword, ctx.Doer)
+		if err != nil {
+			ctx.ServerError("SearchSubjectArticles", err)
+			return
+		}
+		if err := repo_model.RepositoryList(searchResultRepos(results)).LoadOwners(ctx); err != nil {
+			ctx.ServerError("LoadOwners", err)
+			return
+		}
+		ctx.Data["SearchResults"] = results
+	}
+
+	ctx.HTML(http.StatusOK, tplSubjectArticleSearch)
+}
+
+func searchResultRepos(results *repo_service.SubjectSearchResults) []*repo_model.Repository {
+	repos := make([]*repo_model.Repository, 0, len(results.Results))
+	for _, result := range results.Results {
+		repos = append(repos, result.Repo)
+	}
+	return repos
+}
@@ -104,6 +104,21 @@ func HomeSitemap(ctx *context.Context) {
 		idx++
 	}
 
+	_, cnt, err = repo_model.FindSubjects(ctx, repo_model.FindSubjectsOptions{
+		ListOptions:   db.ListOptions{PageSize: 1},
+		HasPublicRepo: true,
+	})
+	if err != nil {
+		ctx.ServerError("FindSubjects", err)
+		return
+	}
+	count = int(cnt)
+	idx = 1
+	for i := 0; i < count; i += setting.UI.SitemapPagingNum {
+		m.Add(sitemap.URL{URL: setting.AppURL + "explore/subjects/sitemap-" + strconv.Itoa(idx) + ".xml"})
+		idx++
+	}
+
 	ctx.Resp.Header().Set("Content-Type", "text/xml")
 	if _, err := m.WriteTo(ctx.Resp); err != nil {
 		log.Error("Failed writing sitemap: %v", err)
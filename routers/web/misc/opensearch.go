@@ -0,0 +1,82 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package misc
+
+import (
+	"encoding/xml"
+	"net/http"
+
+	"code.gitea.io/gitea/modules/httpcache"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+// openSearchShortNameMaxLength is the maximum length of ShortName allowed by the OpenSearch 1.1 spec
+const openSearchShortNameMaxLength = 16
+
+type openSearchURL struct {
+	Type     string `xml:"type,attr"`
+	Method   string `xml:"method,attr,omitempty"`
+	Rel      string `xml:"rel,attr,omitempty"`
+	Template string `xml:"template,attr"`
+}
+
+type openSearchImage struct {
+	Width  int    `xml:"width,attr"`
+	Height int    `xml:"height,attr"`
+	Type   string `xml:"type,attr"`
+	URL    string `xml:",chardata"`
+}
+
+type openSearchDescription struct {
+	XMLName       xml.Name        `xml:"http://a9.com/-/spec/opensearch/1.1/ OpenSearchDescription"`
+	ShortName     string          `xml:"ShortName"`
+	Description   string          `xml:"Description"`
+	InputEncoding string          `xml:"InputEncoding"`
+	Image         openSearchImage `xml:"Image"`
+	URLs          []openSearchURL `xml:"Url"`
+}
+
+// OpenSearch serves an OpenSearch description document so that browsers can
+// add the subject search of this instance as a search engine
+func OpenSearch(w http.ResponseWriter, req *http.Request) {
+	shortName := setting.AppName
+	if runes := []rune(shortName); len(runes) > openSearchShortNameMaxLength {
+		shortName = string(runes[:openSearchShortNameMaxLength])
+	}
+
+	desc := openSearchDescription{
+		ShortName:     shortName,
+		Description:   "Search subjects on " + setting.AppName,
+		InputEncoding: "UTF-8",
+		Image: openSearchImage{
+			Width:  16,
+			Height: 16,
+			Type:   "image/png",
+			URL:    setting.AppURL + "assets/img/favicon.png",
+		},
+		URLs: []openSearchURL{
+			{Type: "text/html", Method: "get", Template: setting.AppURL + "explore/subjects?q={searchTerms}"},
+			{Type: "application/x-suggestions+json", Method: "get", Template: setting.AppURL + "explore/subjects/search?q={searchTerms}"},
+			{Type: "application/opensearchdescription+xml", Rel: "self", Template: setting.AppURL + "opensearch.xml"},
+		},
+	}
+
+	data, err := xml.MarshalIndent(desc, "", "\t")
+	if err != nil {
+		log.Error("failed to marshal OpenSearch description: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	httpcache.SetCacheControlInHeader(w.Header(), httpcache.CacheControlForPublicStatic())
+	w.Header().Set("Content-Type", "application/opensearchdescription+xml;charset=UTF-8")
+	if _, err := w.Write([]byte(xml.Header)); err != nil {
+		log.Error("failed to write OpenSearch description: %v", err)
+		return
+	}
+	if _, err := w.Write(data); err != nil {
+		log.Error("failed to write OpenSearch description: %v", err)
+	}
+}
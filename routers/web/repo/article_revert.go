@@ -0,0 +1,146 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"bytes"
+
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/routers/common"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/forms"
+	repo_service "code.gitea.io/gitea/services/repository"
+	files_service "code.gitea.io/gitea/services/repository/files"
+)
+
+// RevertArticleVersion restores the README of an article to its content at a version of the
+// article history (the "version" form value) with a new commit. The owner commits directly to the
+// default branch, other users submit the restored content as a change request.
+func RevertArticleVersion(ctx *context.Context) {
+	repo := ctx.Repo.Repository
+	if repo.IsArchived {
+		ctx.JSONError(ctx.Tr("repo.editor.article_archived"))
+		return
+	}
+
+	version := ctx.FormString("version")
+	if !git.IsStringLikelyCommitID(ctx.Repo.GetObjectFormat(), version, 7) {
+		ctx.JSONErrorNotFound()
+		return
+	}
+	headCommit, err := ctx.Repo.GitRepo.GetBranchCommit(repo.DefaultBranch)
+	if err != nil {
+		ctx.ServerError("GetBranchCommit", err)
+		return
+	}
+	versionCommit, err := ctx.Repo.GitRepo.GetCommit(version)
+	if err != nil {
+		if git.IsErrNotExist(err) {
+			ctx.JSONErrorNotFound()
+		} else {
+			ctx.ServerError("GetCommit", err)
+		}
+		return
+	}
+
+	// Only versions from the history of the article can be restored, not those of other branches
+	if versionCommit.ID.String() != headCommit.ID.String() {
+		isAncestor, err := headCommit.HasPreviousCommit(versionCommit.ID)
+		if err != nil {
+			ctx.ServerError("HasPreviousCommit", err)
+			return
+		} else if !isAncestor {
+			ctx.JSONErrorNotFound()
+			return
+		}
+	}
+
+	entries, err := headCommit.ListEntries()
+	if err != nil {
+		ctx.ServerError("ListEntries", err)
+		return
+	}
+	readme := common.FindArticleReadme(entries)
+	if readme == nil {
+		ctx.JSONErrorNotFound()
+		return
+	}
+	versionReadme, err := versionCommit.GetTreeEntryByPath(readme.Name())
+	if err != nil {
+		if git.IsErrNotExist(err) {
+			ctx.JSONError(ctx.Tr("repo.article_revert.no_readme"))
+		} else {
+			ctx.ServerError("GetTreeEntryByPath", err)
+		}
+		return
+	}
+	if !versionReadme.IsRegular() && !versionReadme.IsExecutable() {
+		ctx.JSONError(ctx.Tr("repo.article_revert.no_readme"))
+		return
+	}
+	if versionReadme.ID.String() == readme.ID.String() {
+		ctx.JSONError(ctx.Tr("repo.article_revert.unchanged"))
+		return
+	}
+
+	blob := versionReadme.Blob()
+	content, err := blob.GetBlobBytes(blob.Size())
+	if err != nil {
+		ctx.ServerError("GetBlobBytes", err)
+		return
+	}
+
+	shortVersion := base.ShortSha(versionCommit.ID.String())
+	message := ctx.Locale.TrString("repo.article_revert.commit_message", shortVersion)
+
+	perms, err := repo_service.CheckForkOnEditPermissions(ctx, ctx.Doer, repo)
+	if err != nil {
+		ctx.ServerError("CheckForkOnEditPermissions", err)
+		return
+	}
+	if !perms.IsRepoOwner {
+		// Others propose the restored version through the same flow as edits in the article editor
+		ctx.Repo.TreePath = readme.Name()
+		form := &forms.EditRepoFileForm{
+			CommitCommonForm:   forms.CommitCommonForm{TreePath: readme.Name()},
+			Content:            optional.Some(string(content)),
+			ChangeRequestTitle: message,
+		}
+		pr := handleSubmitChangeRequest(ctx, form, &preparedEditorCommitForm[*forms.EditRepoFileForm]{
+			form:       form,
+			commonForm: &form.CommitCommonForm,
+		})
+		if ctx.Written() || pr == nil {
+			return
+		}
+		ctx.JSONRedirect(pr.Issue.Link())
+		return
+	}
+
+	_, err = files_service.ChangeRepoFiles(ctx, repo, ctx.Doer, &files_service.ChangeRepoFilesOptions{
+		LastCommitID: headCommit.ID.String(),
+		OldBranch:    repo.DefaultBranch,
+		NewBranch:    repo.DefaultBranch,
+		Message:      message,
+		Files: []*files_service.ChangeRepoFile{
+			{
+				Operation:     "update",
+				FromTreePath:  readme.Name(),
+				TreePath:      readme.Name(),
+				ContentReader: bytes.NewReader(content),
+			},
+		},
+	})
+	if err != nil {
+		editorHandleFileOperationError(ctx, repo.DefaultBranch, err)
+		return
+	}
+
+	log.Trace("Article %s restored to version %s", repo.FullName(), versionCommit.ID)
+	ctx.Flash.Success(ctx.Tr("repo.article_revert.success", shortVersion))
+	ctx.JSONRedirect(articleLinkOf(ctx, repo))
+}
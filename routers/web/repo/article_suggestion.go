@@ -0,0 +1,167 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"fmt"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/routers/common"
+	"code.gitea.io/gitea/services/context"
+	repo_service "code.gitea.io/gitea/services/repository"
+)
+
+// CreateArticleSuggestionPost records a reader's suggestion to replace a range of lines of the
+// README of an article, as it is at the "commit" form value (the tip of the default branch if empty)
+func CreateArticleSuggestionPost(ctx *context.Context) {
+	repo := ctx.Repo.Repository
+	if repo.IsArchived {
+		ctx.JSONError(ctx.Tr("repo.editor.article_archived"))
+		return
+	}
+	if repo.OwnerID == ctx.Doer.ID {
+		ctx.JSONError(ctx.Tr("repo.article_suggestion.own_article"))
+		return
+	}
+
+	var commit *git.Commit
+	var err error
+	if commitID := ctx.FormString("commit"); commitID != "" {
+		if !git.IsStringLikelyCommitID(ctx.Repo.GetObjectFormat(), commitID, 7) {
+			ctx.JSONErrorNotFound()
+			return
+		}
+		commit, err = ctx.Repo.GitRepo.GetCommit(commitID)
+	} else {
+		commit, err = ctx.Repo.GitRepo.GetBranchCommit(repo.DefaultBranch)
+	}
+	if err != nil {
+		if git.IsErrNotExist(err) {
+			ctx.JSONErrorNotFound()
+		} else {
+			ctx.ServerError("GetCommit", err)
+		}
+		return
+	}
+
+	treePath, readme, ok := readArticleReadmeAt(ctx, commit)
+	if !ok {
+		return
+	}
+
+	_, err = repo_service.CreateArticleSuggestion(ctx, ctx.Doer, repo, repo_service.CreateArticleSuggestionOptions{
+		CommitID:  commit.ID.String(),
+		TreePath:  treePath,
+		Readme:    readme,
+		LineStart: ctx.FormInt("line_start"),
+		LineEnd:   ctx.FormInt("line_end"),
+		Content:   ctx.FormString("content"),
+		Comment:   ctx.FormString("comment"),
+	})
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.JSONError(ctx.Tr("repo.article_suggestion.invalid", err.Error()))
+		} else {
+			ctx.ServerError("CreateArticleSuggestion", err)
+		}
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.article_suggestion.created"))
+	ctx.JSONRedirect(articleLinkOf(ctx, repo))
+}
+
+// AcceptArticleSuggestion lets the owner of an article accept a suggestion, which opens the
+// editor filled with the suggested text in place of the lines it corrects
+func AcceptArticleSuggestion(ctx *context.Context) {
+	suggestion := getArticleSuggestionForOwner(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	// Check that the suggestion still applies before it's closed, the editor applies it again
+	commit, err := ctx.Repo.GitRepo.GetBranchCommit(ctx.Repo.Repository.DefaultBranch)
+	if err != nil {
+		ctx.ServerError("GetBranchCommit", err)
+		return
+	}
+	_, readme, ok := readArticleReadmeAt(ctx, commit)
+	if !ok {
+		return
+	}
+	if _, err := repo_service.AcceptArticleSuggestion(ctx, suggestion, readme); err != nil {
+		if errors.Is(err, repo_service.ErrArticleSuggestionOutdated) {
+			ctx.JSONError(ctx.Tr("repo.article_suggestion.outdated"))
+		} else if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.JSONError(ctx.Tr("repo.article_suggestion.not_open"))
+		} else {
+			ctx.ServerError("AcceptArticleSuggestion", err)
+		}
+		return
+	}
+
+	ctx.JSONRedirect(fmt.Sprintf("%s?view=article&mode=edit&suggestion=%d", articleLinkOf(ctx, ctx.Repo.Repository), suggestion.ID))
+}
+
+// DismissArticleSuggestion lets the owner of an article turn down a suggestion
+func DismissArticleSuggestion(ctx *context.Context) {
+	suggestion := getArticleSuggestionForOwner(ctx)
+	if ctx.Written() {
+		return
+	}
+	if !suggestion.IsOpen() {
+		ctx.JSONError(ctx.Tr("repo.article_suggestion.not_open"))
+		return
+	}
+
+	if err := repo_model.UpdateArticleSuggestionStatus(ctx, suggestion, repo_model.ArticleSuggestionDismissed); err != nil {
+		ctx.ServerError("UpdateArticleSuggestionStatus", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("repo.article_suggestion.dismissed"))
+	ctx.JSONRedirect(articleLinkOf(ctx, ctx.Repo.Repository) + "?view=article&mode=edit")
+}
+
+// getArticleSuggestionForOwner returns the suggestion of the {id} path parameter, if the doer owns the article
+func getArticleSuggestionForOwner(ctx *context.Context) *repo_model.ArticleSuggestion {
+	if ctx.Repo.Repository.OwnerID != ctx.Doer.ID {
+		ctx.JSONErrorNotFound()
+		return nil
+	}
+	suggestion, err := repo_model.GetArticleSuggestionByID(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		if repo_model.IsErrArticleSuggestionNotExist(err) {
+			ctx.JSONErrorNotFound()
+		} else {
+			ctx.ServerError("GetArticleSuggestionByID", err)
+		}
+		return nil
+	}
+	return suggestion
+}
+
+// readArticleReadmeAt returns the path and content of the README of the article at commit.
+// Returns false if an error response has been written.
+func readArticleReadmeAt(ctx *context.Context, commit *git.Commit) (string, string, bool) {
+	entries, err := commit.ListEntries()
+	if err != nil {
+		ctx.ServerError("ListEntries", err)
+		return "", "", false
+	}
+	readme := common.FindArticleReadme(entries)
+	if readme == nil {
+		ctx.JSONErrorNotFound()
+		return "", "", false
+	}
+	content, err := readme.Blob().GetBlobContent(setting.ArticleMaxRenderSize())
+	if err != nil {
+		ctx.ServerError("GetBlobContent", err)
+		return "", "", false
+	}
+	return readme.Name(), content, true
+}
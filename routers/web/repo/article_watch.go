@@ -0,0 +1,25 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/services/context"
+)
+
+// ActionWatchArticle starts or stops notifying the doer of the changes to the article of the repository,
+// that is of the commits changing its README and of its change requests
+func ActionWatchArticle(ctx *context.Context) {
+	doWatch := ctx.PathParam("action") == "watch"
+	if err := repo_model.WatchArticle(ctx, ctx.Doer.ID, ctx.Repo.Repository.ID, doWatch); err != nil {
+		ctx.ServerError("WatchArticle", err)
+		return
+	}
+	if doWatch {
+		ctx.Flash.Success(ctx.Tr("repo.article_watch.watching"))
+	} else {
+		ctx.Flash.Success(ctx.Tr("repo.article_watch.not_watching"))
+	}
+	ctx.JSONRedirect(articleLinkOf(ctx, ctx.Repo.Repository))
+}
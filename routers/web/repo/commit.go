@@ -501,18 +501,67 @@ func ArticleView(ctx *context.Context) {
 	ctx.Data["IsTableView"] = false
 	ctx.Data["IsArticleView"] = true
 
+	canPromote, err := canPromoteFork(ctx, ctx.Repo.Repository)
+	if err != nil {
+		ctx.ServerError("canPromoteFork", err)
+		return
+	}
+	ctx.Data["CanPromoteFork"] = canPromote
+
 	// Render the repository history view which handles article display
 	explore.RenderRepositoryHistory(ctx)
 }
 
+// ArticleEmbed handles the /article/{username}/{subjectname}/embed route, the read-only
+// article without the site chrome for embedding in other sites.
+// If a "version" query parameter is present, the article is pinned to that commit.
+func ArticleEmbed(ctx *context.Context) {
+	if !ctx.Repo.CanRead(unit_model.TypeCode) {
+		ctx.NotFound(errors.New("code unit not readable"))
+		return
+	}
+
+	if commitHash := ctx.FormString("version"); commitHash != "" {
+		if !loadArticleVersion(ctx, commitHash) {
+			return
+		}
+	}
+
+	explore.RenderArticleEmbed(ctx)
+}
+
 // articleCommitView renders the article view at a specific commit
 // for the /article/{username}/{subjectname}?version={commit-hash} route.
 // The commitHash parameter must be non-empty and is passed from ArticleView.
 func articleCommitView(ctx *context.Context, commitHash string) {
+	if !loadArticleVersion(ctx, commitHash) {
+		return
+	}
+
+	// Set up page metadata for article view
+	ctx.Data["Title"] = ctx.Repo.Repository.FullName() + " - Article (Version)"
+	ctx.Data["PageIsExploreRepositories"] = true
+	ctx.Data["PageIsRepoHistory"] = true
+	ctx.Data["IsRepoHistoryView"] = true
+
+	// Force article view mode
+	ctx.Data["HistoryView"] = "article"
+	ctx.Data["IsBubbleView"] = false
+	ctx.Data["IsTableView"] = false
+	ctx.Data["IsArticleView"] = true
+
+	// Render the repository history view which handles article display
+	explore.RenderRepositoryHistory(ctx)
+}
+
+// loadArticleVersion sets up the repository context for the article at commitHash,
+// the value of the "version" query parameter.
+// Returns false if an error response has been written.
+func loadArticleVersion(ctx *context.Context, commitHash string) bool {
 	// Validate that the commit hash looks like a valid git commit ID
 	if !git.IsStringLikelyCommitID(ctx.Repo.GetObjectFormat(), commitHash, 7) {
 		ctx.NotFound(errors.New("invalid commit hash"))
-		return
+		return false
 	}
 
 	// Get the commit from the repository
@@ -523,7 +572,7 @@ func articleCommitView(ctx *context.Context, commitHash string) {
 		} else {
 			ctx.ServerError("GetCommit", err)
 		}
-		return
+		return false
 	}
 
 	// Set up the repository context for the specific commit
@@ -544,19 +593,5 @@ func articleCommitView(ctx *context.Context, commitHash string) {
 	ctx.Data["TreePath"] = ""
 	ctx.Data["BranchName"] = ""
 	ctx.Data["RefTypeNameSubURL"] = ctx.Repo.RefTypeNameSubURL()
-
-	// Set up page metadata for article view
-	ctx.Data["Title"] = ctx.Repo.Repository.FullName() + " - Article (Version)"
-	ctx.Data["PageIsExploreRepositories"] = true
-	ctx.Data["PageIsRepoHistory"] = true
-	ctx.Data["IsRepoHistoryView"] = true
-
-	// Force article view mode
-	ctx.Data["HistoryView"] = "article"
-	ctx.Data["IsBubbleView"] = false
-	ctx.Data["IsTableView"] = false
-	ctx.Data["IsArticleView"] = true
-
-	// Render the repository history view which handles article display
-	explore.RenderRepositoryHistory(ctx)
+	return true
 }
@@ -5,20 +5,21 @@ package repo
 
 import (
 	"bytes"
-	stdctx "context"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/mail"
 	"path"
 	"strings"
 
-	"code.gitea.io/gitea/models/db"
 	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
+	access_model "code.gitea.io/gitea/models/perm/access"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unit"
 	"code.gitea.io/gitea/modules/charset"
+	"code.gitea.io/gitea/modules/container"
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/gitrepo"
 	"code.gitea.io/gitea/modules/httplib"
@@ -26,12 +27,12 @@ import (
 	"code.gitea.io/gitea/modules/markup"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/translation"
 	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/context/upload"
 	"code.gitea.io/gitea/services/forms"
-	pull_service "code.gitea.io/gitea/services/pull"
 	repo_service "code.gitea.io/gitea/services/repository"
 	files_service "code.gitea.io/gitea/services/repository/files"
 )
@@ -96,7 +97,7 @@ func prepareEditorCommitFormOptions(ctx *context.Context, editorAction string) *
 		targetRepo = ctx.Repo.Repository
 		commitFormOptions.TargetRepo = targetRepo
 	}
-	ctx.Data["new_branch_name"] = getUniquePatchBranchName(ctx, ctx.Doer.LowerName, targetRepo)
+	ctx.Data["new_branch_name"] = repo_service.GetUniquePatchBranchName(ctx, ctx.Doer.LowerName, targetRepo)
 	ctx.Data["last_commit"] = ctx.Repo.CommitID
 	return commitFormOptions
 }
@@ -113,6 +114,7 @@ type preparedEditorCommitForm[T any] struct {
 	OldBranchName     string
 	NewBranchName     string
 	GitCommitter      *files_service.IdentityOptions
+	CoAuthors         []string // "Name <email>" credited with Co-authored-by trailers
 }
 
 func (f *preparedEditorCommitForm[T]) GetCommitMessage(defaultCommitMessage string) string {
@@ -120,9 +122,41 @@ func (f *preparedEditorCommitForm[T]) GetCommitMessage(defaultCommitMessage stri
 	if body := strings.TrimSpace(f.commonForm.CommitMessage); body != "" {
 		commitMessage += "\n\n" + body
 	}
+	if len(f.CoAuthors) > 0 {
+		commitMessage += "\n"
+		for _, coAuthor := range f.CoAuthors {
+			commitMessage += "\nCo-authored-by: " + coAuthor
+		}
+	}
 	return commitMessage
 }
 
+// maxCommitCoAuthors is the maximum number of co-authors that can be credited in a commit
+const maxCommitCoAuthors = 10
+
+// parseCommitCoAuthors parses co-authors given as "Name <email>", one per value or line, and
+// returns them normalized and without duplicate emails. invalid is the first entry that isn't
+// a valid "Name <email>".
+func parseCommitCoAuthors(values []string) (coAuthors []string, invalid string) {
+	seen := make(container.Set[string])
+	for _, value := range values {
+		for line := range strings.SplitSeq(value, "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" {
+				continue
+			}
+			addr, err := mail.ParseAddress(line)
+			if err != nil || addr.Name == "" || strings.ContainsAny(addr.Name, "<>\r\n") {
+				return nil, line
+			}
+			if seen.Add(strings.ToLower(addr.Address)) {
+				coAuthors = append(coAuthors, addr.Name+" <"+addr.Address+">")
+			}
+		}
+	}
+	return coAuthors, ""
+}
+
 func prepareEditorCommitSubmittedForm[T forms.CommitCommonFormInterface](ctx *context.Context, allowSubmitChangeRequest bool) *preparedEditorCommitForm[T] {
 	form := web.GetForm(ctx).(T)
 	if ctx.HasError() {
@@ -164,6 +198,13 @@ func prepareEditorCommitSubmittedForm[T forms.CommitCommonFormInterface](ctx *co
 		return nil
 	}
 
+	// Fail early with a clear message if someone else changed the file since the editor was opened,
+	// this applies to change requests too, which are committed without the last commit
+	if editorFileChangedSinceOpen(ctx, commonForm.LastCommit) {
+		ctx.JSONError(ctx.Tr("repo.editor.file_changed_since_open", editorReloadLink(ctx)))
+		return nil
+	}
+
 	// Committer user info
 	gitCommitter, valid := WebGitOperationGetCommitChosenEmailIdentity(ctx, commonForm.CommitEmail)
 	if !valid {
@@ -214,6 +255,34 @@ func prepareEditorCommitSubmittedForm[T forms.CommitCommonFormInterface](ctx *co
 	}
 }
 
+// editorFileChangedSinceOpen reports whether the file being edited or deleted was changed on the
+// branch after lastCommit, the tip of the branch when the editor was opened
+func editorFileChangedSinceOpen(ctx *context.Context, lastCommit string) bool {
+	switch ctx.PathParam("editor_action") {
+	case "_edit", "_delete":
+	default:
+		return false
+	}
+	if lastCommit == "" || ctx.Repo.Commit == nil || ctx.Repo.TreePath == "" || ctx.Repo.Commit.ID.String() == lastCommit {
+		return false
+	}
+	changed, err := ctx.Repo.Commit.FileChangedSinceCommit(ctx.Repo.TreePath, lastCommit)
+	if err != nil {
+		// An invalid last commit is reported when the changes are committed
+		log.Debug("FileChangedSinceCommit(%s, %s): %v", ctx.Repo.TreePath, lastCommit, err)
+		return false
+	}
+	return changed
+}
+
+// editorReloadLink returns the link to open the editor again, the page the form was submitted from
+func editorReloadLink(ctx *context.Context) string {
+	if referer := ctx.Req.Referer(); referer != "" && httplib.IsCurrentGiteaSiteURL(ctx, referer) {
+		return referer
+	}
+	return ctx.Req.URL.RequestURI()
+}
+
 // redirectForCommitChoice redirects after committing the edit to a branch
 func redirectForCommitChoice[T any](ctx *context.Context, parsed *preparedEditorCommitForm[T], treePath string) {
 	// when editing a file in a PR, it should return to the origin location
@@ -360,18 +429,48 @@ func EditFile(ctx *context.Context) {
 	ctx.HTML(http.StatusOK, tplEditFile)
 }
 
+// defaultEditFileCommitMessage returns the commit message of a file edit submitted without a summary.
+// Articles (repositories of a subject) use the configured [repository.article] message, if any.
+func defaultEditFileCommitMessage(locale translation.Locale, repo *repo_model.Repository, isNewFile bool, treePath string) string {
+	if repo.SubjectID > 0 {
+		articleMessage := util.Iif(isNewFile, setting.Repository.Article.DefaultCreateMessage, setting.Repository.Article.DefaultEditMessage)
+		if articleMessage != "" {
+			return articleMessage
+		}
+	}
+	return util.Iif(isNewFile, locale.TrString("repo.editor.add", treePath), locale.TrString("repo.editor.update", treePath))
+}
+
 func EditFilePost(ctx *context.Context) {
 	editorAction := ctx.PathParam("editor_action")
 	isNewFile := editorAction == "_new"
+
+	// Check the co-authors first, preparing the form may already push the base branch to a fork
+	coAuthors, invalidCoAuthor := parseCommitCoAuthors(web.GetForm(ctx).(*forms.EditRepoFileForm).CoAuthors)
+	if invalidCoAuthor != "" {
+		ctx.JSONError(ctx.Tr("repo.editor.invalid_co_author", invalidCoAuthor))
+		return
+	} else if len(coAuthors) > maxCommitCoAuthors {
+		ctx.JSONError(ctx.Tr("repo.editor.too_many_co_authors", maxCommitCoAuthors))
+		return
+	}
+
 	parsed := prepareEditorCommitSubmittedForm[*forms.EditRepoFileForm](ctx, true)
 	if ctx.Written() {
 		return
 	}
+	parsed.CoAuthors = coAuthors
+
+	var promotionCandidate *repo_model.Repository
 
 	// Skip the NeedFork workflow if ForkAndEdit or SubmitChangeRequest is true
 	// The ForkAndEdit workflow (handled later) will create the fork
 	// The SubmitChangeRequest workflow creates a branch in the target repo directly (no fork)
 	if parsed.CommitFormOptions.NeedFork && !parsed.form.ForkAndEdit && !parsed.form.SubmitChangeRequest {
+		if setting.Repository.DisableForkOnEdit {
+			ctx.JSONError(ctx.Tr("repo.editor.fork_on_edit_disabled"))
+			return
+		}
 		baseRepo := ctx.Repo.Repository
 		repoName := getUniqueRepositoryName(ctx, ctx.Doer.ID, baseRepo.Name)
 		if repoName == "" {
@@ -388,80 +487,20 @@ func EditFilePost(ctx *context.Context) {
 			return
 		}
 
-		// Check if the base repository has a README
-		// If not, we should promote the new fork to be the root repository
-		// This is to handle the case where a user creates a subject (empty repo) and another user
-		// contributes the first content (README). The contributor should become the owner of the "main" repo.
-		hasReadme := false
-		if !baseRepo.IsEmpty {
-			// Check for README in the default branch
-			gitRepo, err := gitrepo.OpenRepository(ctx, baseRepo)
-			if err != nil {
-				log.Error("OpenRepository failed: %v", err)
-			} else {
-				defer gitRepo.Close()
-				commit, err := gitRepo.GetBranchCommit(baseRepo.DefaultBranch)
-				if err != nil {
-					log.Error("GetBranchCommit failed: %v", err)
-				} else {
-					entry, err := commit.GetTreeEntryByPath("README.md")
-					if err == nil && entry != nil {
-						hasReadme = true
-					} else {
-						// Try other common names
-						entry, err = commit.GetTreeEntryByPath("readme.md")
-						if err == nil && entry != nil {
-							hasReadme = true
-						}
-					}
-				}
-			}
-		}
-
-		if !hasReadme {
-			// Swap fork status atomically in a transaction
-			err := db.WithTx(ctx, func(txCtx stdctx.Context) error {
-				// 1. Promote forkedRepo to root
-				forkedRepo.IsFork = false
-				forkedRepo.ForkID = 0
-				if err := repo_model.UpdateRepositoryColsNoAutoTime(txCtx, forkedRepo, "is_fork", "fork_id"); err != nil {
-					return fmt.Errorf("failed to update forked repo to root: %w", err)
-				}
-
-				// 2. Demote baseRepo to fork
-				baseRepo.IsFork = true
-				baseRepo.ForkID = forkedRepo.ID
-				if err := repo_model.UpdateRepositoryColsNoAutoTime(txCtx, baseRepo, "is_fork", "fork_id"); err != nil {
-					return fmt.Errorf("failed to update base repo to fork: %w", err)
-				}
-
-				// 3. Update NumForks counters
-				// forkedRepo is no longer a fork of baseRepo, so decrement baseRepo's count
-				if err := repo_model.DecrementRepoForkNum(txCtx, baseRepo.ID); err != nil {
-					return fmt.Errorf("failed to decrement fork count on old root: %w", err)
-				}
-				// baseRepo is now a fork of forkedRepo, so increment forkedRepo's count
-				if err := repo_model.IncrementRepoForkNum(txCtx, forkedRepo.ID); err != nil {
-					return fmt.Errorf("failed to increment fork count on new root: %w", err)
-				}
-
-				return nil
-			})
-			if err != nil {
-				log.Error("Failed to swap fork status: %v", err)
-				ctx.ServerError("SwapForkStatus", err)
-				return
-			}
-
-			// If base repo was empty, the fork is also empty.
-			// We should commit to the default branch instead of a patch branch.
-			if baseRepo.IsEmpty {
-				parsed.NewBranchName = forkedRepo.DefaultBranch
-				if parsed.NewBranchName == "" {
-					parsed.NewBranchName = setting.Repository.DefaultBranch
-				}
-				parsed.OldBranchName = ""
+		// If the base repository has no README, the fork contributes the first content of the article and
+		// takes the place of the root: a user creates a subject (empty repo) and another user contributes
+		// the README, the contributor should own the "main" repo. This is checked once the edit is
+		// committed, so that the commit counts towards ROOT_PROMOTION_MIN_COMMITS.
+		promotionCandidate = forkedRepo
+
+		// If base repo was empty, the fork is also empty.
+		// We should commit to the default branch instead of a patch branch.
+		if baseRepo.IsEmpty {
+			parsed.NewBranchName = forkedRepo.DefaultBranch
+			if parsed.NewBranchName == "" {
+				parsed.NewBranchName = setting.Repository.DefaultBranch
 			}
+			parsed.OldBranchName = ""
 		}
 
 		ctx.Repo.Repository = forkedRepo
@@ -469,7 +508,7 @@ func EditFilePost(ctx *context.Context) {
 		ctx.Repo.RepoLink = forkedRepo.Link()
 	}
 
-	defaultCommitMessage := util.Iif(isNewFile, ctx.Locale.TrString("repo.editor.add", parsed.form.TreePath), ctx.Locale.TrString("repo.editor.update", parsed.form.TreePath))
+	defaultCommitMessage := defaultEditFileCommitMessage(ctx.Locale, ctx.Repo.Repository, isNewFile, parsed.form.TreePath)
 
 	var operation string
 	if isNewFile {
@@ -521,28 +560,87 @@ func EditFilePost(ctx *context.Context) {
 	subjectID := ctx.Repo.Repository.SubjectID
 	isNotFork := !ctx.Repo.Repository.IsFork
 
+	// The first README of a subject anchors all its articles, it may have to pass a stricter check
+	content := strings.ReplaceAll(parsed.form.Content.Value(), "\r", "")
+	if wasEmpty && subjectID > 0 && isNewFile && repo_service.IsArticleReadme(parsed.form.TreePath) {
+		checked, err := repo_service.PrepareFirstArticleReadme(content)
+		if err != nil {
+			if errors.Is(err, util.ErrInvalidArgument) {
+				ctx.JSONError(ctx.Tr("repo.editor.article_readme_invalid", err.Error()))
+			} else {
+				ctx.ServerError("PrepareFirstArticleReadme", err)
+			}
+			return
+		}
+		content = checked
+	}
+
+	changeFiles := []*files_service.ChangeRepoFile{
+		{
+			Operation:     operation,
+			FromTreePath:  ctx.Repo.TreePath,
+			TreePath:      parsed.form.TreePath,
+			ContentReader: strings.NewReader(content),
+		},
+	}
+
+	// Images uploaded while editing went to a patch branch of the original article;
+	// when forking instead, they are carried over to the fork with the edit
+	var imagesPatchBranch string
+	if parsed.form.ForkAndEdit && parsed.form.PatchBranch != "" {
+		ok, err := isDoerPatchBranch(ctx, ctx.Repo.Repository, parsed.form.PatchBranch)
+		if err != nil {
+			ctx.ServerError("isDoerPatchBranch", err)
+			return
+		}
+		if ok {
+			images, err := collectPatchBranchImages(ctx, ctx.Repo.Repository, parsed.form.PatchBranch,
+				path.Join(path.Dir(parsed.form.TreePath), articleImageDir))
+			if err != nil {
+				ctx.ServerError("collectPatchBranchImages", err)
+				return
+			}
+			changeFiles = append(changeFiles, images...)
+			imagesPatchBranch = parsed.form.PatchBranch
+		}
+	}
+
+	// The last commit is one of the original article, an existing fork may not have it
+	lastCommitID := util.Iif(targetRepo.ID == ctx.Repo.Repository.ID, parsed.form.LastCommit, "")
 	_, err := files_service.ChangeRepoFiles(ctx, targetRepo, ctx.Doer, &files_service.ChangeRepoFilesOptions{
-		LastCommitID: parsed.form.LastCommit,
+		LastCommitID: lastCommitID,
 		OldBranch:    parsed.OldBranchName,
 		NewBranch:    parsed.NewBranchName,
 		Message:      parsed.GetCommitMessage(defaultCommitMessage),
-		Files: []*files_service.ChangeRepoFile{
-			{
-				Operation:     operation,
-				FromTreePath:  ctx.Repo.TreePath,
-				TreePath:      parsed.form.TreePath,
-				ContentReader: strings.NewReader(strings.ReplaceAll(parsed.form.Content.Value(), "\r", "")),
-			},
-		},
-		Signoff:   parsed.form.Signoff,
-		Author:    parsed.GitCommitter,
-		Committer: parsed.GitCommitter,
+		Files:        changeFiles,
+		Signoff:      parsed.form.Signoff,
+		SkipSigning:  parsed.form.SkipSigning,
+		Author:       parsed.GitCommitter,
+		Committer:    parsed.GitCommitter,
 	})
 	if err != nil {
 		editorHandleFileOperationError(ctx, parsed.NewBranchName, err)
 		return
 	}
 
+	// The patch branch only held the carried over images, it isn't needed anymore
+	if imagesPatchBranch != "" {
+		if gitRepo, err := gitrepo.OpenRepository(ctx, ctx.Repo.Repository); err != nil {
+			log.Error("OpenRepository failed: %v", err)
+		} else {
+			repo_service.CleanupOrphanedBranch(ctx, ctx.Doer, ctx.Repo.Repository, gitRepo, imagesPatchBranch)
+			gitRepo.Close()
+		}
+	}
+
+	// The fork becomes the root, or waits for an administrator to approve it if required. A fork which
+	// isn't eligible yet is checked again by the pushes to its default branch.
+	if promotionCandidate != nil {
+		if err := repo_service.PromoteForkOfRootWithoutReadme(ctx, ctx.Doer, promotionCandidate); err != nil {
+			log.Error("PromoteForkOfRootWithoutReadme(%-v): %v", promotionCandidate, err)
+		}
+	}
+
 	// First-article-becomes-root logic:
 	// If this was an empty repository with a subject, and it's not already a fork,
 	// check if there's already a root repository for this subject.
@@ -565,6 +663,11 @@ func EditFilePost(ctx *context.Context) {
 func handleForkAndEdit(ctx *context.Context) *repo_model.Repository {
 	originalRepo := ctx.Repo.Repository
 
+	if setting.Repository.DisableForkOnEdit {
+		ctx.JSONError(ctx.Tr("repo.editor.fork_on_edit_disabled"))
+		return nil
+	}
+
 	// Prevent bypassing UI restrictions
 	perms, err := repo_service.CheckForkOnEditPermissions(ctx, ctx.Doer, originalRepo)
 	if err != nil {
@@ -572,6 +675,12 @@ func handleForkAndEdit(ctx *context.Context) *repo_model.Repository {
 		return nil
 	}
 
+	// Archived articles are read-only and can't be forked
+	if perms.IsArchived {
+		ctx.JSONError(ctx.Tr("repo.fork.archived_repo"))
+		return nil
+	}
+
 	// Block if user already owns a different repository for the same subject
 	if perms.BlockedBySubject {
 		ctx.JSONError(ctx.Tr("repo.fork.already_own_subject_repo"))
@@ -603,43 +712,11 @@ func handleForkAndEdit(ctx *context.Context) *repo_model.Repository {
 	return fork
 }
 
-// cleanupOrphanedBranch attempts to delete a branch that was created but is no longer needed
-// (e.g., when PR creation fails after the branch was already created).
-// It performs both a soft-delete (via DeleteBranch) and a hard-delete of the DB
-// record, since the branch was never meant to exist and should leave no trace.
-// It logs any errors but does not propagate them to the caller.
-func cleanupOrphanedBranch(ctx *context.Context, repo *repo_model.Repository, gitRepo *git.Repository, branchName string) {
-	if gitRepo == nil {
-		return
-	}
-	// Skip permission check because this branch was created programmatically via
-	// InternalPush (which bypasses pre-receive hooks and permission checks).
-	// Without this, non-collaborators who can submit change requests would be able
-	// to create branches but not delete them, leaving orphaned branches when PR
-	// creation fails.
-	if err := repo_service.DeleteBranch(ctx, ctx.Doer, repo, gitRepo, branchName, nil, &repo_service.DeleteBranchOptions{
-		SkipPermissionCheck: true,
-	}); err != nil {
-		log.Error("cleanupOrphanedBranch: failed to cleanup branch %s: %v", branchName, err)
-		return
-	}
-
-	// Hard-delete the soft-deleted DB record so the orphaned branch leaves no
-	// trace. DeleteBranch only marks the record as deleted (is_deleted=true);
-	// without this step the branch would still appear in unfiltered queries.
-	branch, err := git_model.GetBranch(ctx, repo.ID, branchName)
-	if err != nil {
-		log.Error("cleanupOrphanedBranch: failed to get branch record for %s: %v", branchName, err)
-		return
-	}
-	if err := git_model.RemoveDeletedBranchByID(ctx, repo.ID, branch.ID); err != nil {
-		log.Error("cleanupOrphanedBranch: failed to hard-delete branch record for %s: %v", branchName, err)
-	}
-}
-
 // handleSubmitChangeRequest handles the submit-change-request workflow for article contributions.
 // It creates a unique branch in the target repository, commits the changes, and creates a change request
 // from that branch to the default branch (same-repo CR, no fork involved).
+// If form.TargetRepoID names another repository of the same subject, the change request
+// is submitted to that repository instead of the one being viewed.
 // Returns the created change request, or nil if an error occurred.
 func handleSubmitChangeRequest(ctx *context.Context, form *forms.EditRepoFileForm, parsed *preparedEditorCommitForm[*forms.EditRepoFileForm]) *issues_model.PullRequest {
 	// Verify user is authenticated (defense-in-depth, middleware should already handle this)
@@ -648,49 +725,45 @@ func handleSubmitChangeRequest(ctx *context.Context, form *forms.EditRepoFileFor
 		return nil
 	}
 
-	targetRepo := ctx.Repo.Repository
-
-	// Verify user has permission to submit change requests
-	// This checks: not repo owner, not blocked by subject ownership, etc.
-	perms, err := repo_service.CheckForkOnEditPermissions(ctx, ctx.Doer, targetRepo)
-	if err != nil {
-		ctx.ServerError("CheckForkOnEditPermissions", err)
+	targetRepo := resolveChangeRequestTargetRepo(ctx, form.TargetRepoID)
+	if targetRepo == nil {
 		return nil
 	}
 
-	// Prevent users from submitting change requests to their own repository
-	if perms.IsRepoOwner {
-		ctx.JSONError(ctx.Tr("repo.editor.cannot_submit_change_request_to_own_repo"))
+	// Verify user can submit change requests: not repo owner, not blocked by subject ownership,
+	// pull requests enabled and not rate limited
+	if err := repo_service.CheckCanSubmitChangeRequest(ctx, ctx.Doer, targetRepo); err != nil {
+		handleCheckCanSubmitChangeRequestError(ctx, err)
 		return nil
 	}
 
-	// Block users who own an independent article for this subject
-	if perms.BlockedBySubject {
-		ctx.JSONError(ctx.Tr("repo.fork.already_own_subject_repo"))
-		return nil
-	}
-
-	// Verify user can actually submit change requests
-	if !perms.CanSubmitChangeRequest {
-		ctx.JSONError(ctx.Tr("repo.editor.no_change_request_permission"))
-		return nil
-	}
-
-	// Check if the repository allows pull requests
-	if !targetRepo.AllowsPulls(ctx) {
-		ctx.JSONError(ctx.Tr("repo.pulls.disabled"))
-		return nil
-	}
-
-	// Generate a unique branch name for the change request
-	branchName := getUniquePatchBranchName(ctx, ctx.Doer.LowerName, targetRepo)
-	if branchName == "" {
-		ctx.JSONError(ctx.Tr("repo.editor.cannot_create_branch"))
-		return nil
+	// Use the patch branch of the editing session, adding the edit to it if images uploaded
+	// while editing have already created it. Older forms without one get a new unique name.
+	branchName, oldBranchName := form.PatchBranch, form.PatchBranch
+	if branchName != "" {
+		if !isDoerPatchBranchName(ctx, branchName) {
+			ctx.JSONError(ctx.Tr("repo.editor.invalid_patch_branch"))
+			return nil
+		}
+		exists, err := git_model.IsBranchExist(ctx, targetRepo.ID, branchName)
+		if err != nil {
+			ctx.ServerError("IsBranchExist", err)
+			return nil
+		}
+		if !exists {
+			oldBranchName = targetRepo.DefaultBranch
+		}
+	} else {
+		branchName = repo_service.GetUniquePatchBranchName(ctx, ctx.Doer.LowerName, targetRepo)
+		if branchName == "" {
+			ctx.JSONError(ctx.Tr("repo.editor.cannot_create_branch"))
+			return nil
+		}
+		oldBranchName = targetRepo.DefaultBranch
 	}
 
 	// Validate that content is provided and is not empty/whitespace-only
-	if !form.Content.Has() || strings.TrimSpace(form.Content.Value()) == "" {
+	if !form.Content.Has() || repo_service.IsBlankChangeRequestContent(form.Content.Value()) {
 		ctx.JSONError(ctx.Tr("repo.editor.content_required"))
 		return nil
 	}
@@ -711,14 +784,14 @@ func handleSubmitChangeRequest(ctx *context.Context, form *forms.EditRepoFileFor
 		ctx.JSONError(ctx.Tr("repo.editor.commit_message_required"))
 		return nil
 	}
-	_, err = files_service.ChangeRepoFiles(ctx, targetRepo, ctx.Doer, &files_service.ChangeRepoFilesOptions{
+	_, err := files_service.ChangeRepoFiles(ctx, targetRepo, ctx.Doer, &files_service.ChangeRepoFilesOptions{
 		// Use an empty LastCommitID so ChangeRepoFiles bases the new commit on the current
-		// HEAD of OldBranch. In this workflow we always create a new branch (NewBranch != OldBranch),
-		// so the file conflict detection that relies on LastCommitID is skipped anyway. This makes
-		// an empty LastCommitID the safest choice and avoids relying on a potentially stale or
-		// branch-mismatched client-side form.LastCommit value.
+		// HEAD of OldBranch. In this workflow the branch is either new (NewBranch != OldBranch) or
+		// only holds the images uploaded while editing, so the file conflict detection that relies
+		// on LastCommitID is not needed. This makes an empty LastCommitID the safest choice and
+		// avoids relying on a potentially stale or branch-mismatched client-side form.LastCommit value.
 		LastCommitID: "",
-		OldBranch:    targetRepo.DefaultBranch,
+		OldBranch:    oldBranchName,
 		NewBranch:    branchName,
 		Message:      commitMessage,
 		Files: []*files_service.ChangeRepoFile{
@@ -730,6 +803,7 @@ func handleSubmitChangeRequest(ctx *context.Context, form *forms.EditRepoFileFor
 			},
 		},
 		Signoff:      form.Signoff,
+		SkipSigning:  form.SkipSigning,
 		Author:       parsed.GitCommitter,
 		Committer:    parsed.GitCommitter,
 		InternalPush: true,
@@ -740,98 +814,73 @@ func handleSubmitChangeRequest(ctx *context.Context, form *forms.EditRepoFileFor
 		return nil
 	}
 
-	// Get compare info for the pull request
-	gitRepo, err := gitrepo.OpenRepository(ctx, targetRepo)
+	changeRequest, err := repo_service.CreatePatchBranchChangeRequest(ctx, ctx.Doer, targetRepo, branchName, prTitle, form.ChangeRequestDescription)
 	if err != nil {
-		log.Error("handleSubmitChangeRequest: failed to open git repo: %v", err)
-		// Note: Branch cleanup not attempted as repository is inaccessible
-		ctx.ServerError("OpenRepository", err)
+		log.Error("handleSubmitChangeRequest: failed to create change request: %v", err)
+		ctx.ServerError("CreatePatchBranchChangeRequest", err)
 		return nil
 	}
-	defer gitRepo.Close()
+	return changeRequest
+}
 
-	// Sync the newly created branch to the database. The InternalPush above
-	// bypasses post-receive hooks, so the branch exists only in git at this
-	// point. Without this sync, any subsequent operation that checks branch
-	// existence via the DB (e.g. ChangeRepoFiles from the API) would fail
-	// with "branch does not exist".
-	newCommitID, err := gitRepo.GetBranchCommitID(branchName)
+// handleCheckCanSubmitChangeRequestError writes the error response for an error returned by
+// repo_service.CheckCanSubmitChangeRequest
+func handleCheckCanSubmitChangeRequestError(ctx *context.Context, err error) {
+	switch {
+	case errors.Is(err, repo_service.ErrChangeRequestToOwnRepo):
+		ctx.JSONError(ctx.Tr("repo.editor.cannot_submit_change_request_to_own_repo"))
+	case errors.Is(err, repo_service.ErrChangeRequestBlockedBySubject):
+		ctx.JSONError(ctx.Tr("repo.fork.already_own_subject_repo"))
+	case errors.Is(err, repo_service.ErrChangeRequestNotAllowed):
+		ctx.JSONError(ctx.Tr("repo.editor.no_change_request_permission"))
+	case errors.Is(err, repo_service.ErrChangeRequestPullsDisabled):
+		ctx.JSONError(ctx.Tr("repo.pulls.disabled"))
+	case errors.Is(err, repo_service.ErrChangeRequestRateLimitExceeded):
+		ctx.JSONError(ctx.Tr("repo.editor.too_many_change_requests"))
+	default:
+		ctx.ServerError("CheckCanSubmitChangeRequest", err)
+	}
+}
+
+// resolveChangeRequestTargetRepo returns the repository a change request should be submitted to.
+// Without a target ID (or when it names the current repository) this is the repository being viewed.
+// Otherwise the target must exist, belong to the same subject and be readable by the doer; the
+// remaining submit checks (ownership, subject block, pulls enabled) are applied by the caller.
+// Returns nil if an error response has been written.
+func resolveChangeRequestTargetRepo(ctx *context.Context, targetRepoID int64) *repo_model.Repository {
+	currentRepo := ctx.Repo.Repository
+	if targetRepoID <= 0 || targetRepoID == currentRepo.ID {
+		return currentRepo
+	}
+
+	targetRepo, err := repo_model.GetRepositoryByID(ctx, targetRepoID)
 	if err != nil {
-		log.Error("handleSubmitChangeRequest: failed to get branch commit ID: %v", err)
-		cleanupOrphanedBranch(ctx, targetRepo, gitRepo, branchName)
-		ctx.ServerError("GetBranchCommitID", err)
+		if repo_model.IsErrRepoNotExist(err) {
+			ctx.JSONError(ctx.Tr("repo.editor.invalid_change_request_target"))
+			return nil
+		}
+		ctx.ServerError("GetRepositoryByID", err)
 		return nil
 	}
-	if err := repo_service.SyncBranchesToDB(ctx, targetRepo.ID, ctx.Doer.ID,
-		[]string{branchName}, []string{newCommitID}, gitRepo.GetCommit); err != nil {
-		log.Error("handleSubmitChangeRequest: failed to sync branch to DB: %v", err)
-		cleanupOrphanedBranch(ctx, targetRepo, gitRepo, branchName)
-		ctx.ServerError("SyncBranchesToDB", err)
+
+	// Only forks/viewpoints of the same subject are valid targets
+	if currentRepo.SubjectID == 0 || targetRepo.SubjectID != currentRepo.SubjectID {
+		ctx.JSONError(ctx.Tr("repo.editor.invalid_change_request_target"))
 		return nil
 	}
 
-	// Same-repo CR: both head and base are in the target repository
-	compareInfo, err := pull_service.GetCompareInfo(ctx, targetRepo, targetRepo, gitRepo,
-		git.BranchPrefix+targetRepo.DefaultBranch, git.BranchPrefix+branchName, false, false)
+	// Don't disclose repositories the user cannot see
+	perm, err := access_model.GetUserRepoPermission(ctx, targetRepo, ctx.Doer)
 	if err != nil {
-		log.Error("handleSubmitChangeRequest: failed to get compare info: %v", err)
-		cleanupOrphanedBranch(ctx, targetRepo, gitRepo, branchName)
-		ctx.ServerError("GetCompareInfo", err)
+		ctx.ServerError("GetUserRepoPermission", err)
 		return nil
 	}
-
-	// Create the change request using the title computed above
-	prContent := strings.TrimSpace(form.ChangeRequestDescription)
-	// Defense-in-depth: cap description length so downstream processing/storage isn't impacted by huge input.
-	// Note: this does not limit the incoming request size.
-	prContent = util.TruncateRunes(prContent, 65535)
-
-	pullIssue := &issues_model.Issue{
-		RepoID:   targetRepo.ID,
-		Repo:     targetRepo,
-		Title:    prTitle,
-		PosterID: ctx.Doer.ID,
-		Poster:   ctx.Doer,
-		IsPull:   true,
-		Content:  prContent,
-	}
-
-	// Same-repo CR: HeadRepo and BaseRepo are both the target repository
-	changeRequest := &issues_model.PullRequest{
-		HeadRepoID: targetRepo.ID,
-		BaseRepoID: targetRepo.ID,
-		HeadBranch: branchName,
-		BaseBranch: targetRepo.DefaultBranch,
-		HeadRepo:   targetRepo,
-		BaseRepo:   targetRepo,
-		MergeBase:  compareInfo.MergeBase,
-		Type:       issues_model.PullRequestGitea,
-	}
-
-	prOpts := &pull_service.NewPullRequestOptions{
-		Repo:        targetRepo,
-		Issue:       pullIssue,
-		PullRequest: changeRequest,
-		// AllowNonCollaborator: The user was already authorized to submit change requests
-		// by the CanSubmitChangeRequest middleware check. This bypasses the collaborator
-		// check since the user created the patch branch programmatically (not via git push).
-		AllowNonCollaborator: true,
-	}
-
-	if err := pull_service.NewPullRequest(ctx, prOpts); err != nil {
-		log.Error("handleSubmitChangeRequest: failed to create change request: %v", err)
-		cleanupOrphanedBranch(ctx, targetRepo, gitRepo, branchName)
-		ctx.ServerError("NewPullRequest", err)
+	if !perm.CanRead(unit.TypeCode) {
+		ctx.JSONError(ctx.Tr("repo.editor.invalid_change_request_target"))
 		return nil
 	}
 
-	log.Info("handleSubmitChangeRequest: created CR #%d from %s to %s in %s/%s",
-		changeRequest.Index,
-		branchName,
-		targetRepo.DefaultBranch,
-		targetRepo.OwnerName, targetRepo.Name)
-
-	return changeRequest
+	return targetRepo
 }
 
 // DeleteFile render delete file page
@@ -933,6 +982,9 @@ func handleFirstArticleBecomesRoot(ctx *context.Context, subjectID int64) {
 			// No other root exists - this repository becomes the root (it's already not a fork)
 			log.Info("Repository %s/%s becomes the root for subject ID %d (first article submitted)",
 				ctx.Repo.Repository.OwnerName, ctx.Repo.Repository.Name, subjectID)
+			if err := repo_model.SetSubjectRootRepoID(ctx, subjectID, ctx.Repo.Repository.ID); err != nil {
+				log.Error("handleFirstArticleBecomesRoot: failed to record root repository: %v", err)
+			}
 			return
 		}
 		log.Error("handleFirstArticleBecomesRoot: failed to get root repository: %v", err)
@@ -951,6 +1003,11 @@ func handleFirstArticleBecomesRoot(ctx *context.Context, subjectID int64) {
 				subjectID, ctx.Repo.Repository.OwnerName, ctx.Repo.Repository.Name)
 			return
 		}
+		if repo_service.IsErrForkArchivedRepo(err) {
+			log.Info("handleFirstArticleBecomesRoot: root repository for subject ID %d is archived, repository %s/%s will not be converted to a fork",
+				subjectID, ctx.Repo.Repository.OwnerName, ctx.Repo.Repository.Name)
+			return
+		}
 		log.Error("handleFirstArticleBecomesRoot: failed to convert to fork: %v", err)
 		return
 	}
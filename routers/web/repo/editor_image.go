@@ -0,0 +1,297 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	git_model "code.gitea.io/gitea/models/git"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/typesniffer"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/context/upload"
+	repo_service "code.gitea.io/gitea/services/repository"
+	files_service "code.gitea.io/gitea/services/repository/files"
+)
+
+// articleImageDir is the directory, next to the edited article, that editor images are committed to
+const articleImageDir = "images"
+
+// articleImageTarget is where an image uploaded from the article editor is committed
+type articleImageTarget struct {
+	Repo        *repo_model.Repository
+	OldBranch   string
+	NewBranch   string
+	PatchBranch string // set in the change request workflow, the branch the change request will be made from
+}
+
+// UploadArticleImagePost commits an image pasted or dropped into the article editor and
+// returns the Markdown referencing it. The image is committed where the edit itself will
+// end up: the edited branch for users who can write to it, the doer's fork in the
+// fork-and-edit workflow, or a patch branch in the submit-change-request workflow.
+func UploadArticleImagePost(ctx *context.Context) {
+	file, header, err := ctx.Req.FormFile("file")
+	if err != nil {
+		if isUploadSizeError(err) {
+			ctx.JSONError(ctx.Tr("repo.editor.image_too_large", base.FileSize(setting.Repository.Upload.FileMaxSize<<20)))
+			return
+		}
+		ctx.JSONError(ctx.Tr("repo.editor.image_upload_missing"))
+		return
+	}
+	defer file.Close()
+
+	maxSize := setting.Repository.Upload.FileMaxSize << 20
+	if header.Size > maxSize {
+		ctx.JSONError(ctx.Tr("repo.editor.image_too_large", base.FileSize(maxSize)))
+		return
+	}
+	content, err := io.ReadAll(io.LimitReader(file, maxSize+1))
+	if err != nil {
+		ctx.ServerError("ReadAll", err)
+		return
+	}
+	if int64(len(content)) > maxSize {
+		ctx.JSONError(ctx.Tr("repo.editor.image_too_large", base.FileSize(maxSize)))
+		return
+	}
+
+	// Only images are accepted, whatever the configured upload types allow
+	sniffed := typesniffer.DetectContentType(content)
+	if !sniffed.IsImage() {
+		ctx.JSONError(ctx.Tr("repo.editor.image_not_an_image"))
+		return
+	}
+
+	fileName := path.Base(files_service.CleanGitTreePath(header.Filename))
+	if fileName == "" || fileName == "." || fileName == "/" {
+		fileName = "image"
+	}
+	if path.Ext(fileName) == "" {
+		if exts, _ := mime.ExtensionsByType(sniffed.GetMimeType()); len(exts) > 0 {
+			fileName += exts[0]
+		}
+	}
+	if err := upload.Verify(content, fileName, setting.Repository.Upload.AllowedTypes); err != nil {
+		ctx.JSONError(ctx.Tr("repo.editor.image_type_forbidden"))
+		return
+	}
+
+	target := resolveArticleImageTarget(ctx)
+	if target == nil {
+		return
+	}
+
+	imageDir := path.Join(path.Dir(ctx.Repo.TreePath), articleImageDir)
+	treePath, err := uniqueArticleImagePath(ctx, target, imageDir, fileName)
+	if err != nil {
+		ctx.ServerError("uniqueArticleImagePath", err)
+		return
+	}
+
+	// Users committing to another user's repository are not collaborators, so skip the
+	// pre-receive hooks like the change request workflow does and sync the branch ourselves
+	internalPush := target.PatchBranch != ""
+	_, err = files_service.ChangeRepoFiles(ctx, target.Repo, ctx.Doer, &files_service.ChangeRepoFilesOptions{
+		OldBranch: target.OldBranch,
+		NewBranch: target.NewBranch,
+		Message:   ctx.Locale.TrString("repo.editor.add_image", path.Base(treePath)),
+		Files: []*files_service.ChangeRepoFile{
+			{
+				Operation:     "create",
+				TreePath:      treePath,
+				ContentReader: bytes.NewReader(content),
+			},
+		},
+		InternalPush: internalPush,
+	})
+	if err != nil {
+		editorHandleFileOperationError(ctx, target.NewBranch, err)
+		return
+	}
+	if internalPush {
+		if err := syncArticleImageBranch(ctx, target); err != nil {
+			ctx.ServerError("syncArticleImageBranch", err)
+			return
+		}
+	}
+
+	link := util.PathEscapeSegments(path.Join(articleImageDir, path.Base(treePath)))
+	alt := strings.TrimSuffix(path.Base(treePath), path.Ext(treePath))
+	ctx.JSON(http.StatusOK, map[string]any{
+		"path":         treePath,
+		"link":         link,
+		"markdown":     fmt.Sprintf("![%s](%s)", alt, link),
+		"raw_url":      target.Repo.Link() + "/raw/branch/" + util.PathEscapeSegments(target.NewBranch) + "/" + util.PathEscapeSegments(treePath),
+		"patch_branch": target.PatchBranch,
+	})
+}
+
+// resolveArticleImageTarget decides where an editor image is committed, applying the same
+// permission checks as the edit workflow it belongs to.
+// Returns nil if an error response has been written.
+func resolveArticleImageTarget(ctx *context.Context) *articleImageTarget {
+	forkAndEdit := ctx.FormBool("fork_and_edit")
+	submitChangeRequest := ctx.FormBool("submit_change_request")
+	if forkAndEdit && submitChangeRequest {
+		ctx.JSONError(ctx.Tr("repo.editor.cannot_use_both_fork_and_submit"))
+		return nil
+	}
+
+	switch {
+	case submitChangeRequest:
+		targetRepo := resolveChangeRequestTargetRepo(ctx, ctx.FormInt64("target_repo_id"))
+		if targetRepo == nil {
+			return nil
+		}
+		if err := repo_service.CheckCanSubmitChangeRequest(ctx, ctx.Doer, targetRepo); err != nil {
+			handleCheckCanSubmitChangeRequestError(ctx, err)
+			return nil
+		}
+
+		// Images go to the patch branch of the editing session, given by the editor form,
+		// which the first image creates and the change request is later made from
+		patchBranch := ctx.FormString("patch_branch")
+		if !isDoerPatchBranchName(ctx, patchBranch) {
+			ctx.JSONError(ctx.Tr("repo.editor.invalid_patch_branch"))
+			return nil
+		}
+		exists, err := git_model.IsBranchExist(ctx, targetRepo.ID, patchBranch)
+		if err != nil {
+			ctx.ServerError("IsBranchExist", err)
+			return nil
+		}
+		oldBranch := util.Iif(exists, patchBranch, targetRepo.DefaultBranch)
+		return &articleImageTarget{Repo: targetRepo, OldBranch: oldBranch, NewBranch: patchBranch, PatchBranch: patchBranch}
+	case forkAndEdit:
+		fork := handleForkAndEdit(ctx)
+		if fork == nil {
+			return nil
+		}
+		return &articleImageTarget{Repo: fork, OldBranch: fork.DefaultBranch, NewBranch: fork.DefaultBranch}
+	default:
+		// CanWriteToBranch has already been checked by the route middleware
+		return &articleImageTarget{Repo: ctx.Repo.Repository, OldBranch: ctx.Repo.BranchName, NewBranch: ctx.Repo.BranchName}
+	}
+}
+
+// isDoerPatchBranchName reports whether branchName is named like the patch branches of the doer,
+// as created by repo_service.GetUniquePatchBranchName, so that images can't be pushed to arbitrary branches.
+func isDoerPatchBranchName(ctx *context.Context, branchName string) bool {
+	suffix, ok := strings.CutPrefix(branchName, ctx.Doer.LowerName+"-patch-")
+	if !ok {
+		return false
+	}
+	n, err := strconv.Atoi(suffix)
+	return err == nil && n > 0 && strconv.Itoa(n) == suffix
+}
+
+// isDoerPatchBranch reports whether branchName is an existing patch branch of the doer in repo
+func isDoerPatchBranch(ctx *context.Context, repo *repo_model.Repository, branchName string) (bool, error) {
+	if !isDoerPatchBranchName(ctx, branchName) {
+		return false, nil
+	}
+	return git_model.IsBranchExist(ctx, repo.ID, branchName)
+}
+
+// uniqueArticleImagePath returns a path in dir for fileName that doesn't exist yet on the
+// branch the image is committed on, adding a numeric suffix to the name if needed.
+func uniqueArticleImagePath(ctx *context.Context, target *articleImageTarget, dir, fileName string) (string, error) {
+	gitRepo, err := gitrepo.OpenRepository(ctx, target.Repo)
+	if err != nil {
+		return "", err
+	}
+	defer gitRepo.Close()
+
+	commit, err := gitRepo.GetBranchCommit(target.OldBranch)
+	if err != nil {
+		return "", err
+	}
+
+	ext := path.Ext(fileName)
+	name := strings.TrimSuffix(fileName, ext)
+	for i := 0; i < maxUniqueNameAttempts; i++ {
+		candidate := path.Join(dir, fileName)
+		if i > 0 {
+			candidate = path.Join(dir, fmt.Sprintf("%s-%d%s", name, i, ext))
+		}
+		if _, err := commit.GetTreeEntryByPath(candidate); err != nil {
+			if git.IsErrNotExist(err) {
+				return candidate, nil
+			}
+			return "", err
+		}
+	}
+	return "", fmt.Errorf("no free name for %s in %s", fileName, dir)
+}
+
+// syncArticleImageBranch records the new head of a patch branch that was pushed with InternalPush,
+// which bypasses the post-receive hook that normally keeps the branch table up to date.
+func syncArticleImageBranch(ctx *context.Context, target *articleImageTarget) error {
+	gitRepo, err := gitrepo.OpenRepository(ctx, target.Repo)
+	if err != nil {
+		return err
+	}
+	defer gitRepo.Close()
+
+	commitID, err := gitRepo.GetBranchCommitID(target.NewBranch)
+	if err != nil {
+		return err
+	}
+	return repo_service.SyncBranchesToDB(ctx, target.Repo.ID, ctx.Doer.ID,
+		[]string{target.NewBranch}, []string{commitID}, gitRepo.GetCommit)
+}
+
+// collectPatchBranchImages returns the editor images that were committed to patchBranch of repo
+// before the doer decided to fork instead, so that they can be committed to the fork as well.
+func collectPatchBranchImages(ctx *context.Context, repo *repo_model.Repository, patchBranch, imageDir string) ([]*files_service.ChangeRepoFile, error) {
+	gitRepo, err := gitrepo.OpenRepository(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	defer gitRepo.Close()
+
+	changed, err := gitRepo.GetFilesChangedBetween(repo.DefaultBranch, patchBranch)
+	if err != nil {
+		return nil, err
+	}
+	commit, err := gitRepo.GetBranchCommit(patchBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*files_service.ChangeRepoFile
+	for _, treePath := range changed {
+		if path.Dir(treePath) != imageDir {
+			continue
+		}
+		content, err := commit.GetFileContent(treePath, 0)
+		if err != nil {
+			if git.IsErrNotExist(err) {
+				continue // removed again on the patch branch
+			}
+			return nil, err
+		}
+		files = append(files, &files_service.ChangeRepoFile{
+			Operation:     "upload",
+			TreePath:      treePath,
+			ContentReader: strings.NewReader(content),
+		})
+	}
+	log.Trace("collectPatchBranchImages: %d images on %s of %s", len(files), patchBranch, repo.FullName())
+	return files, nil
+}
@@ -10,6 +10,9 @@ import (
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/translation"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -17,10 +20,6 @@ import (
 func TestEditorUtils(t *testing.T) {
 	unittest.PrepareTestEnv(t)
 	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
-	t.Run("getUniquePatchBranchName", func(t *testing.T) {
-		branchName := getUniquePatchBranchName(t.Context(), "user2", repo)
-		assert.Equal(t, "user2-patch-1", branchName)
-	})
 	t.Run("getClosestParentWithFiles", func(t *testing.T) {
 		gitRepo, _ := gitrepo.OpenRepository(t.Context(), repo)
 		defer gitRepo.Close()
@@ -62,3 +61,37 @@ func TestGetUniqueRepositoryName(t *testing.T) {
 		assert.Equal(t, "repo1", name)
 	})
 }
+
+func TestParseCommitCoAuthors(t *testing.T) {
+	coAuthors, invalid := parseCommitCoAuthors([]string{
+		"Jane Doe <jane@example.com>",
+		"\"Smith, John\" <john@example.com>\n\nJANE D <JANE@example.com>",
+		"  ",
+	})
+	assert.Empty(t, invalid)
+	assert.Equal(t, []string{"Jane Doe <jane@example.com>", "Smith, John <john@example.com>"}, coAuthors)
+
+	for _, value := range []string{"jane@example.com", "<jane@example.com>", "Jane <not-an-email>", "Jane <jane@example.com"} {
+		_, invalid = parseCommitCoAuthors([]string{value})
+		assert.Equal(t, value, invalid)
+	}
+}
+
+func TestDefaultEditFileCommitMessage(t *testing.T) {
+	defer test.MockVariableValue(&setting.Repository.Article.DefaultEditMessage, "Edited article")()
+	defer test.MockVariableValue(&setting.Repository.Article.DefaultCreateMessage, "Started article")()
+	locale := translation.MockLocale{}
+
+	article := &repo_model.Repository{ID: 1, SubjectID: 1}
+	assert.Equal(t, "Edited article", defaultEditFileCommitMessage(locale, article, false, "README.md"))
+	assert.Equal(t, "Started article", defaultEditFileCommitMessage(locale, article, true, "README.md"))
+
+	// Repositories without a subject keep the file based messages
+	repo := &repo_model.Repository{ID: 2}
+	assert.Equal(t, "repo.editor.update:README.md", defaultEditFileCommitMessage(locale, repo, false, "README.md"))
+	assert.Equal(t, "repo.editor.add:README.md", defaultEditFileCommitMessage(locale, repo, true, "README.md"))
+
+	// So do articles when no article message is configured
+	defer test.MockVariableValue(&setting.Repository.Article.DefaultEditMessage, "")()
+	assert.Equal(t, "repo.editor.update:README.md", defaultEditFileCommitMessage(locale, article, false, "README.md"))
+}
@@ -10,11 +10,9 @@ import (
 	"strings"
 
 	"code.gitea.io/gitea/models/db"
-	git_model "code.gitea.io/gitea/models/git"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/git"
-	"code.gitea.io/gitea/modules/gitrepo"
 	"code.gitea.io/gitea/modules/json"
 	"code.gitea.io/gitea/modules/log"
 	repo_module "code.gitea.io/gitea/modules/repository"
@@ -25,34 +23,6 @@ import (
 // for branches or repositories before giving up.
 const maxUniqueNameAttempts = 1000
 
-// getUniquePatchBranchName Gets a unique branch name for a new patch branch
-// It will be in the form of <username>-patch-<num> where <num> is the first branch of this format
-// that doesn't already exist. If we exceed maxUniqueNameAttempts or an error is thrown, we just return "" so the user has to
-// type in the branch name themselves (will be an empty field)
-func getUniquePatchBranchName(ctx context.Context, prefixName string, repo *repo_model.Repository) string {
-	prefix := prefixName + "-patch-"
-	for i := 1; i <= maxUniqueNameAttempts; i++ {
-		branchName := fmt.Sprintf("%s%d", prefix, i)
-		// Check both the database AND the git repository for branch existence.
-		// The database might be out of sync with git (e.g., if a previous change request
-		// failed after pushing the branch but before creating the PR, or if the branch
-		// was deleted from the database but not from git).
-		if existInDB, err := git_model.IsBranchExist(ctx, repo.ID, branchName); err != nil {
-			log.Error("getUniquePatchBranchName: database check failed: %v", err)
-			return ""
-		} else if existInDB {
-			continue
-		}
-		// Also check the actual git repository to handle cases where the branch
-		// exists in git but not in the database (e.g., orphaned branches from failed operations)
-		if gitrepo.IsBranchExist(ctx, repo, branchName) {
-			continue
-		}
-		return branchName
-	}
-	return ""
-}
-
 // getClosestParentWithFiles Recursively gets the closest path of parent in a tree that has files when a file in a tree is
 // deleted. It returns "" for the tree root if no parents other than the root have files.
 func getClosestParentWithFiles(gitRepo *git.Repository, branchName, originTreePath string) string {
@@ -11,6 +11,7 @@ import (
 	"code.gitea.io/gitea/models/db"
 	git_model "code.gitea.io/gitea/models/git"
 	"code.gitea.io/gitea/models/organization"
+	access_model "code.gitea.io/gitea/models/perm/access"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/log"
@@ -19,9 +20,11 @@ import (
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/forms"
+	pull_service "code.gitea.io/gitea/services/pull"
 	repo_service "code.gitea.io/gitea/services/repository"
 )
 
@@ -238,6 +241,8 @@ func ForkRepoTo(ctx *context.Context, owner *user_model.User, forkOpts repo_serv
 			ctx.JSONError(ctx.Tr("repo.fork.tree_size_limit_reached"))
 		case repo_service.IsErrUserOwnsSubjectRepo(err):
 			ctx.JSONError(ctx.Tr("repo.fork.already_own_subject_repo"))
+		case repo_service.IsErrForkArchivedRepo(err):
+			ctx.JSONError(ctx.Tr("repo.fork.archived_repo"))
 		default:
 			ctx.ServerError("ForkPost", err)
 		}
@@ -245,3 +250,154 @@ func ForkRepoTo(ctx *context.Context, owner *user_model.User, forkOpts repo_serv
 	}
 	return repo
 }
+
+// AbandonFork lets the owner of a fork that was created by editing an article give it up,
+// either by detaching it into a standalone repository (action=detach) or by deleting it
+// (action=delete). Forks with open change requests can't be abandoned.
+func AbandonFork(ctx *context.Context) {
+	repo := ctx.Repo.Repository
+	if !repo.IsFork || ctx.Doer.ID != repo.OwnerID {
+		ctx.NotFound(nil)
+		return
+	}
+
+	var err error
+	action := ctx.FormString("action")
+	switch action {
+	case "detach":
+		err = repo_service.DetachFork(ctx, repo, ctx.FormBool("leave_subject"))
+	case "delete":
+		err = repo_service.DeleteFork(ctx, ctx.Doer, repo)
+	default:
+		ctx.HTTPError(http.StatusBadRequest, "unknown action")
+		return
+	}
+
+	articleLink := articleLinkOf(ctx, repo)
+
+	if err != nil {
+		switch {
+		case repo_service.IsErrForkHasOpenChangeRequests(err):
+			ctx.Flash.Error(ctx.Tr("repo.fork_abandon.has_change_requests"))
+		case repo_model.IsErrRootArticleAlreadyExists(err):
+			ctx.Flash.Error(ctx.Tr("repo.fork_abandon.root_exists"))
+		default:
+			ctx.ServerError("AbandonFork", err)
+			return
+		}
+		ctx.JSONRedirect(articleLink)
+		return
+	}
+
+	if action == "delete" {
+		log.Trace("Fork deleted by its owner: %s", repo.FullName())
+		ctx.Flash.Success(ctx.Tr("repo.fork_abandon.delete_success", repo.FullName()))
+		ctx.JSONRedirect(ctx.Doer.DashboardLink())
+		return
+	}
+
+	log.Trace("Fork detached by its owner: %s", repo.FullName())
+	ctx.Flash.Success(ctx.Tr("repo.fork_abandon.detach_success", repo.FullName()))
+	ctx.JSONRedirect(repo.Link())
+}
+
+// canPromoteFork reports whether the doer may promote fork to the root article of its subject,
+// which is reserved to the administrators of the current root and to site administrators
+func canPromoteFork(ctx *context.Context, fork *repo_model.Repository) (bool, error) {
+	if ctx.Doer == nil || !fork.IsFork || fork.SubjectID == 0 {
+		return false, nil
+	}
+	if ctx.Doer.IsAdmin {
+		return true, nil
+	}
+	rootRepo, err := repo_model.GetSubjectRootRepository(ctx, fork.SubjectID)
+	if err != nil {
+		if repo_model.IsErrRepoNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	perm, err := access_model.GetUserRepoPermission(ctx, rootRepo, ctx.Doer)
+	if err != nil {
+		return false, err
+	}
+	return perm.IsAdmin(), nil
+}
+
+// PromoteForkToRoot lets an administrator of the root article of a subject, or a site administrator,
+// make a fork the new root article. The former root becomes a fork of it.
+func PromoteForkToRoot(ctx *context.Context) {
+	repo := ctx.Repo.Repository
+	canPromote, err := canPromoteFork(ctx, repo)
+	if err != nil {
+		ctx.ServerError("canPromoteFork", err)
+		return
+	}
+	if !canPromote {
+		ctx.NotFound(nil)
+		return
+	}
+
+	articleLink := articleLinkOf(ctx, repo)
+	if err := repo_service.PromoteForkToRootManually(ctx, repo); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Flash.Error(ctx.Tr("repo.fork_promote.invalid"))
+			ctx.JSONRedirect(articleLink)
+			return
+		}
+		ctx.ServerError("PromoteForkToRootManually", err)
+		return
+	}
+
+	log.Trace("Fork promoted to root by %s: %s", ctx.Doer.Name, repo.FullName())
+	ctx.Flash.Success(ctx.Tr("repo.fork_promote.success", repo.FullName()))
+	ctx.JSONRedirect(articleLink)
+}
+
+// articleLinkOf returns the article page of repo, or the repository page if it has no subject
+func articleLinkOf(ctx *context.Context, repo *repo_model.Repository) string {
+	if repo.SubjectID > 0 {
+		return setting.AppSubURL + "/article/" + url.PathEscape(repo.OwnerName) + "/" + url.PathEscape(repo.GetSubject(ctx))
+	}
+	return repo.Link()
+}
+
+// PullArticleUpstream lets the owner of an article fork pull the latest changes of the original
+// article into the fork, fast-forwarding when the fork has no changes of its own and creating a
+// merge commit otherwise. Conflicting changes can't be merged automatically, so the owner is sent
+// to the compare view of the fork and the original instead.
+func PullArticleUpstream(ctx *context.Context) {
+	repo := ctx.Repo.Repository
+	if !repo.IsFork || ctx.Doer.ID != repo.OwnerID {
+		ctx.NotFound(nil)
+		return
+	}
+	if repo.IsArchived {
+		ctx.JSONError(ctx.Tr("repo.editor.article_archived"))
+		return
+	}
+
+	_, err := repo_service.MergeUpstream(ctx, ctx.Doer, repo, repo.DefaultBranch, false)
+	if err != nil {
+		switch {
+		case pull_service.IsErrMergeConflicts(err):
+			if err := repo.GetBaseRepo(ctx); err != nil {
+				ctx.ServerError("GetBaseRepo", err)
+				return
+			}
+			ctx.Flash.Warning(ctx.Tr("repo.article_upstream.conflict"))
+			ctx.JSONRedirect(repo.Link() + "/compare/" + util.PathEscapeSegments(repo.DefaultBranch) + "..." +
+				url.PathEscape(repo.BaseRepo.OwnerName) + "/" + url.PathEscape(repo.BaseRepo.Name) + ":" +
+				util.PathEscapeSegments(repo.BaseRepo.DefaultBranch))
+		case errors.Is(err, util.ErrNotExist):
+			ctx.JSONErrorNotFound()
+		default:
+			ctx.ServerError("MergeUpstream", err)
+		}
+		return
+	}
+
+	log.Trace("Fork %s pulled the latest changes of its original article", repo.FullName())
+	ctx.Flash.Success(ctx.Tr("repo.article_upstream.pull_success"))
+	ctx.JSONRedirect(articleLinkOf(ctx, repo) + "?mode=edit")
+}
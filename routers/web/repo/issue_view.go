@@ -1051,8 +1051,8 @@ func preparePullViewForkRejected(ctx *context.Context, issue *issues_model.Issue
 
 	pr := issue.PullRequest
 
-	// Only applies to closed, non-merged, same-repo PRs
-	if !issue.IsClosed || pr.HasMerged || !pr.IsSameRepo() {
+	// Only applies to non-merged, same-repo PRs, an open one is closed once forked
+	if pr.HasMerged || !pr.IsSameRepo() {
 		return
 	}
 
@@ -763,54 +763,7 @@ func ViewPullEdit(ctx *context.Context) {
 		return
 	}
 
-	// Read @README.md content from head branch, falling back to README.md only
-	// when the file genuinely does not exist. Any other error (I/O failure, git
-	// object corruption, …) is surfaced immediately so we never silently load
-	// the wrong file's content.
-	readmeTreePath := "@README.md"
-	fileContent, err := commit.GetFileContent(readmeTreePath, int(setting.UI.MaxDisplayFileSize))
-	if err != nil {
-		if !git.IsErrNotExist(err) {
-			ctx.ServerError("GetFileContent", err)
-			return
-		}
-		// @README.md does not exist — try the plain name
-		readmeTreePath = "README.md"
-		fileContent, err = commit.GetFileContent(readmeTreePath, int(setting.UI.MaxDisplayFileSize))
-		if err != nil {
-			ctx.ServerError("GetFileContent", err)
-			return
-		}
-	}
-
-	// Set context data for the editor template
-	ctx.Data["FileContent"] = fileContent
-	ctx.Data["BranchName"] = pull.HeadBranch
-	ctx.Data["ReadmeTreePath"] = readmeTreePath
-	ctx.Data["LastCommitID"] = headCommitID
-	ctx.Data["RepoOperationsLink"] = pull.HeadRepo.OperationsLink()
-
-	ctx.Data["IsIssuePoster"] = true
-	ctx.Data["HasIssuesOrPullsWritePermission"] = ctx.Repo.CanWriteIssuesOrPulls(issue.IsPull)
-
-	// Populate PR header metadata (HeadTarget, BaseTarget, NumCommits, branch links, etc.)
-	// required by view_title and tab_menu templates.  Without this the PR description
-	// line shows empty branch names and the files-tab commit count stays at zero.
-	prInfo := preparePullViewPullInfo(ctx, issue)
-	if ctx.Written() {
-		return
-	} else if prInfo == nil {
-		ctx.NotFound(nil)
-		return
-	}
-
-	PrepareBranchList(ctx)
-	if ctx.Written() {
-		return
-	}
-	getBranchData(ctx, issue)
-
-	ctx.HTML(http.StatusOK, tplPullEdit)
+	renderPullArticleEditor(ctx, issue, pull, commit)
 }
 
 // SubmitPullEditPost handles form submission from the PR edit page.
@@ -897,9 +850,171 @@ func SubmitPullEditPost(ctx *context.Context) {
 		return
 	}
 
+	if !commitPullArticleEdit(ctx, issue, pull, headGitRepo, headBranchCommitID) {
+		return
+	}
+
+	// Redirect back to the PR conversation page
+	ctx.JSONRedirect(issue.Link())
+}
+
+// canOwnerEditPull reports whether the doer may revise an open change request's
+// content directly, as the owner or a maintainer of the target repository.
+// Only same-repo change requests qualify: their patch branch lives in the
+// target repository, so the doer already has write access to it.
+func canOwnerEditPull(ctx *context.Context, issue *issues_model.Issue) bool {
+	pull := issue.PullRequest
+	if !ctx.IsSigned || pull == nil || issue.IsClosed || pull.HasMerged {
+		return false
+	}
+	if pull.HeadRepoID != pull.BaseRepoID || pull.BaseRepoID != ctx.Repo.Repository.ID {
+		return false
+	}
+	return ctx.Repo.CanWrite(unit.TypeCode)
+}
+
+// prepareOwnerEditPull runs the shared access checks for the owner edit
+// handlers and opens the head repository. The caller must close the returned
+// git repository. Returns nil if a response has been written.
+func prepareOwnerEditPull(ctx *context.Context) (*issues_model.Issue, *git.Repository) {
+	issue, ok := getPullInfo(ctx)
+	if !ok {
+		return nil, nil
+	}
+	if !canOwnerEditPull(ctx, issue) {
+		ctx.NotFound(nil)
+		return nil, nil
+	}
+
+	pull := issue.PullRequest
+	if err := pull.LoadHeadRepo(ctx); err != nil {
+		ctx.ServerError("LoadHeadRepo", err)
+		return nil, nil
+	}
+	if pull.HeadRepo == nil {
+		ctx.NotFound(nil)
+		return nil, nil
+	}
+
+	headGitRepo, err := gitrepo.OpenRepository(ctx, pull.HeadRepo)
+	if err != nil {
+		ctx.ServerError("OpenRepository", err)
+		return nil, nil
+	}
+	if !headGitRepo.IsBranchExist(pull.HeadBranch) {
+		headGitRepo.Close()
+		ctx.NotFound(nil)
+		return nil, nil
+	}
+	return issue, headGitRepo
+}
+
+// ViewPullOwnerEdit renders the article editor so the target repository's
+// owner or maintainers can tweak a change request before merging it.
+func ViewPullOwnerEdit(ctx *context.Context) {
+	ctx.Data["PageIsPullList"] = true
+	ctx.Data["PageIsPullOwnerEdit"] = true
+
+	issue, headGitRepo := prepareOwnerEditPull(ctx)
+	if ctx.Written() {
+		return
+	}
+	defer headGitRepo.Close()
+
+	commit, err := headGitRepo.GetBranchCommit(issue.PullRequest.HeadBranch)
+	if err != nil {
+		ctx.ServerError("GetBranchCommit", err)
+		return
+	}
+
+	renderPullArticleEditor(ctx, issue, issue.PullRequest, commit)
+}
+
+// SubmitPullOwnerEditPost commits the owner's revision onto the change
+// request's patch branch. The PR diff and commit list follow the new head.
+func SubmitPullOwnerEditPost(ctx *context.Context) {
+	issue, headGitRepo := prepareOwnerEditPull(ctx)
+	if ctx.Written() {
+		return
+	}
+	defer headGitRepo.Close()
+
+	headBranchCommitID, err := headGitRepo.GetBranchCommitID(issue.PullRequest.HeadBranch)
+	if err != nil {
+		ctx.ServerError("GetBranchCommitID", err)
+		return
+	}
+
+	if !commitPullArticleEdit(ctx, issue, issue.PullRequest, headGitRepo, headBranchCommitID) {
+		return
+	}
+
+	ctx.JSONRedirect(issue.Link())
+}
+
+// renderPullArticleEditor renders the article editor for the PR head branch at
+// the given commit. Callers are responsible for access checks.
+func renderPullArticleEditor(ctx *context.Context, issue *issues_model.Issue, pull *issues_model.PullRequest, commit *git.Commit) {
+	headCommitID := commit.ID.String()
+
+	// Read @README.md content from head branch, falling back to README.md only
+	// when the file genuinely does not exist. Any other error (I/O failure, git
+	// object corruption, …) is surfaced immediately so we never silently load
+	// the wrong file's content.
+	readmeTreePath := "@README.md"
+	fileContent, err := commit.GetFileContent(readmeTreePath, int(setting.UI.MaxDisplayFileSize))
+	if err != nil {
+		if !git.IsErrNotExist(err) {
+			ctx.ServerError("GetFileContent", err)
+			return
+		}
+		// @README.md does not exist — try the plain name
+		readmeTreePath = "README.md"
+		fileContent, err = commit.GetFileContent(readmeTreePath, int(setting.UI.MaxDisplayFileSize))
+		if err != nil {
+			ctx.ServerError("GetFileContent", err)
+			return
+		}
+	}
+
+	// Set context data for the editor template
+	ctx.Data["FileContent"] = fileContent
+	ctx.Data["BranchName"] = pull.HeadBranch
+	ctx.Data["ReadmeTreePath"] = readmeTreePath
+	ctx.Data["LastCommitID"] = headCommitID
+	ctx.Data["RepoOperationsLink"] = pull.HeadRepo.OperationsLink()
+
+	ctx.Data["IsIssuePoster"] = issue.IsPoster(ctx.Doer.ID)
+	ctx.Data["HasIssuesOrPullsWritePermission"] = ctx.Repo.CanWriteIssuesOrPulls(issue.IsPull)
+
+	// Populate PR header metadata (HeadTarget, BaseTarget, NumCommits, branch links, etc.)
+	// required by view_title and tab_menu templates.  Without this the PR description
+	// line shows empty branch names and the files-tab commit count stays at zero.
+	prInfo := preparePullViewPullInfo(ctx, issue)
+	if ctx.Written() {
+		return
+	} else if prInfo == nil {
+		ctx.NotFound(nil)
+		return
+	}
+
+	PrepareBranchList(ctx)
+	if ctx.Written() {
+		return
+	}
+	getBranchData(ctx, issue)
+
+	ctx.HTML(http.StatusOK, tplPullEdit)
+}
+
+// commitPullArticleEdit commits the submitted article content onto the PR head
+// branch, syncs refs/pull/N/head, records the push on the timeline and marks
+// existing reviews stale. Callers are responsible for access checks.
+// Returns false if an error response has been written.
+func commitPullArticleEdit(ctx *context.Context, issue *issues_model.Issue, pull *issues_model.PullRequest, headGitRepo *git.Repository, headBranchCommitID string) bool {
 	if err := pull.LoadBaseRepo(ctx); err != nil {
 		ctx.ServerError("LoadBaseRepo", err)
-		return
+		return false
 	}
 	// CreatePushPullComment reads pr.Issue to attach the timeline comment.
 	pull.Issue = issue
@@ -908,7 +1023,7 @@ func SubmitPullEditPost(ctx *context.Context) {
 	content := ctx.Req.FormValue("content")
 	if strings.TrimSpace(content) == "" {
 		ctx.JSONError(ctx.Tr("repo.editor.content_required"))
-		return
+		return false
 	}
 	lastCommitID := ctx.Req.FormValue("last_commit")
 	commitSummary := strings.TrimSpace(ctx.Req.FormValue("commit_summary"))
@@ -921,7 +1036,7 @@ func SubmitPullEditPost(ctx *context.Context) {
 	headCommit, err := headGitRepo.GetCommit(headBranchCommitID)
 	if err != nil {
 		ctx.ServerError("GetCommit", err)
-		return
+		return false
 	}
 	// Probe candidates in priority order. Only skip to the next candidate when
 	// the file genuinely does not exist; any other error (I/O failure, git
@@ -936,12 +1051,12 @@ func SubmitPullEditPost(ctx *context.Context) {
 		}
 		if !git.IsErrNotExist(fileErr) {
 			ctx.ServerError("GetFileContent", fileErr)
-			return
+			return false
 		}
 	}
 	if treePath == "" {
 		ctx.JSONError(ctx.Tr("repo.editor.file_not_found"))
-		return
+		return false
 	}
 
 	// Build commit message
@@ -956,7 +1071,7 @@ func SubmitPullEditPost(ctx *context.Context) {
 	// ChangeRepoFiles checks the DB for branch existence and would fail without this.
 	if _, err = repo_module.SyncRepoBranches(ctx, pull.HeadRepo.ID, 0); err != nil {
 		ctx.ServerError("SyncRepoBranches", err)
-		return
+		return false
 	}
 
 	// Commit changes to the PR head branch
@@ -979,19 +1094,19 @@ func SubmitPullEditPost(ctx *context.Context) {
 		if files_service.IsErrCommitIDDoesNotMatch(err) || git.IsErrPushOutOfDate(err) {
 			// Stale commit ID or concurrent push — user should refresh and retry
 			ctx.JSONError(ctx.Tr("repo.pulls.edit.already_changed"))
-			return
+			return false
 		}
 		if files_service.IsErrRepoFileDoesNotExist(err) {
 			ctx.JSONError(ctx.Tr("repo.editor.file_modifying_no_longer_exists", treePath))
-			return
+			return false
 		}
 		if git.IsErrNotExist(err) {
 			ctx.JSONError(ctx.Tr("repo.editor.file_modifying_no_longer_exists", treePath))
-			return
+			return false
 		}
 		// All other errors are treated as server errors
 		ctx.ServerError("ChangeRepoFiles", err)
-		return
+		return false
 	}
 
 	// Update refs/pull/N/head to point at the new commit.  ChangeRepoFiles used
@@ -1000,7 +1115,7 @@ func SubmitPullEditPost(ctx *context.Context) {
 	// reads a stale ref, treats the PR as broken, and shows the wrong commit
 	// count and diff.
 	if err := pull_service.PushToBaseRepo(ctx, pull); err != nil {
-		log.Error("SubmitPullEditPost: PushToBaseRepo: %v", err)
+		log.Error("commitPullArticleEdit: PushToBaseRepo: %v", err)
 		// Non-fatal: the edit was saved; warn the user so they know the PR
 		// display (diff, commit count) may be stale until the ref is corrected
 		// by the next PR interaction.
@@ -1013,7 +1128,7 @@ func SubmitPullEditPost(ctx *context.Context) {
 		newCommitID := filesResponse.Commit.SHA
 		pushComment, err := pull_service.CreatePushPullComment(ctx, ctx.Doer, pull, lastCommitID, newCommitID, false)
 		if err != nil {
-			log.Error("SubmitPullEditPost: CreatePushPullComment: %v", err)
+			log.Error("commitPullArticleEdit: CreatePushPullComment: %v", err)
 		} else if pushComment != nil {
 			notify_service.PullRequestPushCommits(ctx, ctx.Doer, pull, pushComment)
 		}
@@ -1045,8 +1160,7 @@ func SubmitPullEditPost(ctx *context.Context) {
 		ctx.Flash.Warning(ctx.Locale.Tr("repo.pulls.edit.reviews_stale_failed"))
 	}
 
-	// Redirect back to the PR conversation page
-	ctx.JSONRedirect(issue.Link())
+	return true
 }
 
 func indexCommit(commits []*git.Commit, commitID string) *git.Commit {
@@ -1073,6 +1187,8 @@ func preparePullEditTabVisibility(ctx *context.Context, issue *issues_model.Issu
 	if !issue.IsPull || issue.PullRequest == nil || issue.IsClosed || issue.PullRequest.HasMerged {
 		return nil
 	}
+	ctx.Data["CanOwnerEditPull"] = canOwnerEditPull(ctx, issue)
+
 	reviews, err := issues_model.FindReviews(ctx, issues_model.FindReviewOptions{
 		IssueID:   issue.ID,
 		Types:     []issues_model.ReviewType{issues_model.ReviewTypeReject},
@@ -3145,8 +3261,9 @@ func ForkRejectedChanges(ctx *context.Context) {
 
 	pr := issue.PullRequest
 
-	// Validate: must be a closed, non-merged, same-repo PR
-	if !issue.IsClosed || pr.HasMerged || !pr.IsSameRepo() {
+	// Validate: must be a non-merged, same-repo PR. An open PR is closed once
+	// forked, its author keeps their version instead of waiting for the owner.
+	if pr.HasMerged || !pr.IsSameRepo() {
 		ctx.NotFound(nil)
 		return
 	}
@@ -3261,6 +3378,14 @@ func ForkRejectedChanges(ctx *context.Context) {
 		// Non-fatal: the fork succeeded, timeline comment is best-effort
 	}
 
+	// The changes now live in the fork, an open CR has nothing left to merge
+	if !issue.IsClosed {
+		if err := issue_service.CloseIssue(ctx, issue, ctx.Doer, ""); err != nil {
+			log.Error("ForkRejectedChanges: failed to close CR #%d: %v", pr.Index, err)
+			// Non-fatal: the fork succeeded, the CR can still be closed by hand
+		}
+	}
+
 	// Auto-delete the CR head branch from the base repo now that the changes
 	// have been safely forked. Use SkipPermissionCheck because the contributor
 	// (PR author) typically doesn't have write access to the base repo — the
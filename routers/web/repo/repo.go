@@ -197,6 +197,9 @@ func handleCreateError(ctx *context.Context, owner *user_model.User, err error,
 	case repo_model.IsErrRepoSubjectGloballyTaken(err):
 		ctx.Data["Err_Subject"] = true
 		ctx.RenderWithErr(ctx.Tr("repo.form.subject_globally_taken"), tpl, form)
+	case repo_model.IsErrSubjectDeleted(err):
+		ctx.Data["Err_Subject"] = true
+		ctx.RenderWithErr(ctx.Tr("repo.form.subject_deleted"), tpl, form)
 	case repo_model.IsErrReachLimitOfRepo(err):
 		maxCreationLimit := owner.MaxCreationLimit()
 		msg := ctx.TrN(maxCreationLimit, "repo.form.reach_limit_of_creation_1", "repo.form.reach_limit_of_creation_n", maxCreationLimit)
@@ -347,6 +350,10 @@ func handleActionError(ctx *context.Context, err error) {
 		ctx.Flash.Error(ctx.TrN(limit, "repo.form.reach_limit_of_creation_1", "repo.form.reach_limit_of_creation_n", limit))
 	case errors.Is(err, util.ErrPermissionDenied):
 		ctx.HTTPError(http.StatusNotFound)
+	case repo_service.IsErrUserOwnsSubjectRepo(err):
+		ctx.Flash.Error(ctx.Tr("repo.settings.new_owner_has_subject_repo"))
+	case repo_service.IsErrForkAlreadyExist(err):
+		ctx.Flash.Error(ctx.Tr("repo.settings.new_owner_has_fork"))
 	default:
 		ctx.ServerError(fmt.Sprintf("Action (%s)", ctx.PathParam("action")), err)
 	}
@@ -668,6 +675,11 @@ func CreateFirstArticle(ctx *context.Context) {
 	// Get or create the subject
 	subject, err := repo_model.GetOrCreateSubject(ctx, subjectName)
 	if err != nil {
+		if repo_model.IsErrSubjectDeleted(err) {
+			ctx.Flash.Error(ctx.Tr("repo.form.subject_deleted"))
+			ctx.Redirect(setting.AppSubURL + "/")
+			return
+		}
 		ctx.ServerError("GetOrCreateSubject", err)
 		return
 	}
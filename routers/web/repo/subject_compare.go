@@ -6,7 +6,6 @@ package repo
 import (
 	"errors"
 	"net/http"
-	"strconv"
 	"strings"
 	"time"
 
@@ -19,8 +18,7 @@ import (
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/gitdiff"
-
-	"github.com/sergi/go-diff/diffmatchpatch"
+	repo_service "code.gitea.io/gitea/services/repository"
 )
 
 // readmeFileNames is the list of README file names to search for, in priority order
@@ -350,80 +348,7 @@ type SplitViewLine struct {
 
 // buildDiffLines creates DiffLines by comparing two sets of lines
 func buildDiffLines(lines1, lines2 []string) []*gitdiff.DiffLine {
-	// Use a simple line-by-line diff algorithm (LCS-based)
-	diffLines := make([]*gitdiff.DiffLine, 0)
-
-	// Add section header
-	diffLines = append(diffLines, &gitdiff.DiffLine{
-		Type:    gitdiff.DiffLineSection,
-		Content: "@@ -1," + strconv.Itoa(len(lines1)) + " +1," + strconv.Itoa(len(lines2)) + " @@",
-		SectionInfo: &gitdiff.DiffLineSectionInfo{
-			Path:          "README.md",
-			LastLeftIdx:   0,
-			LastRightIdx:  0,
-			LeftIdx:       1,
-			RightIdx:      1,
-			LeftHunkSize:  len(lines1),
-			RightHunkSize: len(lines2),
-		},
-	})
-
-	// Use Myers diff algorithm via diffmatchpatch for line-level comparison
-	dmp := diffmatchpatch.New()
-
-	// Join lines with a unique separator for line-based diff
-	text1 := strings.Join(lines1, "\n")
-	text2 := strings.Join(lines2, "\n")
-
-	// Get line-based diff
-	a, b, lineArray := dmp.DiffLinesToChars(text1, text2)
-	diffs := dmp.DiffMain(a, b, false)
-	diffs = dmp.DiffCharsToLines(diffs, lineArray)
-	diffs = dmp.DiffCleanupSemantic(diffs)
-
-	leftIdx := 1
-	rightIdx := 1
-
-	for _, d := range diffs {
-		lines := strings.SplitSeq(strings.TrimSuffix(d.Text, "\n"), "\n")
-		for line := range lines {
-			if line == "" && d.Text == "" {
-				continue
-			}
-			switch d.Type {
-			case diffmatchpatch.DiffEqual:
-				diffLines = append(diffLines, &gitdiff.DiffLine{
-					LeftIdx:  leftIdx,
-					RightIdx: rightIdx,
-					Type:     gitdiff.DiffLinePlain,
-					Content:  " " + line,
-					Match:    0,
-				})
-				leftIdx++
-				rightIdx++
-			case diffmatchpatch.DiffDelete:
-				diffLines = append(diffLines, &gitdiff.DiffLine{
-					LeftIdx:  leftIdx,
-					RightIdx: 0,
-					Type:     gitdiff.DiffLineDel,
-					Content:  "-" + line,
-					Match:    -1,
-				})
-				leftIdx++
-			case diffmatchpatch.DiffInsert:
-				diffLines = append(diffLines, &gitdiff.DiffLine{
-					LeftIdx:  0,
-					RightIdx: rightIdx,
-					Type:     gitdiff.DiffLineAdd,
-					Content:  "+" + line,
-					Match:    -1,
-				})
-				rightIdx++
-			}
-		}
-	}
-
-	return diffLines
+	return repo_service.BuildReadmeDiffLines(lines1, lines2)
 }
 
 // pairDiffLinesForSplitView pairs delete/add lines for side-by-side rendering
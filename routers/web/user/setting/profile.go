@@ -236,6 +236,13 @@ func Repos(ctx *context.Context) {
 	ctx.Data["allowAdopt"] = ctx.IsUserSiteAdmin() || setting.Repository.AllowAdoptionOfUnadoptedRepositories
 	ctx.Data["allowDelete"] = ctx.IsUserSiteAdmin() || setting.Repository.AllowDeleteOfUnadoptedRepositories
 
+	forkAfterMerge, err := user_model.GetUserSetting(ctx, ctx.Doer.ID, user_model.SettingsKeyForkAfterMerge, user_model.SettingForkAfterMergeKeep)
+	if err != nil {
+		ctx.ServerError("GetUserSetting", err)
+		return
+	}
+	ctx.Data["ForkAfterMergePreference"] = forkAfterMerge
+
 	opts := db.ListOptions{
 		PageSize: setting.UI.Admin.UserPagingNum,
 		Page:     ctx.FormInt("page"),
@@ -335,6 +342,24 @@ func Repos(ctx *context.Context) {
 	ctx.HTML(http.StatusOK, tplSettingsRepositories)
 }
 
+// ReposForkAfterMergePost sets what happens to the user's forks once their change requests are merged
+func ReposForkAfterMergePost(ctx *context.Context) {
+	preference := ctx.FormString("preference")
+	if !(preference == user_model.SettingForkAfterMergeKeep ||
+		preference == user_model.SettingForkAfterMergeConvert ||
+		preference == user_model.SettingForkAfterMergeDelete) {
+		ctx.Flash.Error(ctx.Tr("invalid_data", preference))
+		ctx.Redirect(setting.AppSubURL + "/user/settings/repos")
+		return
+	}
+	if err := user_model.SetUserSetting(ctx, ctx.Doer.ID, user_model.SettingsKeyForkAfterMerge, preference); err != nil {
+		ctx.ServerError("SetUserSetting", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("settings.fork_after_merge.success"))
+	ctx.Redirect(setting.AppSubURL + "/user/settings/repos")
+}
+
 // Appearance render user's appearance settings
 func Appearance(ctx *context.Context) {
 	ctx.Data["Title"] = ctx.Tr("settings.appearance")
@@ -251,6 +251,7 @@ func registerRepoFileEditorRoutes(m *web.Router, reqRepoCodeWriter func(*context
 			m.Combo("/{editor_action:_upload}/*", repo.MustBeAbleToUpload).
 				Get(repo.UploadFile).
 				Post(web.Bind(forms.UploadRepoFileForm{}), canWriteToBranch, repo.UploadFilePost)
+			m.Post("/{editor_action:_image}/*", repo.MustBeAbleToUpload, canWriteToBranch, repo.UploadArticleImagePost)
 			m.Combo("/{editor_action:_diffpatch}/*").
 				Get(repo.NewDiffPatch).
 				Post(web.Bind(forms.EditRepoFileForm{}), canWriteToBranch, repo.NewDiffPatchPost)
@@ -302,6 +303,7 @@ func Routes() *web.Router {
 	}
 
 	routes.Methods("GET,HEAD", "/robots.txt", append(mid, misc.RobotsTxt)...)
+	routes.Methods("GET,HEAD", "/opensearch.xml", append(mid, misc.OpenSearch)...)
 	routes.Get("/ssh_info", misc.SSHInfo)
 	routes.Get("/api/healthz", healthcheck.Check)
 
@@ -533,6 +535,7 @@ func registerWebRoutes(m *web.Router) {
 
 	m.Get("/subject/{subjectname}", optSignIn, context.RepoAssignmentBySubject, context.RepoRefByDefaultBranch(), repo.SetEditorconfigIfExists, explore.RepoHistory)
 	m.Get("/subject/{subjectname}/compare/{owners}", optSignIn, repo.CompareReadme)
+	m.Get("/subject/{subjectname}/search", optSignIn, context.RepoAssignmentBySubject, explore.SubjectArticleSearch)
 
 	m.Group("/explore", func() {
 		m.Get("", func(ctx *context.Context) {
@@ -540,9 +543,10 @@ func registerWebRoutes(m *web.Router) {
 		})
 		m.Get("/articles", explore.Repos)
 		m.Get("/subjects", explore.Subjects)
+		m.Get("/subjects/search", explore.SubjectSearch)
 		m.Get("/articles/history/{username}/{reponame}", optSignIn, context.RepoAssignment, context.RepoRefByDefaultBranch(), repo.SetEditorconfigIfExists, explore.RepoHistory)
 		m.Get("/articles/sitemap-{idx}.xml", sitemapEnabled, explore.Repos)
-		m.Get("/subjects/sitemap-{idx}.xml", sitemapEnabled, explore.Subjects)
+		m.Get("/subjects/sitemap-{idx}.xml", sitemapEnabled, explore.SubjectsSitemap)
 		m.Get("/users", explore.Users)
 		m.Get("/users/sitemap-{idx}.xml", sitemapEnabled, explore.Users)
 		m.Get("/organizations", explore.Organizations)
@@ -715,6 +719,7 @@ func registerWebRoutes(m *web.Router) {
 		m.Get("/organization", user_setting.Organization)
 		m.Get("/repos", user_setting.Repos)
 		m.Post("/repos/unadopted", user_setting.AdoptOrDeleteRepository)
+		m.Post("/repos/fork_after_merge", user_setting.ReposForkAfterMergePost)
 
 		m.Group("/hooks", func() {
 			m.Get("", user_setting.Webhooks)
@@ -814,6 +819,16 @@ func registerWebRoutes(m *web.Router) {
 			m.Post("/delete", admin.DeleteRepo)
 		})
 
+		m.Group("/subjects", func() {
+			m.Get("", admin.SubjectHealth)
+			m.Post("/promote_root", admin.PromoteSubjectRoot)
+			m.Post("/repair_orphaned_forks", admin.RepairOrphanedForks)
+			m.Post("/approve_root_promotion", admin.ApproveRootPromotion)
+			m.Post("/reject_root_promotion", admin.RejectRootPromotion)
+			m.Post("/feature", admin.FeatureSubject)
+			m.Post("/unfeature", admin.UnfeatureSubject)
+		})
+
 		m.Group("/packages", func() {
 			m.Get("", admin.Packages)
 			m.Post("/delete", admin.DeletePackageVersion)
@@ -1220,6 +1235,18 @@ func registerWebRoutes(m *web.Router) {
 	m.Get("/article/repo/{username}/{reponame}", optSignIn, context.RepoAssignment, context.RepoRefByType(git.RefTypeBranch), repo.SetEditorconfigIfExists, explore.RepoHistory)
 	// Article route - shows commit view if version parameter is present, otherwise shows home
 	m.Get("/article/{username}/{subjectname}", optSignIn, context.RepoAssignmentByOwnerAndSubject, repo.ArticleView)
+	m.Get("/article/{username}/{subjectname}/embed", optSignIn, context.RepoAssignmentByOwnerAndSubject, repo.ArticleEmbed)
+
+	m.Post("/article/{username}/{subjectname}/abandon", reqSignIn, context.RepoAssignmentByOwnerAndSubject, repo.AbandonFork)
+	m.Post("/article/{username}/{subjectname}/promote", reqSignIn, context.RepoAssignmentByOwnerAndSubject, repo.PromoteForkToRoot)
+	m.Post("/article/{username}/{subjectname}/pull-upstream", reqSignIn, context.RepoAssignmentByOwnerAndSubject, repo.PullArticleUpstream)
+	m.Post("/article/{username}/{subjectname}/revert", reqSignIn, context.RepoAssignmentByOwnerAndSubject, reqUnitCodeReader, repo.RevertArticleVersion)
+	m.Post("/article/{username}/{subjectname}/{action:watch|unwatch}", reqSignIn, context.RepoAssignmentByOwnerAndSubject, reqUnitCodeReader, repo.ActionWatchArticle)
+	m.Group("/article/{username}/{subjectname}/suggestions", func() {
+		m.Post("", repo.CreateArticleSuggestionPost)
+		m.Post("/{id}/accept", repo.AcceptArticleSuggestion)
+		m.Post("/{id}/dismiss", repo.DismissArticleSuggestion)
+	}, reqSignIn, context.RepoAssignmentByOwnerAndSubject, reqUnitCodeReader)
 
 	// Article-based file operation routes - mirror the repository-based routes but use subject name
 	m.Group("/article/{username}/{subjectname}", func() {
@@ -1250,6 +1277,8 @@ func registerWebRoutes(m *web.Router) {
 			m.Post("/conflicts", context.RepoMustNotBeArchived(), repo.SubmitConflictResolution)
 			m.Get("/edit", repo.ViewPullEdit)
 			m.Post("/edit", context.RepoMustNotBeArchived(), repo.SubmitPullEditPost)
+			m.Get("/owner_edit", repo.ViewPullOwnerEdit)
+			m.Post("/owner_edit", context.RepoMustNotBeArchived(), repo.SubmitPullOwnerEditPost)
 			m.Group("/files", func() {
 				m.Get("", repo.SetEditorconfigIfExists, repo.SetDiffViewStyle, repo.SetWhitespaceBehavior, repo.SetShowOutdatedComments, repo.ViewPullFilesForAllCommitsOfPr)
 				m.Get("/{shaFrom:[a-f0-9]{7,64}}..{shaTo:[a-f0-9]{7,64}}", repo.SetEditorconfigIfExists, repo.SetDiffViewStyle, repo.SetWhitespaceBehavior, repo.SetShowOutdatedComments, repo.ViewPullFilesForRange)
@@ -1629,6 +1658,8 @@ func registerWebRoutes(m *web.Router) {
 			m.Post("/conflicts", context.RepoMustNotBeArchived(), repo.SubmitConflictResolution)
 			m.Get("/edit", repo.ViewPullEdit)
 			m.Post("/edit", context.RepoMustNotBeArchived(), repo.SubmitPullEditPost)
+			m.Get("/owner_edit", repo.ViewPullOwnerEdit)
+			m.Post("/owner_edit", context.RepoMustNotBeArchived(), repo.SubmitPullOwnerEditPost)
 			m.Group("/files", func() {
 				m.Get("", repo.SetEditorconfigIfExists, repo.SetDiffViewStyle, repo.SetWhitespaceBehavior, repo.SetShowOutdatedComments, repo.ViewPullFilesForAllCommitsOfPr)
 				m.Get("/{shaFrom:[a-f0-9]{7,64}}..{shaTo:[a-f0-9]{7,64}}", repo.SetEditorconfigIfExists, repo.SetDiffViewStyle, repo.SetWhitespaceBehavior, repo.SetShowOutdatedComments, repo.ViewPullFilesForRange)
@@ -327,6 +327,13 @@ Loop:
 	return true, signingKey, sig, nil
 }
 
+// CRUDActionsAlwaysSign reports whether the CRUD_ACTIONS signing rules are "always", in which case
+// users can't choose to leave their commits unsigned
+func CRUDActionsAlwaysSign() bool {
+	rules := signingModeFromStrings(setting.Repository.Signing.CRUDActions)
+	return len(rules) == 1 && rules[0] == always
+}
+
 // SignMerge determines if we should sign a PR merge commit to the base repository
 func SignMerge(ctx context.Context, pr *issues_model.PullRequest, u *user_model.User, tmpBasePath, baseCommit, headCommit string) (bool, *git.SigningKey, *git.Signature, error) {
 	if err := pr.LoadBaseRepo(ctx); err != nil {
@@ -40,19 +40,19 @@ func CanWriteToBranch() func(ctx *Context) {
 	return func(ctx *Context) {
 		editorAction := ctx.PathParam("editor_action")
 
-		// Allow fork-and-edit workflow to bypass write permission check for _edit and _new
+		// Allow fork-and-edit workflow to bypass write permission check for _edit, _new and _image
 		// The handler will create a personal fork and commit to that instead
 		if ctx.Req.FormValue("fork_and_edit") == "true" {
-			if editorAction == "_edit" || editorAction == "_new" {
+			if editorAction == "_edit" || editorAction == "_new" || editorAction == "_image" {
 				return
 			}
 		}
 
-		// Allow submit-change-request workflow to bypass write permission check for _edit only
+		// Allow submit-change-request workflow to bypass write permission check for _edit and _image
 		// This workflow creates an in-repo branch and PR to propose changes to existing articles
 		// It does NOT support _new - creating new files should be done in the user's own repository
 		if ctx.Req.FormValue("submit_change_request") == "true" {
-			if editorAction == "_edit" {
+			if editorAction == "_edit" || editorAction == "_image" {
 				return
 			}
 			// For _new action with submit_change_request, fall through to permission check
@@ -755,6 +755,19 @@ func ToTopicResponse(topic *repo_model.Topic) *api.TopicResponse {
 	}
 }
 
+// ToSubject convert from repo_model.Subject to api.Subject
+func ToSubject(subject *repo_model.Subject) *api.Subject {
+	return &api.Subject{
+		ID:          subject.ID,
+		Name:        subject.Name,
+		Slug:        subject.Slug,
+		Description: subject.Description,
+		Lang:        subject.Lang,
+		Created:     subject.CreatedUnix.AsTime(),
+		Updated:     subject.UpdatedUnix.AsTime(),
+	}
+}
+
 // ToOAuth2Application convert from auth.OAuth2Application to api.OAuth2Application
 func ToOAuth2Application(app *auth.OAuth2Application) *api.OAuth2Application {
 	return &api.OAuth2Application{
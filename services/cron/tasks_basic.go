@@ -9,9 +9,11 @@ import (
 
 	"code.gitea.io/gitea/models"
 	git_model "code.gitea.io/gitea/models/git"
+	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/models/webhook"
 	"code.gitea.io/gitea/modules/git/gitcmd"
+	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/services/auth"
 	"code.gitea.io/gitea/services/migrations"
@@ -166,12 +168,80 @@ func registerSyncRepoLicenses() {
 	})
 }
 
+func registerReconcileSubjectCounts() {
+	RegisterTaskFatal("reconcile_subject_counts", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: true,
+		Schedule:   "@midnight",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		fixed, err := repo_model.ReconcileSubjectCounts(ctx)
+		if fixed > 0 {
+			log.Info("Fixed the repository counts of %d subjects", fixed)
+		}
+		return err
+	})
+}
+
+func registerRegenerateSubjectSlugs() {
+	RegisterTaskFatal("regenerate_subject_slugs", &BaseConfig{
+		Enabled:    false,
+		RunAtStart: false,
+		Schedule:   "@annually",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		changed, err := repo_model.RegenerateSubjectSlugs(ctx)
+		if changed > 0 {
+			log.Info("Changed the slugs of %d subjects", changed)
+		}
+		return err
+	})
+}
+
+func registerPruneEmptySubjects() {
+	RegisterTaskFatal("prune_empty_subjects", &OlderThanConfig{
+		BaseConfig: BaseConfig{
+			Enabled:    false,
+			RunAtStart: false,
+			Schedule:   "@weekly",
+		},
+		OlderThan: 30 * 24 * time.Hour,
+	}, func(ctx context.Context, _ *user_model.User, config Config) error {
+		realConfig := config.(*OlderThanConfig)
+		result, err := repo_model.PruneEmptySubjects(ctx, realConfig.OlderThan)
+		if result.Pruned > 0 || result.Kept > 0 {
+			log.Info("Pruned %d empty subjects, kept %d featured or redirected ones", result.Pruned, result.Kept)
+		}
+		return err
+	})
+}
+
+func registerArchiveInactiveForks() {
+	RegisterTaskFatal("archive_inactive_forks", &OlderThanConfig{
+		BaseConfig: BaseConfig{
+			Enabled:    false,
+			RunAtStart: false,
+			Schedule:   "@weekly",
+		},
+		OlderThan: 180 * 24 * time.Hour,
+	}, func(ctx context.Context, _ *user_model.User, config Config) error {
+		realConfig := config.(*OlderThanConfig)
+		archived, err := repo_service.ArchiveInactiveForks(ctx, realConfig.OlderThan)
+		if archived > 0 {
+			log.Info("Archived %d forks without activity for %s", archived, realConfig.OlderThan)
+		}
+		return err
+	})
+}
+
 func initBasicTasks() {
 	if setting.Mirror.Enabled {
 		registerUpdateMirrorTask()
 	}
 	registerRepoHealthCheck()
 	registerCheckRepoStats()
+	registerReconcileSubjectCounts()
+	registerRegenerateSubjectSlugs()
+	registerPruneEmptySubjects()
+	registerArchiveInactiveForks()
 	registerArchiveCleanup()
 	registerSyncExternalUsers()
 	registerDeletedBranchesCleanup()
@@ -166,6 +166,18 @@ func prepareDBConsistencyChecks() []consistencyCheck {
 			Fixer:        repo_model.DeleteOrphanedTopics,
 			FixedMessage: "Removed",
 		},
+		{
+			Name:         "Subjects with a stale root repository pointer",
+			Counter:      repo_model.CountSubjectsWithStaleRoot,
+			Fixer:        repo_model.ClearStaleSubjectRoots,
+			FixedMessage: "Cleared",
+		},
+		{
+			Name:         "Subject repositories without the license of their root repository",
+			Counter:      repo_model.CountSubjectReposWithoutRootLicense,
+			Fixer:        repo_model.FixSubjectReposWithoutRootLicense,
+			FixedMessage: "Fixed",
+		},
 		{
 			Name:         "Repository level Runners with non-zero owner_id",
 			Counter:      actions_model.CountWrongRepoLevelRunners,
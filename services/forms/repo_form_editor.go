@@ -40,10 +40,14 @@ func (f *CommitCommonForm) GetCommitCommonForm() *CommitCommonForm {
 type EditRepoFileForm struct {
 	CommitCommonForm
 	Content                  optional.Option[string]
-	ForkAndEdit              bool   // If true, fork the repository first and commit to the fork
-	SubmitChangeRequest      bool   // If true, fork + create branch + commit + create CR back to original
-	ChangeRequestTitle       string // Optional custom title for the Change Request
-	ChangeRequestDescription string // Optional custom description for the Change Request
+	ForkAndEdit              bool     // If true, fork the repository first and commit to the fork
+	SubmitChangeRequest      bool     // If true, fork + create branch + commit + create CR back to original
+	ChangeRequestTitle       string   // Optional custom title for the Change Request
+	ChangeRequestDescription string   // Optional custom description for the Change Request
+	TargetRepoID             int64    // Optional fork (same subject) to submit the Change Request to instead of the viewed repo
+	PatchBranch              string   // Optional patch branch already holding images uploaded while editing
+	CoAuthors                []string // Optional "Name <email>" of people to credit with Co-authored-by trailers, one per value or line
+	SkipSigning              bool     // If true, don't sign the commit, unless the signing policy or the branch requires signed commits
 }
 
 type DeleteRepoFileForm struct {
@@ -159,6 +159,7 @@ func (r *indexerNotifier) MergePullRequest(ctx context.Context, doer *user_model
 		return
 	}
 	issue_indexer.UpdateIssueIndexer(ctx, pr.Issue.ID)
+	updateMergedBaseRepoIndexer(ctx, pr)
 }
 
 func (r *indexerNotifier) AutoMergePullRequest(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
@@ -167,4 +168,21 @@ func (r *indexerNotifier) AutoMergePullRequest(ctx context.Context, doer *user_m
 		return
 	}
 	issue_indexer.UpdateIssueIndexer(ctx, pr.Issue.ID)
+	updateMergedBaseRepoIndexer(ctx, pr)
+}
+
+// updateMergedBaseRepoIndexer reindexes the base repository of a merged change request, so that the
+// merged article text is searchable even if the merge didn't go through the push hooks.
+// The indexer queue is unique, so a merge that also triggered PushCommits is only indexed once.
+func updateMergedBaseRepoIndexer(ctx context.Context, pr *issues_model.PullRequest) {
+	if !setting.Indexer.RepoIndexerEnabled {
+		return
+	}
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		log.Error("LoadBaseRepo: %v", err)
+		return
+	}
+	if pr.BaseBranch == pr.BaseRepo.DefaultBranch {
+		code_indexer.UpdateRepoIndexer(pr.BaseRepo)
+	}
 }
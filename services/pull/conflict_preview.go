@@ -0,0 +1,84 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"context"
+	"fmt"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/modules/cache"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// conflictPreviewCacheTimeout is how long a conflict preview is cached, in seconds.
+// Entries are keyed by the base and head commits, so they never become stale.
+const conflictPreviewCacheTimeout = 7 * 24 * 60 * 60
+
+// ConflictPreview is the outcome of a test merge of a pull request onto the default branch of its base repository
+type ConflictPreview struct {
+	BaseCommitID    string
+	HeadCommitID    string
+	Clean           bool
+	ConflictedFiles []string
+}
+
+func getConflictPreviewCacheKey(prID int64, baseCommitID, headCommitID string) string {
+	return fmt.Sprintf("pull-conflict-preview-%d-%s-%s", prID, baseCommitID, headCommitID)
+}
+
+// PreviewMergeConflicts attempts a test merge of pr onto the current default branch of its base repository,
+// which may differ from the base branch it was checked against, without changing the status of pr.
+// baseGitRepo must be the open git repository of the base repository.
+func PreviewMergeConflicts(ctx context.Context, pr *issues_model.PullRequest, baseGitRepo *git.Repository) (*ConflictPreview, error) {
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return nil, err
+	}
+	baseCommitID, err := baseGitRepo.GetBranchCommitID(pr.BaseRepo.DefaultBranch)
+	if err != nil {
+		return nil, err
+	}
+	headCommitID, err := baseGitRepo.GetRefCommitID(pr.GetGitHeadRefName())
+	if err != nil {
+		return nil, err
+	}
+
+	cacheKey := getConflictPreviewCacheKey(pr.ID, baseCommitID, headCommitID)
+	if data, ok := cache.GetCache().Get(cacheKey); ok && data != "" {
+		preview := &ConflictPreview{}
+		if err := json.Unmarshal(util.UnsafeStringToBytes(data), preview); err == nil {
+			return preview, nil
+		}
+		log.Warn("PreviewMergeConflicts: invalid cache entry %s", cacheKey)
+	}
+
+	// Test a copy so that the status and merge base of pr are left as they are
+	testPR := *pr
+	testPR.BaseBranch = pr.BaseRepo.DefaultBranch
+	prCtx, cancel, err := createTemporaryRepoForPR(ctx, &testPR)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	if err := testPullRequestTmpRepoBranchMergeable(ctx, prCtx, &testPR); err != nil {
+		return nil, err
+	}
+
+	preview := &ConflictPreview{
+		BaseCommitID:    baseCommitID,
+		HeadCommitID:    headCommitID,
+		Clean:           testPR.Status != issues_model.PullRequestStatusConflict,
+		ConflictedFiles: testPR.ConflictedFiles,
+	}
+	if bs, err := json.Marshal(preview); err == nil {
+		if err := cache.GetCache().Put(cacheKey, util.UnsafeBytesToString(bs), conflictPreviewCacheTimeout); err != nil {
+			log.Warn("PreviewMergeConflicts: failed to cache %s: %v", cacheKey, err)
+		}
+	}
+	return preview, nil
+}
@@ -14,6 +14,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 
 	"code.gitea.io/gitea/models/db"
@@ -291,6 +292,13 @@ func Merge(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.U
 	// Reset cached commit count
 	cache.Remove(pr.Issue.Repo.GetCommitsCountCacheKey(pr.BaseBranch, true))
 
+	// Merged change requests count as activity on the article's subject
+	if pr.Issue.Repo.SubjectID > 0 {
+		if err := repo_model.TouchSubject(ctx, pr.Issue.Repo.SubjectID, time.Now()); err != nil {
+			log.Error("TouchSubject for %-v: %v", pr, err)
+		}
+	}
+
 	return handleCloseCrossReferences(ctx, pr, doer)
 }
 
@@ -0,0 +1,177 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/markup"
+	"code.gitea.io/gitea/modules/markup/markdown"
+	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/setting"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+// articleWordsPerMinute is the reading speed the reading time of an article is estimated with
+const articleWordsPerMinute = 200
+
+// articleReadingQueue computes the reading metadata of the articles whose default branch was pushed to
+var articleReadingQueue *queue.WorkerPoolQueue[int64]
+
+func initArticleReadingQueue() error {
+	articleReadingQueue = queue.CreateUniqueQueue(graceful.GetManager().ShutdownContext(), "article_reading_metadata", articleReadingHandler)
+	if articleReadingQueue == nil {
+		return errors.New("unable to create article_reading_metadata queue")
+	}
+	go graceful.GetManager().RunWithCancel(articleReadingQueue)
+	return nil
+}
+
+// AddRepoToArticleReadingQueue queues the computation of the reading metadata of the latest version of the article of a repository
+func AddRepoToArticleReadingQueue(repoID int64) error {
+	return articleReadingQueue.Push(repoID)
+}
+
+func articleReadingHandler(repoIDs ...int64) []int64 {
+	ctx := graceful.GetManager().ShutdownContext()
+	for _, repoID := range repoIDs {
+		if err := updateArticleReadingMetadata(ctx, repoID); err != nil {
+			log.Error("updateArticleReadingMetadata [%d] failed: %v", repoID, err)
+		}
+	}
+	return nil
+}
+
+// updateArticleReadingMetadata stores the reading metadata of the README at the head of the default branch of a
+// repository. The metadata of the previous head is reused when the README didn't change.
+func updateArticleReadingMetadata(ctx context.Context, repoID int64) error {
+	repo, err := repo_model.GetRepositoryByID(ctx, repoID)
+	if err != nil {
+		return err
+	}
+	if repo.IsEmpty {
+		return nil
+	}
+
+	gitRepo, err := gitrepo.OpenRepository(ctx, repo)
+	if err != nil {
+		return err
+	}
+	defer gitRepo.Close()
+
+	commit, err := gitRepo.GetBranchCommit(repo.DefaultBranch)
+	if err != nil {
+		return err
+	}
+	readme, err := findArticleReadmeEntry(commit)
+	if err != nil || readme == nil {
+		return err
+	}
+
+	commitID := commit.ID.String()
+	latest, has, err := repo_model.GetLatestArticleReadingMetadata(ctx, repo.ID)
+	if err != nil {
+		return err
+	}
+	if has && latest.CommitID == commitID {
+		return nil
+	}
+
+	var metadata *repo_model.ArticleReadingMetadata
+	if has && latest.ReadmeBlobID == readme.ID.String() {
+		metadata = latest
+	} else {
+		blob := readme.Blob()
+		if blob.Size() >= setting.ArticleMaxRenderSize() {
+			return nil
+		}
+		content, err := blob.GetBlobBytes(blob.Size())
+		if err != nil {
+			return err
+		}
+		metadata = ComputeArticleReadingMetadata(readme.Name(), content)
+	}
+	metadata.RepoID = repo.ID
+	metadata.CommitID = commitID
+	metadata.ReadmeBlobID = readme.ID.String()
+	return repo_model.SaveArticleReadingMetadata(ctx, metadata)
+}
+
+// findArticleReadmeEntry returns the README an article is read from at the root of commit,
+// like FindArticleReadme in the article view, or nil if there is none
+func findArticleReadmeEntry(commit *git.Commit) (*git.TreeEntry, error) {
+	entries, err := commit.ListEntries()
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if (entry.IsRegular() || entry.IsExecutable()) && IsArticleReadme(entry.Name()) {
+			return entry, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetArticleReadingMetadata returns the reading metadata of readme, the README of the article of repo at the commit.
+// It is computed when it wasn't stored by the article reading queue, nil is returned if the README is too large.
+func GetArticleReadingMetadata(ctx context.Context, repo *repo_model.Repository, commitID string, readme *git.TreeEntry) (*repo_model.ArticleReadingMetadata, error) {
+	metadata, has, err := repo_model.GetArticleReadingMetadata(ctx, repo.ID, commitID)
+	if err != nil || has {
+		return metadata, err
+	}
+
+	blob := readme.Blob()
+	if blob.Size() >= setting.ArticleMaxRenderSize() {
+		return nil, nil
+	}
+	content, err := blob.GetBlobBytes(blob.Size())
+	if err != nil {
+		return nil, err
+	}
+	metadata = ComputeArticleReadingMetadata(readme.Name(), content)
+	metadata.RepoID = repo.ID
+	metadata.CommitID = commitID
+	metadata.ReadmeBlobID = readme.ID.String()
+	return metadata, nil
+}
+
+// ComputeArticleReadingMetadata computes the word count, reading time and outline of the content of the README
+// at treePath. The front matter and the code and HTML blocks of a markdown README don't count as words.
+func ComputeArticleReadingMetadata(treePath string, content []byte) *repo_model.ArticleReadingMetadata {
+	metadata := &repo_model.ArticleReadingMetadata{Outline: []*repo_model.ArticleHeading{}}
+	if markup.DetectMarkupTypeByFileName(treePath) != markdown.MarkupName {
+		metadata.WordCount = len(strings.Fields(string(content)))
+	} else {
+		if body, err := markdown.ExtractMetadataBytes(content, &map[string]any{}); err == nil {
+			content = body
+		}
+		_ = ast.Walk(goldmark.DefaultParser().Parse(text.NewReader(content)), func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+			if !entering {
+				return ast.WalkContinue, nil
+			}
+			switch n := n.(type) {
+			case *ast.Heading:
+				metadata.Outline = append(metadata.Outline, &repo_model.ArticleHeading{
+					Level: n.Level,
+					Text:  string(n.Text(content)), //nolint:staticcheck // Text is deprecated
+				})
+			case *ast.Text:
+				metadata.WordCount += len(strings.Fields(string(n.Segment.Value(content))))
+			}
+			return ast.WalkContinue, nil
+		})
+	}
+	metadata.ReadingMinutes = (metadata.WordCount + articleWordsPerMinute - 1) / articleWordsPerMinute
+	return metadata
+}
@@ -0,0 +1,35 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeArticleReadingMetadata(t *testing.T) {
+	t.Run("Markdown", func(t *testing.T) {
+		metadata := ComputeArticleReadingMetadata("README.md", []byte("---\ntitle: Not counted\n---\n# Rivers\n\nThe *Nile* flows north.\n\n```go\nnot counted\n```\n\n<div>not counted</div>\n\n## Deltas\n"))
+		assert.Equal(t, 6, metadata.WordCount)
+		assert.Equal(t, 1, metadata.ReadingMinutes)
+		assert.Equal(t, []*repo_model.ArticleHeading{{Level: 1, Text: "Rivers"}, {Level: 2, Text: "Deltas"}}, metadata.Outline)
+	})
+
+	t.Run("PlainText", func(t *testing.T) {
+		metadata := ComputeArticleReadingMetadata("README.txt", []byte("# Not a heading\n"+strings.Repeat("word ", 401)))
+		assert.Equal(t, 405, metadata.WordCount)
+		assert.Equal(t, 3, metadata.ReadingMinutes)
+		assert.Empty(t, metadata.Outline)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		metadata := ComputeArticleReadingMetadata("README.md", nil)
+		assert.Zero(t, metadata.WordCount)
+		assert.Zero(t, metadata.ReadingMinutes)
+	})
+}
@@ -0,0 +1,157 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+	"gopkg.in/yaml.v3"
+)
+
+// disallowedArticleHTML are the raw HTML constructs stripped from the first README of a subject,
+// the first capture group of each regexp names the construct in the problem reported
+var disallowedArticleHTML = []struct {
+	regexp  *regexp.Regexp
+	problem string
+}{
+	// scripts and styles are stripped with their content, which isn't meant to be read
+	{regexp.MustCompile(`(?is)<(script|style)\b[^>]*>.*?</(?:script|style)\s*>`), "raw HTML <%s> element"},
+	{regexp.MustCompile(`(?i)</?(script|style|iframe|frame|frameset|object|embed|applet|form|input|button|textarea|select|meta|link|base)\b[^>]*>`), "raw HTML <%s> element"},
+	{regexp.MustCompile(`(?i)\s(on[a-z]+)\s*=\s*(?:"[^"]*"|'[^']*'|[^\s>]+)`), "raw HTML %s attribute"},
+	{regexp.MustCompile(`(?i)\s(href|src)\s*=\s*(?:"\s*javascript:[^"]*"|'\s*javascript:[^']*'|javascript:[^\s>]*)`), "raw HTML javascript: URL in a %s attribute"},
+}
+
+// PrepareFirstArticleReadme applies setting.Repository.Article.FirstReadmeCheck to the content of
+// the README first committed to the repository of a subject, which becomes the root of its articles.
+// With "fix" the content is normalized, with "reject" an invalid argument error lists its problems.
+func PrepareFirstArticleReadme(content string) (string, error) {
+	switch setting.Repository.Article.FirstReadmeCheck {
+	case setting.ArticleReadmeCheckFix:
+		normalized, _ := NormalizeArticleReadme(content)
+		return normalized, nil
+	case setting.ArticleReadmeCheckReject:
+		if _, problems := NormalizeArticleReadme(content); len(problems) > 0 {
+			return "", util.NewInvalidArgumentErrorf("%s", strings.Join(problems, "; "))
+		}
+	}
+	return content, nil
+}
+
+// NormalizeArticleReadme checks the YAML front matter and the raw HTML of the content of a README.
+// It returns the content with its front matter re-encoded, or dropped if it is broken, and the
+// disallowed raw HTML stripped, along with the problems found.
+func NormalizeArticleReadme(content string) (string, []string) {
+	var problems []string
+	var frontMatter string
+
+	lines := strings.SplitAfter(content, "\n")
+	if isFrontMatterSeparator(lines[0]) {
+		end := slices.IndexFunc(lines[1:], isFrontMatterSeparator)
+		if end < 0 {
+			problems = append(problems, "the front matter is not closed by a --- line")
+			content = strings.Join(lines[1:], "")
+		} else {
+			content = strings.Join(lines[end+2:], "")
+			if normalized, err := normalizeArticleFrontMatter(strings.Join(lines[1:end+1], "")); err != nil {
+				problems = append(problems, err.Error())
+			} else {
+				frontMatter = "---\n" + normalized + "---\n"
+			}
+		}
+	}
+
+	content, htmlProblems := stripDisallowedArticleHTML(content)
+	return frontMatter + content, append(problems, htmlProblems...)
+}
+
+// isFrontMatterSeparator reports whether line is a line of three or more dashes, like markdown.ExtractMetadata
+func isFrontMatterSeparator(line string) bool {
+	line = strings.TrimSpace(line)
+	return len(line) >= 3 && strings.Trim(line, "-") == ""
+}
+
+// normalizeArticleFrontMatter re-encodes YAML front matter, which must be a mapping
+func normalizeArticleFrontMatter(front string) (string, error) {
+	if strings.TrimSpace(front) == "" {
+		return "", nil
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(front), &node); err != nil {
+		return "", fmt.Errorf("the front matter is not valid YAML: %w", err)
+	}
+	if len(node.Content) != 1 || node.Content[0].Kind != yaml.MappingNode {
+		return "", errors.New("the front matter is not a set of keys and values")
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// stripDisallowedArticleHTML strips the disallowed constructs from the raw HTML of markdown content.
+// The markdown is parsed to only look at its raw HTML, leaving code blocks and spans untouched.
+func stripDisallowedArticleHTML(content string) (string, []string) {
+	var ranges [][2]int
+	_ = ast.Walk(goldmark.DefaultParser().Parse(text.NewReader([]byte(content))), func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n := n.(type) {
+		case *ast.HTMLBlock:
+			lines := n.Lines()
+			if lines.Len() == 0 {
+				break
+			}
+			stop := lines.At(lines.Len() - 1).Stop
+			if n.HasClosure() {
+				stop = n.ClosureLine.Stop
+			}
+			ranges = append(ranges, [2]int{lines.At(0).Start, stop})
+		case *ast.RawHTML:
+			if n.Segments.Len() > 0 {
+				ranges = append(ranges, [2]int{n.Segments.At(0).Start, n.Segments.At(n.Segments.Len() - 1).Stop})
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+
+	var problems []string
+	var out strings.Builder
+	last := 0
+	for _, r := range ranges {
+		out.WriteString(content[last:r[0]])
+		html := content[r[0]:r[1]]
+		for _, disallowed := range disallowedArticleHTML {
+			for _, match := range disallowed.regexp.FindAllStringSubmatch(html, -1) {
+				problem := fmt.Sprintf(disallowed.problem, strings.ToLower(match[1]))
+				if !slices.Contains(problems, problem) {
+					problems = append(problems, problem)
+				}
+			}
+			html = disallowed.regexp.ReplaceAllString(html, "")
+		}
+		out.WriteString(html)
+		last = r[1]
+	}
+	out.WriteString(content[last:])
+	return out.String(), problems
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeArticleReadme(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		content, problems := NormalizeArticleReadme("---\ntitle:   Rivers\ntags: [water, geography]\n---\n# Rivers\n\n```html\n<script>kept()</script>\n```\n")
+		assert.Empty(t, problems)
+		assert.Equal(t, "---\ntitle: Rivers\ntags: [water, geography]\n---\n# Rivers\n\n```html\n<script>kept()</script>\n```\n", content)
+	})
+
+	t.Run("BrokenFrontMatter", func(t *testing.T) {
+		content, problems := NormalizeArticleReadme("---\ntitle: [Rivers\n---\n# Rivers\n")
+		assert.Len(t, problems, 1)
+		assert.Equal(t, "# Rivers\n", content)
+
+		content, problems = NormalizeArticleReadme("---\ntitle: Rivers\n# Rivers\n")
+		assert.Len(t, problems, 1)
+		assert.Equal(t, "title: Rivers\n# Rivers\n", content)
+	})
+
+	t.Run("DisallowedHTML", func(t *testing.T) {
+		content, problems := NormalizeArticleReadme("# Rivers\n<script>alert(1)</script>\n\nThe <a href=\"javascript:alert(1)\" onclick=\"steal()\">Nile</a> flows north.\n")
+		assert.Equal(t, []string{
+			"raw HTML <script> element",
+			"raw HTML onclick attribute",
+			"raw HTML javascript: URL in a href attribute",
+		}, problems)
+		assert.Equal(t, "# Rivers\n\n\nThe <a>Nile</a> flows north.\n", content)
+	})
+}
+
+func TestPrepareFirstArticleReadme(t *testing.T) {
+	const broken = "---\ntitle: [Rivers\n---\n# Rivers\n"
+
+	t.Run("Off", func(t *testing.T) {
+		defer test.MockVariableValue(&setting.Repository.Article.FirstReadmeCheck, setting.ArticleReadmeCheckOff)()
+		content, err := PrepareFirstArticleReadme(broken)
+		require.NoError(t, err)
+		assert.Equal(t, broken, content)
+	})
+
+	t.Run("Fix", func(t *testing.T) {
+		defer test.MockVariableValue(&setting.Repository.Article.FirstReadmeCheck, setting.ArticleReadmeCheckFix)()
+		content, err := PrepareFirstArticleReadme(broken)
+		require.NoError(t, err)
+		assert.Equal(t, "# Rivers\n", content)
+	})
+
+	t.Run("Reject", func(t *testing.T) {
+		defer test.MockVariableValue(&setting.Repository.Article.FirstReadmeCheck, setting.ArticleReadmeCheckReject)()
+		_, err := PrepareFirstArticleReadme(broken)
+		assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+		content, err := PrepareFirstArticleReadme("# Rivers\n")
+		require.NoError(t, err)
+		assert.Equal(t, "# Rivers\n", content)
+	})
+}
@@ -0,0 +1,149 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// ErrArticleSuggestionOutdated is returned when the lines a suggestion corrects can no longer be
+// found in the article
+var ErrArticleSuggestionOutdated = errors.New("the suggested lines are no longer in the article")
+
+// CreateArticleSuggestionOptions describes a suggestion on the README of an article
+type CreateArticleSuggestionOptions struct {
+	CommitID string
+	TreePath string
+	// Readme is the content of the README at CommitID
+	Readme string
+	// LineStart and LineEnd are the 1-based, inclusive range of the lines to replace
+	LineStart int
+	LineEnd   int
+	// Content is the text proposed in place of the lines
+	Content string
+	Comment string
+}
+
+// CreateArticleSuggestion records a suggestion by doer to replace a range of lines of the README
+// of the repository with new text. The range must be within the README and the text must differ
+// from the lines it replaces.
+func CreateArticleSuggestion(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, opts CreateArticleSuggestionOptions) (*repo_model.ArticleSuggestion, error) {
+	lines, _ := splitArticleLines(opts.Readme)
+	if opts.LineStart < 1 || opts.LineEnd < opts.LineStart || opts.LineEnd > len(lines) {
+		return nil, util.NewInvalidArgumentErrorf("lines %d-%d are not in the article", opts.LineStart, opts.LineEnd)
+	}
+	original := strings.Join(lines[opts.LineStart-1:opts.LineEnd], "\n")
+	content := normalizeSuggestionText(opts.Content)
+	if content == original {
+		return nil, util.NewInvalidArgumentErrorf("the suggestion doesn't change the article")
+	}
+
+	suggestion := &repo_model.ArticleSuggestion{
+		RepoID:          repo.ID,
+		PosterID:        doer.ID,
+		Poster:          doer,
+		CommitID:        opts.CommitID,
+		TreePath:        opts.TreePath,
+		LineStart:       opts.LineStart,
+		LineEnd:         opts.LineEnd,
+		OriginalContent: original,
+		Content:         content,
+		Comment:         strings.TrimSpace(opts.Comment),
+	}
+	if err := repo_model.CreateArticleSuggestion(ctx, suggestion); err != nil {
+		return nil, err
+	}
+	log.Trace("Suggestion %d on lines %d-%d of %-v created by %s", suggestion.ID, suggestion.LineStart, suggestion.LineEnd, repo, doer.Name)
+	return suggestion, nil
+}
+
+// AcceptArticleSuggestion marks a suggestion as accepted by the owner of the article and returns
+// readme, the current content of the README, with the suggestion applied, to fill the editor with.
+// Nothing is committed: the owner still reviews and submits the edit.
+func AcceptArticleSuggestion(ctx context.Context, suggestion *repo_model.ArticleSuggestion, readme string) (string, error) {
+	if !suggestion.IsOpen() {
+		return "", util.NewInvalidArgumentErrorf("the suggestion is no longer open")
+	}
+	content, err := ApplyArticleSuggestion(readme, suggestion)
+	if err != nil {
+		return "", err
+	}
+	if err := repo_model.UpdateArticleSuggestionStatus(ctx, suggestion, repo_model.ArticleSuggestionAccepted); err != nil {
+		return "", err
+	}
+	return content, nil
+}
+
+// ApplyArticleSuggestion replaces the lines a suggestion corrects in readme with the suggested text.
+// The lines are looked for at the range of the suggestion first, then anywhere else in case the
+// article has changed since the suggestion was made. ErrArticleSuggestionOutdated is returned if
+// they are not found, or found more than once.
+func ApplyArticleSuggestion(readme string, suggestion *repo_model.ArticleSuggestion) (string, error) {
+	lines, trailingNewline := splitArticleLines(readme)
+	original := strings.Split(suggestion.OriginalContent, "\n")
+
+	start := suggestion.LineStart - 1
+	if !linesMatchAt(lines, original, start) {
+		start = -1
+		for i := range lines {
+			if !linesMatchAt(lines, original, i) {
+				continue
+			}
+			if start >= 0 {
+				return "", ErrArticleSuggestionOutdated
+			}
+			start = i
+		}
+		if start < 0 {
+			return "", ErrArticleSuggestionOutdated
+		}
+	}
+
+	replaced := make([]string, 0, len(lines)-len(original)+1)
+	replaced = append(replaced, lines[:start]...)
+	if suggestion.Content != "" {
+		replaced = append(replaced, strings.Split(suggestion.Content, "\n")...)
+	}
+	replaced = append(replaced, lines[start+len(original):]...)
+
+	result := strings.Join(replaced, "\n")
+	if trailingNewline {
+		result += "\n"
+	}
+	return result, nil
+}
+
+// splitArticleLines splits the content of a README into lines, and tells whether it ends with a newline
+func splitArticleLines(content string) ([]string, bool) {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	trailingNewline := strings.HasSuffix(content, "\n")
+	if content == "" {
+		return nil, false
+	}
+	return strings.Split(strings.TrimSuffix(content, "\n"), "\n"), trailingNewline
+}
+
+func linesMatchAt(lines, want []string, start int) bool {
+	if start < 0 || start+len(want) > len(lines) {
+		return false
+	}
+	for i, line := range want {
+		if lines[start+i] != line {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeSuggestionText turns the text of a suggestion submitted from a browser into lines of the README
+func normalizeSuggestionText(text string) string {
+	return strings.TrimSuffix(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+}
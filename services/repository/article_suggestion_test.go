@@ -0,0 +1,106 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"testing"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const suggestionReadme = "# Rivers\n\nThe Nile is the longest river.\nIt flows north.\n"
+
+func TestCreateArticleSuggestion(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 10})
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 13})
+
+	opts := CreateArticleSuggestionOptions{
+		CommitID:  "65f1bf27bc3bf70f64657658635e66094edbcb4d",
+		TreePath:  "README.md",
+		Readme:    suggestionReadme,
+		LineStart: 3,
+		LineEnd:   3,
+		Content:   "The Nile is one of the longest rivers.\r\n",
+		Comment:   "  The Amazon may be longer ",
+	}
+	suggestion, err := CreateArticleSuggestion(t.Context(), doer, repo, opts)
+	require.NoError(t, err)
+	created := unittest.AssertExistsAndLoadBean(t, &repo_model.ArticleSuggestion{ID: suggestion.ID})
+	assert.Equal(t, "The Nile is the longest river.", created.OriginalContent)
+	assert.Equal(t, "The Nile is one of the longest rivers.", created.Content)
+	assert.Equal(t, "The Amazon may be longer", created.Comment)
+	assert.True(t, created.IsOpen())
+
+	suggestions, err := repo_model.FindOpenArticleSuggestions(t.Context(), repo.ID)
+	require.NoError(t, err)
+	if assert.Len(t, suggestions, 1) {
+		assert.Equal(t, doer.ID, suggestions[0].Poster.ID)
+	}
+
+	t.Run("OutOfRange", func(t *testing.T) {
+		opts := opts
+		opts.LineStart, opts.LineEnd = 4, 5
+		_, err := CreateArticleSuggestion(t.Context(), doer, repo, opts)
+		assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	})
+
+	t.Run("Unchanged", func(t *testing.T) {
+		opts := opts
+		opts.Content = "The Nile is the longest river."
+		_, err := CreateArticleSuggestion(t.Context(), doer, repo, opts)
+		assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	})
+}
+
+func TestAcceptArticleSuggestion(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 10})
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 13})
+
+	suggest := func(t *testing.T) *repo_model.ArticleSuggestion {
+		suggestion, err := CreateArticleSuggestion(t.Context(), doer, repo, CreateArticleSuggestionOptions{
+			TreePath:  "README.md",
+			Readme:    suggestionReadme,
+			LineStart: 3,
+			LineEnd:   4,
+			Content:   "The Nile is one of the longest rivers.\nIt flows north into the Mediterranean.",
+		})
+		require.NoError(t, err)
+		return suggestion
+	}
+
+	t.Run("Unchanged", func(t *testing.T) {
+		suggestion := suggest(t)
+		content, err := AcceptArticleSuggestion(t.Context(), suggestion, suggestionReadme)
+		require.NoError(t, err)
+		assert.Equal(t, "# Rivers\n\nThe Nile is one of the longest rivers.\nIt flows north into the Mediterranean.\n", content)
+		unittest.AssertExistsAndLoadBean(t, &repo_model.ArticleSuggestion{ID: suggestion.ID, Status: repo_model.ArticleSuggestionAccepted})
+
+		// A suggestion is accepted only once
+		_, err = AcceptArticleSuggestion(t.Context(), suggestion, suggestionReadme)
+		assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	})
+
+	t.Run("LinesMoved", func(t *testing.T) {
+		suggestion := suggest(t)
+		readme := "# Rivers\n\nRivers of Africa:\n\nThe Nile is the longest river.\nIt flows north.\n"
+		content, err := AcceptArticleSuggestion(t.Context(), suggestion, readme)
+		require.NoError(t, err)
+		assert.Equal(t, "# Rivers\n\nRivers of Africa:\n\nThe Nile is one of the longest rivers.\nIt flows north into the Mediterranean.\n", content)
+	})
+
+	t.Run("Outdated", func(t *testing.T) {
+		suggestion := suggest(t)
+		_, err := AcceptArticleSuggestion(t.Context(), suggestion, "# Rivers\n\nThe Nile flows north.\n")
+		assert.ErrorIs(t, err, ErrArticleSuggestionOutdated)
+		assert.True(t, unittest.AssertExistsAndLoadBean(t, &repo_model.ArticleSuggestion{ID: suggestion.ID}).IsOpen())
+	})
+}
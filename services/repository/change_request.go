@@ -0,0 +1,216 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	git_model "code.gitea.io/gitea/models/git"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+	pull_service "code.gitea.io/gitea/services/pull"
+)
+
+// Errors returned by CheckCanSubmitChangeRequest when a change request can't be submitted
+var (
+	ErrChangeRequestToOwnRepo         = util.NewPermissionDeniedErrorf("change requests can't be submitted to your own repository")
+	ErrChangeRequestBlockedBySubject  = util.NewPermissionDeniedErrorf("you already own an article for this subject")
+	ErrChangeRequestNotAllowed        = util.NewPermissionDeniedErrorf("you are not allowed to submit change requests to this repository")
+	ErrChangeRequestPullsDisabled     = util.NewPermissionDeniedErrorf("pull requests are disabled for this repository")
+	ErrChangeRequestRateLimitExceeded = errors.New("too many change requests submitted recently")
+)
+
+// maxUniquePatchBranchAttempts is the maximum number of patch branch names tried by GetUniquePatchBranchName
+const maxUniquePatchBranchAttempts = 1000
+
+// GetUniquePatchBranchName Gets a unique branch name for a new patch branch
+// It will be in the form of <username>-patch-<num> where <num> is the first branch of this format
+// that doesn't already exist. If we exceed maxUniquePatchBranchAttempts or an error is thrown, we just return "" so the user has to
+// type in the branch name themselves (will be an empty field)
+func GetUniquePatchBranchName(ctx context.Context, prefixName string, repo *repo_model.Repository) string {
+	prefix := prefixName + "-patch-"
+	for i := 1; i <= maxUniquePatchBranchAttempts; i++ {
+		branchName := fmt.Sprintf("%s%d", prefix, i)
+		// Check both the database AND the git repository for branch existence.
+		// The database might be out of sync with git (e.g., if a previous change request
+		// failed after pushing the branch but before creating the PR, or if the branch
+		// was deleted from the database but not from git).
+		if existInDB, err := git_model.IsBranchExist(ctx, repo.ID, branchName); err != nil {
+			log.Error("GetUniquePatchBranchName: database check failed: %v", err)
+			return ""
+		} else if existInDB {
+			continue
+		}
+		// Also check the actual git repository to handle cases where the branch
+		// exists in git but not in the database (e.g., orphaned branches from failed operations)
+		if gitrepo.IsBranchExist(ctx, repo, branchName) {
+			continue
+		}
+		return branchName
+	}
+	return ""
+}
+
+// CheckCanSubmitChangeRequest checks that doer may submit a change request to repo now: doer
+// must not own repo nor an independent article of its subject, must be allowed to submit change
+// requests to it, and must not have opened more than [repository.pull-request]
+// CHANGE_REQUEST_RATE_LIMIT change requests to it within the rate limit window.
+func CheckCanSubmitChangeRequest(ctx context.Context, doer *user_model.User, repo *repo_model.Repository) error {
+	perms, err := CheckForkOnEditPermissions(ctx, doer, repo)
+	if err != nil {
+		return fmt.Errorf("CheckForkOnEditPermissions: %w", err)
+	}
+	switch {
+	case perms.IsRepoOwner:
+		return ErrChangeRequestToOwnRepo
+	case perms.BlockedBySubject:
+		return ErrChangeRequestBlockedBySubject
+	case !perms.CanSubmitChangeRequest:
+		return ErrChangeRequestNotAllowed
+	case !repo.AllowsPulls(ctx):
+		return ErrChangeRequestPullsDisabled
+	}
+
+	// Throttle rapid submissions before any branch is created
+	if limit := setting.Repository.PullRequest.ChangeRequestRateLimit; limit > 0 {
+		since := timeutil.TimeStampNow().AddDuration(-setting.Repository.PullRequest.ChangeRequestRateLimitWindow)
+		count, err := issues_model.CountOpenPullRequestsByPosterSince(ctx, repo.ID, doer.ID, since)
+		if err != nil {
+			return fmt.Errorf("CountOpenPullRequestsByPosterSince: %w", err)
+		}
+		if count >= int64(limit) {
+			return ErrChangeRequestRateLimitExceeded
+		}
+	}
+	return nil
+}
+
+// IsBlankChangeRequestContent reports whether the content submitted for a change request is empty or only whitespace
+func IsBlankChangeRequestContent(content string) bool {
+	return strings.TrimSpace(content) == ""
+}
+
+// CleanupOrphanedBranch attempts to delete a branch that was created but is no longer needed
+// (e.g., when PR creation fails after the branch was already created).
+// It performs both a soft-delete (via DeleteBranch) and a hard-delete of the DB
+// record, since the branch was never meant to exist and should leave no trace.
+// It logs any errors but does not propagate them to the caller.
+func CleanupOrphanedBranch(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, gitRepo *git.Repository, branchName string) {
+	if gitRepo == nil {
+		return
+	}
+	// Skip permission check because this branch was created programmatically via
+	// InternalPush (which bypasses pre-receive hooks and permission checks).
+	// Without this, non-collaborators who can submit change requests would be able
+	// to create branches but not delete them, leaving orphaned branches when PR
+	// creation fails.
+	if err := DeleteBranch(ctx, doer, repo, gitRepo, branchName, nil, &DeleteBranchOptions{
+		SkipPermissionCheck: true,
+	}); err != nil {
+		log.Error("CleanupOrphanedBranch: failed to cleanup branch %s: %v", branchName, err)
+		return
+	}
+
+	// Hard-delete the soft-deleted DB record so the orphaned branch leaves no
+	// trace. DeleteBranch only marks the record as deleted (is_deleted=true);
+	// without this step the branch would still appear in unfiltered queries.
+	branch, err := git_model.GetBranch(ctx, repo.ID, branchName)
+	if err != nil {
+		log.Error("CleanupOrphanedBranch: failed to get branch record for %s: %v", branchName, err)
+		return
+	}
+	if err := git_model.RemoveDeletedBranchByID(ctx, repo.ID, branch.ID); err != nil {
+		log.Error("CleanupOrphanedBranch: failed to hard-delete branch record for %s: %v", branchName, err)
+	}
+}
+
+// CreatePatchBranchChangeRequest creates a same-repo change request from branchName, a patch branch
+// the edit of doer has just been committed to with an internal push, to the default branch of repo.
+// The patch branch is removed again if the change request can't be created.
+func CreatePatchBranchChangeRequest(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, branchName, title, description string) (*issues_model.PullRequest, error) {
+	gitRepo, err := gitrepo.OpenRepository(ctx, repo)
+	if err != nil {
+		// Note: Branch cleanup not attempted as repository is inaccessible
+		return nil, fmt.Errorf("OpenRepository: %w", err)
+	}
+	defer gitRepo.Close()
+
+	// Sync the newly created branch to the database. The internal push bypasses
+	// post-receive hooks, so the branch exists only in git at this point. Without
+	// this sync, any subsequent operation that checks branch existence via the DB
+	// (e.g. ChangeRepoFiles from the API) would fail with "branch does not exist".
+	newCommitID, err := gitRepo.GetBranchCommitID(branchName)
+	if err != nil {
+		CleanupOrphanedBranch(ctx, doer, repo, gitRepo, branchName)
+		return nil, fmt.Errorf("GetBranchCommitID: %w", err)
+	}
+	if err := SyncBranchesToDB(ctx, repo.ID, doer.ID,
+		[]string{branchName}, []string{newCommitID}, gitRepo.GetCommit); err != nil {
+		CleanupOrphanedBranch(ctx, doer, repo, gitRepo, branchName)
+		return nil, fmt.Errorf("SyncBranchesToDB: %w", err)
+	}
+
+	// Same-repo CR: both head and base are in the repository
+	compareInfo, err := pull_service.GetCompareInfo(ctx, repo, repo, gitRepo,
+		git.BranchPrefix+repo.DefaultBranch, git.BranchPrefix+branchName, false, false)
+	if err != nil {
+		CleanupOrphanedBranch(ctx, doer, repo, gitRepo, branchName)
+		return nil, fmt.Errorf("GetCompareInfo: %w", err)
+	}
+
+	// Enforce maximum title length (255 characters) to prevent excessively long titles.
+	// Use rune-based truncation to avoid corrupting multi-byte UTF-8 characters.
+	title = util.TruncateRunes(title, 255)
+	// Defense-in-depth: cap description length so downstream processing/storage isn't impacted by huge input.
+	// Note: this does not limit the incoming request size.
+	description = util.TruncateRunes(strings.TrimSpace(description), 65535)
+
+	pullIssue := &issues_model.Issue{
+		RepoID:   repo.ID,
+		Repo:     repo,
+		Title:    title,
+		PosterID: doer.ID,
+		Poster:   doer,
+		IsPull:   true,
+		Content:  description,
+	}
+
+	changeRequest := &issues_model.PullRequest{
+		HeadRepoID: repo.ID,
+		BaseRepoID: repo.ID,
+		HeadBranch: branchName,
+		BaseBranch: repo.DefaultBranch,
+		HeadRepo:   repo,
+		BaseRepo:   repo,
+		MergeBase:  compareInfo.MergeBase,
+		Type:       issues_model.PullRequestGitea,
+	}
+
+	if err := pull_service.NewPullRequest(ctx, &pull_service.NewPullRequestOptions{
+		Repo:        repo,
+		Issue:       pullIssue,
+		PullRequest: changeRequest,
+		// AllowNonCollaborator: the callers have already checked that doer can submit change
+		// requests. This bypasses the collaborator check since the patch branch was created
+		// programmatically (not via git push).
+		AllowNonCollaborator: true,
+	}); err != nil {
+		CleanupOrphanedBranch(ctx, doer, repo, gitRepo, branchName)
+		return nil, fmt.Errorf("NewPullRequest: %w", err)
+	}
+
+	log.Info("CreatePatchBranchChangeRequest: created CR #%d from %s to %s in %s/%s",
+		changeRequest.Index, branchName, repo.DefaultBranch, repo.OwnerName, repo.Name)
+	return changeRequest, nil
+}
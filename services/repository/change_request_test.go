@@ -0,0 +1,27 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"testing"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetUniquePatchBranchName(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	assert.Equal(t, "user2-patch-1", GetUniquePatchBranchName(t.Context(), "user2", repo))
+}
+
+func TestIsBlankChangeRequestContent(t *testing.T) {
+	assert.True(t, IsBlankChangeRequestContent(""))
+	assert.True(t, IsBlankChangeRequestContent(" \n\t\r\n"))
+	assert.False(t, IsBlankChangeRequestContent("# Article\n"))
+}
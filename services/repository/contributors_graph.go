@@ -26,10 +26,13 @@ import (
 	api "code.gitea.io/gitea/modules/structs"
 )
 
-const (
-	contributorStatsCacheKey           = "GetContributorStats/%s/%s"
-	contributorStatsCacheTimeout int64 = 60 * 10
-)
+const contributorStatsCacheKey = "GetContributorStats/%s/%s"
+
+// contributorStatsCacheTimeout returns the TTL in seconds for the contributor stats cache,
+// derived from the fork contributor stats cache which is computed from it
+func contributorStatsCacheTimeout() int64 {
+	return 2 * forkContributorStatsCacheTimeout()
+}
 
 var (
 	ErrAwaitGeneration  = errors.New("generation took longer than ")
@@ -103,7 +106,7 @@ func GetContributorStats(ctx context.Context, cache cache.StringCache, repo *rep
 			break
 		}
 	}
-	// TODO: renew timeout of cache cache.UpdateTimeout(cacheKey, contributorStatsCacheTimeout)
+	// TODO: renew timeout of cache cache.UpdateTimeout(cacheKey, contributorStatsCacheTimeout())
 	var res map[string]*ContributorData
 	if _, cacheErr := cache.GetJSON(cacheKey, &res); cacheErr != nil {
 		return nil, fmt.Errorf("cached error: %w", cacheErr.ToError())
@@ -205,7 +208,7 @@ func generateContributorStats(genDone chan struct{}, cache cache.StringCache, ca
 
 	gitRepo, closer, err := gitrepo.RepositoryFromContextOrOpen(ctx, repo)
 	if err != nil {
-		_ = cache.PutJSON(cacheKey, fmt.Errorf("OpenRepository: %w", err), contributorStatsCacheTimeout)
+		_ = cache.PutJSON(cacheKey, fmt.Errorf("OpenRepository: %w", err), contributorStatsCacheTimeout())
 		return
 	}
 	defer closer.Close()
@@ -215,11 +218,11 @@ func generateContributorStats(genDone chan struct{}, cache cache.StringCache, ca
 	}
 	extendedCommitStats, err := getExtendedCommitStats(gitRepo, revision)
 	if err != nil {
-		_ = cache.PutJSON(cacheKey, fmt.Errorf("ExtendedCommitStats: %w", err), contributorStatsCacheTimeout)
+		_ = cache.PutJSON(cacheKey, fmt.Errorf("ExtendedCommitStats: %w", err), contributorStatsCacheTimeout())
 		return
 	}
 	if len(extendedCommitStats) == 0 {
-		_ = cache.PutJSON(cacheKey, fmt.Errorf("no commit stats returned for revision '%s'", revision), contributorStatsCacheTimeout)
+		_ = cache.PutJSON(cacheKey, fmt.Errorf("no commit stats returned for revision '%s'", revision), contributorStatsCacheTimeout())
 		return
 	}
 
@@ -301,7 +304,7 @@ func generateContributorStats(genDone chan struct{}, cache cache.StringCache, ca
 		total.TotalCommits++
 	}
 
-	_ = cache.PutJSON(cacheKey, contributorsCommitStats, contributorStatsCacheTimeout)
+	_ = cache.PutJSON(cacheKey, contributorsCommitStats, contributorStatsCacheTimeout())
 	generateLock.Delete(cacheKey)
 	if genDone != nil {
 		genDone <- struct{}{}
@@ -37,6 +37,7 @@ import (
 type CreateRepoOptions struct {
 	Name             string
 	Subject          string
+	SubjectLang      string // language of the subject, only recorded if the subject has none yet
 	Description      string
 	OriginalURL      string
 	GitServiceType   api.GitServiceType
@@ -239,6 +240,11 @@ func CreateRepositoryDirectly(ctx context.Context, doer, owner *user_model.User,
 			return nil, fmt.Errorf("failed to get or create subject: %w", err)
 		}
 		subjectID = subject.ID
+		if opts.SubjectLang != "" && subject.Lang == "" {
+			if err := repo_model.SetSubjectLangIfEmpty(ctx, subjectID, opts.SubjectLang); err != nil {
+				return nil, fmt.Errorf("failed to set subject language: %w", err)
+			}
+		}
 	}
 
 	repo := &repo_model.Repository{
@@ -287,7 +293,15 @@ func CreateRepositoryDirectly(ctx context.Context, doer, owner *user_model.User,
 			}
 		}
 
-		return createRepositoryInDB(txCtx, doer, owner, repo, false)
+		if err := createRepositoryInDB(txCtx, doer, owner, repo, false); err != nil {
+			return err
+		}
+
+		// A repository created with content is immediately the root of its subject
+		if subjectID > 0 && !repo.IsEmpty {
+			return repo_model.SetSubjectRootRepoID(txCtx, subjectID, repo.ID)
+		}
+		return nil
 	})
 
 	// Handle the case where we need to fork instead of creating a new root
@@ -508,6 +522,10 @@ func createRepositoryInDB(ctx context.Context, doer, u *user_model.User, repo *r
 		return fmt.Errorf("CopyDefaultWebhooksToRepo: %w", err)
 	}
 
+	if err = repo_model.RefreshSubjectCounts(ctx, repo.SubjectID); err != nil {
+		return fmt.Errorf("RefreshSubjectCounts: %w", err)
+	}
+
 	return nil
 }
 
@@ -163,6 +163,11 @@ func DeleteRepositoryDirectly(ctx context.Context, repoID int64, ignoreOrgTeams
 		&repo_model.Release{RepoID: repoID},
 		&repo_model.RepoIndexerStatus{RepoID: repoID},
 		&repo_model.Redirect{RedirectRepoID: repoID},
+		&repo_model.RootPromotion{RepoID: repoID},
+		&repo_model.RootPromotion{RootRepoID: repoID},
+		&repo_model.ArticleSuggestion{RepoID: repoID},
+		&repo_model.ArticleWatch{RepoID: repoID},
+		&repo_model.ArticleReadingMetadata{RepoID: repoID},
 		&repo_model.RepoUnit{RepoID: repoID},
 		&repo_model.Star{RepoID: repoID},
 		&admin_model.Task{RepoID: repoID},
@@ -292,6 +297,10 @@ func DeleteRepositoryDirectly(ctx context.Context, repoID int64, ignoreOrgTeams
 		return err
 	}
 
+	if err = repo_model.RefreshSubjectCounts(ctx, repo.SubjectID); err != nil {
+		return fmt.Errorf("RefreshSubjectCounts: %w", err)
+	}
+
 	if err = committer.Commit(); err != nil {
 		return err
 	}
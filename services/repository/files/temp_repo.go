@@ -431,6 +431,7 @@ type CommitTreeUserOptions struct {
 	TreeHash                  string
 	CommitMessage             string
 	SignOff                   bool
+	SkipSigning               bool // don't sign even if the signing rules would sign
 
 	DoerUser *user_model.User
 
@@ -499,9 +500,12 @@ func (t *TemporaryUploadRepository) CommitTree(ctx context.Context, opts *Commit
 	var sign bool
 	var key *git.SigningKey
 	var signer *git.Signature
-	if opts.ParentCommitID != "" {
+	switch {
+	case opts.SkipSigning:
+		// leave the commit unsigned
+	case opts.ParentCommitID != "":
 		sign, key, signer, _ = asymkey_service.SignCRUDAction(ctx, t.repo.RepoPath(), opts.DoerUser, t.basePath, opts.ParentCommitID)
-	} else {
+	default:
 		sign, key, signer, _ = asymkey_service.SignInitialCommit(ctx, t.repo.RepoPath(), opts.DoerUser)
 	}
 	if sign {
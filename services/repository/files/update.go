@@ -60,6 +60,9 @@ type ChangeRepoFilesOptions struct {
 	Committer    *IdentityOptions
 	Dates        *CommitDateOptions
 	Signoff      bool
+	// SkipSigning commits without a signature even if the signing rules would sign the commit.
+	// It is ignored when the signing rules always sign or the new branch requires signed commits.
+	SkipSigning bool
 	// InternalPush skips pre-receive and post-receive hooks when pushing.
 	// This should ONLY be used for internal/programmatic operations where
 	// permissions have already been verified (e.g., submit-change-request workflow).
@@ -126,6 +129,16 @@ func ChangeRepoFiles(ctx context.Context, repo *repo_model.Repository, doer *use
 		opts.NewBranch = opts.OldBranch
 	}
 
+	if opts.SkipSigning {
+		// Never leave a commit unsigned when the signing policy or the branch requires signed commits
+		protectedBranch, err := git_model.GetFirstMatchProtectedBranchRule(ctx, repo.ID, opts.NewBranch)
+		if err != nil {
+			return nil, err
+		}
+		opts.SkipSigning = !asymkey_service.CRUDActionsAlwaysSign() &&
+			(protectedBranch == nil || !protectedBranch.RequireSignedCommits)
+	}
+
 	gitRepo, closer, err := gitrepo.RepositoryFromContextOrOpen(ctx, repo)
 	if err != nil {
 		return nil, err
@@ -293,6 +306,7 @@ func ChangeRepoFiles(ctx context.Context, repo *repo_model.Repository, doer *use
 		TreeHash:          treeHash,
 		CommitMessage:     message,
 		SignOff:           opts.Signoff,
+		SkipSigning:       opts.SkipSigning,
 		DoerUser:          doer,
 		AuthorIdentity:    opts.Author,
 		AuthorTime:        nil,
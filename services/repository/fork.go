@@ -7,11 +7,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
 	"code.gitea.io/gitea/models/db"
 	git_model "code.gitea.io/gitea/models/git"
+	issues_model "code.gitea.io/gitea/models/issues"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unit"
 	user_model "code.gitea.io/gitea/models/user"
@@ -43,11 +45,15 @@ type ForkOnEditPermissions struct {
 	HasExistingFork bool
 	// ExistingFork is the user's existing fork (nil if none)
 	ExistingFork *repo_model.Repository
-	// BlockedBySubject is true if the user already owns a different repo for the same subject
-	// that is NOT a fork of the current repository (i.e., they have their own independent article)
+	// BlockedBySubject is true if the user already owns as many repos for the same subject as
+	// [repository.article] MAX_REPOS_PER_USER_PER_SUBJECT allows and none of them is a fork of the
+	// current repository (i.e., they have their own independent article)
 	BlockedBySubject bool
-	// OwnRepoForSubject is the user's existing repo for the subject (nil if none)
+	// OwnRepoForSubject is the user's existing repo for the subject, the one in the fork tree of the
+	// current repository if any (nil if none)
 	OwnRepoForSubject *repo_model.Repository
+	// IsArchived is true if the repository is archived, so it can neither be edited nor forked
+	IsArchived bool
 	// CanSubmitChangeRequest is true if the user can submit a change request to this repository.
 	// This is true when the user has an existing fork of this repo (or any repo in the same subject's
 	// fork tree) and wants to propose changes via a pull request instead of editing their own fork.
@@ -72,18 +78,24 @@ func CheckForkOnEditPermissions(ctx context.Context, doer *user_model.User, repo
 		return perms, nil
 	}
 
+	// Archived articles are frozen - don't offer to fork them
+	if repo.IsArchived {
+		perms.IsArchived = true
+		return perms, nil
+	}
+
 	// Run subject ownership check and fork detection in parallel.
 	// These queries are independent and can be executed concurrently.
-	var ownRepo *repo_model.Repository
+	var ownRepos repo_model.RepositoryList
 	var existingFork *repo_model.Repository
 
 	g, gCtx := errgroup.WithContext(ctx)
 
-	// Check if user owns a different repository for the same subject
+	// Check if user owns other repositories for the same subject
 	if repo.SubjectID > 0 {
 		g.Go(func() error {
 			var err error
-			ownRepo, err = repo_model.GetRepositoryByOwnerIDAndSubjectID(gCtx, doer.ID, repo.SubjectID)
+			ownRepos, err = repo_model.GetRepositoriesByOwnerIDAndSubjectID(gCtx, doer.ID, repo.SubjectID)
 			return err
 		})
 	}
@@ -103,62 +115,73 @@ func CheckForkOnEditPermissions(ctx context.Context, doer *user_model.User, repo
 	// Process the results to determine permissions.
 	// Different scenarios:
 	//
-	// 1. User has no repo for this subject (ownRepo == nil):
+	// 1. User has no repo for this subject (ownRepos is empty), or fewer than allowed and none in this fork tree:
 	//    - If they have a fork of this repo: HasExistingFork=true, CanSubmitChangeRequest=true
 	//    - If they don't have a fork: NeedsFork=true, CanSubmitChangeRequest=true
 	//
-	// 2. User has a repo for this subject (ownRepo != nil):
-	//    a. Their repo IS a fork of this repo (ownRepo.ID == existingFork.ID):
-	//       - HasExistingFork=true, CanSubmitChangeRequest=true
-	//       - They can submit change requests to propose changes to this article
-	//    b. Their repo is NOT a fork of this repo (independent article):
-	//       - BlockedBySubject=true
-	//       - They cannot fork or submit change requests (one article per subject rule)
-
-	if ownRepo != nil {
-		// User owns a repo for this subject - check if it's part of the same fork tree
-		if existingFork != nil && ownRepo.ID == existingFork.ID {
-			// Case 2a: User's repo for the subject IS their direct fork of this repo
-			// They can submit change requests to propose changes
-			perms.HasExistingFork = true
-			perms.ExistingFork = existingFork
-			perms.OwnRepoForSubject = ownRepo
-			perms.CanSubmitChangeRequest = true
-		} else if ownRepo.IsFork {
+	// 2. User has a repo for this subject in this fork tree:
+	//    - Their repo IS a fork of this repo (ownRepo.ID == existingFork.ID), or an indirect fork
+	//      in the same fork tree:
+	//      - HasExistingFork=true, CanSubmitChangeRequest=true
+	//      - They can submit change requests to propose changes to this article
+	//
+	// 3. User has as many repos for this subject as allowed, none of them a fork of this repo:
+	//    - BlockedBySubject=true
+	//    - They cannot fork or submit change requests (limited articles per subject rule)
+
+	if len(ownRepos) > 0 {
+		// User owns repos for this subject - check if one of them is part of the same fork tree
+		if existingFork != nil {
+			if idx := slices.IndexFunc(ownRepos, func(ownRepo *repo_model.Repository) bool { return ownRepo.ID == existingFork.ID }); idx >= 0 {
+				// Case 2: User's repo for the subject IS their direct fork of this repo
+				// They can submit change requests to propose changes
+				perms.HasExistingFork = true
+				perms.ExistingFork = existingFork
+				perms.OwnRepoForSubject = ownRepos[idx]
+				perms.CanSubmitChangeRequest = true
+				return perms, nil
+			}
+		}
+
+		var repoRoot int64
+		for _, ownRepo := range ownRepos {
+			if !ownRepo.IsFork {
+				// The user's root article for this subject isn't a fork of this repo
+				continue
+			}
 			// ownRepo is a fork - check if it's an indirect fork (fork of a fork) in the same tree
 			// by comparing fork tree roots
 			ownRepoRoot, err := repo_model.FindForkTreeRoot(ctx, ownRepo.ID)
 			if err != nil {
 				return nil, err
 			}
-			repoRoot, err := repo_model.FindForkTreeRoot(ctx, repo.ID)
-			if err != nil {
-				return nil, err
+			if repoRoot == 0 {
+				if repoRoot, err = repo_model.FindForkTreeRoot(ctx, repo.ID); err != nil {
+					return nil, err
+				}
 			}
 
 			if ownRepoRoot == repoRoot {
-				// Case 2a (indirect): User's repo is an indirect fork in the same fork tree
+				// Case 2 (indirect): User's repo is an indirect fork in the same fork tree
 				// They can submit change requests to propose changes
 				perms.HasExistingFork = true
 				perms.ExistingFork = ownRepo
 				perms.OwnRepoForSubject = ownRepo
 				perms.CanSubmitChangeRequest = true
-			} else {
-				// Case 2b: User has an independent article for this subject (not in the same fork tree)
-				// Block them from forking or editing - one article per subject rule
-				perms.BlockedBySubject = true
-				perms.OwnRepoForSubject = ownRepo
+				return perms, nil
 			}
-		} else {
-			// Case 2b: User owns the root article for this subject (not a fork)
-			// Block them from forking or editing - they should edit their own root
+		}
+
+		if len(ownRepos) >= setting.Repository.Article.MaxReposPerUserPerSubject {
+			// Case 3: User has independent articles for this subject (not in the same fork tree)
+			// Block them from forking or editing - they should edit their own articles
 			perms.BlockedBySubject = true
-			perms.OwnRepoForSubject = ownRepo
+			perms.OwnRepoForSubject = ownRepos[0]
+			return perms, nil
 		}
-		return perms, nil
 	}
 
-	// Case 1: User has no repo for this subject
+	// Case 1: User has no repo for this subject in this fork tree and may own another one
 	// They can submit change requests and potentially fork
 	perms.CanSubmitChangeRequest = true
 
@@ -193,8 +216,8 @@ func (err ErrForkAlreadyExist) Unwrap() error {
 	return util.ErrAlreadyExist
 }
 
-// ErrUserOwnsSubjectRepo represents an error when a user already owns a different
-// repository for the same subject and cannot fork/edit another repository for that subject.
+// ErrUserOwnsSubjectRepo represents an error when a user already owns as many repositories
+// for the same subject as allowed and cannot fork/edit another repository for that subject.
 type ErrUserOwnsSubjectRepo struct {
 	UserID         int64
 	SubjectID      int64
@@ -216,6 +239,70 @@ func (err ErrUserOwnsSubjectRepo) Unwrap() error {
 	return util.ErrAlreadyExist
 }
 
+// CheckSubjectRepoLimit checks that ownerID may own one more repository of a subject, i.e. that it
+// owns fewer than [repository.article] MAX_REPOS_PER_USER_PER_SUBJECT repositories of the subject
+// besides excludeRepoID. It returns ErrUserOwnsSubjectRepo otherwise.
+func CheckSubjectRepoLimit(ctx context.Context, ownerID, subjectID, excludeRepoID int64) error {
+	if subjectID == 0 {
+		return nil
+	}
+	ownRepos, err := repo_model.GetRepositoriesByOwnerIDAndSubjectID(ctx, ownerID, subjectID)
+	if err != nil {
+		return err
+	}
+	ownRepos = slices.DeleteFunc(ownRepos, func(repo *repo_model.Repository) bool {
+		return repo.ID == excludeRepoID
+	})
+	if len(ownRepos) >= setting.Repository.Article.MaxReposPerUserPerSubject {
+		return ErrUserOwnsSubjectRepo{
+			UserID:         ownerID,
+			SubjectID:      subjectID,
+			ExistingRepoID: ownRepos[0].ID,
+		}
+	}
+	return nil
+}
+
+// ErrForkArchivedRepo represents an error when trying to fork an archived repository.
+type ErrForkArchivedRepo struct {
+	RepoID int64
+}
+
+// IsErrForkArchivedRepo checks if an error is an ErrForkArchivedRepo.
+func IsErrForkArchivedRepo(err error) bool {
+	var e ErrForkArchivedRepo
+	return errors.As(err, &e)
+}
+
+func (err ErrForkArchivedRepo) Error() string {
+	return fmt.Sprintf("cannot fork an archived repository [repo_id: %d]", err.RepoID)
+}
+
+func (err ErrForkArchivedRepo) Unwrap() error {
+	return util.ErrPermissionDenied
+}
+
+// ErrForkHasOpenChangeRequests represents an error when a fork cannot be detached or
+// deleted because other users still have change requests open against it.
+type ErrForkHasOpenChangeRequests struct {
+	RepoID int64
+	Count  int64
+}
+
+// IsErrForkHasOpenChangeRequests checks if an error is an ErrForkHasOpenChangeRequests.
+func IsErrForkHasOpenChangeRequests(err error) bool {
+	var e ErrForkHasOpenChangeRequests
+	return errors.As(err, &e)
+}
+
+func (err ErrForkHasOpenChangeRequests) Error() string {
+	return fmt.Sprintf("fork has open change requests [repo_id: %d, count: %d]", err.RepoID, err.Count)
+}
+
+func (err ErrForkHasOpenChangeRequests) Unwrap() error {
+	return util.ErrPermissionDenied
+}
+
 // ForkRepoOptions contains the fork repository options
 type ForkRepoOptions struct {
 	BaseRepo     *repo_model.Repository
@@ -274,6 +361,11 @@ func ForkRepository(ctx context.Context, doer, owner *user_model.User, opts Fork
 		return nil, user_model.ErrBlockedUser
 	}
 
+	// Archived repositories are frozen, forking them would only create confusing divergence
+	if opts.BaseRepo.IsArchived {
+		return nil, ErrForkArchivedRepo{RepoID: opts.BaseRepo.ID}
+	}
+
 	// Fork is prohibited, if user has reached maximum limit of repositories
 	if !doer.CanForkRepoIn(owner) {
 		return nil, repo_model.ErrReachLimitOfRepo{
@@ -286,20 +378,9 @@ func ForkRepository(ctx context.Context, doer, owner *user_model.User, opts Fork
 		return nil, err
 	}
 
-	// Check if user already owns a different repository for the same subject
-	// In Forkana, each user should only have one repository per subject
-	if opts.BaseRepo.SubjectID > 0 {
-		ownRepo, err := repo_model.GetRepositoryByOwnerIDAndSubjectID(ctx, owner.ID, opts.BaseRepo.SubjectID)
-		if err != nil {
-			return nil, err
-		}
-		if ownRepo != nil && ownRepo.ID != opts.BaseRepo.ID {
-			return nil, ErrUserOwnsSubjectRepo{
-				UserID:         owner.ID,
-				SubjectID:      opts.BaseRepo.SubjectID,
-				ExistingRepoID: ownRepo.ID,
-			}
-		}
+	// Check if user already owns as many repositories for the same subject as allowed
+	if err := CheckSubjectRepoLimit(ctx, owner.ID, opts.BaseRepo.SubjectID, opts.BaseRepo.ID); err != nil {
+		return nil, err
 	}
 
 	forkedRepo, err := repo_model.GetUserFork(ctx, opts.BaseRepo.ID, owner.ID)
@@ -465,13 +546,83 @@ func ConvertForkToNormalRepository(ctx context.Context, repo *repo_model.Reposit
 
 		repo.IsFork = false
 		repo.ForkID = 0
-		return repo_model.UpdateRepositoryColsNoAutoTime(ctx, repo, "is_fork", "fork_id")
+		if err := repo_model.UpdateRepositoryColsNoAutoTime(ctx, repo, "is_fork", "fork_id"); err != nil {
+			return err
+		}
+		return repo_model.RefreshSubjectCounts(ctx, repo.SubjectID)
 	})
 }
 
+// checkForkHasNoOpenChangeRequests returns ErrForkHasOpenChangeRequests if change requests are open against the fork
+func checkForkHasNoOpenChangeRequests(ctx context.Context, repo *repo_model.Repository) error {
+	count, err := issues_model.CountOpenPullRequestsByBaseRepo(ctx, repo.ID)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrForkHasOpenChangeRequests{RepoID: repo.ID, Count: count}
+	}
+	return nil
+}
+
+// DetachFork converts a fork its owner no longer wants to follow its parent back into a
+// standalone repository. If leaveSubject is true the repository also stops belonging to its
+// subject, so it no longer counts towards it. Otherwise it must become the subject's root,
+// which is only possible if the subject has no other root; ErrRootArticleAlreadyExists is
+// returned if it does. Forks with open change requests can't be detached.
+func DetachFork(ctx context.Context, repo *repo_model.Repository, leaveSubject bool) error {
+	if !repo.IsFork {
+		return nil
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := checkForkHasNoOpenChangeRequests(ctx, repo); err != nil {
+			return err
+		}
+
+		if repo.SubjectID > 0 && !leaveSubject {
+			rootRepo, err := repo_model.GetSubjectRootRepositoryExcluding(ctx, repo.SubjectID, repo.ID)
+			if err == nil {
+				return repo_model.ErrRootArticleAlreadyExists{SubjectID: repo.SubjectID, RootRepoID: rootRepo.ID}
+			} else if !repo_model.IsErrRepoNotExist(err) {
+				return err
+			}
+		}
+
+		if err := ConvertForkToNormalRepository(ctx, repo); err != nil {
+			return err
+		}
+		repo.IsFork = false
+		repo.ForkID = 0
+
+		if repo.SubjectID > 0 {
+			if leaveSubject {
+				subjectID := repo.SubjectID
+				repo.SubjectID = 0
+				if err := repo_model.UpdateRepositoryColsNoAutoTime(ctx, repo, "subject_id"); err != nil {
+					return err
+				}
+				return repo_model.RefreshSubjectCounts(ctx, subjectID)
+			}
+			if !repo.IsEmpty {
+				return repo_model.SetSubjectRootRepoID(ctx, repo.SubjectID, repo.ID)
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteFork deletes a fork at its owner's request. Forks with open change requests can't be deleted.
+func DeleteFork(ctx context.Context, doer *user_model.User, repo *repo_model.Repository) error {
+	if err := checkForkHasNoOpenChangeRequests(ctx, repo); err != nil {
+		return err
+	}
+	return DeleteRepository(ctx, doer, repo, true)
+}
+
 // ConvertNormalToForkRepository converts a normal repository to a fork of the specified root repository.
 // This is used by the first-article-becomes-root logic when a repository becomes non-empty
 // after another repository with the same subject has already become the root.
+// ErrForkArchivedRepo is returned if the root repository is archived.
 func ConvertNormalToForkRepository(ctx context.Context, repo *repo_model.Repository, rootRepoID int64) error {
 	return db.WithTx(ctx, func(ctx context.Context) error {
 		// Re-fetch the repo within the transaction to ensure consistency
@@ -496,6 +647,11 @@ func ConvertNormalToForkRepository(ctx context.Context, repo *repo_model.Reposit
 			return err
 		}
 
+		// Archived roots are frozen and can't be forked
+		if rootRepo.IsArchived {
+			return ErrForkArchivedRepo{RepoID: rootRepoID}
+		}
+
 		// Check if fork tree has reached maximum size limit
 		if err := checkForkTreeSizeLimit(ctx, rootRepo); err != nil {
 			return err
@@ -510,10 +666,134 @@ func ConvertNormalToForkRepository(ctx context.Context, repo *repo_model.Reposit
 		// Update this repository to be a fork
 		repo.IsFork = true
 		repo.ForkID = rootRepoID
-		return repo_model.UpdateRepositoryColsNoAutoTime(ctx, repo, "is_fork", "fork_id")
+		if err := repo_model.UpdateRepositoryColsNoAutoTime(ctx, repo, "is_fork", "fork_id"); err != nil {
+			return err
+		}
+		// Like ForkRepository, the fork gets the license of the root unless it has its own
+		if err := repo_model.InheritRootLicense(ctx, rootRepo, repo); err != nil {
+			return err
+		}
+		return repo_model.RefreshSubjectCounts(ctx, repo.SubjectID)
 	})
 }
 
+// PromoteOldestRepositoryToRoot repairs a subject that has repositories but no root by
+// converting its oldest non-empty repository into a normal (root) repository.
+// It returns ErrRepoNotExist if the subject only has empty repositories.
+func PromoteOldestRepositoryToRoot(ctx context.Context, subjectID int64) (*repo_model.Repository, error) {
+	rootCount, err := repo_model.CountRootRepositoriesBySubject(ctx, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	if rootCount > 0 {
+		rootRepo, err := repo_model.GetSubjectRootRepository(ctx, subjectID)
+		if err != nil {
+			return nil, err
+		}
+		return nil, repo_model.ErrRootArticleAlreadyExists{SubjectID: subjectID, RootRepoID: rootRepo.ID}
+	}
+
+	repo, err := repo_model.GetOldestNonEmptyRepositoryBySubject(ctx, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	if err := ConvertForkToNormalRepository(ctx, repo); err != nil {
+		return nil, err
+	}
+	if err := repo_model.SetSubjectRootRepoID(ctx, subjectID, repo.ID); err != nil {
+		return nil, err
+	}
+	repo.IsFork = false
+	repo.ForkID = 0
+	return repo, nil
+}
+
+// ReconcileSubjectRoots repairs subjects that have more than one root repository, which the
+// first-article-becomes-root logic prevents but bulk-imported data may still contain. The oldest
+// root of each subject stays its root and the other roots are converted to forks of it. A root
+// that can't be converted because the fork tree is full or the root is archived is left alone.
+// It returns the number of converted repositories, so running it again reports no changes.
+func ReconcileSubjectRoots(ctx context.Context) (int64, error) {
+	subjectIDs, err := repo_model.FindSubjectIDsWithMultipleRoots(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var converted int64
+	for _, subjectID := range subjectIDs {
+		repos, err := repo_model.FindNonEmptyRepositoriesBySubject(ctx, subjectID)
+		if err != nil {
+			return converted, err
+		}
+
+		var rootRepo *repo_model.Repository
+		for _, repo := range repos {
+			if repo.IsFork {
+				continue
+			}
+			if rootRepo == nil {
+				rootRepo = repo
+				if err := repo_model.SetSubjectRootRepoID(ctx, subjectID, rootRepo.ID); err != nil {
+					return converted, err
+				}
+				continue
+			}
+
+			if err := ConvertNormalToForkRepository(ctx, repo, rootRepo.ID); err != nil {
+				if repo_model.IsErrForkTreeTooLarge(err) || IsErrForkArchivedRepo(err) {
+					log.Warn("Unable to convert %-v to a fork of subject root %-v: %v", repo, rootRepo, err)
+					continue
+				}
+				return converted, fmt.Errorf("convert %-v to a fork of %-v: %w", repo, rootRepo, err)
+			}
+			log.Trace("Converted %-v to a fork of subject root %-v", repo, rootRepo)
+			converted++
+		}
+	}
+	return converted, nil
+}
+
+// RepairOrphanedFork repairs a fork whose parent repository no longer exists. If the fork's
+// subject still has a root repository the fork is reparented to it, otherwise the fork is
+// converted to a normal repository. It returns the new parent, or nil if the fork was converted.
+func RepairOrphanedFork(ctx context.Context, repo *repo_model.Repository) (*repo_model.Repository, error) {
+	var newParent *repo_model.Repository
+	err := db.WithTx(ctx, func(ctx context.Context) error {
+		repo, err := repo_model.GetRepositoryByID(ctx, repo.ID)
+		if err != nil {
+			return err
+		}
+		if !repo.IsFork {
+			return nil
+		}
+
+		// Only repair forks that are really orphaned
+		if _, err := repo_model.GetRepositoryByID(ctx, repo.ForkID); err == nil {
+			return nil
+		} else if !repo_model.IsErrRepoNotExist(err) {
+			return err
+		}
+
+		if repo.SubjectID > 0 {
+			rootRepo, err := repo_model.GetSubjectRootRepositoryExcluding(ctx, repo.SubjectID, repo.ID)
+			if err != nil && !repo_model.IsErrRepoNotExist(err) {
+				return err
+			}
+			if rootRepo != nil {
+				if err := repo_model.IncrementRepoForkNum(ctx, rootRepo.ID); err != nil {
+					return err
+				}
+				repo.ForkID = rootRepo.ID
+				newParent = rootRepo
+				return repo_model.UpdateRepositoryColsNoAutoTime(ctx, repo, "fork_id")
+			}
+		}
+
+		return ConvertForkToNormalRepository(ctx, repo)
+	})
+	return newParent, err
+}
+
 type findForksOptions struct {
 	db.ListOptions
 	RepoID int64
@@ -536,3 +816,31 @@ func FindForks(ctx context.Context, repo *repo_model.Repository, doer *user_mode
 		Doer:        doer,
 	})
 }
+
+type findSubjectReposOptions struct {
+	db.ListOptions
+	SubjectID int64
+	Doer      *user_model.User
+}
+
+func (opts findSubjectReposOptions) ToConds() builder.Cond {
+	cond := builder.Eq{"subject_id": opts.SubjectID}
+	if opts.Doer != nil && opts.Doer.IsAdmin {
+		return cond
+	}
+	return cond.And(repo_model.AccessibleRepositoryCondition(opts.Doer, unit.TypeInvalid))
+}
+
+func (opts findSubjectReposOptions) ToOrders() string {
+	// the root article first, then the most recently updated ones
+	return "is_fork ASC, is_empty ASC, updated_unix DESC, id ASC"
+}
+
+// FindRepositoriesBySubject returns the repositories of a subject that are visible to doer
+func FindRepositoriesBySubject(ctx context.Context, subjectID int64, doer *user_model.User, listOptions db.ListOptions) ([]*repo_model.Repository, int64, error) {
+	return db.FindAndCount[repo_model.Repository](ctx, findSubjectReposOptions{
+		ListOptions: listOptions,
+		SubjectID:   subjectID,
+		Doer:        doer,
+	})
+}
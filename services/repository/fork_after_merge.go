@@ -0,0 +1,121 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"context"
+	"errors"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/queue"
+	notify_service "code.gitea.io/gitea/services/notify"
+)
+
+// forkAfterMergeQueue applies the fork after merge preference once change requests have been merged,
+// after the merge handlers are done with the head branch of the fork
+var forkAfterMergeQueue *queue.WorkerPoolQueue[int64]
+
+func initForkAfterMergeQueue() error {
+	forkAfterMergeQueue = queue.CreateUniqueQueue(graceful.GetManager().ShutdownContext(), "fork_after_merge", forkAfterMergeHandler)
+	if forkAfterMergeQueue == nil {
+		return errors.New("unable to create fork_after_merge queue")
+	}
+	go graceful.GetManager().RunWithCancel(forkAfterMergeQueue)
+
+	// every merge is announced to the notifiers, whether it was done from the UI, the API, by the
+	// automerge or detected when the head was pushed to the base branch manually
+	notify_service.RegisterNotifier(&forkAfterMergeNotifier{})
+	return nil
+}
+
+func forkAfterMergeHandler(prIDs ...int64) []int64 {
+	ctx := graceful.GetManager().ShutdownContext()
+	for _, prID := range prIDs {
+		pr, err := issues_model.GetPullRequestByID(ctx, prID)
+		if err != nil {
+			log.Error("GetPullRequestByID [%d] failed: %v", prID, err)
+			continue
+		}
+		if _, err := ApplyForkAfterMergePreference(ctx, pr); err != nil {
+			log.Error("ApplyForkAfterMergePreference [%d] failed: %v", prID, err)
+		}
+	}
+	return nil
+}
+
+type forkAfterMergeNotifier struct {
+	notify_service.NullNotifier
+}
+
+var _ notify_service.Notifier = &forkAfterMergeNotifier{}
+
+func (n *forkAfterMergeNotifier) MergePullRequest(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
+	if err := forkAfterMergeQueue.Push(pr.ID); err != nil {
+		log.Error("Unable to queue the fork after merge preference of pull request %d: %v", pr.ID, err)
+	}
+}
+
+func (n *forkAfterMergeNotifier) AutoMergePullRequest(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
+	n.MergePullRequest(ctx, doer, pr)
+}
+
+// ApplyForkAfterMergePreference disposes of the fork a merged change request was proposed
+// from, as chosen by the fork owner's SettingsKeyForkAfterMerge preference: the fork is
+// kept (the default), converted into a standalone repository that leaves its subject, or
+// deleted. Nothing is done for change requests that don't come from a fork of their base
+// repository, or while the fork still has other open change requests, from or against it.
+// It returns the disposition that was applied.
+func ApplyForkAfterMergePreference(ctx context.Context, pr *issues_model.PullRequest) (string, error) {
+	if !pr.HasMerged || pr.HeadRepoID == pr.BaseRepoID {
+		return user_model.SettingForkAfterMergeKeep, nil
+	}
+	if err := pr.LoadHeadRepo(ctx); err != nil {
+		return "", err
+	}
+	fork := pr.HeadRepo
+	if fork == nil || !fork.IsFork || fork.ForkID != pr.BaseRepoID {
+		return user_model.SettingForkAfterMergeKeep, nil
+	}
+
+	disposition, err := user_model.GetUserSetting(ctx, fork.OwnerID, user_model.SettingsKeyForkAfterMerge, user_model.SettingForkAfterMergeKeep)
+	if err != nil {
+		return "", err
+	}
+	if disposition != user_model.SettingForkAfterMergeConvert && disposition != user_model.SettingForkAfterMergeDelete {
+		return user_model.SettingForkAfterMergeKeep, nil
+	}
+
+	openFrom, err := issues_model.CountOpenPullRequestsByHeadRepo(ctx, fork.ID)
+	if err != nil {
+		return "", err
+	}
+	openAgainst, err := issues_model.CountOpenPullRequestsByBaseRepo(ctx, fork.ID)
+	if err != nil {
+		return "", err
+	}
+	if openFrom > 0 || openAgainst > 0 {
+		log.Trace("Keeping fork %-v after merge of pull request %d: it still has open change requests", fork, pr.ID)
+		return user_model.SettingForkAfterMergeKeep, nil
+	}
+
+	switch disposition {
+	case user_model.SettingForkAfterMergeConvert:
+		if err := DetachFork(ctx, fork, true); err != nil {
+			return "", err
+		}
+		log.Trace("Fork %-v converted into a standalone repository after merge of pull request %d", fork, pr.ID)
+	case user_model.SettingForkAfterMergeDelete:
+		if err := fork.LoadOwner(ctx); err != nil {
+			return "", err
+		}
+		if err := DeleteFork(ctx, fork.Owner, fork); err != nil {
+			return "", err
+		}
+		log.Trace("Fork %-v deleted after merge of pull request %d", fork, pr.ID)
+	}
+	return disposition, nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyForkAfterMergePreference(t *testing.T) {
+	// PR 3 proposes changes from repo11 (owned by user13) to repo10, which repo11 is a fork of
+	mergePR := func(t *testing.T, preference string) *issues_model.PullRequest {
+		require.NoError(t, unittest.PrepareTestDatabase())
+		if preference != "" {
+			require.NoError(t, user_model.SetUserSetting(t.Context(), 13, user_model.SettingsKeyForkAfterMerge, preference))
+		}
+		_, err := db.GetEngine(t.Context()).ID(8).Cols("is_closed").Update(&issues_model.Issue{IsClosed: true})
+		require.NoError(t, err)
+		pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 3})
+		pr.HasMerged = true
+		return pr
+	}
+
+	t.Run("KeepByDefault", func(t *testing.T) {
+		pr := mergePR(t, "")
+
+		disposition, err := ApplyForkAfterMergePreference(t.Context(), pr)
+		require.NoError(t, err)
+		assert.Equal(t, user_model.SettingForkAfterMergeKeep, disposition)
+		assertRepoForkOf(t, 11, 10)
+		unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 10, NumForks: 1})
+	})
+
+	t.Run("Keep", func(t *testing.T) {
+		pr := mergePR(t, user_model.SettingForkAfterMergeKeep)
+
+		disposition, err := ApplyForkAfterMergePreference(t.Context(), pr)
+		require.NoError(t, err)
+		assert.Equal(t, user_model.SettingForkAfterMergeKeep, disposition)
+		assertRepoForkOf(t, 11, 10)
+		unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 10, NumForks: 1})
+	})
+
+	t.Run("Convert", func(t *testing.T) {
+		pr := mergePR(t, user_model.SettingForkAfterMergeConvert)
+
+		disposition, err := ApplyForkAfterMergePreference(t.Context(), pr)
+		require.NoError(t, err)
+		assert.Equal(t, user_model.SettingForkAfterMergeConvert, disposition)
+		assertRepoForkOf(t, 11, 0)
+		root := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 10})
+		assert.Equal(t, 0, root.NumForks)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		pr := mergePR(t, user_model.SettingForkAfterMergeDelete)
+
+		disposition, err := ApplyForkAfterMergePreference(t.Context(), pr)
+		require.NoError(t, err)
+		assert.Equal(t, user_model.SettingForkAfterMergeDelete, disposition)
+		unittest.AssertNotExistsBean(t, &repo_model.Repository{ID: 11})
+		root := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 10})
+		assert.Equal(t, 0, root.NumForks)
+	})
+
+	t.Run("KeepWhileOtherChangeRequestsAreOpen", func(t *testing.T) {
+		pr := mergePR(t, user_model.SettingForkAfterMergeDelete)
+		_, err := db.GetEngine(t.Context()).ID(8).Cols("is_closed").Update(&issues_model.Issue{IsClosed: false})
+		require.NoError(t, err)
+
+		disposition, err := ApplyForkAfterMergePreference(t.Context(), pr)
+		require.NoError(t, err)
+		assert.Equal(t, user_model.SettingForkAfterMergeKeep, disposition)
+		assertRepoForkOf(t, 11, 10)
+	})
+
+	t.Run("NotMerged", func(t *testing.T) {
+		pr := mergePR(t, user_model.SettingForkAfterMergeDelete)
+		pr.HasMerged = false
+
+		disposition, err := ApplyForkAfterMergePreference(t.Context(), pr)
+		require.NoError(t, err)
+		assert.Equal(t, user_model.SettingForkAfterMergeKeep, disposition)
+		assertRepoForkOf(t, 11, 10)
+	})
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/cache"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	pull_service "code.gitea.io/gitea/services/pull"
+)
+
+// forkDivergenceCacheTimeout is how long the divergence of a fork is cached, in seconds.
+// Entries are keyed by the head commits of both repositories, so they never become stale.
+const forkDivergenceCacheTimeout = 7 * 24 * 60 * 60
+
+func getForkDivergenceCacheKey(rootRepoID int64, rootCommitID string, forkRepoID int64, forkCommitID string) string {
+	return fmt.Sprintf("fork-divergence-%d-%s-%d-%s", rootRepoID, rootCommitID, forkRepoID, forkCommitID)
+}
+
+// GetForkDivergence returns how many commits the default branch of fork is ahead of and behind
+// the default branch of rootRepo, whose head is rootCommitID. forkGitRepo must be the open git
+// repository of fork, so that callers iterating over many forks don't open them twice.
+func GetForkDivergence(ctx context.Context, rootRepo *repo_model.Repository, rootCommitID string, fork *repo_model.Repository, forkGitRepo *git.Repository) (*git.DivergeObject, error) {
+	forkCommitID, err := forkGitRepo.GetBranchCommitID(fork.DefaultBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheKey := getForkDivergenceCacheKey(rootRepo.ID, rootCommitID, fork.ID, forkCommitID)
+	if data, ok := cache.GetCache().Get(cacheKey); ok && data != "" {
+		divergence := &git.DivergeObject{}
+		if err := json.Unmarshal(util.UnsafeStringToBytes(data), divergence); err == nil {
+			return divergence, nil
+		}
+		log.Warn("GetForkDivergence: invalid cache entry %s", cacheKey)
+	}
+
+	// fileOnly avoids loading the commits, only the merge base is needed to count them
+	compareInfo, err := pull_service.GetCompareInfo(ctx, rootRepo, fork, forkGitRepo, rootRepo.DefaultBranch, fork.DefaultBranch, false, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// The commits of the root repository have been fetched into the fork by GetCompareInfo
+	divergence := &git.DivergeObject{}
+	ahead, err := forkGitRepo.CommitsCountBetween(compareInfo.MergeBase, compareInfo.HeadCommitID)
+	if err != nil {
+		return nil, err
+	}
+	behind, err := forkGitRepo.CommitsCountBetween(compareInfo.MergeBase, compareInfo.BaseCommitID)
+	if err != nil {
+		return nil, err
+	}
+	divergence.Ahead, divergence.Behind = int(ahead), int(behind)
+
+	if bs, err := json.Marshal(divergence); err == nil {
+		if err := cache.GetCache().Put(cacheKey, util.UnsafeBytesToString(bs), forkDivergenceCacheTimeout); err != nil {
+			log.Warn("GetForkDivergence: failed to cache %s: %v", cacheKey, err)
+		}
+	}
+	return divergence, nil
+}
+
+// ForkDivergence is how far the default branch of a fork diverges from the repository it was
+// forked from, and how many people contributed to the fork since
+type ForkDivergence struct {
+	Ahead            int
+	Behind           int
+	ContributorCount int
+}
+
+// GetForksDivergence returns the divergence of the forks of repo from its default branch, keyed
+// by fork ID. Empty forks, and forks whose divergence can't be computed, are left out.
+// The divergence and the contributor counts are cached, see GetForkDivergence and getContributorStats.
+func GetForksDivergence(ctx context.Context, repo *repo_model.Repository, forks []*repo_model.Repository) (map[int64]*ForkDivergence, error) {
+	result := make(map[int64]*ForkDivergence, len(forks))
+	if repo.IsEmpty || len(forks) == 0 {
+		return result, nil
+	}
+
+	gitRepo, err := gitrepo.OpenRepository(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	commitID, err := gitRepo.GetBranchCommitID(repo.DefaultBranch)
+	gitRepo.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fork := range forks {
+		if fork.IsEmpty {
+			continue
+		}
+		forkGitRepo, err := gitrepo.OpenRepository(ctx, fork)
+		if err != nil {
+			log.Warn("GetForksDivergence: OpenRepository for fork %s: %v", fork.FullName(), err)
+			continue
+		}
+		divergence, err := GetForkDivergence(ctx, repo, commitID, fork, forkGitRepo)
+		forkGitRepo.Close()
+		if err != nil {
+			log.Warn("GetForksDivergence: GetForkDivergence for fork %s: %v", fork.FullName(), err)
+			continue
+		}
+
+		info := &ForkDivergence{Ahead: divergence.Ahead, Behind: divergence.Behind}
+		if stats, err := getContributorStats(fork, setting.Repository.ContributorStatsWindowDays, getForkSinceTime(fork)); err == nil {
+			info.ContributorCount = stats.TotalCount
+		} else {
+			log.Warn("GetForksDivergence: getContributorStats for fork %s: %v", fork.FullName(), err)
+		}
+		result[fork.ID] = info
+	}
+	return result, nil
+}
@@ -12,14 +12,19 @@ import (
 	"time"
 
 	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
 	perm_model "code.gitea.io/gitea/models/perm"
 	access_model "code.gitea.io/gitea/models/perm/access"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/cache"
 	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
 	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/services/convert"
+
+	"golang.org/x/sync/errgroup"
 )
 
 // Error definitions
@@ -139,13 +144,19 @@ func clearForkStatsCacheKeysForTesting() {
 
 // ForkGraphParams represents parameters for building fork graph
 type ForkGraphParams struct {
-	IncludeContributors bool
-	ContributorDays     int
-	MaxDepth            int
-	IncludePrivate      bool
-	Sort                string
-	Page                int
-	Limit               int
+	IncludeContributors   bool
+	IncludeChangeRequests bool
+	ContributorDays       int // window for "recent" contributors, 0 means setting.Repository.ContributorStatsWindowDays
+	MaxDepth              int
+	IncludePrivate        bool
+	Sort                  string
+	Page                  int
+	Limit                 int
+	// Flatten attaches all the forks directly to the root node, see flattenForkTree
+	Flatten bool
+	// RootRepoID, if set, is the repository the graph starts at instead of the root of the fork
+	// tree, limiting it to the subtree of e.g. the fork of a user
+	RootRepoID int64
 }
 
 // ForkGraphResponse represents the complete fork graph response
@@ -163,6 +174,24 @@ type ForkNode struct {
 	Level        int               `json:"level"`
 	Children     []*ForkNode       `json:"children"`
 
+	// OpenChangeRequests is the number of open change requests targeting the default branch,
+	// only set if the graph was built with IncludeChangeRequests
+	OpenChangeRequests int `json:"open_change_requests,omitempty"`
+
+	// OriginalParentID is the ID of the node of the repository this one is a fork of,
+	// only set on the forks of a graph built with Flatten
+	OriginalParentID string `json:"original_parent_id,omitempty"`
+
+	// ViewerFork is the full name of the viewer's fork of this repository, only set if they have one
+	ViewerFork string `json:"viewer_fork,omitempty"`
+
+	// LastCommitUnix is when the repository was last updated, a stand-in for its last commit
+	// that doesn't need git
+	LastCommitUnix int64 `json:"last_commit_unix,omitempty"`
+	// HasOwnCommits is true if the repository is a fork that has been updated since it was
+	// created, i.e. it most likely has commits beyond those inherited from its base
+	HasOwnCommits bool `json:"has_own_commits"`
+
 	// Internal field for batch processing (not exported to JSON)
 	repo *repo_model.Repository `json:"-"`
 }
@@ -181,6 +210,9 @@ type GraphMetadata struct {
 	CacheStatus           string    `json:"cache_status"`
 	GeneratedAt           time.Time `json:"generated_at"`
 	ContributorWindowDays int       `json:"contributor_window_days,omitempty"`
+
+	// IncludesChangeRequests is true if the nodes have OpenChangeRequests counts
+	IncludesChangeRequests bool `json:"includes_change_requests,omitempty"`
 }
 
 // PaginationInfo represents pagination information
@@ -192,19 +224,32 @@ type PaginationInfo struct {
 }
 
 const (
-	maxNodes          = 10000
-	processingTimeout = 30 * time.Second
+	// defaultMaxNodes and defaultProcessingTimeout are used when [repository] FORK_GRAPH_MAX_NODES
+	// and FORK_GRAPH_TIMEOUT aren't positive
+	defaultMaxNodes          = 10000
+	defaultProcessingTimeout = 30 * time.Second
+
+	// contributorStatsPrefetchLimit bounds the number of repositories whose contributor
+	// stats are computed concurrently while building a fork graph
+	contributorStatsPrefetchLimit = 8
+
+	// forkSettleTime is how long after its creation a fork may still be updated by the
+	// forking itself, see forkHasOwnCommits
+	forkSettleTime = time.Minute
 
 	// forkContributorStatsCacheKey is the cache key format for pre-filtered fork contributor stats.
 	// Format: "ForkContributorStats/{repoID}/{sinceUnix}/{days}"
 	// This secondary cache stores pre-filtered results to avoid repeated post-cache filtering.
 	forkContributorStatsCacheKey = "ForkContributorStats/%d/%d/%d"
-	// forkContributorStatsCacheTimeout is the TTL for fork contributor stats cache (5 minutes).
-	// This is shorter than the base contributor stats cache (10 minutes) to ensure
-	// the secondary cache doesn't outlive the underlying data.
-	forkContributorStatsCacheTimeout int64 = 60 * 5
 )
 
+// forkContributorStatsCacheTimeout returns the TTL in seconds for the fork contributor stats cache,
+// [repository] FORK_CONTRIBUTOR_STATS_CACHE_TTL. It is half of contributorStatsCacheTimeout to ensure
+// the secondary cache doesn't outlive the underlying data.
+func forkContributorStatsCacheTimeout() int64 {
+	return int64(setting.Repository.ForkContributorStatsCacheTTL.Seconds())
+}
+
 // BuildForkGraph builds the fork graph for a repository
 func BuildForkGraph(ctx context.Context, repo *repo_model.Repository, params ForkGraphParams, doer *user_model.User) (*ForkGraphResponse, error) {
 	// Find the root repository for the fork graph.
@@ -212,11 +257,26 @@ func BuildForkGraph(ctx context.Context, repo *repo_model.Repository, params For
 	// 1. If the repository has a subject, find the subject's root repository (first non-empty, non-fork repo for that subject)
 	// 2. Otherwise, traverse up the fork chain to find the root
 	// This ensures the bubble view always shows the global subject fork tree, not a user-specific view.
+	if params.ContributorDays <= 0 {
+		params.ContributorDays = setting.Repository.ContributorStatsWindowDays
+	}
+
 	rootRepo := repo
 	foundNonEmptyRoot := false
 
-	// First, try to find the subject's root repository
-	if repo.SubjectID > 0 {
+	if params.RootRepoID > 0 {
+		// The graph is limited to the subtree of the given repository
+		startRepo, err := repo_model.GetRepositoryByID(ctx, params.RootRepoID)
+		if err != nil {
+			return nil, err
+		}
+		if err := startRepo.LoadOwner(ctx); err != nil {
+			return nil, err
+		}
+		rootRepo = startRepo
+		foundNonEmptyRoot = !startRepo.IsEmpty
+	} else if repo.SubjectID > 0 {
+		// First, try to find the subject's root repository
 		subjectRoot, err := repo_model.GetSubjectRootRepository(ctx, repo.SubjectID)
 		if err == nil {
 			if err := subjectRoot.LoadOwner(ctx); err != nil {
@@ -233,7 +293,7 @@ func BuildForkGraph(ctx context.Context, repo *repo_model.Repository, params For
 	}
 
 	// If we didn't find a subject root, traverse up the fork chain
-	if rootRepo.ID == repo.ID && repo.IsFork {
+	if params.RootRepoID == 0 && rootRepo.ID == repo.ID && repo.IsFork {
 		current := repo
 		for current.IsFork {
 			parent, err := repo_model.GetRepositoryByID(ctx, current.ForkID)
@@ -259,19 +319,12 @@ func BuildForkGraph(ctx context.Context, repo *repo_model.Repository, params For
 	// Empty repositories should not be shown as bubbles - only repositories with actual content count.
 	if !foundNonEmptyRoot && rootRepo.IsEmpty {
 		log.Info("Repository %s is empty and no non-empty root exists for subject ID %d. Returning empty graph.", repo.FullName(), repo.SubjectID)
-		return &ForkGraphResponse{
-			Root: nil,
-			Metadata: GraphMetadata{
-				TotalForks:      0,
-				VisibleForks:    0,
-				MaxDepthReached: false,
-				CacheStatus:     "miss",
-				GeneratedAt:     time.Now(),
-			},
-		}, nil
+		return NewEmptyForkGraph(), nil
 	}
 
 	// Create context with timeout
+	processingTimeout := util.Iif(setting.Repository.ForkGraphTimeout > 0, setting.Repository.ForkGraphTimeout, defaultProcessingTimeout)
+	maxNodes := util.Iif(setting.Repository.ForkGraphMaxNodes > 0, setting.Repository.ForkGraphMaxNodes, defaultMaxNodes)
 	timeoutCtx, cancel := context.WithTimeout(ctx, processingTimeout)
 	defer cancel()
 
@@ -281,10 +334,13 @@ func BuildForkGraph(ctx context.Context, repo *repo_model.Repository, params For
 	maxDepthReached := false
 
 	// Build the tree structure
-	rootNode, err := buildNode(timeoutCtx, rootRepo, 0, params, doer, visited, &nodeCount, &maxDepthReached)
+	rootNode, err := buildNode(timeoutCtx, rootRepo, 0, params, doer, visited, &nodeCount, maxNodes, &maxDepthReached)
 	if err != nil {
 		return nil, err
 	}
+	if params.Flatten {
+		flattenForkTree(rootNode)
+	}
 
 	// Collect all repositories from the tree for batch loading
 	allRepos := collectRepositories(rootNode)
@@ -295,6 +351,29 @@ func BuildForkGraph(ctx context.Context, repo *repo_model.Repository, params For
 		// Continue anyway - individual loads will happen in convert.ToRepo
 	}
 
+	// Compute the contributor stats of all nodes concurrently instead of one node at a time
+	if params.IncludeContributors {
+		stats := prefetchContributorStats(timeoutCtx, allRepos, params.ContributorDays)
+		setContributorStats(rootNode, stats)
+	}
+
+	// Count open change requests before the internal repositories are dropped by the conversion
+	includesChangeRequests := false
+	if params.IncludeChangeRequests {
+		if err := loadOpenChangeRequestCounts(ctx, rootNode, allRepos); err != nil {
+			log.Warn("Failed to load open change request counts: %v", err)
+		} else {
+			includesChangeRequests = true
+		}
+	}
+
+	// Mark the repositories the viewer has already forked, with a single query for all nodes
+	if doer != nil {
+		if err := loadViewerForks(ctx, rootNode, allRepos, doer.ID); err != nil {
+			log.Warn("Failed to load the forks of user %d: %v", doer.ID, err)
+		}
+	}
+
 	// Convert all nodes to API format (using preloaded data)
 	convertNodesToAPI(ctx, rootNode)
 
@@ -317,12 +396,28 @@ func BuildForkGraph(ctx context.Context, repo *repo_model.Repository, params For
 	if params.IncludeContributors {
 		response.Metadata.ContributorWindowDays = params.ContributorDays
 	}
+	response.Metadata.IncludesChangeRequests = includesChangeRequests
 
 	return response, nil
 }
 
-// buildNode recursively builds a fork node
-func buildNode(ctx context.Context, repo *repo_model.Repository, level int, params ForkGraphParams, doer *user_model.User, visited map[int64]bool, nodeCount *int, maxDepthReached *bool) (*ForkNode, error) {
+// NewEmptyForkGraph returns a fork graph without any node, which the bubble view shows as the
+// "Create first article" prompt
+func NewEmptyForkGraph() *ForkGraphResponse {
+	return &ForkGraphResponse{
+		Root: nil,
+		Metadata: GraphMetadata{
+			TotalForks:      0,
+			VisibleForks:    0,
+			MaxDepthReached: false,
+			CacheStatus:     "miss",
+			GeneratedAt:     time.Now(),
+		},
+	}
+}
+
+// buildNode recursively builds a fork node, ErrTooManyNodes is returned once the graph has maxNodes nodes
+func buildNode(ctx context.Context, repo *repo_model.Repository, level int, params ForkGraphParams, doer *user_model.User, visited map[int64]bool, nodeCount *int, maxNodes int, maxDepthReached *bool) (*ForkNode, error) {
 	// Check timeout
 	select {
 	case <-ctx.Done():
@@ -346,20 +441,20 @@ func buildNode(ctx context.Context, repo *repo_model.Repository, level int, para
 	// Check depth limit
 	if level >= params.MaxDepth {
 		*maxDepthReached = true
-		return createLeafNode(repo, level, params)
+		return createLeafNode(repo, level)
 	}
 
 	// Get direct forks
 	forks, err := getDirectForks(ctx, repo.ID, doer, params)
 	if err != nil {
 		log.Error("Failed to get forks for repo %d: %v", repo.ID, err)
-		return createLeafNode(repo, level, params)
+		return createLeafNode(repo, level)
 	}
 
 	// Build children
 	children := make([]*ForkNode, 0, len(forks))
 	for _, fork := range forks {
-		childNode, err := buildNode(ctx, fork, level+1, params, doer, visited, nodeCount, maxDepthReached)
+		childNode, err := buildNode(ctx, fork, level+1, params, doer, visited, nodeCount, maxNodes, maxDepthReached)
 		if err != nil {
 			if errors.Is(err, ErrProcessingTimeout) || errors.Is(err, ErrTooManyNodes) {
 				return nil, err
@@ -378,46 +473,57 @@ func buildNode(ctx context.Context, repo *repo_model.Repository, level int, para
 		}
 	}
 
-	// Create node
-	node := &ForkNode{
-		ID:       fmt.Sprintf("repo_%d", repo.ID),
-		Level:    level,
-		Children: children,
-		repo:     repo, // Store for batch processing
-	}
+	return newForkNode(repo, level, children), nil
+}
 
-	// Add contributor stats if requested
-	if params.IncludeContributors {
-		stats, err := getContributorStats(repo, params.ContributorDays, getForkSinceTime(repo))
-		if err != nil {
-			log.Warn("Failed to get contributor stats for repo %d: %v", repo.ID, err)
-		} else {
-			node.Contributors = stats
+// flattenForkTree makes all the descendants of root its direct children, in depth-first order, each
+// with the ID of the node it was a child of as OriginalParentID. The tree was built with the usual
+// cycle detection and node limits, so it is only reshaped.
+func flattenForkTree(root *ForkNode) {
+	descendants := make([]*ForkNode, 0, len(root.Children))
+	var collect func(node *ForkNode)
+	collect = func(node *ForkNode) {
+		for _, child := range node.Children {
+			child.OriginalParentID = node.ID
+			descendants = append(descendants, child)
+			collect(child)
 		}
 	}
+	collect(root)
 
-	return node, nil
+	for _, node := range descendants {
+		node.Level = 1
+		node.Children = []*ForkNode{}
+	}
+	root.Children = descendants
 }
 
 // createLeafNode creates a leaf node without children
-func createLeafNode(repo *repo_model.Repository, level int, params ForkGraphParams) (*ForkNode, error) {
-	node := &ForkNode{
-		ID:       fmt.Sprintf("repo_%d", repo.ID),
-		Level:    level,
-		Children: []*ForkNode{},
-		repo:     repo, // Store for batch processing
-	}
+func createLeafNode(repo *repo_model.Repository, level int) (*ForkNode, error) {
+	return newForkNode(repo, level, []*ForkNode{}), nil
+}
 
-	if params.IncludeContributors {
-		stats, err := getContributorStats(repo, params.ContributorDays, getForkSinceTime(repo))
-		if err != nil {
-			log.Warn("Failed to get contributor stats for repo %d: %v", repo.ID, err)
-		} else {
-			node.Contributors = stats
-		}
+// newForkNode creates the node of repo, with the activity fields derived from the
+// repository's timestamps rather than from git
+func newForkNode(repo *repo_model.Repository, level int, children []*ForkNode) *ForkNode {
+	return &ForkNode{
+		ID:             fmt.Sprintf("repo_%d", repo.ID),
+		Level:          level,
+		Children:       children,
+		LastCommitUnix: int64(repo.UpdatedUnix),
+		HasOwnCommits:  forkHasOwnCommits(repo),
+		repo:           repo, // Store for batch processing
 	}
+}
 
-	return node, nil
+// forkHasOwnCommits reports whether repo is a fork that was updated after forkSettleTime had
+// passed since its creation. Forking itself updates the repository a moment after creating it,
+// so updates within that window don't count.
+func forkHasOwnCommits(repo *repo_model.Repository) bool {
+	if !repo.IsFork || repo.CreatedUnix == 0 {
+		return false
+	}
+	return repo.UpdatedUnix.AsTime().Sub(repo.CreatedUnix.AsTime()) > forkSettleTime
 }
 
 // createReadPermission creates a basic read permission for repositories
@@ -652,7 +758,7 @@ func getContributorStats(repo *repo_model.Repository, days int, since time.Time)
 	// This prevents multiple goroutines from racing to write the same cache entry
 	// Errors are logged but don't fail the request - cache is best-effort
 	if shouldCache {
-		if err := c.PutJSON(secondaryCacheKey, result, forkContributorStatsCacheTimeout); err != nil {
+		if err := c.PutJSON(secondaryCacheKey, result, forkContributorStatsCacheTimeout()); err != nil {
 			log.Warn("Failed to cache fork contributor stats for repo %d: %v", repo.ID, err)
 		} else {
 			// Register the cache key for invalidation on push
@@ -750,6 +856,97 @@ func batchLoadRepositoryAttributes(ctx context.Context, repos []*repo_model.Repo
 	return nil
 }
 
+// loadOpenChangeRequestCounts sets the OpenChangeRequests of all nodes, counted with one query
+// for all repositories of the graph
+func loadOpenChangeRequestCounts(ctx context.Context, rootNode *ForkNode, repos []*repo_model.Repository) error {
+	counts, err := issues_model.CountOpenPullRequestsToDefaultBranches(ctx, repos)
+	if err != nil {
+		return err
+	}
+	var setCounts func(*ForkNode)
+	setCounts = func(n *ForkNode) {
+		if n == nil || n.repo == nil {
+			return
+		}
+		n.OpenChangeRequests = counts[n.repo.ID]
+		for _, child := range n.Children {
+			setCounts(child)
+		}
+	}
+	setCounts(rootNode)
+	return nil
+}
+
+// loadViewerForks sets the ViewerFork of every node whose repository the viewer has forked
+func loadViewerForks(ctx context.Context, rootNode *ForkNode, repos []*repo_model.Repository, viewerID int64) error {
+	repoIDs := make([]int64, 0, len(repos))
+	for _, repo := range repos {
+		repoIDs = append(repoIDs, repo.ID)
+	}
+	forks, err := repo_model.GetForkedRepos(ctx, viewerID, repoIDs)
+	if err != nil {
+		return err
+	}
+	var setForks func(*ForkNode)
+	setForks = func(n *ForkNode) {
+		if n == nil || n.repo == nil {
+			return
+		}
+		if fork, ok := forks[n.repo.ID]; ok {
+			n.ViewerFork = fork.FullName()
+		}
+		for _, child := range n.Children {
+			setForks(child)
+		}
+	}
+	setForks(rootNode)
+	return nil
+}
+
+// prefetchContributorStats computes the contributor stats of the given repositories with a
+// bounded number of concurrent workers, warming the per-repository stats cache.
+// Repositories whose stats can't be computed are missing from the returned map.
+func prefetchContributorStats(ctx context.Context, repos []*repo_model.Repository, days int) map[int64]*ContributorStats {
+	results := make([]*ContributorStats, len(repos))
+
+	var g errgroup.Group
+	g.SetLimit(contributorStatsPrefetchLimit)
+	for i, repo := range repos {
+		g.Go(func() error {
+			if ctx.Err() != nil {
+				return nil
+			}
+			stats, err := getContributorStats(repo, days, getForkSinceTime(repo))
+			if err != nil {
+				log.Warn("Failed to get contributor stats for repo %d: %v", repo.ID, err)
+				return nil
+			}
+			results[i] = stats
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	statsByRepo := make(map[int64]*ContributorStats, len(repos))
+	for i, repo := range repos {
+		if results[i] != nil {
+			statsByRepo[repo.ID] = results[i]
+		}
+	}
+	return statsByRepo
+}
+
+// setContributorStats sets the Contributors of all nodes from the prefetched stats
+func setContributorStats(node *ForkNode, statsByRepo map[int64]*ContributorStats) {
+	if node == nil || node.repo == nil {
+		return
+	}
+	node.Contributors = statsByRepo[node.repo.ID]
+	for _, child := range node.Children {
+		setContributorStats(child, statsByRepo)
+	}
+}
+
 // convertNodesToAPI recursively converts all nodes to API format using preloaded data
 func convertNodesToAPI(ctx context.Context, node *ForkNode) {
 	if node == nil {
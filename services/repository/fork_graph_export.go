@@ -0,0 +1,180 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// walkForkGraph calls fn for node and all its descendants, parent is nil for the root
+func walkForkGraph(node, parent *ForkNode, fn func(node, parent *ForkNode) error) error {
+	if node == nil {
+		return nil
+	}
+	if err := fn(node, parent); err != nil {
+		return err
+	}
+	for _, child := range node.Children {
+		if err := walkForkGraph(child, node, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FlatNode is a repository of a fork graph with its path from the root repository
+type FlatNode struct {
+	ID         string          `json:"id"`
+	Repository *api.Repository `json:"repository"`
+	Level      int             `json:"level"`
+	// Path are the ids of the ancestors of the node, starting with the root
+	Path []string `json:"path"`
+}
+
+// Flatten returns the nodes of the graph in depth-first order, every node directly followed by
+// its forks in the order of the tree. The root has an empty path.
+func (r *ForkGraphResponse) Flatten() []FlatNode {
+	nodes := make([]FlatNode, 0)
+	var flatten func(node *ForkNode, path []string)
+	flatten = func(node *ForkNode, path []string) {
+		nodes = append(nodes, FlatNode{
+			ID:         node.ID,
+			Repository: node.Repository,
+			Level:      node.Level,
+			Path:       path,
+		})
+		// children share the parent's path, cap it so appends never write into each other
+		childPath := append(path[:len(path):len(path)], node.ID)
+		for _, child := range node.Children {
+			flatten(child, childPath)
+		}
+	}
+	if r.Root != nil {
+		flatten(r.Root, []string{})
+	}
+	return nodes
+}
+
+// forkNodeName returns the full name of the repository of node
+func forkNodeName(node *ForkNode) string {
+	if node.Repository == nil {
+		return node.ID
+	}
+	return node.Repository.FullName
+}
+
+// forkNodeRepoID returns the id of the repository of node
+func forkNodeRepoID(node *ForkNode) int64 {
+	if node.Repository == nil {
+		return 0
+	}
+	return node.Repository.ID
+}
+
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// WriteForkGraphDOT writes graph in the Graphviz DOT language, with an edge from every
+// repository to each of its forks
+func WriteForkGraphDOT(w io.Writer, graph *ForkGraphResponse) error {
+	if _, err := io.WriteString(w, "digraph forks {\n"); err != nil {
+		return err
+	}
+	err := walkForkGraph(graph.Root, nil, func(node, parent *ForkNode) error {
+		if _, err := fmt.Fprintf(w, "\t\"%s\" [label=\"%s\", repo_id=%d, level=%d];\n",
+			dotEscaper.Replace(node.ID), dotEscaper.Replace(forkNodeName(node)), forkNodeRepoID(node), node.Level); err != nil {
+			return err
+		}
+		if parent != nil {
+			if _, err := fmt.Fprintf(w, "\t\"%s\" -> \"%s\";\n", dotEscaper.Replace(parent.ID), dotEscaper.Replace(node.ID)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "}\n")
+	return err
+}
+
+type graphMLDocument struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string `xml:"source,attr"`
+	Target string `xml:"target,attr"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// WriteForkGraphGraphML writes graph as a GraphML document. Every repository is a node with
+// its id, full name and level in the fork tree, and every fork is an edge from its parent.
+func WriteForkGraphGraphML(w io.Writer, graph *ForkGraphResponse) error {
+	doc := graphMLDocument{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "repo_id", For: "node", AttrName: "repo_id", AttrType: "long"},
+			{ID: "name", For: "node", AttrName: "name", AttrType: "string"},
+			{ID: "level", For: "node", AttrName: "level", AttrType: "int"},
+		},
+		Graph: graphMLGraph{ID: "forks", EdgeDefault: "directed"},
+	}
+	_ = walkForkGraph(graph.Root, nil, func(node, parent *ForkNode) error {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{
+			ID: node.ID,
+			Data: []graphMLData{
+				{Key: "repo_id", Value: fmt.Sprint(forkNodeRepoID(node))},
+				{Key: "name", Value: forkNodeName(node)},
+				{Key: "level", Value: fmt.Sprint(node.Level)},
+			},
+		})
+		if parent != nil {
+			doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{Source: parent.ID, Target: node.ID})
+		}
+		return nil
+	})
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"testing"
+
+	api "code.gitea.io/gitea/modules/structs"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testExportForkGraph() *ForkGraphResponse {
+	return &ForkGraphResponse{
+		Root: &ForkNode{
+			ID:         "repo_1",
+			Repository: &api.Repository{ID: 1, FullName: "user2/repo1"},
+			Children: []*ForkNode{
+				{
+					ID:         "repo_2",
+					Repository: &api.Repository{ID: 2, FullName: `user<3>/"fork"&co`},
+					Level:      1,
+				},
+			},
+		},
+	}
+}
+
+func TestWriteForkGraphDOT(t *testing.T) {
+	var sb strings.Builder
+	require.NoError(t, WriteForkGraphDOT(&sb, testExportForkGraph()))
+	assert.Equal(t, `digraph forks {
+	"repo_1" [label="user2/repo1", repo_id=1, level=0];
+	"repo_2" [label="user<3>/\"fork\"&co", repo_id=2, level=1];
+	"repo_1" -> "repo_2";
+}
+`, sb.String())
+
+	sb.Reset()
+	require.NoError(t, WriteForkGraphDOT(&sb, &ForkGraphResponse{}))
+	assert.Equal(t, "digraph forks {\n}\n", sb.String())
+}
+
+func TestWriteForkGraphGraphML(t *testing.T) {
+	var sb strings.Builder
+	require.NoError(t, WriteForkGraphGraphML(&sb, testExportForkGraph()))
+	assert.Contains(t, sb.String(), `user&lt;3&gt;/&#34;fork&#34;&amp;co`)
+
+	var doc graphMLDocument
+	require.NoError(t, xml.Unmarshal([]byte(sb.String()), &doc))
+	require.Len(t, doc.Graph.Nodes, 2)
+	assert.Equal(t, "repo_2", doc.Graph.Nodes[1].ID)
+	assert.Equal(t, []graphMLData{
+		{Key: "repo_id", Value: "2"},
+		{Key: "name", Value: `user<3>/"fork"&co`},
+		{Key: "level", Value: "1"},
+	}, doc.Graph.Nodes[1].Data)
+	assert.Equal(t, []graphMLEdge{{Source: "repo_1", Target: "repo_2"}}, doc.Graph.Edges)
+}
+
+func TestForkGraphFlatten(t *testing.T) {
+	node := func(id int64, level int, children ...*ForkNode) *ForkNode {
+		return &ForkNode{
+			ID:         fmt.Sprintf("repo_%d", id),
+			Repository: &api.Repository{ID: id},
+			Level:      level,
+			Children:   children,
+		}
+	}
+	graph := &ForkGraphResponse{
+		Root: node(1, 0,
+			node(2, 1,
+				node(4, 2),
+				node(5, 2),
+			),
+			node(3, 1,
+				node(6, 2,
+					node(7, 3),
+				),
+			),
+		),
+	}
+
+	flat := graph.Flatten()
+	ids := make([]string, 0, len(flat))
+	for _, n := range flat {
+		ids = append(ids, n.ID)
+	}
+	assert.Equal(t, []string{"repo_1", "repo_2", "repo_4", "repo_5", "repo_3", "repo_6", "repo_7"}, ids)
+
+	assert.Equal(t, []string{}, flat[0].Path)
+	assert.Equal(t, []string{"repo_1"}, flat[1].Path)
+	assert.Equal(t, []string{"repo_1", "repo_2"}, flat[2].Path)
+	assert.Equal(t, []string{"repo_1", "repo_2"}, flat[3].Path)
+	assert.Equal(t, []string{"repo_1"}, flat[4].Path)
+	assert.Equal(t, []string{"repo_1", "repo_3"}, flat[5].Path)
+	assert.Equal(t, []string{"repo_1", "repo_3", "repo_6"}, flat[6].Path)
+	assert.Equal(t, 3, flat[6].Level)
+	assert.EqualValues(t, 7, flat[6].Repository.ID)
+
+	assert.Equal(t, flat, graph.Flatten())
+	assert.Empty(t, (&ForkGraphResponse{}).Flatten())
+}
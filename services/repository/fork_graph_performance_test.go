@@ -305,6 +305,34 @@ func BenchmarkBuildForkGraph(b *testing.B) {
 	}
 }
 
+// BenchmarkBuildForkGraphWithContributors benchmarks the fork graph building with
+// contributor stats, which are prefetched concurrently for all nodes
+func BenchmarkBuildForkGraphWithContributors(b *testing.B) {
+	assert.NoError(b, unittest.PrepareTestDatabase())
+
+	repo := unittest.AssertExistsAndLoadBean(b, &repo_model.Repository{ID: 1})
+	user := unittest.AssertExistsAndLoadBean(b, &user_model.User{ID: 2})
+
+	params := ForkGraphParams{
+		IncludeContributors: true,
+		ContributorDays:     90,
+		MaxDepth:            10,
+		IncludePrivate:      false,
+		Sort:                "updated",
+		Page:                1,
+		Limit:               50,
+	}
+
+	ctx := context.Background()
+
+	for b.Loop() {
+		_, err := BuildForkGraph(ctx, repo, params, user)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 // BenchmarkCollectRepositories benchmarks repository collection
 func BenchmarkCollectRepositories(b *testing.B) {
 	// Create a test tree with 100 nodes
@@ -10,13 +10,17 @@ import (
 	"testing"
 	"time"
 
+	"code.gitea.io/gitea/models/db"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/cache"
 	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/timeutil"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestBuildForkGraph(t *testing.T) {
@@ -70,6 +74,142 @@ func TestBuildForkGraphWithContributors(t *testing.T) {
 	assert.Equal(t, 30, graph.Metadata.ContributorWindowDays)
 }
 
+func TestBuildForkGraphWithChangeRequests(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	// repo11 is a fork of repo10, pull request 3 is open from repo11 into master of repo10
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 10})
+
+	params := ForkGraphParams{
+		IncludeChangeRequests: true,
+		MaxDepth:              10,
+		Sort:                  "updated",
+		Page:                  1,
+		Limit:                 50,
+	}
+
+	graph, err := BuildForkGraph(t.Context(), repo, params, nil)
+	assert.NoError(t, err)
+	assert.True(t, graph.Metadata.IncludesChangeRequests)
+	assert.Equal(t, 1, graph.Root.OpenChangeRequests)
+	if assert.Len(t, graph.Root.Children, 1) {
+		assert.EqualValues(t, 11, graph.Root.Children[0].Repository.ID)
+		assert.Equal(t, 0, graph.Root.Children[0].OpenChangeRequests)
+	}
+
+	params.IncludeChangeRequests = false
+	graph, err = BuildForkGraph(t.Context(), repo, params, nil)
+	assert.NoError(t, err)
+	assert.False(t, graph.Metadata.IncludesChangeRequests)
+	assert.Equal(t, 0, graph.Root.OpenChangeRequests)
+}
+
+func TestBuildForkGraphViewerFork(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	// user13 owns repo11, a fork of repo10, and has not forked repo11 itself
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 10})
+	params := ForkGraphParams{MaxDepth: 10, Sort: "updated", Page: 1, Limit: 50}
+
+	viewer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 13})
+	graph, err := BuildForkGraph(t.Context(), repo, params, viewer)
+	assert.NoError(t, err)
+	assert.Equal(t, "user13/repo11", graph.Root.ViewerFork)
+	if assert.Len(t, graph.Root.Children, 1) {
+		assert.Empty(t, graph.Root.Children[0].ViewerFork)
+	}
+
+	graph, err = BuildForkGraph(t.Context(), repo, params, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, graph.Root.ViewerFork)
+}
+
+func TestBuildForkGraphActivity(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	setTimes := func(t *testing.T, repoID int64, created, updated timeutil.TimeStamp) {
+		_, err := db.GetEngine(t.Context()).ID(repoID).Cols("created_unix", "updated_unix").NoAutoTime().
+			Update(&repo_model.Repository{CreatedUnix: created, UpdatedUnix: updated})
+		require.NoError(t, err)
+	}
+	buildGraph := func(t *testing.T) *ForkGraphResponse {
+		repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 10})
+		graph, err := BuildForkGraph(t.Context(), repo, ForkGraphParams{MaxDepth: 10, Sort: "updated", Page: 1, Limit: 50}, nil)
+		require.NoError(t, err)
+		require.Len(t, graph.Root.Children, 1)
+		return graph
+	}
+
+	// repo11 is a fork of repo10, updated an hour after it was forked
+	setTimes(t, 10, 1000, 5000)
+	setTimes(t, 11, 2000, 2000+3600)
+	graph := buildGraph(t)
+	assert.EqualValues(t, 5000, graph.Root.LastCommitUnix)
+	assert.False(t, graph.Root.HasOwnCommits, "the root is not a fork")
+	fork := graph.Root.Children[0]
+	assert.EqualValues(t, 5600, fork.LastCommitUnix)
+	assert.True(t, fork.HasOwnCommits)
+
+	// Updates made while forking don't count as own commits
+	setTimes(t, 11, 2000, 2005)
+	fork = buildGraph(t).Root.Children[0]
+	assert.EqualValues(t, 2005, fork.LastCommitUnix)
+	assert.False(t, fork.HasOwnCommits)
+}
+
+func TestBuildForkGraphFlatten(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// repo11 is a fork of repo10, make repo4 a fork of repo11 and repo33 a fork of repo4
+	for repoID, forkID := range map[int64]int64{4: 11, 33: 4} {
+		_, err := db.GetEngine(t.Context()).ID(repoID).Cols("is_fork", "fork_id").Update(&repo_model.Repository{IsFork: true, ForkID: forkID})
+		require.NoError(t, err)
+	}
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 10})
+	params := ForkGraphParams{MaxDepth: 10, Sort: "updated", Page: 1, Limit: 50}
+
+	graph, err := BuildForkGraph(t.Context(), repo, params, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, getMaxLevel(graph.Root))
+
+	params.Flatten = true
+	graph, err = BuildForkGraph(t.Context(), repo, params, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "repo_10", graph.Root.ID)
+	assert.Empty(t, graph.Root.OriginalParentID)
+	require.Len(t, graph.Root.Children, 3)
+	for i, expected := range []struct{ id, parentID string }{
+		{"repo_11", "repo_10"},
+		{"repo_4", "repo_11"},
+		{"repo_33", "repo_4"},
+	} {
+		node := graph.Root.Children[i]
+		assert.Equal(t, expected.id, node.ID)
+		assert.Equal(t, expected.parentID, node.OriginalParentID)
+		assert.Equal(t, 1, node.Level)
+		assert.Empty(t, node.Children)
+	}
+	assert.Equal(t, 3, graph.Metadata.VisibleForks)
+}
+
+func TestBuildForkGraphRootRepoID(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// repo11 is a fork of repo10, make repo4 a fork of repo11
+	_, err := db.GetEngine(t.Context()).ID(4).Cols("is_fork", "fork_id").Update(&repo_model.Repository{IsFork: true, ForkID: 11})
+	require.NoError(t, err)
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 10})
+	params := ForkGraphParams{MaxDepth: 10, Sort: "updated", Page: 1, Limit: 50, RootRepoID: 11}
+
+	graph, err := BuildForkGraph(t.Context(), repo, params, nil)
+	require.NoError(t, err)
+	require.NotNil(t, graph.Root)
+	assert.Equal(t, "repo_11", graph.Root.ID)
+	assert.Equal(t, 0, graph.Root.Level)
+	require.Len(t, graph.Root.Children, 1)
+	assert.Equal(t, "repo_4", graph.Root.Children[0].ID)
+}
+
 func TestBuildForkGraphMaxDepth(t *testing.T) {
 	assert.NoError(t, unittest.PrepareTestDatabase())
 
@@ -201,12 +341,12 @@ func TestCycleDetection(t *testing.T) {
 	maxDepthReached := false
 
 	// Build node twice with same repo - should detect cycle
-	node1, err := buildNode(ctx, repo, 0, params, user, visited, &nodeCount, &maxDepthReached)
+	node1, err := buildNode(ctx, repo, 0, params, user, visited, &nodeCount, defaultMaxNodes, &maxDepthReached)
 	assert.NoError(t, err)
 	assert.NotNil(t, node1)
 
 	// Try to build same repo again - should return ErrCycleDetected
-	node2, err := buildNode(ctx, repo, 0, params, user, visited, &nodeCount, &maxDepthReached)
+	node2, err := buildNode(ctx, repo, 0, params, user, visited, &nodeCount, defaultMaxNodes, &maxDepthReached)
 	assert.Error(t, err)
 	assert.True(t, IsErrCycleDetected(err))
 	assert.Nil(t, node2)
@@ -374,11 +514,34 @@ func TestProcessingTimeout(t *testing.T) {
 	nodeCount := 0
 	maxDepthReached := false
 
-	_, err := buildNode(ctx, repo, 0, params, user, visited, &nodeCount, &maxDepthReached)
+	_, err := buildNode(ctx, repo, 0, params, user, visited, &nodeCount, defaultMaxNodes, &maxDepthReached)
 	assert.Error(t, err)
 	assert.True(t, IsErrProcessingTimeout(err))
 }
 
+func TestBuildForkGraphMaxNodesSetting(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	// repo10 has a single fork, repo11
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 10})
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 12})
+	params := ForkGraphParams{
+		MaxDepth: 10,
+		Sort:     "updated",
+		Page:     1,
+		Limit:    50,
+	}
+
+	graph, err := BuildForkGraph(t.Context(), repo, params, user)
+	require.NoError(t, err)
+	assert.Equal(t, 1, graph.Metadata.VisibleForks)
+
+	// A cap below the size of the graph fails the build before the fork is reached
+	defer test.MockVariableValue(&setting.Repository.ForkGraphMaxNodes, 1)()
+	_, err = BuildForkGraph(t.Context(), repo, params, user)
+	assert.True(t, IsErrTooManyNodes(err))
+}
+
 // Helper function to get max level in tree
 func getMaxLevel(node *ForkNode) int {
 	if node == nil || len(node.Children) == 0 {
@@ -418,13 +581,13 @@ func TestCycleDetection_SelfLoop(t *testing.T) {
 	maxDepthReached := false
 
 	// First call should succeed
-	node1, err := buildNode(ctx, repo, 0, params, user, visited, &nodeCount, &maxDepthReached)
+	node1, err := buildNode(ctx, repo, 0, params, user, visited, &nodeCount, defaultMaxNodes, &maxDepthReached)
 	assert.NoError(t, err)
 	assert.NotNil(t, node1)
 	assert.Equal(t, 1, nodeCount)
 
 	// Second call with same repo should detect cycle
-	node2, err := buildNode(ctx, repo, 0, params, user, visited, &nodeCount, &maxDepthReached)
+	node2, err := buildNode(ctx, repo, 0, params, user, visited, &nodeCount, defaultMaxNodes, &maxDepthReached)
 	assert.Error(t, err)
 	assert.True(t, IsErrCycleDetected(err))
 	assert.Nil(t, node2)
@@ -454,7 +617,7 @@ func TestCycleDetection_VisitedMapPreventsInfiniteRecursion(t *testing.T) {
 	maxDepthReached := false
 
 	// Build node - this will mark repo as visited
-	node, err := buildNode(ctx, repo, 0, params, user, visited, &nodeCount, &maxDepthReached)
+	node, err := buildNode(ctx, repo, 0, params, user, visited, &nodeCount, defaultMaxNodes, &maxDepthReached)
 	assert.NoError(t, err)
 	assert.NotNil(t, node)
 
@@ -463,7 +626,7 @@ func TestCycleDetection_VisitedMapPreventsInfiniteRecursion(t *testing.T) {
 
 	// Attempting to visit again should immediately return ErrCycleDetected
 	// without causing stack overflow or infinite recursion
-	node2, err := buildNode(ctx, repo, 0, params, user, visited, &nodeCount, &maxDepthReached)
+	node2, err := buildNode(ctx, repo, 0, params, user, visited, &nodeCount, defaultMaxNodes, &maxDepthReached)
 	assert.Error(t, err)
 	assert.True(t, IsErrCycleDetected(err))
 	assert.Nil(t, node2)
@@ -522,7 +685,7 @@ func TestCycleDetection_DeepForkChain(t *testing.T) {
 	maxDepthReached := false
 
 	// Build a deep chain - should not cause stack overflow
-	node, err := buildNode(ctx, repo, 0, params, user, visited, &nodeCount, &maxDepthReached)
+	node, err := buildNode(ctx, repo, 0, params, user, visited, &nodeCount, defaultMaxNodes, &maxDepthReached)
 	assert.NoError(t, err)
 	assert.NotNil(t, node)
 
@@ -823,3 +986,41 @@ func TestInvalidateForkContributorStatsCacheMultipleKeys(t *testing.T) {
 	keys = getForkStatsCacheKeysForTesting(repoID)
 	assert.Nil(t, keys)
 }
+
+// ttlRecordingCache records the TTL every key was last stored with
+type ttlRecordingCache struct {
+	cache.StringCache
+	ttls map[string]int64
+}
+
+func (c *ttlRecordingCache) PutJSON(key string, v any, ttl int64) error {
+	c.ttls[key] = ttl
+	return c.StringCache.PutJSON(key, v, ttl)
+}
+
+func TestForkContributorStatsCacheTTL(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	clearForkStatsCacheKeysForTesting()
+	defer clearForkStatsCacheKeysForTesting()
+
+	stringCache, err := cache.NewStringCache(setting.Cache{})
+	assert.NoError(t, err)
+	recordingCache := &ttlRecordingCache{StringCache: stringCache, ttls: map[string]int64{}}
+	originalCache := cache.GetCache()
+	cache.SetDefaultCache(recordingCache)
+	defer cache.SetDefaultCache(originalCache)
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	// Populate the primary cache so that the secondary cache is written synchronously
+	assert.NoError(t, stringCache.PutJSON(fmt.Sprintf(contributorStatsCacheKey, repo.FullName(), repo.DefaultBranch), map[string]*ContributorData{}, 600))
+
+	for _, ttl := range []time.Duration{5 * time.Minute, time.Hour} {
+		defer test.MockVariableValue(&setting.Repository.ForkContributorStatsCacheTTL, ttl)()
+		_, err := getContributorStats(repo, 90, time.Time{})
+		assert.NoError(t, err)
+		key := fmt.Sprintf(forkContributorStatsCacheKey, repo.ID, 0, 90)
+		assert.EqualValues(t, ttl.Seconds(), recordingCache.ttls[key])
+		assert.Less(t, recordingCache.ttls[key], contributorStatsCacheTimeout())
+		InvalidateForkContributorStatsCache(repo.ID)
+	}
+}
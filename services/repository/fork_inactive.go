@@ -0,0 +1,95 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/timeutil"
+	actions_service "code.gitea.io/gitea/services/actions"
+
+	"xorm.io/builder"
+)
+
+// ArchiveInactiveForks archives the forks that had neither a commit to their article README nor
+// change request activity for olderThan, so that abandoned forks stop cluttering the subject trees.
+// Archived forks stay in the fork graph but become read-only and can't be forked anymore.
+// It returns the number of forks that were archived.
+func ArchiveInactiveForks(ctx context.Context, olderThan time.Duration) (int, error) {
+	since := time.Now().Add(-olderThan)
+	archived := 0
+
+	// Forks younger than olderThan haven't had the time to become inactive. Archived forks are
+	// skipped by the callback rather than the condition: db.Iterate pages with offsets, which
+	// archiving forks that match the condition would shift.
+	cond := builder.Eq{"is_fork": true, "is_mirror": false}.And(builder.Lt{"created_unix": since.Unix()})
+	err := db.Iterate(ctx, cond, func(ctx context.Context, repo *repo_model.Repository) error {
+		if repo.IsArchived {
+			return nil
+		}
+		active, err := isForkActiveSince(ctx, repo, since)
+		if err != nil {
+			log.Warn("ArchiveInactiveForks: unable to check the activity of %s: %v", repo.FullName(), err)
+			return nil
+		}
+		if active {
+			return nil
+		}
+
+		if err := repo_model.SetArchiveRepoState(ctx, repo, true); err != nil {
+			return err
+		}
+		if err := actions_service.CleanRepoScheduleTasks(ctx, repo); err != nil {
+			log.Error("CleanRepoScheduleTasks for archived repo %s: %v", repo.FullName(), err)
+		}
+		issue_indexer.UpdateRepoIndexer(ctx, repo.ID)
+		log.Trace("Archived inactive fork %s", repo.FullName())
+		archived++
+		return nil
+	})
+	return archived, err
+}
+
+// isForkActiveSince reports whether fork has a change request that is open or was updated since
+// the given time, or commits to its article README since then (see CommitsSinceFork in the
+// subject history)
+func isForkActiveSince(ctx context.Context, fork *repo_model.Repository, since time.Time) (bool, error) {
+	hasChangeRequests, err := issues_model.HasPullRequestActivitySince(ctx, fork.ID, timeutil.TimeStamp(since.Unix()))
+	if err != nil || hasChangeRequests {
+		return hasChangeRequests, err
+	}
+	if fork.IsEmpty {
+		return false, nil
+	}
+
+	gitRepo, err := gitrepo.OpenRepository(ctx, fork)
+	if err != nil {
+		return false, err
+	}
+	defer gitRepo.Close()
+
+	commit, err := gitRepo.GetBranchCommit(fork.DefaultBranch)
+	if err != nil {
+		return false, err
+	}
+	readme, err := findArticleReadmeEntry(commit)
+	if err != nil || readme == nil {
+		return false, err
+	}
+	count, err := git.CommitsCount(ctx, git.CommitsCountOptions{
+		RepoPath: gitRepo.Path,
+		Revision: []string{commit.ID.String()},
+		RelPath:  []string{readme.Name()},
+		Since:    since.Format(time.RFC3339),
+	})
+	return count > 0, err
+}
@@ -0,0 +1,47 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveInactiveForks(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// repo11 is the head of the open change request #1 of repo10, while the empty forks
+	// repo29 and repo30 had no change requests
+	archived, err := ArchiveInactiveForks(t.Context(), 24*time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 2, archived)
+	assert.False(t, unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 11}).IsArchived)
+	assert.True(t, unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 29}).IsArchived)
+	assert.True(t, unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 30}).IsArchived)
+	// the roots they were forked from are left alone
+	assert.False(t, unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 10}).IsArchived)
+
+	// Once the change request is closed, repo11 has no activity left: its README commits are old
+	_, err = db.GetEngine(t.Context()).ID(8).Cols("is_closed").NoAutoTime().Update(&issues_model.Issue{IsClosed: true})
+	require.NoError(t, err)
+
+	// Forks younger than the duration are kept
+	archived, err = ArchiveInactiveForks(t.Context(), 100*365*24*time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 0, archived)
+	assert.False(t, unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 11}).IsArchived)
+
+	archived, err = ArchiveInactiveForks(t.Context(), 24*time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 1, archived)
+	assert.True(t, unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 11}).IsArchived)
+}
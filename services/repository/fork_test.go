@@ -7,14 +7,17 @@ import (
 	"os"
 	"testing"
 
+	"code.gitea.io/gitea/models/db"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/timeutil"
 	"code.gitea.io/gitea/modules/util"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestForkRepository(t *testing.T) {
@@ -141,6 +144,32 @@ func TestConvertNormalToForkRepository(t *testing.T) {
 		_ = DeleteRepositoryDirectly(t.Context(), rootRepo.ID)
 	})
 
+	t.Run("InheritsRootLicense", func(t *testing.T) {
+		assert.NoError(t, unittest.PrepareTestDatabase())
+
+		rootRepo, err := CreateRepositoryDirectly(t.Context(), user2, user2, CreateRepoOptions{
+			Name: "license-test-root",
+		}, true)
+		assert.NoError(t, err)
+		assert.NoError(t, db.Insert(t.Context(), &repo_model.RepoLicense{RepoID: rootRepo.ID, License: "CC-BY-SA-4.0"}))
+
+		normalRepo, err := CreateRepositoryDirectly(t.Context(), user4, user4, CreateRepoOptions{
+			Name: "license-test-normal",
+		}, true)
+		assert.NoError(t, err)
+
+		err = ConvertNormalToForkRepository(t.Context(), normalRepo, rootRepo.ID)
+		assert.NoError(t, err)
+
+		licenses, err := repo_model.GetRepoLicenses(t.Context(), normalRepo)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"CC-BY-SA-4.0"}, licenses.StringList())
+
+		// Cleanup
+		_ = DeleteRepositoryDirectly(t.Context(), normalRepo.ID)
+		_ = DeleteRepositoryDirectly(t.Context(), rootRepo.ID)
+	})
+
 	t.Run("IdempotentAlreadyFork", func(t *testing.T) {
 		assert.NoError(t, unittest.PrepareTestDatabase())
 
@@ -288,6 +317,137 @@ func TestConvertNormalToForkRepository(t *testing.T) {
 	})
 }
 
+func TestPromoteOldestRepositoryToRoot(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	subject, err := repo_model.GetOrCreateSubject(ctx, "Promote Root Subject")
+	assert.NoError(t, err)
+
+	emptyRepo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 2})
+	emptyRepo.SubjectID = subject.ID
+	emptyRepo.IsEmpty = true
+	assert.NoError(t, repo_model.UpdateRepositoryColsNoAutoTime(ctx, emptyRepo, "subject_id", "is_empty"))
+
+	t.Run("OnlyEmptyRepositories", func(t *testing.T) {
+		_, err := PromoteOldestRepositoryToRoot(ctx, subject.ID)
+		assert.True(t, repo_model.IsErrRepoNotExist(err))
+	})
+
+	forkRepo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 4})
+	forkRepo.SubjectID = subject.ID
+	forkRepo.IsFork = true
+	forkRepo.ForkID = emptyRepo.ID
+	assert.NoError(t, repo_model.UpdateRepositoryColsNoAutoTime(ctx, forkRepo, "subject_id", "is_fork", "fork_id"))
+
+	t.Run("PromotesNonEmptyFork", func(t *testing.T) {
+		promoted, err := PromoteOldestRepositoryToRoot(ctx, subject.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, forkRepo.ID, promoted.ID)
+
+		promoted = unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: forkRepo.ID})
+		assert.False(t, promoted.IsFork)
+		assert.Zero(t, promoted.ForkID)
+		assert.Equal(t, forkRepo.ID, unittest.AssertExistsAndLoadBean(t, &repo_model.Subject{ID: subject.ID}).RootRepoID)
+
+		subjects, err := repo_model.FindSubjectsWithoutRoot(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, subjects)
+	})
+
+	t.Run("AlreadyHasRoot", func(t *testing.T) {
+		_, err := PromoteOldestRepositoryToRoot(ctx, subject.ID)
+		assert.True(t, repo_model.IsErrRootArticleAlreadyExists(err))
+	})
+}
+
+func TestReconcileSubjectRoots(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	subject, err := repo_model.GetOrCreateSubject(ctx, "Imported Subject")
+	assert.NoError(t, err)
+
+	// Two stray roots of the same subject, as left behind by a bulk import; repo3 is the older one
+	newerRoot := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 2})
+	olderRoot := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3})
+	for i, repo := range []*repo_model.Repository{olderRoot, newerRoot} {
+		repo.SubjectID = subject.ID
+		repo.IsEmpty = false
+		repo.CreatedUnix = timeutil.TimeStamp(1700000000 + i)
+		assert.NoError(t, repo_model.UpdateRepositoryColsNoAutoTime(ctx, repo, "subject_id", "is_empty", "created_unix"))
+	}
+
+	subjectIDs, err := repo_model.FindSubjectIDsWithMultipleRoots(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{subject.ID}, subjectIDs)
+
+	converted, err := ReconcileSubjectRoots(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, converted)
+
+	root := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: olderRoot.ID})
+	assert.False(t, root.IsFork)
+	assert.Equal(t, olderRoot.NumForks+1, root.NumForks)
+	fork := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: newerRoot.ID})
+	assert.True(t, fork.IsFork)
+	assert.Equal(t, olderRoot.ID, fork.ForkID)
+	assert.Equal(t, olderRoot.ID, unittest.AssertExistsAndLoadBean(t, &repo_model.Subject{ID: subject.ID}).RootRepoID)
+
+	// Reconciling again changes nothing
+	converted, err = ReconcileSubjectRoots(ctx)
+	assert.NoError(t, err)
+	assert.Zero(t, converted)
+}
+
+func TestRepairOrphanedFork(t *testing.T) {
+	ctx := t.Context()
+
+	t.Run("ReparentToSubjectRoot", func(t *testing.T) {
+		assert.NoError(t, unittest.PrepareTestDatabase())
+
+		// repo11 is a fork of repo10; move it into repo1's subject and drop its parent
+		fork := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 11})
+		fork.SubjectID = 1
+		assert.NoError(t, repo_model.UpdateRepositoryColsNoAutoTime(ctx, fork, "subject_id"))
+		_, err := db.DeleteByID[repo_model.Repository](ctx, 10)
+		assert.NoError(t, err)
+
+		orphans, err := repo_model.FindOrphanedForks(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, orphans, 1)
+
+		parent, err := RepairOrphanedFork(ctx, orphans[0])
+		assert.NoError(t, err)
+		if assert.NotNil(t, parent) {
+			assert.EqualValues(t, 1, parent.ID)
+		}
+
+		fork = unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 11})
+		assert.True(t, fork.IsFork)
+		assert.EqualValues(t, 1, fork.ForkID)
+	})
+
+	t.Run("ConvertWithoutSubjectRoot", func(t *testing.T) {
+		assert.NoError(t, unittest.PrepareTestDatabase())
+
+		_, err := db.DeleteByID[repo_model.Repository](ctx, 10)
+		assert.NoError(t, err)
+
+		parent, err := RepairOrphanedFork(ctx, unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 11}))
+		assert.NoError(t, err)
+		assert.Nil(t, parent)
+
+		fork := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 11})
+		assert.False(t, fork.IsFork)
+		assert.Zero(t, fork.ForkID)
+
+		orphans, err := repo_model.FindOrphanedForks(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, orphans)
+	})
+}
+
 func TestForkRepositoryTreeSizeLimit(t *testing.T) {
 	assert.NoError(t, unittest.PrepareTestDatabase())
 
@@ -361,6 +521,73 @@ func TestForkRepositoryTreeSizeLimit(t *testing.T) {
 	}
 }
 
+func TestForkRepositoryArchived(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	// repo51 is archived
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo51 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 51})
+	assert.True(t, repo51.IsArchived)
+
+	fork, err := ForkRepository(t.Context(), user2, user2, ForkRepoOptions{
+		BaseRepo: repo51,
+		Name:     "test-archived",
+	})
+	assert.Nil(t, fork)
+	assert.True(t, IsErrForkArchivedRepo(err))
+	assert.ErrorIs(t, err, util.ErrPermissionDenied)
+
+	perms, err := CheckForkOnEditPermissions(t.Context(), user2, repo51)
+	assert.NoError(t, err)
+	assert.True(t, perms.IsArchived)
+	assert.False(t, perms.NeedsFork)
+	assert.False(t, perms.CanSubmitChangeRequest)
+}
+
+func TestMaxReposPerUserPerSubject(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// user5 owns repo4, make it an independent article of subject 1 whose root is repo1
+	_, err := db.GetEngine(t.Context()).ID(4).Cols("subject_id").Update(&repo_model.Repository{SubjectID: 1})
+	require.NoError(t, err)
+	user5 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	t.Run("Limit1", func(t *testing.T) {
+		perms, err := CheckForkOnEditPermissions(t.Context(), user5, repo1)
+		require.NoError(t, err)
+		assert.True(t, perms.BlockedBySubject)
+		assert.False(t, perms.NeedsFork)
+		assert.EqualValues(t, 4, perms.OwnRepoForSubject.ID)
+
+		fork, err := ForkRepository(t.Context(), user5, user5, ForkRepoOptions{BaseRepo: repo1, Name: "second-viewpoint"})
+		assert.Nil(t, fork)
+		assert.True(t, IsErrUserOwnsSubjectRepo(err))
+	})
+
+	t.Run("Limit2", func(t *testing.T) {
+		defer test.MockVariableValue(&setting.Repository.Article.MaxReposPerUserPerSubject, 2)()
+
+		perms, err := CheckForkOnEditPermissions(t.Context(), user5, repo1)
+		require.NoError(t, err)
+		assert.False(t, perms.BlockedBySubject)
+		assert.True(t, perms.NeedsFork)
+		assert.True(t, perms.CanSubmitChangeRequest)
+
+		fork, err := ForkRepository(t.Context(), user5, user5, ForkRepoOptions{BaseRepo: repo1, Name: "second-viewpoint"})
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, fork.SubjectID)
+
+		// The second repository reaches the limit
+		assert.True(t, IsErrUserOwnsSubjectRepo(CheckSubjectRepoLimit(t.Context(), user5.ID, 1, 0)))
+		// The fork is now in the fork tree of repo1, so user5 can submit change requests
+		perms, err = CheckForkOnEditPermissions(t.Context(), user5, repo1)
+		require.NoError(t, err)
+		assert.True(t, perms.HasExistingFork)
+		assert.Equal(t, fork.ID, perms.ExistingFork.ID)
+	})
+}
+
 // TestCheckForkOnEditPermissions tests the CheckForkOnEditPermissions function
 // which determines how a user can edit a repository they don't own.
 func TestCheckForkOnEditPermissions(t *testing.T) {
@@ -596,3 +823,45 @@ func TestCheckForkOnEditPermissions(t *testing.T) {
 		assert.Equal(t, fork2Repo.ID, perms.ExistingFork.ID, "ExistingFork should be the user's indirect fork")
 	})
 }
+
+func TestFindRepositoriesBySubject(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	// repo1 (public) is the root of subject 1, make the private repo2 a fork of it
+	repo2 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 2})
+	assert.True(t, repo2.IsPrivate)
+	repo2.SubjectID = 1
+	repo2.IsFork = true
+	repo2.ForkID = 1
+	assert.NoError(t, repo_model.UpdateRepositoryColsNoAutoTime(t.Context(), repo2, "subject_id", "is_fork", "fork_id"))
+
+	listOptions := db.ListOptions{Page: 1, PageSize: 10}
+
+	t.Run("Owner", func(t *testing.T) {
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		repos, total, err := FindRepositoriesBySubject(t.Context(), 1, user2, listOptions)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 2, total)
+		if assert.Len(t, repos, 2) {
+			assert.EqualValues(t, 1, repos[0].ID) // root first
+			assert.EqualValues(t, 2, repos[1].ID)
+		}
+	})
+
+	t.Run("NonMemberDoesNotSeePrivateFork", func(t *testing.T) {
+		user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+		repos, total, err := FindRepositoriesBySubject(t.Context(), 1, user4, listOptions)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, total)
+		if assert.Len(t, repos, 1) {
+			assert.EqualValues(t, 1, repos[0].ID)
+		}
+	})
+
+	t.Run("Anonymous", func(t *testing.T) {
+		repos, total, err := FindRepositoriesBySubject(t.Context(), 1, nil, listOptions)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, total)
+		assert.Len(t, repos, 1)
+	})
+}
@@ -126,7 +126,11 @@ func UpdateRepoLicenses(ctx context.Context, repo *repo_model.Repository, commit
 	}
 
 	if git.IsErrNotExist(err) {
-		return repo_model.CleanRepoLicenses(ctx, repo)
+		if err := repo_model.CleanRepoLicenses(ctx, repo); err != nil {
+			return err
+		}
+		// Articles without a LICENSE file of their own share the license of their subject's root
+		return repo_model.InheritSubjectRootLicense(ctx, repo)
 	}
 
 	licenses := make([]string, 0)
@@ -193,6 +193,16 @@ func pushUpdates(optsList []*repo_module.PushUpdateOptions) error {
 					if err := DelRepoDivergenceFromCache(ctx, repo.ID); err != nil {
 						log.Error("DelRepoDivergenceFromCache: %v", err)
 					}
+					// The article is read from the default branch, precompute its reading metadata
+					if err := AddRepoToArticleReadingQueue(repo.ID); err != nil {
+						log.Error("AddRepoToArticleReadingQueue: %v", err)
+					}
+					// A fork which was too new to take the place of a root without a README may be eligible now
+					if repo.IsFork && repo.SubjectID > 0 {
+						if err := PromoteForkOfRootWithoutReadme(ctx, pusher, repo); err != nil {
+							log.Error("PromoteForkOfRootWithoutReadme(%-v): %v", repo, err)
+						}
+					}
 				} else {
 					if err := DelDivergenceFromCache(repo.ID, branch); err != nil {
 						log.Error("DelDivergenceFromCache: %v", err)
@@ -203,6 +213,8 @@ func pushUpdates(optsList []*repo_module.PushUpdateOptions) error {
 				// This is especially important for forks where contributor counts are filtered
 				// by the fork creation time
 				InvalidateForkContributorStatsCache(repo.ID)
+				InvalidateSubjectStatsCache(repo.SubjectID)
+				InvalidateSubjectLeaderboardCache(repo.SubjectID)
 
 				commits := repo_module.GitToPushCommits(l)
 				commits.HeadCommit = repo_module.CommitToPushCommit(newCommit)
@@ -247,6 +259,13 @@ func pushUpdates(optsList []*repo_module.PushUpdateOptions) error {
 		return fmt.Errorf("UpdateRepositoryUpdatedTime: %w", err)
 	}
 
+	// Pushes to an article count as activity on its subject
+	if repo.SubjectID > 0 {
+		if err := repo_model.TouchSubject(ctx, repo.SubjectID, time.Now()); err != nil {
+			log.Error("TouchSubject for %-v: %v", repo, err)
+		}
+	}
+
 	return nil
 }
 
@@ -299,7 +318,11 @@ func pushNewBranch(ctx context.Context, repo *repo_model.Repository, pusher *use
 		if repo.SubjectID > 0 && !repo.IsFork {
 			// Check for an existing root repository (excluding this one)
 			rootRepo, err := repo_model.GetSubjectRootRepositoryExcluding(ctx, repo.SubjectID, repo.ID)
-			if err == nil && rootRepo != nil {
+			if err == nil && rootRepo != nil && rootRepo.IsArchived {
+				// Archived roots are frozen - leave this repository standalone instead of forking it
+				log.Info("Repository %s (ID: %d) became non-empty but root %s (ID: %d) for subject ID %d is archived. Not converting to fork.",
+					repo.FullName(), repo.ID, rootRepo.FullName(), rootRepo.ID, repo.SubjectID)
+			} else if err == nil && rootRepo != nil {
 				// Another repository is already the root - convert this one to a fork
 				log.Info("Repository %s (ID: %d) became non-empty after root %s (ID: %d) for subject ID %d. Converting to fork.",
 					repo.FullName(), repo.ID, rootRepo.FullName(), rootRepo.ID, repo.SubjectID)
@@ -311,10 +334,19 @@ func pushNewBranch(ctx context.Context, repo *repo_model.Repository, pusher *use
 					repo.IsFork = true
 					repo.ForkID = rootRepo.ID
 				}
-			} else if err != nil && !repo_model.IsErrRepoNotExist(err) {
+			} else if repo_model.IsErrRepoNotExist(err) {
+				// No root exists, so this repository becomes the root
+				if err := repo_model.SetSubjectRootRepoID(ctx, repo.SubjectID, repo.ID); err != nil {
+					log.Error("Failed to record repository %s as root of subject ID %d: %v", repo.FullName(), repo.SubjectID, err)
+				}
+			} else if err != nil {
 				log.Warn("Failed to check for existing root repository for subject ID %d: %v", repo.SubjectID, err)
 			}
-			// If no root exists, this repository becomes the root (no action needed)
+		}
+
+		// The repository now counts as a non-empty article of its subject
+		if err := repo_model.RefreshSubjectCounts(ctx, repo.SubjectID); err != nil {
+			log.Error("Failed to refresh repository counts of subject ID %d: %v", repo.SubjectID, err)
 		}
 
 		// Trigger contributor stats generation for newly non-empty repositories
@@ -0,0 +1,152 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"strconv"
+	"strings"
+
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/gitdiff"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// BuildReadmeDiffLines diffs two versions of a README line by line. The lines start with a section
+// line covering the whole README, the diff isn't split into hunks.
+func BuildReadmeDiffLines(lines1, lines2 []string) []*gitdiff.DiffLine {
+	// Use a simple line-by-line diff algorithm (LCS-based)
+	diffLines := make([]*gitdiff.DiffLine, 0)
+
+	// Add section header
+	diffLines = append(diffLines, &gitdiff.DiffLine{
+		Type:    gitdiff.DiffLineSection,
+		Content: "@@ -1," + strconv.Itoa(len(lines1)) + " +1," + strconv.Itoa(len(lines2)) + " @@",
+		SectionInfo: &gitdiff.DiffLineSectionInfo{
+			Path:          "README.md",
+			LastLeftIdx:   0,
+			LastRightIdx:  0,
+			LeftIdx:       1,
+			RightIdx:      1,
+			LeftHunkSize:  len(lines1),
+			RightHunkSize: len(lines2),
+		},
+	})
+
+	// Use Myers diff algorithm via diffmatchpatch for line-level comparison
+	dmp := diffmatchpatch.New()
+
+	// Join lines with a unique separator for line-based diff
+	text1 := strings.Join(lines1, "\n")
+	text2 := strings.Join(lines2, "\n")
+
+	// Get line-based diff
+	a, b, lineArray := dmp.DiffLinesToChars(text1, text2)
+	diffs := dmp.DiffMain(a, b, false)
+	diffs = dmp.DiffCharsToLines(diffs, lineArray)
+	diffs = dmp.DiffCleanupSemantic(diffs)
+
+	leftIdx := 1
+	rightIdx := 1
+
+	for _, d := range diffs {
+		lines := strings.SplitSeq(strings.TrimSuffix(d.Text, "\n"), "\n")
+		for line := range lines {
+			if line == "" && d.Text == "" {
+				continue
+			}
+			switch d.Type {
+			case diffmatchpatch.DiffEqual:
+				diffLines = append(diffLines, &gitdiff.DiffLine{
+					LeftIdx:  leftIdx,
+					RightIdx: rightIdx,
+					Type:     gitdiff.DiffLinePlain,
+					Content:  " " + line,
+					Match:    0,
+				})
+				leftIdx++
+				rightIdx++
+			case diffmatchpatch.DiffDelete:
+				diffLines = append(diffLines, &gitdiff.DiffLine{
+					LeftIdx:  leftIdx,
+					RightIdx: 0,
+					Type:     gitdiff.DiffLineDel,
+					Content:  "-" + line,
+					Match:    -1,
+				})
+				leftIdx++
+			case diffmatchpatch.DiffInsert:
+				diffLines = append(diffLines, &gitdiff.DiffLine{
+					LeftIdx:  0,
+					RightIdx: rightIdx,
+					Type:     gitdiff.DiffLineAdd,
+					Content:  "+" + line,
+					Match:    -1,
+				})
+				rightIdx++
+			}
+		}
+	}
+
+	return diffLines
+}
+
+// ReadmeDiffHunk is a run of changed lines of a README diff along with the unchanged lines around them
+type ReadmeDiffHunk struct {
+	OldStart int
+	OldLines int
+	NewStart int
+	NewLines int
+	Lines    []*gitdiff.DiffLine
+}
+
+// SplitReadmeDiffHunks groups the lines of BuildReadmeDiffLines into hunks, like a unified diff, keeping
+// up to contextLines unchanged lines before and after each change. Identical READMEs have no hunk.
+func SplitReadmeDiffHunks(diffLines []*gitdiff.DiffLine, contextLines int) []*ReadmeDiffHunk {
+	lines := make([]*gitdiff.DiffLine, 0, len(diffLines))
+	for _, line := range diffLines {
+		if line.Type != gitdiff.DiffLineSection {
+			lines = append(lines, line)
+		}
+	}
+
+	// The ranges of lines of the hunks, changes closer than twice the context share a hunk
+	var ranges [][2]int
+	for i, line := range lines {
+		if line.Type == gitdiff.DiffLinePlain {
+			continue
+		}
+		start, end := max(i-contextLines, 0), min(i+contextLines, len(lines)-1)
+		if len(ranges) > 0 && start <= ranges[len(ranges)-1][1]+1 {
+			ranges[len(ranges)-1][1] = end
+		} else {
+			ranges = append(ranges, [2]int{start, end})
+		}
+	}
+
+	hunks := make([]*ReadmeDiffHunk, 0, len(ranges))
+	oldBefore, newBefore, next := 0, 0, 0
+	for _, r := range ranges {
+		// The lines between hunks are unchanged, on both sides
+		oldBefore += r[0] - next
+		newBefore += r[0] - next
+		hunk := &ReadmeDiffHunk{Lines: lines[r[0] : r[1]+1]}
+		for _, line := range hunk.Lines {
+			if line.Type != gitdiff.DiffLineAdd {
+				hunk.OldLines++
+			}
+			if line.Type != gitdiff.DiffLineDel {
+				hunk.NewLines++
+			}
+		}
+		// As in unified diffs, an empty side starts at the line before the hunk
+		hunk.OldStart = util.Iif(hunk.OldLines > 0, oldBefore+1, oldBefore)
+		hunk.NewStart = util.Iif(hunk.NewLines > 0, newBefore+1, newBefore)
+		oldBefore += hunk.OldLines
+		newBefore += hunk.NewLines
+		next = r[1] + 1
+		hunks = append(hunks, hunk)
+	}
+	return hunks
+}
@@ -0,0 +1,56 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	"code.gitea.io/gitea/services/gitdiff"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitReadmeDiffHunks(t *testing.T) {
+	lines := func(s string) []string { return strings.Split(s, "\n") }
+	types := func(hunk *ReadmeDiffHunk) (s string) {
+		for _, line := range hunk.Lines {
+			s += line.Content[:1]
+		}
+		return s
+	}
+
+	t.Run("Identical", func(t *testing.T) {
+		diffLines := BuildReadmeDiffLines(lines("a\nb\nc"), lines("a\nb\nc"))
+		assert.Empty(t, SplitReadmeDiffHunks(diffLines, 1))
+	})
+
+	t.Run("SeparateChanges", func(t *testing.T) {
+		diffLines := BuildReadmeDiffLines(lines("a\nb\nc\nd\ne\nf\ng\nh\ni"), lines("a\nB\nc\nd\ne\nf\ng\nH\ni"))
+		assert.Equal(t, gitdiff.DiffLineSection, diffLines[0].Type)
+
+		hunks := SplitReadmeDiffHunks(diffLines, 1)
+		require.Len(t, hunks, 2)
+		assert.Equal(t, " -+ ", types(hunks[0]))
+		assert.Equal(t, [4]int{1, 3, 1, 3}, [4]int{hunks[0].OldStart, hunks[0].OldLines, hunks[0].NewStart, hunks[0].NewLines})
+		assert.Equal(t, " -+ ", types(hunks[1]))
+		assert.Equal(t, [4]int{7, 3, 7, 3}, [4]int{hunks[1].OldStart, hunks[1].OldLines, hunks[1].NewStart, hunks[1].NewLines})
+	})
+
+	t.Run("CloseChanges", func(t *testing.T) {
+		diffLines := BuildReadmeDiffLines(lines("a\nb\nc\nd\ne\nf"), lines("a\nB\nc\nd\nE\nf"))
+		hunks := SplitReadmeDiffHunks(diffLines, 2)
+		require.Len(t, hunks, 1)
+		assert.Equal(t, " -+  -+ ", types(hunks[0]))
+	})
+
+	t.Run("OnlyAdded", func(t *testing.T) {
+		diffLines := BuildReadmeDiffLines(lines(""), lines("a\nb"))
+		hunks := SplitReadmeDiffHunks(diffLines, 3)
+		require.Len(t, hunks, 1)
+		assert.Equal(t, 0, hunks[0].OldStart)
+		assert.Equal(t, 1, hunks[0].NewStart)
+	})
+}
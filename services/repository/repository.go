@@ -112,6 +112,12 @@ func Init(ctx context.Context) error {
 	if err := initPushQueue(); err != nil {
 		return err
 	}
+	if err := initArticleReadingQueue(); err != nil {
+		return err
+	}
+	if err := initForkAfterMergeQueue(); err != nil {
+		return err
+	}
 	return initBranchSyncQueue(graceful.GetManager().ShutdownContext())
 }
 
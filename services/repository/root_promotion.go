@@ -0,0 +1,310 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// ErrRootPromotionOutdated represents an error when a pending root promotion can't be approved
+// anymore because the fork tree changed since it was requested.
+type ErrRootPromotionOutdated struct {
+	PromotionID int64
+}
+
+// IsErrRootPromotionOutdated checks if an error is an ErrRootPromotionOutdated.
+func IsErrRootPromotionOutdated(err error) bool {
+	var e ErrRootPromotionOutdated
+	return errors.As(err, &e)
+}
+
+func (err ErrRootPromotionOutdated) Error() string {
+	return fmt.Sprintf("root promotion is outdated [id: %d]", err.PromotionID)
+}
+
+func (err ErrRootPromotionOutdated) Unwrap() error {
+	return util.ErrInvalidArgument
+}
+
+// ErrRootPromotionIneligible represents an error when a fork is too new to take the place of
+// the root repository, see IsForkEligibleForRootPromotion.
+type ErrRootPromotionIneligible struct {
+	RepoID int64
+}
+
+// IsErrRootPromotionIneligible checks if an error is an ErrRootPromotionIneligible.
+func IsErrRootPromotionIneligible(err error) bool {
+	var e ErrRootPromotionIneligible
+	return errors.As(err, &e)
+}
+
+func (err ErrRootPromotionIneligible) Error() string {
+	return fmt.Sprintf("fork is not eligible for root promotion [repo_id: %d]", err.RepoID)
+}
+
+func (err ErrRootPromotionIneligible) Unwrap() error {
+	return util.ErrPermissionDenied
+}
+
+// IsForkEligibleForRootPromotion reports whether fork may take the place of rootRepo. A root
+// repository with commits can only be displaced by a fork with at least
+// [repository.article] ROOT_PROMOTION_MIN_COMMITS commits of its own, or older than
+// ROOT_PROMOTION_MIN_AGE, so that a drive-by edit doesn't demote an established article.
+func IsForkEligibleForRootPromotion(ctx context.Context, fork, rootRepo *repo_model.Repository) (bool, error) {
+	minCommits, minAge := setting.Repository.Article.RootPromotionMinCommits, setting.Repository.Article.RootPromotionMinAge
+	if rootRepo.IsEmpty || (minCommits <= 0 && minAge <= 0) {
+		return true, nil
+	}
+	if minAge > 0 && time.Since(fork.CreatedUnix.AsTime()) >= minAge {
+		return true, nil
+	}
+	if minCommits <= 0 || fork.IsEmpty {
+		return false, nil
+	}
+
+	rootGitRepo, err := gitrepo.OpenRepository(ctx, rootRepo)
+	if err != nil {
+		return false, err
+	}
+	defer rootGitRepo.Close()
+	rootCommitID, err := rootGitRepo.GetBranchCommitID(rootRepo.DefaultBranch)
+	if err != nil {
+		return false, err
+	}
+
+	forkGitRepo, err := gitrepo.OpenRepository(ctx, fork)
+	if err != nil {
+		return false, err
+	}
+	defer forkGitRepo.Close()
+	divergence, err := GetForkDivergence(ctx, rootRepo, rootCommitID, fork, forkGitRepo)
+	if err != nil {
+		return false, err
+	}
+	return divergence.Ahead >= minCommits, nil
+}
+
+// PromoteForkToRoot makes fork, a fork of rootRepo, the root repository of their subject and
+// rootRepo a fork of it. When [repository] REQUIRE_ROOT_PROMOTION_APPROVAL is enabled, the
+// repositories are left unchanged and a pending root promotion is recorded instead, in which
+// case it returns true. It returns ErrRootPromotionIneligible if fork is too new to take the
+// place of rootRepo.
+func PromoteForkToRoot(ctx context.Context, doer *user_model.User, fork, rootRepo *repo_model.Repository) (pending bool, err error) {
+	eligible, err := IsForkEligibleForRootPromotion(ctx, fork, rootRepo)
+	if err != nil {
+		return false, err
+	}
+	if !eligible {
+		return false, ErrRootPromotionIneligible{RepoID: fork.ID}
+	}
+
+	if setting.Repository.RequireRootPromotionApproval {
+		if err := repo_model.CreateRootPromotion(ctx, &repo_model.RootPromotion{
+			SubjectID:  fork.SubjectID,
+			RepoID:     fork.ID,
+			RootRepoID: rootRepo.ID,
+			DoerID:     doer.ID,
+		}); err != nil {
+			return false, err
+		}
+		log.Info("Promotion of %-v to root in place of %-v is waiting for approval", fork, rootRepo)
+		return true, nil
+	}
+	return false, swapRootRepository(ctx, fork, rootRepo)
+}
+
+// PromoteForkOfRootWithoutReadme makes fork the root repository of its subject in place of its parent
+// if the parent is a root repository without a README, so that the first content contributed to an
+// article takes the place of the root, see PromoteForkToRoot. A fork which is not eligible yet is
+// left as it is, it is checked again when its default branch changes.
+func PromoteForkOfRootWithoutReadme(ctx context.Context, doer *user_model.User, fork *repo_model.Repository) error {
+	// Reload the fork, it may have been promoted in the meantime
+	fork, err := repo_model.GetRepositoryByID(ctx, fork.ID)
+	if err != nil {
+		return err
+	}
+	if !fork.IsFork {
+		return nil
+	}
+	rootRepo, err := repo_model.GetRepositoryByID(ctx, fork.ForkID)
+	if err != nil {
+		if repo_model.IsErrRepoNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if rootRepo.IsFork {
+		return nil
+	}
+	hasReadme, err := hasRootReadme(ctx, rootRepo)
+	if err != nil || hasReadme {
+		return err
+	}
+
+	if _, err := PromoteForkToRoot(ctx, doer, fork, rootRepo); err != nil {
+		if IsErrRootPromotionIneligible(err) {
+			log.Trace("Fork %-v is too new to take the place of the root %-v", fork, rootRepo)
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// hasRootReadme reports whether the default branch of rootRepo has a README.md
+func hasRootReadme(ctx context.Context, rootRepo *repo_model.Repository) (bool, error) {
+	if rootRepo.IsEmpty {
+		return false, nil
+	}
+	gitRepo, err := gitrepo.OpenRepository(ctx, rootRepo)
+	if err != nil {
+		return false, err
+	}
+	defer gitRepo.Close()
+	commit, err := gitRepo.GetBranchCommit(rootRepo.DefaultBranch)
+	if err != nil {
+		return false, err
+	}
+	for _, treePath := range []string{"README.md", "readme.md"} {
+		if _, err := commit.GetTreeEntryByPath(treePath); err == nil {
+			return true, nil
+		} else if !git.IsErrNotExist(err) {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+// ApproveRootPromotion performs a pending root promotion and removes it from the queue.
+// It returns ErrRootPromotionOutdated if the fork is no longer a fork of the root.
+func ApproveRootPromotion(ctx context.Context, promotion *repo_model.RootPromotion) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		fork, err := repo_model.GetRepositoryByID(ctx, promotion.RepoID)
+		if err != nil {
+			if repo_model.IsErrRepoNotExist(err) {
+				return ErrRootPromotionOutdated{PromotionID: promotion.ID}
+			}
+			return err
+		}
+		rootRepo, err := repo_model.GetRepositoryByID(ctx, promotion.RootRepoID)
+		if err != nil {
+			if repo_model.IsErrRepoNotExist(err) {
+				return ErrRootPromotionOutdated{PromotionID: promotion.ID}
+			}
+			return err
+		}
+		if !fork.IsFork || fork.ForkID != rootRepo.ID || rootRepo.IsFork {
+			return ErrRootPromotionOutdated{PromotionID: promotion.ID}
+		}
+
+		if err := swapRootRepository(ctx, fork, rootRepo); err != nil {
+			return err
+		}
+		return repo_model.DeleteRootPromotion(ctx, promotion.ID)
+	})
+}
+
+// PromoteForkToRootManually makes fork the root repository of its subject in place of the current
+// root, which becomes a fork of it. Unlike PromoteForkToRoot it is a deliberate choice of an owner
+// of the root or of an administrator, so it never waits for approval. fork may be any fork in the
+// fork tree of the root, it returns an invalid argument error otherwise.
+func PromoteForkToRootManually(ctx context.Context, fork *repo_model.Repository) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if !fork.IsFork || fork.SubjectID == 0 {
+			return util.NewInvalidArgumentErrorf("%s is not a fork of an article", fork.FullName())
+		}
+		rootRepo, err := repo_model.GetSubjectRootRepository(ctx, fork.SubjectID)
+		if err != nil {
+			return err
+		}
+		if err := checkForkDescendsFrom(ctx, fork, rootRepo); err != nil {
+			return err
+		}
+		return swapRootRepository(ctx, fork, rootRepo)
+	})
+}
+
+// checkForkDescendsFrom checks that following the parents of fork leads to rootRepo, so that
+// promoting fork can't create a cycle or join two fork trees. The walk is bounded like
+// repo_model.FindForkTreeRoot in case the fork tree is already broken.
+func checkForkDescendsFrom(ctx context.Context, fork, rootRepo *repo_model.Repository) error {
+	depthLimit := setting.Repository.MaxForkTreeNodes
+	if depthLimit <= 0 {
+		depthLimit = 300
+	}
+
+	visited := make(map[int64]bool, 8)
+	repo := fork
+	for depth := 0; repo.IsFork; depth++ {
+		if visited[repo.ID] || depth >= depthLimit {
+			return util.NewInvalidArgumentErrorf("the fork tree of %s is broken", fork.FullName())
+		}
+		visited[repo.ID] = true
+		if repo.ForkID == rootRepo.ID {
+			return nil
+		}
+		parent, err := repo_model.GetRepositoryByID(ctx, repo.ForkID)
+		if err != nil {
+			if repo_model.IsErrRepoNotExist(err) {
+				break
+			}
+			return err
+		}
+		repo = parent
+	}
+	return util.NewInvalidArgumentErrorf("%s is not in the fork tree of %s", fork.FullName(), rootRepo.FullName())
+}
+
+// swapRootRepository atomically turns fork into a root repository and rootRepo into a fork of it.
+// fork can be an indirect fork of rootRepo, its parent then keeps being a fork of rootRepo.
+func swapRootRepository(ctx context.Context, fork, rootRepo *repo_model.Repository) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		parentID := fork.ForkID
+
+		// 1. Promote the fork to root
+		fork.IsFork = false
+		fork.ForkID = 0
+		if err := repo_model.UpdateRepositoryColsNoAutoTime(ctx, fork, "is_fork", "fork_id"); err != nil {
+			return fmt.Errorf("failed to update forked repo to root: %w", err)
+		}
+
+		// 2. Demote the former root to a fork
+		rootRepo.IsFork = true
+		rootRepo.ForkID = fork.ID
+		if err := repo_model.UpdateRepositoryColsNoAutoTime(ctx, rootRepo, "is_fork", "fork_id"); err != nil {
+			return fmt.Errorf("failed to update base repo to fork: %w", err)
+		}
+
+		// 3. Update NumForks counters
+		// The fork is no longer a fork of its parent, usually the former root, so decrement its count
+		if err := repo_model.DecrementRepoForkNum(ctx, parentID); err != nil {
+			return fmt.Errorf("failed to decrement fork count on the parent of the fork: %w", err)
+		}
+		// The former root is now a fork of the new root, so increment its count
+		if err := repo_model.IncrementRepoForkNum(ctx, fork.ID); err != nil {
+			return fmt.Errorf("failed to increment fork count on new root: %w", err)
+		}
+
+		// 4. Point the subject at its new root
+		if fork.SubjectID > 0 {
+			if err := repo_model.SetSubjectRootRepoID(ctx, fork.SubjectID, fork.ID); err != nil {
+				return fmt.Errorf("failed to update subject root: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,190 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// assertRepoForkOf asserts that the repository is a fork of forkID, or not a fork if it is 0
+func assertRepoForkOf(t *testing.T, repoID, forkID int64) {
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: repoID})
+	assert.Equal(t, forkID != 0, repo.IsFork)
+	assert.Equal(t, forkID, repo.ForkID)
+}
+
+func TestPromoteForkToRootRequiresApproval(t *testing.T) {
+	defer test.MockVariableValue(&setting.Repository.RequireRootPromotionApproval, true)()
+
+	loadRepos := func(t *testing.T) (fork, rootRepo *repo_model.Repository) {
+		// repo11 is a fork of repo10, both in subject 2
+		_, err := db.GetEngine(t.Context()).In("id", 10, 11).Cols("subject_id").Update(&repo_model.Repository{SubjectID: 2})
+		require.NoError(t, err)
+		fork = unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 11})
+		rootRepo = unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 10})
+		return fork, rootRepo
+	}
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 13})
+
+	t.Run("Approve", func(t *testing.T) {
+		require.NoError(t, unittest.PrepareTestDatabase())
+		fork, rootRepo := loadRepos(t)
+
+		pending, err := PromoteForkToRoot(t.Context(), doer, fork, rootRepo)
+		require.NoError(t, err)
+		assert.True(t, pending)
+
+		// Nothing is swapped until the promotion is approved
+		assertRepoForkOf(t, 11, 10)
+		assertRepoForkOf(t, 10, 0)
+		promotion := unittest.AssertExistsAndLoadBean(t, &repo_model.RootPromotion{RepoID: 11, RootRepoID: 10, SubjectID: 2, DoerID: 13})
+
+		// Requesting it again doesn't queue it twice
+		_, err = PromoteForkToRoot(t.Context(), doer, fork, rootRepo)
+		require.NoError(t, err)
+		unittest.AssertCount(t, &repo_model.RootPromotion{}, 1)
+
+		require.NoError(t, ApproveRootPromotion(t.Context(), promotion))
+		assertRepoForkOf(t, 11, 0)
+		assertRepoForkOf(t, 10, 11)
+		unittest.AssertExistsAndLoadBean(t, &repo_model.Subject{ID: 2, RootRepoID: 11})
+		unittest.AssertNotExistsBean(t, &repo_model.RootPromotion{ID: promotion.ID})
+
+		// Approving it once more finds the fork tree already changed
+		assert.True(t, IsErrRootPromotionOutdated(ApproveRootPromotion(t.Context(), promotion)))
+	})
+
+	t.Run("Reject", func(t *testing.T) {
+		require.NoError(t, unittest.PrepareTestDatabase())
+		fork, rootRepo := loadRepos(t)
+
+		_, err := PromoteForkToRoot(t.Context(), doer, fork, rootRepo)
+		require.NoError(t, err)
+		promotion := unittest.AssertExistsAndLoadBean(t, &repo_model.RootPromotion{RepoID: 11})
+
+		require.NoError(t, repo_model.DeleteRootPromotion(t.Context(), promotion.ID))
+		assertRepoForkOf(t, 11, 10)
+		assertRepoForkOf(t, 10, 0)
+	})
+
+	t.Run("WithoutApproval", func(t *testing.T) {
+		require.NoError(t, unittest.PrepareTestDatabase())
+		defer test.MockVariableValue(&setting.Repository.RequireRootPromotionApproval, false)()
+		fork, rootRepo := loadRepos(t)
+
+		pending, err := PromoteForkToRoot(t.Context(), doer, fork, rootRepo)
+		require.NoError(t, err)
+		assert.False(t, pending)
+		assertRepoForkOf(t, 11, 0)
+		assertRepoForkOf(t, 10, 11)
+		unittest.AssertCount(t, &repo_model.RootPromotion{}, 0)
+	})
+}
+
+func TestPromoteForkToRootManually(t *testing.T) {
+	// Manual promotions never wait for approval
+	defer test.MockVariableValue(&setting.Repository.RequireRootPromotionApproval, true)()
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// repo11 is a fork of repo10, both in subject 2
+	_, err := db.GetEngine(t.Context()).In("id", 10, 11).Cols("subject_id").Update(&repo_model.Repository{SubjectID: 2})
+	require.NoError(t, err)
+	fork := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 11})
+	oldRoot := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 10})
+
+	require.NoError(t, PromoteForkToRootManually(t.Context(), fork))
+	assertRepoForkOf(t, 11, 0)
+	assertRepoForkOf(t, 10, 11)
+	unittest.AssertExistsAndLoadBean(t, &repo_model.Subject{ID: 2, RootRepoID: 11})
+	unittest.AssertCount(t, &repo_model.RootPromotion{}, 0)
+	assert.Equal(t, oldRoot.NumForks-1, unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 10}).NumForks)
+	assert.Equal(t, fork.NumForks+1, unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 11}).NumForks)
+
+	// The new root is not a fork anymore
+	fork = unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 11})
+	assert.ErrorIs(t, PromoteForkToRootManually(t.Context(), fork), util.ErrInvalidArgument)
+}
+
+func TestPromoteForkToRootManuallyOutsideForkTree(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// repo11 is a fork of repo10, but repo1 is the root of subject 1
+	_, err := db.GetEngine(t.Context()).ID(11).Cols("subject_id").Update(&repo_model.Repository{SubjectID: 1})
+	require.NoError(t, err)
+	fork := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 11})
+
+	assert.ErrorIs(t, PromoteForkToRootManually(t.Context(), fork), util.ErrInvalidArgument)
+	assertRepoForkOf(t, 11, 10)
+	assertRepoForkOf(t, 1, 0)
+}
+
+func TestPromoteForkToRootEligibility(t *testing.T) {
+	loadRepos := func(t *testing.T) (fork, rootRepo *repo_model.Repository) {
+		require.NoError(t, unittest.PrepareTestDatabase())
+		// repo11 is a fork of repo10, both in subject 2
+		_, err := db.GetEngine(t.Context()).In("id", 10, 11).Cols("subject_id").Update(&repo_model.Repository{SubjectID: 2})
+		require.NoError(t, err)
+		fork = unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 11})
+		rootRepo = unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 10})
+		return fork, rootRepo
+	}
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 13})
+
+	t.Run("TooNew", func(t *testing.T) {
+		defer test.MockVariableValue(&setting.Repository.Article.RootPromotionMinAge, time.Hour)()
+		fork, rootRepo := loadRepos(t)
+		fork.CreatedUnix = timeutil.TimeStampNow()
+
+		_, err := PromoteForkToRoot(t.Context(), doer, fork, rootRepo)
+		assert.True(t, IsErrRootPromotionIneligible(err))
+		assertRepoForkOf(t, 11, 10)
+		assertRepoForkOf(t, 10, 0)
+	})
+
+	t.Run("OldEnough", func(t *testing.T) {
+		defer test.MockVariableValue(&setting.Repository.Article.RootPromotionMinAge, time.Hour)()
+		fork, rootRepo := loadRepos(t)
+		fork.CreatedUnix = timeutil.TimeStamp(time.Now().Add(-2 * time.Hour).Unix())
+
+		_, err := PromoteForkToRoot(t.Context(), doer, fork, rootRepo)
+		require.NoError(t, err)
+		assertRepoForkOf(t, 11, 0)
+		assertRepoForkOf(t, 10, 11)
+	})
+
+	t.Run("TooFewCommits", func(t *testing.T) {
+		defer test.MockVariableValue(&setting.Repository.Article.RootPromotionMinCommits, 1000)()
+		fork, rootRepo := loadRepos(t)
+
+		_, err := PromoteForkToRoot(t.Context(), doer, fork, rootRepo)
+		assert.True(t, IsErrRootPromotionIneligible(err))
+		assertRepoForkOf(t, 11, 10)
+		assertRepoForkOf(t, 10, 0)
+	})
+
+	t.Run("EmptyRoot", func(t *testing.T) {
+		defer test.MockVariableValue(&setting.Repository.Article.RootPromotionMinCommits, 1000)()
+		fork, rootRepo := loadRepos(t)
+		// An empty root has no article to keep, any fork can take its place
+		rootRepo.IsEmpty = true
+
+		_, err := PromoteForkToRoot(t.Context(), doer, fork, rootRepo)
+		require.NoError(t, err)
+		assertRepoForkOf(t, 11, 0)
+		assertRepoForkOf(t, 10, 11)
+	})
+}
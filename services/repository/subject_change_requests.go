@@ -0,0 +1,75 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"context"
+	"sort"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+)
+
+// subjectChangeRequestsLimit is the maximum number of change requests listed for a subject
+const subjectChangeRequestsLimit = 50
+
+// FindSubjectChangeRequests returns the change requests targeting any repository of a subject
+// that doer can read the pull requests of, newest first. Both change requests from a branch of
+// the repository itself and from other forks are included. state is "open", "closed" or "all".
+func FindSubjectChangeRequests(ctx context.Context, subjectID int64, doer *user_model.User, state string) (issues_model.PullRequestList, error) {
+	repos, err := repo_model.FindNonEmptyRepositoriesBySubject(ctx, subjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	changeRequests := make(issues_model.PullRequestList, 0, subjectChangeRequestsLimit)
+	for _, repo := range repos {
+		perm, err := access_model.GetUserRepoPermission(ctx, repo, doer)
+		if err != nil {
+			return nil, err
+		}
+		if !perm.CanRead(unit.TypePullRequests) {
+			continue
+		}
+
+		prs, _, err := issues_model.PullRequests(ctx, repo.ID, &issues_model.PullRequestsOptions{
+			ListOptions: db.ListOptions{PageSize: subjectChangeRequestsLimit},
+			State:       state,
+		})
+		if err != nil {
+			return nil, err
+		}
+		prs.SetBaseRepo(repo)
+		changeRequests = append(changeRequests, prs...)
+	}
+
+	if len(changeRequests) == 0 {
+		return changeRequests, nil
+	}
+	if err := changeRequests.LoadRepositories(ctx); err != nil {
+		return nil, err
+	}
+	issues, err := changeRequests.LoadIssues(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := issues.LoadPosters(ctx); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(changeRequests, func(i, j int) bool {
+		if changeRequests[i].Issue.CreatedUnix != changeRequests[j].Issue.CreatedUnix {
+			return changeRequests[i].Issue.CreatedUnix > changeRequests[j].Issue.CreatedUnix
+		}
+		return changeRequests[i].ID > changeRequests[j].ID
+	})
+	if len(changeRequests) > subjectChangeRequestsLimit {
+		changeRequests = changeRequests[:subjectChangeRequestsLimit]
+	}
+	return changeRequests, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindSubjectChangeRequests(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// Put repo1 (change requests from its own branches), repo10 (a change request from its fork repo11)
+	// and the private repo3 in subject 2
+	_, err := db.GetEngine(t.Context()).In("id", 1, 3, 10, 11).Cols("subject_id").Update(&repo_model.Repository{SubjectID: 2})
+	require.NoError(t, err)
+
+	changeRequestIDs := func(prs issues_model.PullRequestList) []int64 {
+		ids := make([]int64, 0, len(prs))
+		for _, pr := range prs {
+			ids = append(ids, pr.ID)
+		}
+		return ids
+	}
+
+	t.Run("Anonymous", func(t *testing.T) {
+		prs, err := FindSubjectChangeRequests(t.Context(), 2, nil, "open")
+		require.NoError(t, err)
+		// Newest first, without the change request to the private repo3
+		assert.Equal(t, []int64{5, 3, 2, 1}, changeRequestIDs(prs))
+		for _, pr := range prs {
+			require.NotNil(t, pr.Issue)
+			require.NotNil(t, pr.BaseRepo)
+			assert.Equal(t, pr.BaseRepoID, pr.BaseRepo.ID)
+		}
+	})
+
+	t.Run("Admin", func(t *testing.T) {
+		admin := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+		prs, err := FindSubjectChangeRequests(t.Context(), 2, admin, "open")
+		require.NoError(t, err)
+		assert.Equal(t, []int64{6, 5, 3, 2, 1}, changeRequestIDs(prs))
+	})
+
+	t.Run("Closed", func(t *testing.T) {
+		prs, err := FindSubjectChangeRequests(t.Context(), 2, nil, "closed")
+		require.NoError(t, err)
+		assert.Empty(t, prs)
+	})
+
+	t.Run("OtherSubject", func(t *testing.T) {
+		prs, err := FindSubjectChangeRequests(t.Context(), 1, nil, "all")
+		require.NoError(t, err)
+		assert.Empty(t, prs)
+	})
+}
@@ -0,0 +1,150 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/cache"
+	"code.gitea.io/gitea/modules/log"
+)
+
+const (
+	subjectLeaderboardCacheKey = "SubjectContributorLeaderboard/%d"
+
+	// maxSubjectLeaderboardSize is the number of contributors kept in a cached leaderboard, larger
+	// limits are capped to it
+	maxSubjectLeaderboardSize = 50
+)
+
+// SubjectContributor is a contributor to the articles of a subject. The email the commits were
+// made with is only used to recognize the contributor across repositories and isn't exposed.
+type SubjectContributor struct {
+	Name       string `json:"name"`
+	Login      string `json:"login"`
+	AvatarLink string `json:"avatar_link"`
+	HomeLink   string `json:"home_link"`
+	// Commits counts the commits in all articles of the subject, for forks only those made since
+	// the fork was created
+	Commits int64 `json:"commits"`
+	// RepoCount is the number of articles of the subject the contributor has commits in
+	RepoCount int `json:"repo_count"`
+}
+
+// GetSubjectContributorLeaderboard returns the contributors with the most commits across the root
+// and all forks of a subject, at most limit (capped to maxSubjectLeaderboardSize) of them. Results
+// are cached with the subject stats and invalidated at the same time.
+func GetSubjectContributorLeaderboard(ctx context.Context, subjectID int64, limit int) ([]*SubjectContributor, error) {
+	if limit <= 0 || limit > maxSubjectLeaderboardSize {
+		limit = maxSubjectLeaderboardSize
+	}
+
+	c := cache.GetCache()
+	if c == nil {
+		return nil, nil
+	}
+	cacheKey := fmt.Sprintf(subjectLeaderboardCacheKey, subjectID)
+	var leaderboard []*SubjectContributor
+	if exists, cacheErr := c.GetJSON(cacheKey, &leaderboard); !exists || cacheErr != nil {
+		repos, err := repo_model.FindNonEmptyRepositoriesBySubject(ctx, subjectID)
+		if err != nil {
+			return nil, err
+		}
+
+		// Contributor statistics are generated asynchronously; don't cache partial results
+		complete := true
+		contributors := make(map[string]*SubjectContributor)
+		for _, repo := range repos {
+			contributorStats, err := GetContributorStats(ctx, c, repo, repo.DefaultBranch)
+			if err != nil {
+				if errors.Is(err, ErrAwaitGeneration) {
+					// Each uncached repository may block for awaitGenerationTime, so only wait once
+					// and leave the remaining repositories for a later request
+					complete = false
+					break
+				}
+				return nil, err
+			}
+			addLeaderboardContributors(contributors, contributorStats, getForkSinceTime(repo))
+		}
+
+		leaderboard = rankSubjectContributors(contributors, maxSubjectLeaderboardSize)
+		if complete {
+			if err := c.PutJSON(cacheKey, leaderboard, subjectStatsCacheTimeout); err != nil {
+				log.Warn("Failed to cache the contributor leaderboard of subject %d: %v", subjectID, err)
+			}
+		}
+	}
+
+	if len(leaderboard) > limit {
+		leaderboard = leaderboard[:limit]
+	}
+	return leaderboard, nil
+}
+
+// addLeaderboardContributors adds the commits made in one repository after since to the
+// contributors of a subject, keyed by normalized email
+func addLeaderboardContributors(contributors map[string]*SubjectContributor, contributorStats map[string]*ContributorData, since time.Time) {
+	for email, data := range contributorStats {
+		// Skip the "total" summary entry
+		if email == "total" {
+			continue
+		}
+		commits := countCommitsSince(data, since)
+		if commits == 0 {
+			continue
+		}
+
+		key := normalizeContributorEmail(email)
+		contributor, ok := contributors[key]
+		if !ok {
+			contributor = &SubjectContributor{Name: data.Name}
+			contributors[key] = contributor
+		}
+		// Prefer the details of a repository where the email belongs to a known user
+		if contributor.Login == "" && data.Login != "" {
+			contributor.Name, contributor.Login = data.Name, data.Login
+			contributor.AvatarLink, contributor.HomeLink = data.AvatarLink, data.HomeLink
+		} else if contributor.AvatarLink == "" {
+			contributor.AvatarLink = data.AvatarLink
+		}
+		contributor.Commits += commits
+		contributor.RepoCount++
+	}
+}
+
+// rankSubjectContributors orders contributors by commits, then by name, keeping at most limit
+func rankSubjectContributors(contributors map[string]*SubjectContributor, limit int) []*SubjectContributor {
+	leaderboard := make([]*SubjectContributor, 0, len(contributors))
+	for _, contributor := range contributors {
+		leaderboard = append(leaderboard, contributor)
+	}
+	sort.Slice(leaderboard, func(i, j int) bool {
+		if leaderboard[i].Commits != leaderboard[j].Commits {
+			return leaderboard[i].Commits > leaderboard[j].Commits
+		}
+		return strings.ToLower(leaderboard[i].Name) < strings.ToLower(leaderboard[j].Name)
+	})
+	if len(leaderboard) > limit {
+		leaderboard = leaderboard[:limit]
+	}
+	return leaderboard
+}
+
+// InvalidateSubjectLeaderboardCache removes the cached contributor leaderboard of a subject
+func InvalidateSubjectLeaderboardCache(subjectID int64) {
+	c := cache.GetCache()
+	if c == nil || subjectID == 0 {
+		return
+	}
+	if err := c.Delete(fmt.Sprintf(subjectLeaderboardCacheKey, subjectID)); err != nil {
+		log.Warn("Failed to invalidate the contributor leaderboard cache for subject %d: %v", subjectID, err)
+	}
+}
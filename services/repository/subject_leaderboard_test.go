@@ -0,0 +1,72 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubjectContributorLeaderboard(t *testing.T) {
+	forkCreated := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	beforeFork := time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC).UnixMilli()
+	afterFork := time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC).UnixMilli()
+
+	weeks := func(week int64, commits int) map[int64]*WeekData {
+		return map[int64]*WeekData{week: {Week: week, Commits: commits}}
+	}
+
+	root := map[string]*ContributorData{
+		"total":             {TotalCommits: 5, Weeks: weeks(beforeFork, 5)},
+		"alice@example.com": {Name: "Alice", TotalCommits: 3, Weeks: weeks(beforeFork, 3)},
+		"bob@example.com":   {Name: "Bob", TotalCommits: 2, Weeks: weeks(beforeFork, 2)},
+	}
+	fork1 := map[string]*ContributorData{
+		// Alice's commits in the root are inherited by the fork and only counted once
+		"Alice@Example.com ": {Name: "Alice", Login: "alice", HomeLink: "/alice", TotalCommits: 7, Weeks: map[int64]*WeekData{
+			beforeFork: {Week: beforeFork, Commits: 3},
+			afterFork:  {Week: afterFork, Commits: 4},
+		}},
+		"bob@example.com":   {Name: "Bob", TotalCommits: 2, Weeks: weeks(beforeFork, 2)},
+		"carol@example.com": {Name: "Carol", TotalCommits: 2, Weeks: weeks(afterFork, 2)},
+	}
+	fork2 := map[string]*ContributorData{
+		"alice@example.com": {Name: "Alice", TotalCommits: 1, Weeks: weeks(afterFork, 1)},
+		"carol@example.com": {Name: "Carol", TotalCommits: 6, Weeks: weeks(afterFork, 6)},
+	}
+
+	contributors := make(map[string]*SubjectContributor)
+	addLeaderboardContributors(contributors, root, time.Time{})
+	addLeaderboardContributors(contributors, fork1, forkCreated)
+	addLeaderboardContributors(contributors, fork2, forkCreated)
+	require.Len(t, contributors, 3)
+
+	leaderboard := rankSubjectContributors(contributors, maxSubjectLeaderboardSize)
+	require.Len(t, leaderboard, 3)
+
+	// Alice: 3 in the root, 4 in fork1 and 1 in fork2
+	assert.Equal(t, "Alice", leaderboard[0].Name)
+	assert.EqualValues(t, 8, leaderboard[0].Commits)
+	assert.Equal(t, 3, leaderboard[0].RepoCount)
+	// The details of the known user are kept
+	assert.Equal(t, "alice", leaderboard[0].Login)
+	assert.Equal(t, "/alice", leaderboard[0].HomeLink)
+
+	// Carol: 2 in fork1 and 6 in fork2, ties with Alice are ordered by name
+	assert.Equal(t, "Carol", leaderboard[1].Name)
+	assert.EqualValues(t, 8, leaderboard[1].Commits)
+	assert.Equal(t, 2, leaderboard[1].RepoCount)
+
+	// Bob only committed to the root, his commits inherited by fork1 don't count again
+	assert.Equal(t, "Bob", leaderboard[2].Name)
+	assert.EqualValues(t, 2, leaderboard[2].Commits)
+	assert.Equal(t, 1, leaderboard[2].RepoCount)
+
+	// The leaderboard is capped
+	leaderboard = rankSubjectContributors(contributors, 2)
+	assert.Len(t, leaderboard, 2)
+}
@@ -0,0 +1,191 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/cache"
+	"code.gitea.io/gitea/modules/container"
+
+	"xorm.io/builder"
+)
+
+// RelatedSubject is a subject related to another one
+type RelatedSubject struct {
+	*repo_model.Subject
+	SharedContributors int // contributors of both subjects
+	SharedNameTokens   int // words both subject names contain
+}
+
+// score ranks related subjects, a shared contributor weighs as much as a shared word
+func (s *RelatedSubject) score() int {
+	return s.SharedContributors + s.SharedNameTokens
+}
+
+// FindRelatedSubjects returns up to limit subjects related to a subject, ranked by how many
+// contributors they share with it and how many words their names share with its name.
+// Contributors of a subject are the owners of its public articles and the users who edited
+// them or submitted change requests to them. The contributor statistics of the subject's
+// articles are also used where they have already been computed.
+func FindRelatedSubjects(ctx context.Context, subjectID int64, limit int) ([]*RelatedSubject, error) {
+	subject, err := repo_model.GetSubjectByID(ctx, subjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	related := make(map[int64]*RelatedSubject)
+
+	contributors, err := findSubjectContributors(ctx, builder.Eq{"repository.subject_id": subjectID}, nil)
+	if err != nil {
+		return nil, err
+	}
+	contributorIDs := contributors[subjectID]
+	if contributorIDs == nil {
+		contributorIDs = make(container.Set[int64])
+	}
+	if err := addCachedSubjectContributors(ctx, subjectID, contributorIDs); err != nil {
+		return nil, err
+	}
+
+	if len(contributorIDs) > 0 {
+		others, err := findSubjectContributors(ctx, builder.Neq{"repository.subject_id": subjectID}, contributorIDs.Values())
+		if err != nil {
+			return nil, err
+		}
+		otherIDs := make([]int64, 0, len(others))
+		for otherID := range others {
+			otherIDs = append(otherIDs, otherID)
+		}
+		subjects := make(map[int64]*repo_model.Subject, len(otherIDs))
+		if err := db.GetEngine(ctx).In("id", otherIDs).And("deleted_unix = ?", 0).Find(&subjects); err != nil {
+			return nil, err
+		}
+		for otherID, shared := range others {
+			if other, ok := subjects[otherID]; ok {
+				related[otherID] = &RelatedSubject{Subject: other, SharedContributors: len(shared)}
+			}
+		}
+	}
+
+	similar, err := repo_model.FindSubjectsSharingNameTokens(ctx, subject, limit)
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range similar {
+		if related[other.ID] == nil {
+			related[other.ID] = &RelatedSubject{Subject: other}
+		}
+		related[other.ID].SharedNameTokens = repo_model.CountSharedNameTokens(subject.Name, other.Name)
+	}
+
+	result := make([]*RelatedSubject, 0, len(related))
+	for _, s := range related {
+		result = append(result, s)
+	}
+	slices.SortFunc(result, func(a, b *RelatedSubject) int {
+		return cmp.Or(b.score()-a.score(), cmp.Compare(a.ID, b.ID))
+	})
+	return result[:min(len(result), limit)], nil
+}
+
+// findSubjectContributors returns the contributors of the subjects matching subjectCond by
+// subject ID, optionally limited to the given users
+func findSubjectContributors(ctx context.Context, subjectCond builder.Cond, userIDs []int64) (map[int64]container.Set[int64], error) {
+	type subjectContributor struct {
+		SubjectID int64
+		UserID    int64
+	}
+
+	repoCond := builder.Gt{"repository.subject_id": 0}.
+		And(builder.Eq{"repository.is_private": false}).
+		And(subjectCond)
+
+	ownerCond := repoCond
+	if userIDs != nil {
+		ownerCond = ownerCond.And(builder.In("repository.owner_id", userIDs))
+	}
+	var owners []*subjectContributor
+	if err := db.GetEngine(ctx).
+		Table("repository").
+		Where(ownerCond).
+		Select("DISTINCT repository.subject_id AS subject_id, repository.owner_id AS user_id").
+		Find(&owners); err != nil {
+		return nil, fmt.Errorf("find article owners: %w", err)
+	}
+
+	// Every action is stored once for the doer and once more for each watcher
+	actorCond := repoCond.
+		And(builder.Expr("action.user_id = action.act_user_id")).
+		And(builder.In("action.op_type", activities_model.ActionCommitRepo, activities_model.ActionCreatePullRequest))
+	if userIDs != nil {
+		actorCond = actorCond.And(builder.In("action.act_user_id", userIDs))
+	}
+	var actors []*subjectContributor
+	if err := db.GetEngine(ctx).
+		Table("action").
+		Join("INNER", "repository", "repository.id = action.repo_id").
+		Where(actorCond).
+		Select("DISTINCT repository.subject_id AS subject_id, action.act_user_id AS user_id").
+		Find(&actors); err != nil {
+		return nil, fmt.Errorf("find article editors: %w", err)
+	}
+
+	contributors := make(map[int64]container.Set[int64])
+	for _, c := range append(owners, actors...) {
+		if contributors[c.SubjectID] == nil {
+			contributors[c.SubjectID] = make(container.Set[int64])
+		}
+		contributors[c.SubjectID].Add(c.UserID)
+	}
+	return contributors, nil
+}
+
+// addCachedSubjectContributors adds the users among the contributors in the already computed
+// contributor statistics of the articles of a subject. Statistics aren't generated for this.
+func addCachedSubjectContributors(ctx context.Context, subjectID int64, contributorIDs container.Set[int64]) error {
+	c := cache.GetCache()
+	if c == nil {
+		return nil
+	}
+	repos, err := repo_model.FindNonEmptyRepositoriesBySubject(ctx, subjectID)
+	if err != nil {
+		return err
+	}
+
+	emails := make([]string, 0, 10)
+	for _, repo := range repos {
+		if repo.IsPrivate {
+			continue
+		}
+		var contributorStats map[string]*ContributorData
+		cacheKey := fmt.Sprintf(contributorStatsCacheKey, repo.FullName(), repo.DefaultBranch)
+		if exists, cacheErr := c.GetJSON(cacheKey, &contributorStats); !exists || cacheErr != nil {
+			continue
+		}
+		for email := range contributorStats {
+			if email != "total" {
+				emails = append(emails, email)
+			}
+		}
+	}
+
+	users, err := user_model.GetUsersByEmails(ctx, emails)
+	if err != nil || users == nil {
+		return err
+	}
+	for _, email := range emails {
+		if user := users.GetByEmail(email); user != nil {
+			contributorIDs.Add(user.ID)
+		}
+	}
+	return nil
+}
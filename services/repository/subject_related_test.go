@@ -0,0 +1,75 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"testing"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindRelatedSubjects(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	sharedSubject, err := repo_model.GetOrCreateSubject(ctx, "Moon Landing")
+	require.NoError(t, err)
+	unrelatedSubject, err := repo_model.GetOrCreateSubject(ctx, "Deep Sea")
+	require.NoError(t, err)
+
+	// repo1 of user2 is the root of subject 1; user2 also edited repo10 (owned by user12)
+	// of the shared subject, while repo8 of the unrelated subject has nothing in common
+	for repoID, subjectID := range map[int64]int64{10: sharedSubject.ID, 8: unrelatedSubject.ID} {
+		repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: repoID})
+		repo.SubjectID = subjectID
+		require.NoError(t, repo_model.UpdateRepositoryColsNoAutoTime(ctx, repo, "subject_id"))
+	}
+	require.NoError(t, db.Insert(ctx, &activities_model.Action{
+		UserID:    2,
+		ActUserID: 2,
+		OpType:    activities_model.ActionCommitRepo,
+		RepoID:    10,
+	}))
+
+	relatedIDs := func(related []*RelatedSubject) []int64 {
+		ids := make([]int64, 0, len(related))
+		for _, s := range related {
+			ids = append(ids, s.ID)
+		}
+		return ids
+	}
+
+	t.Run("SharedContributor", func(t *testing.T) {
+		related, err := FindRelatedSubjects(ctx, 1, 10)
+		require.NoError(t, err)
+		// "another-subject" shares the word "subject" with "example-subject"
+		assert.Equal(t, []int64{2, sharedSubject.ID}, relatedIDs(related))
+		assert.Equal(t, 1, related[0].SharedNameTokens)
+		assert.Equal(t, 1, related[1].SharedContributors)
+	})
+
+	t.Run("Symmetric", func(t *testing.T) {
+		related, err := FindRelatedSubjects(ctx, sharedSubject.ID, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1}, relatedIDs(related))
+	})
+
+	t.Run("Unrelated", func(t *testing.T) {
+		related, err := FindRelatedSubjects(ctx, unrelatedSubject.ID, 10)
+		require.NoError(t, err)
+		assert.Empty(t, related)
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		related, err := FindRelatedSubjects(ctx, 1, 1)
+		require.NoError(t, err)
+		assert.Len(t, related, 1)
+	})
+}
@@ -0,0 +1,173 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"context"
+	"html"
+	"html/template"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/indexer"
+	code_indexer "code.gitea.io/gitea/modules/indexer/code"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+)
+
+const (
+	// MaxSubjectSearchRepos is the maximum number of articles of a subject searched per request
+	MaxSubjectSearchRepos = 50
+	// maxSubjectSearchSnippets is the maximum number of matching lines returned per article
+	maxSubjectSearchSnippets = 3
+	// maxSubjectSearchSnippetLength is the length matching lines are cut to, in characters
+	maxSubjectSearchSnippetLength = 200
+)
+
+// SubjectSearchResult is an article of a subject whose content matches a search
+type SubjectSearchResult struct {
+	Repo     *repo_model.Repository
+	Snippets []template.HTML
+}
+
+// SubjectSearchResults are the results of SearchSubjectArticles
+type SubjectSearchResults struct {
+	Results []*SubjectSearchResult
+	// Truncated is true if the subject has more articles than were searched
+	Truncated bool
+}
+
+// IsArticleReadme reports whether treePath is the README an article is read from,
+// see findReadmeInEntries in the article view
+func IsArticleReadme(treePath string) bool {
+	switch strings.ToLower(treePath) {
+	case "readme.md", "readme", "readme.txt":
+		return true
+	}
+	return false
+}
+
+// SearchSubjectArticles searches the README of the articles of a subject that doer can see for query.
+// The repo indexer is used when it's enabled, otherwise the articles are searched with git grep.
+// At most MaxSubjectSearchRepos articles are searched, the root article and the most recently
+// updated ones first.
+func SearchSubjectArticles(ctx context.Context, subjectID int64, query string, doer *user_model.User) (*SubjectSearchResults, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return &SubjectSearchResults{}, nil
+	}
+
+	repos, total, err := FindRepositoriesBySubject(ctx, subjectID, doer, db.ListOptions{Page: 1, PageSize: MaxSubjectSearchRepos})
+	if err != nil {
+		return nil, err
+	}
+	results := &SubjectSearchResults{Truncated: total > int64(len(repos))}
+
+	searchable := make([]*repo_model.Repository, 0, len(repos))
+	for _, repo := range repos {
+		if !repo.IsEmpty && !repo.IsBroken() {
+			searchable = append(searchable, repo)
+		}
+	}
+	if len(searchable) == 0 {
+		return results, nil
+	}
+
+	var snippets map[int64][]template.HTML
+	if setting.Indexer.RepoIndexerEnabled && code_indexer.IsAvailable(ctx) {
+		snippets, err = searchSubjectArticlesIndexer(ctx, subjectID, searchable, query)
+	} else {
+		snippets, err = searchSubjectArticlesGrep(ctx, searchable, query)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, repo := range searchable {
+		if repoSnippets, ok := snippets[repo.ID]; ok {
+			results.Results = append(results.Results, &SubjectSearchResult{Repo: repo, Snippets: repoSnippets})
+		}
+	}
+	return results, nil
+}
+
+// searchSubjectArticlesIndexer searches the repo indexer for query in the articles of subjectID.
+// repos limits the results to the articles doer can see.
+func searchSubjectArticlesIndexer(ctx context.Context, subjectID int64, repos []*repo_model.Repository, query string) (map[int64][]template.HTML, error) {
+	repoIDs := make([]int64, 0, len(repos))
+	for _, repo := range repos {
+		repoIDs = append(repoIDs, repo.ID)
+	}
+
+	_, searchResults, _, err := code_indexer.PerformSearch(ctx, &code_indexer.SearchOptions{
+		RepoIDs:    repoIDs,
+		SubjectID:  subjectID,
+		Keyword:    query,
+		SearchMode: indexer.SearchModeWords,
+		Paginator:  &db.ListOptions{Page: 1, PageSize: MaxSubjectSearchRepos * 4},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	snippets := make(map[int64][]template.HTML, len(searchResults))
+	for _, result := range searchResults {
+		if !IsArticleReadme(result.Filename) {
+			continue
+		}
+		repoSnippets := snippets[result.RepoID]
+		for _, line := range result.Lines {
+			if len(repoSnippets) >= maxSubjectSearchSnippets {
+				break
+			}
+			repoSnippets = append(repoSnippets, line.FormattedContent)
+		}
+		snippets[result.RepoID] = repoSnippets
+	}
+	return snippets, nil
+}
+
+func searchSubjectArticlesGrep(ctx context.Context, repos []*repo_model.Repository, query string) (map[int64][]template.HTML, error) {
+	snippets := make(map[int64][]template.HTML)
+	for _, repo := range repos {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		gitRepo, err := gitrepo.OpenRepository(ctx, repo)
+		if err != nil {
+			log.Warn("SearchSubjectArticles: OpenRepository %s: %v", repo.FullName(), err)
+			continue
+		}
+		grepResults, err := git.GrepSearch(ctx, gitRepo, query, git.GrepOptions{
+			RefName:        repo.DefaultBranch,
+			MaxResultLimit: 1,
+			GrepMode:       git.GrepModeWords,
+			PathspecList:   []string{":(icase)readme.md", ":(icase)readme", ":(icase)readme.txt"},
+		})
+		gitRepo.Close()
+		if err != nil {
+			log.Warn("SearchSubjectArticles: GrepSearch %s: %v", repo.FullName(), err)
+			continue
+		}
+
+		for _, result := range grepResults {
+			repoSnippets := make([]template.HTML, 0, maxSubjectSearchSnippets)
+			for _, line := range result.LineCodes {
+				if len(repoSnippets) >= maxSubjectSearchSnippets {
+					break
+				}
+				line = util.EllipsisDisplayString(strings.TrimSpace(line), maxSubjectSearchSnippetLength)
+				repoSnippets = append(repoSnippets, template.HTML(html.EscapeString(line)))
+			}
+			snippets[repo.ID] = repoSnippets
+		}
+	}
+	return snippets, nil
+}
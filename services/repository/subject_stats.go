@@ -0,0 +1,161 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/cache"
+	"code.gitea.io/gitea/modules/log"
+)
+
+const (
+	subjectStatsCacheKey           = "SubjectStats/%d"
+	subjectStatsCacheTimeout int64 = 60 * 10
+)
+
+// SubjectStats summarizes the activity across all articles of a subject
+type SubjectStats struct {
+	RepoCount     int64 `json:"repo_count"`
+	RootRepoCount int64 `json:"root_repo_count"`
+	ForkRepoCount int64 `json:"fork_repo_count"`
+
+	// ContributorCount counts distinct contributors across the root and all forks,
+	// deduplicated by normalized email. Contributions inherited by a fork from its
+	// parent are not counted for that fork.
+	ContributorCount int `json:"contributor_count"`
+
+	// ForkCommitCount is the number of commits made in forks since they diverged
+	ForkCommitCount int64 `json:"fork_commit_count"`
+
+	// MostDivergedRepoID is the fork with the most commits since it diverged, 0 if no fork has any
+	MostDivergedRepoID      int64 `json:"most_diverged_repo_id"`
+	MostDivergedRepoCommits int64 `json:"most_diverged_repo_commits"`
+}
+
+// GetSubjectStats returns the statistics of a subject. Results are cached and
+// invalidated when commits are pushed to any repository of the subject.
+//
+// Commits of a fork are counted from the contributor statistics of the fork since its
+// creation (see getForkSinceTime) rather than by comparing with the root: commits made
+// in a fork are not present in the root repository, so a git comparison from the root
+// would fail for exactly the forks that have diverged.
+func GetSubjectStats(ctx context.Context, subjectID int64) (*SubjectStats, error) {
+	c := cache.GetCache()
+	cacheKey := fmt.Sprintf(subjectStatsCacheKey, subjectID)
+	if c != nil {
+		var cached SubjectStats
+		if exists, cacheErr := c.GetJSON(cacheKey, &cached); exists && cacheErr == nil {
+			return &cached, nil
+		}
+	}
+
+	counts, err := repo_model.BatchCountRepositoriesBySubjects(ctx, []int64{subjectID})
+	if err != nil {
+		return nil, err
+	}
+	stats := &SubjectStats{
+		RepoCount:     counts[subjectID].RepoCount,
+		RootRepoCount: counts[subjectID].RootRepoCount,
+		ForkRepoCount: counts[subjectID].ForkRepoCount,
+	}
+
+	repos, err := repo_model.FindNonEmptyRepositoriesBySubject(ctx, subjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Contributor statistics are generated asynchronously; don't cache partial results
+	complete := c != nil
+	if c != nil {
+		contributors := make(map[string]struct{})
+		for _, repo := range repos {
+			contributorStats, err := GetContributorStats(ctx, c, repo, repo.DefaultBranch)
+			if err != nil {
+				if errors.Is(err, ErrAwaitGeneration) {
+					// Each uncached repository may block for awaitGenerationTime, so only wait once
+					// and leave the remaining repositories for a later request
+					complete = false
+					break
+				}
+				return nil, err
+			}
+
+			since := getForkSinceTime(repo)
+			addSubjectContributors(contributors, contributorStats, since)
+
+			if repo.IsFork {
+				commits := countCommitsSince(contributorStats["total"], since)
+				stats.ForkCommitCount += commits
+				if commits > stats.MostDivergedRepoCommits {
+					stats.MostDivergedRepoID = repo.ID
+					stats.MostDivergedRepoCommits = commits
+				}
+			}
+		}
+		stats.ContributorCount = len(contributors)
+	}
+
+	if complete {
+		if err := c.PutJSON(cacheKey, stats, subjectStatsCacheTimeout); err != nil {
+			log.Warn("Failed to cache stats for subject %d: %v", subjectID, err)
+		}
+	}
+	return stats, nil
+}
+
+// InvalidateSubjectStatsCache removes the cached statistics of a subject
+func InvalidateSubjectStatsCache(subjectID int64) {
+	c := cache.GetCache()
+	if c == nil || subjectID == 0 {
+		return
+	}
+	if err := c.Delete(fmt.Sprintf(subjectStatsCacheKey, subjectID)); err != nil {
+		log.Warn("Failed to invalidate stats cache for subject %d: %v", subjectID, err)
+	}
+}
+
+// normalizeContributorEmail normalizes an email address so that the same contributor
+// is recognized across repositories, e.g. "Alice@Example.com " and "alice@example.com"
+func normalizeContributorEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// addSubjectContributors adds the contributors of one repository to the set of
+// contributors of a subject, skipping those without commits after since
+func addSubjectContributors(contributors map[string]struct{}, contributorStats map[string]*ContributorData, since time.Time) {
+	for email, contributor := range contributorStats {
+		// Skip the "total" summary entry
+		if email == "total" {
+			continue
+		}
+		if !hasCommitsAfter(contributor, since) {
+			continue
+		}
+		contributors[normalizeContributorEmail(email)] = struct{}{}
+	}
+}
+
+// countCommitsSince counts the commits of a contributor (or of the "total" summary entry)
+// in weeks starting after since, with the same conservative approach as hasCommitsAfter
+func countCommitsSince(contributor *ContributorData, since time.Time) int64 {
+	if contributor == nil {
+		return 0
+	}
+	if since.IsZero() {
+		return contributor.TotalCommits
+	}
+	var commits int64
+	for _, week := range contributor.Weeks {
+		if !time.UnixMilli(week.Week).Before(since) {
+			commits += int64(week.Commits)
+		}
+	}
+	return commits
+}
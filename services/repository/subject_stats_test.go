@@ -0,0 +1,54 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddSubjectContributors(t *testing.T) {
+	forkCreated := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	beforeFork := time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC).UnixMilli()
+	afterFork := time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC).UnixMilli()
+
+	weeks := func(week int64, commits int) map[int64]*WeekData {
+		return map[int64]*WeekData{week: {Week: week, Commits: commits}}
+	}
+
+	root := map[string]*ContributorData{
+		"total":             {TotalCommits: 5, Weeks: weeks(beforeFork, 5)},
+		"Alice@Example.com": {TotalCommits: 3, Weeks: weeks(beforeFork, 3)},
+		"bob@example.com":   {TotalCommits: 2, Weeks: weeks(beforeFork, 2)},
+	}
+	fork := map[string]*ContributorData{
+		"total": {TotalCommits: 9, Weeks: map[int64]*WeekData{
+			beforeFork: {Week: beforeFork, Commits: 5},
+			afterFork:  {Week: afterFork, Commits: 4},
+		}},
+		// Same person as in the root, with differently cased and padded email
+		" alice@example.COM": {TotalCommits: 4, Weeks: weeks(afterFork, 1)},
+		// Only inherited from the root, not counted for the fork
+		"carol@example.com": {TotalCommits: 1, Weeks: weeks(beforeFork, 1)},
+		"dave@example.com":  {TotalCommits: 3, Weeks: weeks(afterFork, 3)},
+	}
+
+	contributors := make(map[string]struct{})
+	addSubjectContributors(contributors, root, time.Time{})
+	addSubjectContributors(contributors, fork, forkCreated)
+
+	assert.Len(t, contributors, 3)
+	assert.Contains(t, contributors, "alice@example.com")
+	assert.Contains(t, contributors, "bob@example.com")
+	assert.Contains(t, contributors, "dave@example.com")
+	assert.NotContains(t, contributors, "total")
+	assert.NotContains(t, contributors, "carol@example.com")
+
+	// Only the commits made after the fork was created count towards its divergence
+	assert.EqualValues(t, 4, countCommitsSince(fork["total"], forkCreated))
+	assert.EqualValues(t, 9, countCommitsSince(fork["total"], time.Time{}))
+	assert.Zero(t, countCommitsSince(nil, forkCreated))
+}
@@ -0,0 +1,116 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/cache"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+const (
+	trendingSubjectsCacheKey           = "TrendingSubjects/%d/%d"
+	trendingSubjectsCacheTimeout int64 = 60 * 5
+)
+
+// trendingSubjectActions are the actions that count as activity of a subject:
+// edits of its articles and change requests between them
+var trendingSubjectActions = []activities_model.ActionType{
+	activities_model.ActionCommitRepo,
+	activities_model.ActionCreatePullRequest,
+	activities_model.ActionMergePullRequest,
+}
+
+// TrendingSubject is a subject with its number of recent activities
+type TrendingSubject struct {
+	*repo_model.Subject
+	Activity int64
+}
+
+type subjectActivity struct {
+	SubjectID int64 `json:"subject_id"`
+	Activity  int64 `json:"activity"`
+}
+
+// FindTrendingSubjects returns up to limit subjects ranked by their activity within the window,
+// counted across the public articles (the root and all forks) of each subject. Subjects without
+// activity in the window are not returned. The ranking is cached for a few minutes.
+func FindTrendingSubjects(ctx context.Context, window time.Duration, limit int) ([]*TrendingSubject, error) {
+	activities, err := findSubjectActivities(ctx, window, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(activities) == 0 {
+		return []*TrendingSubject{}, nil
+	}
+
+	subjectIDs := make([]int64, 0, len(activities))
+	for _, activity := range activities {
+		subjectIDs = append(subjectIDs, activity.SubjectID)
+	}
+	subjects := make(map[int64]*repo_model.Subject, len(subjectIDs))
+	if err := db.GetEngine(ctx).In("id", subjectIDs).And("deleted_unix = ?", 0).Find(&subjects); err != nil {
+		return nil, err
+	}
+
+	// Subjects deleted since the ranking was cached are left out
+	trending := make([]*TrendingSubject, 0, len(activities))
+	for _, activity := range activities {
+		if subject, ok := subjects[activity.SubjectID]; ok {
+			trending = append(trending, &TrendingSubject{Subject: subject, Activity: activity.Activity})
+		}
+	}
+	return trending, nil
+}
+
+// findSubjectActivities ranks the subjects by their activity within the window. Every action is
+// stored once for the doer and once more for each watcher, so only the doer's copy is counted.
+func findSubjectActivities(ctx context.Context, window time.Duration, limit int) ([]*subjectActivity, error) {
+	c := cache.GetCache()
+	cacheKey := fmt.Sprintf(trendingSubjectsCacheKey, int64(window.Seconds()), limit)
+	if c != nil {
+		var cached []*subjectActivity
+		if exists, cacheErr := c.GetJSON(cacheKey, &cached); exists && cacheErr == nil {
+			return cached, nil
+		}
+	}
+
+	since := timeutil.TimeStamp(time.Now().Add(-window).Unix())
+	activities := make([]*subjectActivity, 0, limit)
+	if err := db.GetEngine(ctx).
+		Table("action").
+		Join("INNER", "repository", "repository.id = action.repo_id").
+		Join("INNER", "subject", "subject.id = repository.subject_id").
+		Where(builder.Eq{
+			"action.is_deleted":    false,
+			"action.is_private":    false,
+			"subject.deleted_unix": 0,
+		}.
+			And(builder.Expr("action.user_id = action.act_user_id")).
+			And(builder.In("action.op_type", trendingSubjectActions)).
+			And(builder.Gte{"action.created_unix": since})).
+		Select("repository.subject_id AS subject_id, COUNT(*) AS activity").
+		GroupBy("repository.subject_id").
+		OrderBy("activity DESC, repository.subject_id ASC").
+		Limit(limit).
+		Find(&activities); err != nil {
+		return nil, fmt.Errorf("find subject activities: %w", err)
+	}
+
+	if c != nil {
+		if err := c.PutJSON(cacheKey, activities, trendingSubjectsCacheTimeout); err != nil {
+			log.Warn("Failed to cache trending subjects: %v", err)
+		}
+	}
+	return activities, nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"testing"
+	"time"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindTrendingSubjects(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	busySubject, err := repo_model.GetOrCreateSubject(ctx, "Busy Subject")
+	require.NoError(t, err)
+	staleSubject, err := repo_model.GetOrCreateSubject(ctx, "Stale Subject")
+	require.NoError(t, err)
+
+	// repo1 is the root of subject 1; repo4 and repo10 are articles of the busy subject
+	// and repo8 is the only article of the stale subject
+	for repoID, subjectID := range map[int64]int64{4: busySubject.ID, 10: busySubject.ID, 8: staleSubject.ID} {
+		repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: repoID})
+		repo.SubjectID = subjectID
+		require.NoError(t, repo_model.UpdateRepositoryColsNoAutoTime(ctx, repo, "subject_id"))
+	}
+
+	now := time.Now()
+	addActions := func(repoID int64, opType activities_model.ActionType, n int, when time.Time, watcherCopy bool) {
+		for range n {
+			act := &activities_model.Action{
+				UserID:      2,
+				ActUserID:   2,
+				OpType:      opType,
+				RepoID:      repoID,
+				CreatedUnix: timeutil.TimeStamp(when.Unix()),
+			}
+			if watcherCopy {
+				act.UserID = 4
+			}
+			_, err := db.GetEngine(ctx).NoAutoTime().Insert(act)
+			require.NoError(t, err)
+		}
+	}
+	addActions(1, activities_model.ActionCommitRepo, 1, now, false)
+	// Copies of an action in the feeds of watchers don't count as activity
+	addActions(1, activities_model.ActionCommitRepo, 3, now, true)
+	// Activity across the root and its forks counts for the subject
+	addActions(4, activities_model.ActionCommitRepo, 2, now, false)
+	addActions(10, activities_model.ActionCreatePullRequest, 1, now, false)
+	// Activity outside of the window and other kinds of actions don't count
+	addActions(8, activities_model.ActionCommitRepo, 5, now.Add(-30*24*time.Hour), false)
+	addActions(8, activities_model.ActionStarRepo, 5, now, false)
+
+	trending, err := FindTrendingSubjects(ctx, 7*24*time.Hour, 10)
+	require.NoError(t, err)
+	if assert.Len(t, trending, 2) {
+		assert.Equal(t, busySubject.ID, trending[0].ID)
+		assert.EqualValues(t, 3, trending[0].Activity)
+		assert.EqualValues(t, 1, trending[1].ID)
+		assert.EqualValues(t, 1, trending[1].Activity)
+	}
+
+	trending, err = FindTrendingSubjects(ctx, 7*24*time.Hour, 1)
+	require.NoError(t, err)
+	if assert.Len(t, trending, 1) {
+		assert.Equal(t, busySubject.ID, trending[0].ID)
+	}
+}
@@ -70,6 +70,11 @@ func AcceptTransferOwnership(ctx context.Context, repo *repo_model.Repository, d
 			return util.ErrPermissionDenied
 		}
 
+		// The recipient may have created an article for the subject since the transfer was started
+		if err := checkTransferKeepsForkTree(ctx, repo, repoTransfer.Recipient); err != nil {
+			return err
+		}
+
 		if err := repo.LoadOwner(ctx); err != nil {
 			return err
 		}
@@ -420,6 +425,10 @@ func StartRepositoryTransfer(ctx context.Context, doer, newOwner *user_model.Use
 	oldOwnerName := repo.OwnerName
 
 	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		if err := checkTransferKeepsForkTree(ctx, repo, newOwner); err != nil {
+			return err
+		}
+
 		// Admin is always allowed to transfer || user transfer repo back to his account,
 		// then it will transfer directly without acceptance.
 		if doer.IsAdmin || doer.ID == newOwner.ID {
@@ -471,6 +480,69 @@ func StartRepositoryTransfer(ctx context.Context, doer, newOwner *user_model.Use
 	return nil
 }
 
+// TransferForkOwnership directly moves a fork to a new owner, e.g. when its owner leaves or an
+// organization takes over the article. The fork keeps its ForkID, SubjectID and its own forks,
+// but the new owner must not already own as many repositories of the subject as allowed.
+func TransferForkOwnership(ctx context.Context, repo *repo_model.Repository, newOwner *user_model.User) error {
+	releaser, err := globallock.Lock(ctx, getRepoWorkingLockKey(repo.ID))
+	if err != nil {
+		return fmt.Errorf("lock.Lock: %w", err)
+	}
+	defer releaser()
+
+	if err := repo_model.TestRepositoryReadyForTransfer(repo.Status); err != nil {
+		return err
+	}
+
+	if err := repo.LoadOwner(ctx); err != nil {
+		return err
+	}
+	oldOwner := repo.Owner
+
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		if err := checkTransferKeepsForkTree(ctx, repo, newOwner); err != nil {
+			return err
+		}
+		return transferOwnership(ctx, oldOwner, newOwner.Name, repo, nil)
+	}); err != nil {
+		return err
+	}
+	releaser()
+
+	notify_service.TransferRepository(ctx, oldOwner, repo, oldOwner.Name)
+
+	return nil
+}
+
+// checkTransferKeepsForkTree checks that the new owner of repo may own it without breaking the
+// fork tree of its subject: the new owner must not already own as many repositories of the subject
+// as allowed, nor another fork of the same base repository.
+func checkTransferKeepsForkTree(ctx context.Context, repo *repo_model.Repository, newOwner *user_model.User) error {
+	// The new owner may only own a limited number of repositories per subject
+	if err := CheckSubjectRepoLimit(ctx, newOwner.ID, repo.SubjectID, repo.ID); err != nil {
+		return err
+	}
+
+	if !repo.IsFork {
+		return nil
+	}
+	forkedRepo, err := repo_model.GetUserFork(ctx, repo.ForkID, newOwner.ID)
+	if err != nil {
+		return err
+	}
+	if forkedRepo != nil && forkedRepo.ID != repo.ID {
+		if err := repo.GetBaseRepo(ctx); err != nil {
+			return err
+		}
+		return ErrForkAlreadyExist{
+			Uname:    newOwner.Name,
+			RepoName: repo.BaseRepo.FullName(),
+			ForkName: forkedRepo.FullName(),
+		}
+	}
+	return nil
+}
+
 // RejectRepositoryTransfer marks the repository as ready and remove pending transfer entry,
 // thus cancel the transfer process.
 // The accepter can reject the transfer.
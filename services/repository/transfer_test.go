@@ -164,3 +164,46 @@ func TestRepositoryTransferRejection(t *testing.T) {
 	assert.Error(t, err)
 	assert.True(t, IsRepositoryLimitReached(err))
 }
+
+func TestTransferForkOwnership(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	// repo11 (owned by user13) is a fork of repo10
+	repo10 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 10})
+	repo11 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 11})
+	repo11.SubjectID = 1
+	require.NoError(t, repo_model.UpdateRepositoryColsNoAutoTime(t.Context(), repo11, "subject_id"))
+	admin := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+
+	t.Run("SubjectCollision", func(t *testing.T) {
+		// user2 already owns repo1 for subject 1
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		err := TransferForkOwnership(t.Context(), repo11, user2)
+		assert.True(t, IsErrUserOwnsSubjectRepo(err))
+
+		// the regular transfer flow enforces the same check
+		err = StartRepositoryTransfer(t.Context(), admin, user2, repo11, nil)
+		assert.True(t, IsErrUserOwnsSubjectRepo(err))
+
+		repo11 = unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 11})
+		assert.EqualValues(t, 13, repo11.OwnerID)
+		assert.Equal(t, repo_model.RepositoryReady, repo11.Status)
+	})
+
+	t.Run("Transfer", func(t *testing.T) {
+		user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+		require.NoError(t, TransferForkOwnership(t.Context(), repo11, user4))
+
+		transferredRepo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 11})
+		assert.Equal(t, user4.ID, transferredRepo.OwnerID)
+		assert.Equal(t, user4.Name, transferredRepo.OwnerName)
+		assert.True(t, transferredRepo.IsFork)
+		assert.Equal(t, repo10.ID, transferredRepo.ForkID)
+		assert.EqualValues(t, 1, transferredRepo.SubjectID)
+		assert.Equal(t, repo10.NumForks, unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 10}).NumForks)
+
+		exist, err := util.IsExist(repo_model.RepoPath(user4.Name, repo11.Name))
+		assert.NoError(t, err)
+		assert.True(t, exist)
+	})
+}
@@ -5,6 +5,7 @@ package uinotification
 
 import (
 	"context"
+	"slices"
 
 	activities_model "code.gitea.io/gitea/models/activities"
 	"code.gitea.io/gitea/models/db"
@@ -12,10 +13,13 @@ import (
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/gitrepo"
 	"code.gitea.io/gitea/modules/graceful"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/repository"
 	notify_service "code.gitea.io/gitea/services/notify"
+	repo_service "code.gitea.io/gitea/services/repository"
 )
 
 type (
@@ -147,6 +151,15 @@ func (ns *notificationService) NewPullRequest(ctx context.Context, pr *issues_mo
 	for _, id := range repoWatchers {
 		toNotify.Add(id)
 	}
+	// Change requests are changes to the article, its watchers are notified of them too
+	articleWatchers, err := repo_model.GetArticleWatcherIDs(ctx, pr.Issue.RepoID)
+	if err != nil {
+		log.Error("GetArticleWatcherIDs: %v", err)
+		return
+	}
+	for _, id := range articleWatchers {
+		toNotify.Add(id)
+	}
 	issueParticipants, err := issues_model.GetParticipantsIDsByIssueID(ctx, pr.IssueID)
 	if err != nil {
 		log.Error("GetParticipantsIDsByIssueID: %v", err)
@@ -168,6 +181,47 @@ func (ns *notificationService) NewPullRequest(ctx context.Context, pr *issues_mo
 	}
 }
 
+// PushCommits notifies the watchers of the article of the repository when a push to the default
+// branch changes its README, the other pushes are only of interest to the watchers of the repository
+func (ns *notificationService) PushCommits(ctx context.Context, pusher *user_model.User, repo *repo_model.Repository, opts *repository.PushUpdateOptions, commits *repository.PushCommits) {
+	if !opts.IsUpdateBranch() || opts.RefFullName.BranchName() != repo.DefaultBranch {
+		return
+	}
+	watcherIDs, err := repo_model.GetArticleWatcherIDs(ctx, repo.ID)
+	if err != nil {
+		log.Error("GetArticleWatcherIDs: %v", err)
+		return
+	}
+	receiverIDs := make([]int64, 0, len(watcherIDs))
+	for _, id := range watcherIDs {
+		if id != pusher.ID {
+			receiverIDs = append(receiverIDs, id)
+		}
+	}
+	if len(receiverIDs) == 0 {
+		return
+	}
+
+	gitRepo, closer, err := gitrepo.RepositoryFromContextOrOpen(ctx, repo)
+	if err != nil {
+		log.Error("OpenRepository %-v: %v", repo, err)
+		return
+	}
+	defer closer.Close()
+	files, err := gitRepo.GetFilesChangedBetween(opts.OldCommitID, opts.NewCommitID)
+	if err != nil {
+		log.Error("GetFilesChangedBetween %-v: %v", repo, err)
+		return
+	}
+	if !slices.ContainsFunc(files, repo_service.IsArticleReadme) {
+		return
+	}
+
+	if err := activities_model.CreateArticleChangeNotifications(ctx, pusher.ID, repo.ID, opts.NewCommitID, receiverIDs); err != nil {
+		log.Error("CreateArticleChangeNotifications: %v", err)
+	}
+}
+
 func (ns *notificationService) PullRequestReview(ctx context.Context, pr *issues_model.PullRequest, r *issues_model.Review, c *issues_model.Comment, mentions []*user_model.User) {
 	opts := issueNotificationOpts{
 		IssueID:              pr.Issue.ID,
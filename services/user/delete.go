@@ -76,6 +76,7 @@ func deleteUser(ctx context.Context, u *user_model.User, purge bool) (err error)
 		&repo_model.Collaboration{UserID: u.ID},
 		&access_model.Access{UserID: u.ID},
 		&repo_model.Watch{UserID: u.ID},
+		&repo_model.ArticleWatch{UserID: u.ID},
 		&repo_model.Star{UID: u.ID},
 		&user_model.Follow{UserID: u.ID},
 		&user_model.Follow{FollowID: u.ID},
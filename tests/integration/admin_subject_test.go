@@ -0,0 +1,76 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminSubjectsWithoutRoot(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	subject, err := repo_model.GetOrCreateSubject(t.Context(), "Rootless Admin Subject")
+	assert.NoError(t, err)
+
+	// repo2 has content but is a fork, so the subject has no root
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 2})
+	repo.SubjectID = subject.ID
+	repo.IsFork = true
+	repo.ForkID = 1
+	assert.NoError(t, repo_model.UpdateRepositoryColsNoAutoTime(t.Context(), repo, "subject_id", "is_fork", "fork_id"))
+
+	session := loginUser(t, "user2")
+	session.MakeRequest(t, NewRequest(t, "GET", "/-/admin/subjects"), http.StatusForbidden)
+
+	session = loginUser(t, "user1")
+	resp := session.MakeRequest(t, NewRequest(t, "GET", "/-/admin/subjects"), http.StatusOK)
+	assert.Contains(t, resp.Body.String(), subject.Name)
+
+	req := NewRequestWithValues(t, "POST", "/-/admin/subjects/promote_root", map[string]string{
+		"_csrf": GetUserCSRFToken(t, session),
+		"id":    fmt.Sprint(subject.ID),
+	})
+	session.MakeRequest(t, req, http.StatusSeeOther)
+
+	repo = unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 2})
+	assert.False(t, repo.IsFork)
+
+	subjects, err := repo_model.FindSubjectsWithoutRoot(t.Context())
+	assert.NoError(t, err)
+	assert.Empty(t, subjects)
+}
+
+func TestAdminRepairOrphanedForks(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	// Delete repo10 out from under its fork repo11
+	_, err := db.DeleteByID[repo_model.Repository](t.Context(), 10)
+	assert.NoError(t, err)
+	fork := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 11})
+
+	session := loginUser(t, "user1")
+	resp := session.MakeRequest(t, NewRequest(t, "GET", "/-/admin/subjects"), http.StatusOK)
+	assert.Contains(t, resp.Body.String(), fork.FullName())
+
+	req := NewRequestWithValues(t, "POST", "/-/admin/subjects/repair_orphaned_forks", map[string]string{
+		"_csrf": GetUserCSRFToken(t, session),
+	})
+	session.MakeRequest(t, req, http.StatusSeeOther)
+
+	fork = unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 11})
+	assert.False(t, fork.IsFork)
+
+	orphans, err := repo_model.FindOrphanedForks(t.Context())
+	assert.NoError(t, err)
+	assert.Empty(t, orphans)
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIAdminForkanaIntegrity(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	// user1 is an admin user
+	token := getUserToken(t, "user1", auth_model.AccessTokenScopeReadAdmin)
+	getReport := func(t *testing.T) *api.ForkanaIntegrity {
+		req := NewRequest(t, "GET", "/api/v1/admin/forkana/integrity").AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		report := &api.ForkanaIntegrity{}
+		DecodeJSON(t, resp, report)
+		return report
+	}
+
+	t.Run("NotAdmin", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+		userToken := getUserToken(t, "user2", auth_model.AccessTokenScopeReadAdmin)
+		req := NewRequest(t, "GET", "/api/v1/admin/forkana/integrity").AddTokenAuth(userToken)
+		MakeRequest(t, req, http.StatusForbidden)
+	})
+
+	baseline := getReport(t)
+
+	// Seed one of each inconsistency
+	e := db.GetEngine(t.Context())
+	// subject 2 only has an empty repository, so it has no root
+	_, err := e.Exec("UPDATE `repository` SET subject_id = 2, is_empty = ? WHERE id = 4", true)
+	require.NoError(t, err)
+	// subject 1 gets a second non-fork, non-empty repository besides its root repo1
+	_, err = e.Exec("UPDATE `repository` SET subject_id = 1 WHERE id = 33")
+	require.NoError(t, err)
+	// repo3 becomes a fork of a repository that doesn't exist
+	_, err = e.Exec("UPDATE `repository` SET is_fork = ?, fork_id = 999999 WHERE id = 3", true)
+	require.NoError(t, err)
+	// repo10 has a single fork (repo11) but claims five
+	_, err = e.Exec("UPDATE `repository` SET num_forks = 5 WHERE id = 10")
+	require.NoError(t, err)
+
+	report := getReport(t)
+	assert.False(t, report.Healthy)
+
+	assert.Equal(t, baseline.SubjectsWithoutRoot.Count+1, report.SubjectsWithoutRoot.Count)
+	assert.Contains(t, report.SubjectsWithoutRoot.SampleIDs, int64(2))
+
+	assert.Equal(t, baseline.SubjectsWithMultipleRoots.Count+1, report.SubjectsWithMultipleRoots.Count)
+	assert.Contains(t, report.SubjectsWithMultipleRoots.SampleIDs, int64(1))
+
+	assert.Equal(t, baseline.OrphanedForks.Count+1, report.OrphanedForks.Count)
+	assert.Contains(t, report.OrphanedForks.SampleIDs, int64(3))
+
+	assert.Equal(t, baseline.ForkCountDrift.Count+1, report.ForkCountDrift.Count)
+	assert.Contains(t, report.ForkCountDrift.SampleIDs, int64(10))
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIGetRenderedArticle(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	const initialVersion = "65f1bf27bc3bf70f64657658635e66094edbcb4d"
+
+	require.NoError(t, createOrReplaceFileInBranch(user2, repo1, "README.md", repo1.DefaultBranch,
+		"---\ntitle: Moon Landing\ntags: [space, history]\n---\n# Moon Landing\n\nApollo 11 landed in 1969.\n"))
+
+	t.Run("Latest", func(t *testing.T) {
+		resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/article/rendered"), http.StatusOK)
+		var article api.RenderedArticle
+		DecodeJSON(t, resp, &article)
+
+		assert.Equal(t, "README.md", article.Path)
+		assert.Equal(t, "markdown", article.MarkupType)
+		assert.Contains(t, article.HTML, "Moon Landing</h1>")
+		assert.Contains(t, article.HTML, "Apollo 11 landed in 1969.")
+		// The front matter is returned as fields instead of being rendered
+		assert.NotContains(t, article.HTML, "frontmatter")
+		assert.Equal(t, "Moon Landing", article.FrontMatter["title"])
+		assert.Equal(t, []any{"space", "history"}, article.FrontMatter["tags"])
+	})
+
+	t.Run("Version", func(t *testing.T) {
+		resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/article/rendered?version="+initialVersion), http.StatusOK)
+		var article api.RenderedArticle
+		DecodeJSON(t, resp, &article)
+
+		assert.Equal(t, initialVersion, article.Version)
+		assert.Contains(t, article.HTML, "repo1</h1>")
+		assert.Empty(t, article.FrontMatter)
+	})
+
+	t.Run("UnknownVersion", func(t *testing.T) {
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/article/rendered?version=0000000000000000000000000000000000000000"), http.StatusNotFound)
+	})
+
+	t.Run("PrivateRepo", func(t *testing.T) {
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo2/article/rendered"), http.StatusNotFound)
+	})
+}
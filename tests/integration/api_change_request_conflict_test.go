@@ -0,0 +1,57 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIChangeRequestConflictPreview(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		nonOwner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+		repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+		prIndex := submitChangeRequestAndGetPR(t, loginUser(t, nonOwner.Name), owner, repo, "# repo1\n\nA description proposed in a change request\n")
+		previewURL := fmt.Sprintf("/api/v1/repos/%s/%s/change-requests/%d/conflict-preview", owner.Name, repo.Name, prIndex)
+
+		req := NewRequest(t, "GET", previewURL)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var preview api.ChangeRequestConflictPreview
+		DecodeJSON(t, resp, &preview)
+		assert.True(t, preview.Clean)
+		assert.Empty(t, preview.ConflictedFiles)
+		baseCommitID := preview.BaseCommitID
+
+		// The owner changes the same lines of the README on the default branch
+		require.NoError(t, createOrReplaceFileInBranch(owner, repo, "README.md", repo.DefaultBranch, "# repo1\n\nA description changed by the owner\n"))
+
+		req = NewRequest(t, "GET", previewURL)
+		resp = MakeRequest(t, req, http.StatusOK)
+		preview = api.ChangeRequestConflictPreview{}
+		DecodeJSON(t, resp, &preview)
+		assert.False(t, preview.Clean)
+		assert.Equal(t, []string{"README.md"}, preview.ConflictedFiles)
+		assert.NotEqual(t, baseCommitID, preview.BaseCommitID)
+
+		pr, err := issues_model.GetPullRequestByIndex(t.Context(), repo.ID, prIndex)
+		require.NoError(t, err)
+		assert.Equal(t, pr.HeadCommitID, preview.HeadCommitID)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("/api/v1/repos/%s/%s/change-requests/%d/conflict-preview", owner.Name, repo.Name, 9999))
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+}
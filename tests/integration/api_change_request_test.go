@@ -0,0 +1,96 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	git_model "code.gitea.io/gitea/models/git"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPICreateChangeRequest(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		// user4 doesn't own repo1 of user2, the root of subject 1
+		owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		nonOwner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+		repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+		createURL := fmt.Sprintf("/api/v1/repos/%s/%s/change-requests", owner.Name, repo.Name)
+		token := getUserToken(t, nonOwner.Name, auth_model.AccessTokenScopeWriteRepository)
+
+		t.Run("CreatesInRepoPR", func(t *testing.T) {
+			newContent := "# Updated through the API\n\nThis is a test change.\n"
+			req := NewRequestWithJSON(t, "POST", createURL, &api.CreateChangeRequestOption{
+				Content:     newContent,
+				Title:       "Propose a change from a bot",
+				Description: "Submitted by an integration",
+			}).AddTokenAuth(token)
+			resp := MakeRequest(t, req, http.StatusCreated)
+			apiPR := &api.PullRequest{}
+			DecodeJSON(t, resp, apiPR)
+			assert.Equal(t, "Propose a change from a bot", apiPR.Title)
+			assert.Equal(t, "Submitted by an integration", apiPR.Body)
+
+			pr, err := issues_model.GetPullRequestByIndex(t.Context(), repo.ID, apiPR.Index)
+			require.NoError(t, err)
+			assert.Equal(t, repo.ID, pr.HeadRepoID, "Head repo should be the target repo")
+			assert.Equal(t, repo.ID, pr.BaseRepoID, "Base repo should be the target repo")
+			assert.Equal(t, repo.DefaultBranch, pr.BaseBranch)
+			assert.Equal(t, nonOwner.LowerName+"-patch-1", pr.HeadBranch)
+			require.NoError(t, pr.LoadIssue(t.Context()))
+			assert.Equal(t, nonOwner.ID, pr.Issue.PosterID)
+
+			// The README of the patch branch holds the submitted content
+			req = NewRequest(t, "GET", fmt.Sprintf("/%s/%s/raw/branch/%s/README.md", owner.Name, repo.Name, pr.HeadBranch))
+			resp = MakeRequest(t, req, http.StatusOK)
+			assert.Equal(t, newContent, resp.Body.String())
+		})
+
+		t.Run("WhitespaceOnlyContent", func(t *testing.T) {
+			req := NewRequestWithJSON(t, "POST", createURL, &api.CreateChangeRequestOption{
+				Content: "  \t\n  \n",
+			}).AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+			// No patch branch is left behind
+			exist, err := git_model.IsBranchExist(t.Context(), repo.ID, nonOwner.LowerName+"-patch-2")
+			require.NoError(t, err)
+			assert.False(t, exist)
+		})
+
+		t.Run("MissingFile", func(t *testing.T) {
+			req := NewRequestWithJSON(t, "POST", createURL, &api.CreateChangeRequestOption{
+				Content:  "# Missing\n",
+				TreePath: "does-not-exist.md",
+			}).AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusNotFound)
+		})
+
+		t.Run("OwnRepository", func(t *testing.T) {
+			ownerToken := getUserToken(t, owner.Name, auth_model.AccessTokenScopeWriteRepository)
+			req := NewRequestWithJSON(t, "POST", createURL, &api.CreateChangeRequestOption{
+				Content: "# Owner change\n",
+			}).AddTokenAuth(ownerToken)
+			MakeRequest(t, req, http.StatusForbidden)
+		})
+
+		t.Run("Unauthenticated", func(t *testing.T) {
+			req := NewRequestWithJSON(t, "POST", createURL, &api.CreateChangeRequestOption{
+				Content: "# Anonymous change\n",
+			})
+			MakeRequest(t, req, http.StatusUnauthorized)
+		})
+	})
+}
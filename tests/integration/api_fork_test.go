@@ -220,3 +220,47 @@ func TestAPIForkWithSubjectConflict(t *testing.T) {
 		MakeRequest(t, req, http.StatusForbidden)
 	})
 }
+
+func TestAPIForkListWithDivergence(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	fork, err := repo_service.ForkRepository(t.Context(), user4, user4, repo_service.ForkRepoOptions{
+		BaseRepo: repo1,
+		Name:     "divergence-fork",
+	})
+	require.NoError(t, err)
+
+	// The fork is one commit ahead and two commits behind
+	require.NoError(t, createOrReplaceFileInBranch(user4, fork, "README.md", fork.DefaultBranch, "# The fork's version\n"))
+	require.NoError(t, createOrReplaceFileInBranch(user2, repo1, "README.md", repo1.DefaultBranch, "# The original's version\n"))
+	require.NoError(t, createOrReplaceFileInBranch(user2, repo1, "README.md", repo1.DefaultBranch, "# The original's version, again\n"))
+
+	findFork := func(forks []*api.Repository) *api.Repository {
+		for _, repo := range forks {
+			if repo.ID == fork.ID {
+				return repo
+			}
+		}
+		return nil
+	}
+
+	// The divergence is only computed if asked for
+	resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/forks"), http.StatusOK)
+	var forks []*api.Repository
+	DecodeJSON(t, resp, &forks)
+	apiFork := findFork(forks)
+	require.NotNil(t, apiFork)
+	assert.Nil(t, apiFork.Divergence)
+
+	resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/forks?with_divergence=true"), http.StatusOK)
+	DecodeJSON(t, resp, &forks)
+	apiFork = findFork(forks)
+	require.NotNil(t, apiFork)
+	require.NotNil(t, apiFork.Divergence)
+	assert.Equal(t, 1, apiFork.Divergence.Ahead)
+	assert.Equal(t, 2, apiFork.Divergence.Behind)
+}
@@ -49,6 +49,7 @@ func TestAPIExposedSettings(t *testing.T) {
 		MigrationsDisabled:   setting.Repository.DisableMigrations,
 		TimeTrackingDisabled: false,
 		LFSDisabled:          !setting.LFS.StartServer,
+		DefaultBranch:        setting.Repository.DefaultBranch,
 	}, repo)
 
 	attachment := new(api.GeneralAttachmentSettings)
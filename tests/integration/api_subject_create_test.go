@@ -0,0 +1,76 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPICreateSubject(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	adminToken := getUserToken(t, "user1", auth_model.AccessTokenScopeWriteAdmin)
+
+	t.Run("Success", func(t *testing.T) {
+		req := NewRequestWithJSON(t, "POST", "/api/v1/subjects", &api.CreateSubjectOption{
+			Name:        "  Rivers of Africa ",
+			Description: "The major rivers of the African continent",
+			Lang:        "EN",
+		}).AddTokenAuth(adminToken)
+		resp := MakeRequest(t, req, http.StatusCreated)
+
+		var subject api.Subject
+		DecodeJSON(t, resp, &subject)
+		assert.Equal(t, "Rivers of Africa", subject.Name)
+		assert.Equal(t, "rivers-of-africa", subject.Slug)
+		assert.Equal(t, "The major rivers of the African continent", subject.Description)
+		assert.Equal(t, "en", subject.Lang)
+		unittest.AssertExistsAndLoadBean(t, &repo_model.Subject{
+			ID:          subject.ID,
+			Slug:        "rivers-of-africa",
+			Description: "The major rivers of the African continent",
+			Lang:        "en",
+		})
+	})
+
+	t.Run("DuplicateSlug", func(t *testing.T) {
+		// "Rivers of africa!" has the same slug as the subject created above
+		req := NewRequestWithJSON(t, "POST", "/api/v1/subjects", &api.CreateSubjectOption{
+			Name: "Rivers of africa!",
+		}).AddTokenAuth(adminToken)
+		MakeRequest(t, req, http.StatusConflict)
+		unittest.AssertNotExistsBean(t, &repo_model.Subject{Name: "Rivers of africa!"})
+	})
+
+	t.Run("ReservedSlug", func(t *testing.T) {
+		req := NewRequestWithJSON(t, "POST", "/api/v1/subjects", &api.CreateSubjectOption{
+			Name: "Search",
+		}).AddTokenAuth(adminToken)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("EmptyName", func(t *testing.T) {
+		req := NewRequestWithJSON(t, "POST", "/api/v1/subjects", &api.CreateSubjectOption{
+			Name: "   ",
+		}).AddTokenAuth(adminToken)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("NotAdmin", func(t *testing.T) {
+		token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteAdmin)
+		req := NewRequestWithJSON(t, "POST", "/api/v1/subjects", &api.CreateSubjectOption{
+			Name: "Rivers of Asia",
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusForbidden)
+	})
+}
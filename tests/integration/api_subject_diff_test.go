@@ -0,0 +1,87 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+	repo_service "code.gitea.io/gitea/services/repository"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIGetSubjectReadmeDiff(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1}) // root article of subject 1
+
+	fork, err := repo_service.ForkRepository(t.Context(), user4, user4, repo_service.ForkRepoOptions{
+		BaseRepo: repo1,
+		Name:     "diff-fork",
+	})
+	require.NoError(t, err)
+	require.NoError(t, createOrReplaceFileInBranch(user4, fork, "README.md", fork.DefaultBranch, "# repo1\n\nA new description for repo1\n\nAnd a new paragraph"))
+
+	t.Run("Forks", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/api/v1/subjects/example-subject/diff?from=user2/repo1&to=user4/diff-fork")
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var diff api.SubjectReadmeDiff
+		DecodeJSON(t, resp, &diff)
+		assert.Equal(t, "user2/repo1", diff.From.FullName)
+		assert.Equal(t, "user4/diff-fork", diff.To.FullName)
+		assert.Equal(t, "README.md", diff.From.Path)
+		require.NotNil(t, diff.From.LastCommit)
+		require.NotNil(t, diff.To.LastCommit)
+		assert.Equal(t, "65f1bf27bc3bf70f64657658635e66094edbcb4d", diff.From.LastCommit.ID)
+		assert.NotEqual(t, diff.From.LastCommit.ID, diff.To.LastCommit.ID)
+
+		assert.Equal(t, 3, diff.Additions)
+		assert.Equal(t, 1, diff.Deletions)
+		require.Len(t, diff.Hunks, 1)
+		hunk := diff.Hunks[0]
+		assert.Equal(t, 1, hunk.OldStart)
+		assert.Equal(t, 3, hunk.OldLines)
+		assert.Equal(t, 1, hunk.NewStart)
+		assert.Equal(t, 5, hunk.NewLines)
+		if assert.Len(t, hunk.Lines, 6) {
+			assert.Equal(t, &api.ArticleDiffLine{Type: "context", OldNumber: 1, NewNumber: 1, Content: "# repo1"}, hunk.Lines[0])
+			assert.Equal(t, &api.ArticleDiffLine{Type: "delete", OldNumber: 3, Content: "Description for repo1"}, hunk.Lines[2])
+			assert.Equal(t, &api.ArticleDiffLine{Type: "add", NewNumber: 5, Content: "And a new paragraph"}, hunk.Lines[5])
+		}
+	})
+
+	t.Run("Identical", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/api/v1/subjects/example-subject/diff?from=user2/repo1&to=user2/repo1")
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var diff api.SubjectReadmeDiff
+		DecodeJSON(t, resp, &diff)
+		assert.Zero(t, diff.Additions)
+		assert.Zero(t, diff.Deletions)
+		assert.Empty(t, diff.Hunks)
+	})
+
+	t.Run("CrossSubject", func(t *testing.T) {
+		// repo4 isn't an article of the subject
+		req := NewRequest(t, "GET", "/api/v1/subjects/example-subject/diff?from=user2/repo1&to=user5/repo4")
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/api/v1/subjects/example-subject/diff?from=user2/repo1")
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		req = NewRequest(t, "GET", "/api/v1/subjects/example-subject/diff?from=user2/repo1&to=user4/no-such-repo")
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+}
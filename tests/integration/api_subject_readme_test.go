@@ -0,0 +1,55 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIGetSubjectReadme(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	t.Run("Latest", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/api/v1/subjects/example-subject/readme")
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, "# repo1\n\nDescription for repo1", resp.Body.String())
+	})
+
+	t.Run("Version", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/api/v1/subjects/example-subject/readme?version=5c050d3b6d2db231ab1f64e324f1b6b9a0b181c2")
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, "# repo1\n\nDescription for repo1\n\nAnd change for branch2\n", resp.Body.String())
+
+		req = NewRequest(t, "GET", "/api/v1/subjects/example-subject/readme?version=not-a-commit")
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("NoRoot", func(t *testing.T) {
+		// another-subject has no repository, so no root article
+		req := NewRequest(t, "GET", "/api/v1/subjects/another-subject/readme")
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("PrivateRoot", func(t *testing.T) {
+		repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+		repo1.IsPrivate = true
+		require.NoError(t, repo_model.UpdateRepositoryColsNoAutoTime(t.Context(), repo1, "is_private"))
+
+		req := NewRequest(t, "GET", "/api/v1/subjects/example-subject/readme")
+		MakeRequest(t, req, http.StatusNotFound)
+
+		token := getUserToken(t, "user2", auth_model.AccessTokenScopeReadRepository)
+		req = NewRequest(t, "GET", "/api/v1/subjects/example-subject/readme").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusOK)
+	})
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIListSubjectRepos(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	// make the private repo2 a fork of repo1 (the root of subject 1)
+	repo2 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 2})
+	repo2.SubjectID = 1
+	repo2.IsFork = true
+	repo2.ForkID = 1
+	require.NoError(t, repo_model.UpdateRepositoryColsNoAutoTime(t.Context(), repo2, "subject_id", "is_fork", "fork_id"))
+
+	t.Run("Anonymous", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/api/v1/subjects/example-subject/repos")
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var repos []*api.Repository
+		DecodeJSON(t, resp, &repos)
+		if assert.Len(t, repos, 1) {
+			assert.EqualValues(t, 1, repos[0].ID)
+		}
+		assert.Equal(t, "1", resp.Header().Get("X-Total-Count"))
+	})
+
+	t.Run("Owner", func(t *testing.T) {
+		token := getUserToken(t, "user2", auth_model.AccessTokenScopeReadRepository)
+		req := NewRequest(t, "GET", "/api/v1/subjects/example-subject/repos").AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var repos []*api.Repository
+		DecodeJSON(t, resp, &repos)
+		if assert.Len(t, repos, 2) {
+			assert.EqualValues(t, 1, repos[0].ID)
+			assert.EqualValues(t, 2, repos[1].ID)
+		}
+	})
+
+	t.Run("RegeneratedSlug", func(t *testing.T) {
+		_, err := db.GetEngine(t.Context()).ID(1).Cols("slug").Update(&repo_model.Subject{Slug: "example_subject"})
+		require.NoError(t, err)
+		changed, err := repo_model.RegenerateSubjectSlugs(t.Context())
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, changed)
+
+		req := NewRequest(t, "GET", "/api/v1/subjects/example_subject/repos?page=1")
+		resp := MakeRequest(t, req, http.StatusMovedPermanently)
+		assert.Equal(t, "/api/v1/subjects/example-subject/repos?page=1", resp.Header().Get("Location"))
+	})
+
+	t.Run("UnknownSubject", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/api/v1/subjects/no-such-subject/repos")
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+}
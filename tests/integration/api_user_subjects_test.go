@@ -0,0 +1,64 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIListUserSubjects(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	// user2 owns repo1 in subject 1, and now the private repo2 in subject 2
+	_, err := db.GetEngine(t.Context()).ID(2).Cols("subject_id").Update(&repo_model.Repository{SubjectID: 2})
+	require.NoError(t, err)
+
+	t.Run("Owner", func(t *testing.T) {
+		token := getUserToken(t, "user2", auth_model.AccessTokenScopeReadUser, auth_model.AccessTokenScopeReadRepository)
+		req := NewRequest(t, "GET", "/api/v1/users/user2/subjects").AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var userSubjects []*api.UserSubject
+		DecodeJSON(t, resp, &userSubjects)
+		require.Len(t, userSubjects, 2)
+		assert.Equal(t, "example-subject", userSubjects[0].Subject.Slug)
+		assert.Equal(t, "user2/repo1", userSubjects[0].Repository.FullName)
+		assert.Equal(t, "another-subject", userSubjects[1].Subject.Slug)
+		assert.Equal(t, "user2/repo2", userSubjects[1].Repository.FullName)
+		assert.Equal(t, "2", resp.Header().Get("X-Total-Count"))
+	})
+
+	t.Run("Anonymous", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/api/v1/users/user2/subjects")
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var userSubjects []*api.UserSubject
+		DecodeJSON(t, resp, &userSubjects)
+		require.Len(t, userSubjects, 1)
+		assert.Equal(t, "example-subject", userSubjects[0].Subject.Slug)
+	})
+
+	t.Run("NoSubjects", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/api/v1/users/user5/subjects")
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var userSubjects []*api.UserSubject
+		DecodeJSON(t, resp, &userSubjects)
+		assert.Empty(t, userSubjects)
+	})
+
+	t.Run("UnknownUser", func(t *testing.T) {
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/users/no-such-user/subjects"), http.StatusNotFound)
+	})
+}
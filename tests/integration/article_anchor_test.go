@@ -0,0 +1,51 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestArticleAnchorIDPrefix tests that only the article fragments swapped into the subject view have prefixed anchor ids
+func TestArticleAnchorIDPrefix(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})       // owner of repo1
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1}) // root article of subject 1
+	require.NoError(t, repo1.LoadSubject(t.Context()))
+
+	readme := "# Introduction\n\nSee the [notes](#notes).\n\n<a name=\"notes\"></a>Notes\n"
+	require.NoError(t, createOrReplaceFileInBranch(user2, repo1, "README.md", repo1.DefaultBranch, readme))
+	articleURL := fmt.Sprintf("/article/%s/%s", user2.Name, repo1.SubjectRelation.Name)
+
+	t.Run("Page", func(t *testing.T) {
+		// Links shared from the article page keep working
+		resp := MakeRequest(t, NewRequest(t, "GET", articleURL), http.StatusOK)
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		assert.Equal(t, 1, htmlDoc.Find("#user-content-introduction").Length())
+		assert.Equal(t, 1, htmlDoc.Find(`a[href="#user-content-notes"]`).Length())
+		assert.Equal(t, 1, htmlDoc.Find(`a[name="notes"]`).Length())
+	})
+
+	t.Run("Fragment", func(t *testing.T) {
+		prefix := fmt.Sprintf("article-%d-", repo1.ID)
+		resp := MakeRequest(t, NewRequest(t, "GET", articleURL+"?view=article&fragment=1"), http.StatusOK)
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		assert.Equal(t, 1, htmlDoc.Find("#user-content-"+prefix+"introduction").Length())
+		assert.Equal(t, 0, htmlDoc.Find("#user-content-introduction").Length())
+		// The link to the named anchor still matches it once the frontend adds user-content- to the name
+		assert.Equal(t, 1, htmlDoc.Find(`a[href="#user-content-`+prefix+`notes"]`).Length())
+		assert.Equal(t, 1, htmlDoc.Find(`a[name="`+prefix+`notes"]`).Length())
+	})
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestArticleReadCache tests that browsers can revalidate the read view of an article with its ETag
+func TestArticleReadCache(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	const articleLink = "/article/user2/example-subject"
+
+	t.Run("Read", func(t *testing.T) {
+		resp := MakeRequest(t, NewRequest(t, "GET", articleLink), http.StatusOK)
+		etag := resp.Header().Get("ETag")
+		require.NotEmpty(t, etag)
+		assert.Empty(t, resp.Header().Get("Last-Modified"))
+		assert.Equal(t, "private, no-cache", resp.Header().Get("Cache-Control"))
+
+		req := NewRequest(t, "GET", articleLink)
+		req.Header.Set("If-None-Match", etag)
+		resp = MakeRequest(t, req, http.StatusNotModified)
+		assert.Empty(t, resp.Body.String())
+		assert.Equal(t, "private, no-cache", resp.Header().Get("Cache-Control"))
+
+		// The page of a signed in user shows their own state, it is never revalidated
+		session := loginUser(t, "user2")
+		req = NewRequest(t, "GET", articleLink)
+		req.Header.Set("If-None-Match", etag)
+		resp = session.MakeRequest(t, req, http.StatusOK)
+		assert.Empty(t, resp.Header().Get("ETag"))
+	})
+
+	t.Run("RepositoryUpdated", func(t *testing.T) {
+		resp := MakeRequest(t, NewRequest(t, "GET", articleLink), http.StatusOK)
+		etag := resp.Header().Get("ETag")
+
+		// Changing the repository, e.g. its description, changes the page without a new commit
+		repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{OwnerName: "user2", Name: "repo1"})
+		_, err := db.GetEngine(t.Context()).ID(repo.ID).Cols("updated_unix").
+			Update(&repo_model.Repository{UpdatedUnix: repo.UpdatedUnix + 1})
+		require.NoError(t, err)
+
+		req := NewRequest(t, "GET", articleLink)
+		req.Header.Set("If-None-Match", etag)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.NotEqual(t, etag, resp.Header().Get("ETag"))
+	})
+
+	t.Run("Version", func(t *testing.T) {
+		defer test.MockVariableValue(&setting.IsProd, true)()
+
+		versionLink := articleLink + "?version=65f1bf27bc3bf70f64657658635e66094edbcb4d"
+		resp := MakeRequest(t, NewRequest(t, "GET", versionLink), http.StatusOK)
+		etag := resp.Header().Get("ETag")
+		require.NotEmpty(t, etag)
+		assert.Equal(t, "private, max-age=86400", resp.Header().Get("Cache-Control"))
+
+		req := NewRequest(t, "GET", versionLink)
+		req.Header.Set("If-None-Match", etag)
+		MakeRequest(t, req, http.StatusNotModified)
+
+		// An abbreviated commit ID may become ambiguous, it isn't pinned
+		resp = MakeRequest(t, NewRequest(t, "GET", articleLink+"?version=65f1bf27"), http.StatusOK)
+		assert.Equal(t, "private, no-cache", resp.Header().Get("Cache-Control"))
+	})
+
+	t.Run("OtherModes", func(t *testing.T) {
+		for _, mode := range []string{"edit", "history"} {
+			resp := MakeRequest(t, NewRequest(t, "GET", articleLink+"?mode="+mode), http.StatusOK)
+			assert.Empty(t, resp.Header().Get("ETag"), mode)
+		}
+	})
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestArticleEmbed tests the read-only article view that other sites can show in an iframe
+func TestArticleEmbed(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1}) // root article of subject 1, owned by user2
+	require.NoError(t, repo1.LoadSubject(t.Context()))
+	embedLink := "/article/user2/" + repo1.SubjectRelation.Name + "/embed"
+
+	t.Run("Latest", func(t *testing.T) {
+		resp := MakeRequest(t, NewRequest(t, "GET", embedLink), http.StatusOK)
+		assert.Empty(t, resp.Header().Get("X-Frame-Options"))
+		assert.Equal(t, "frame-ancestors *", resp.Header().Get("Content-Security-Policy"))
+
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		AssertHTMLElement(t, htmlDoc, "#article-embed .file-view", true)
+		AssertHTMLElement(t, htmlDoc, "#article-embed-attribution", true)
+		// No site chrome
+		AssertHTMLElement(t, htmlDoc, "#navbar", false)
+	})
+
+	t.Run("Version", func(t *testing.T) {
+		gitRepo, err := gitrepo.OpenRepository(t.Context(), repo1)
+		require.NoError(t, err)
+		defer gitRepo.Close()
+		commitID, err := gitRepo.GetBranchCommitID(repo1.DefaultBranch)
+		require.NoError(t, err)
+
+		resp := MakeRequest(t, NewRequest(t, "GET", embedLink+"?version="+commitID), http.StatusOK)
+		link, _ := NewHTMLParser(t, resp.Body).Find("#article-embed-attribution a").Attr("href")
+		assert.Contains(t, link, "?version="+commitID)
+
+		MakeRequest(t, NewRequest(t, "GET", embedLink+"?version=0000000000000000000000000000000000000000"), http.StatusNotFound)
+	})
+
+	t.Run("AllowedOrigins", func(t *testing.T) {
+		defer test.MockVariableValue(&setting.Repository.ArticleEmbedOrigins, []string{"https://blog.example.com"})()
+		resp := MakeRequest(t, NewRequest(t, "GET", embedLink), http.StatusOK)
+		assert.Equal(t, "frame-ancestors 'self' https://blog.example.com", resp.Header().Get("Content-Security-Policy"))
+	})
+
+	t.Run("Private", func(t *testing.T) {
+		repo1.IsPrivate = true
+		require.NoError(t, repo_model.UpdateRepositoryColsNoAutoTime(t.Context(), repo1, "is_private"))
+		defer func() {
+			repo1.IsPrivate = false
+			require.NoError(t, repo_model.UpdateRepositoryColsNoAutoTime(t.Context(), repo1, "is_private"))
+		}()
+
+		MakeRequest(t, NewRequest(t, "GET", embedLink), http.StatusNotFound)
+		session := loginUser(t, "user2")
+		session.MakeRequest(t, NewRequest(t, "GET", embedLink), http.StatusOK)
+	})
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestArticleHistoryAuthorFilter tests that the article history can be limited to the edits of one user
+func TestArticleHistoryAuthorFilter(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	require.NoError(t, repo1.LoadSubject(t.Context()))
+	articleLink := fmt.Sprintf("/article/%s/%s", user2.Name, repo1.SubjectRelation.Name)
+
+	// Replacing the README adds two edits by user2
+	require.NoError(t, createOrReplaceFileInBranch(user2, repo1, "README.md", repo1.DefaultBranch, "# Edited by user2\n"))
+
+	// The README of repo1 was committed by user1
+	const user1Version = "65f1bf27bc"
+
+	historyVersions := func(t *testing.T, query string) []string {
+		resp := MakeRequest(t, NewRequest(t, "GET", articleLink+"?mode=history"+query), http.StatusOK)
+		var versions []string
+		NewHTMLParser(t, resp.Body).Find("#commits-table td.sha a").Each(func(_ int, s *goquery.Selection) {
+			versions = append(versions, strings.TrimSpace(s.Text()))
+		})
+		return versions
+	}
+
+	t.Run("Unfiltered", func(t *testing.T) {
+		versions := historyVersions(t, "")
+		assert.Len(t, versions, 3)
+		assert.Contains(t, versions, user1Version)
+	})
+
+	t.Run("ByAuthor", func(t *testing.T) {
+		versions := historyVersions(t, "&author="+user2.Name)
+		assert.Len(t, versions, 2)
+		assert.NotContains(t, versions, user1Version)
+	})
+
+	t.Run("AuthorWithoutEdits", func(t *testing.T) {
+		assert.Empty(t, historyVersions(t, "&author=user5"))
+	})
+
+	t.Run("UnknownAuthor", func(t *testing.T) {
+		MakeRequest(t, NewRequest(t, "GET", articleLink+"?mode=history&author=no-such-user"), http.StatusNotFound)
+	})
+}
+
+// TestArticleLatestChangeLink tests that the read view of an article links to its latest change
+func TestArticleLatestChangeLink(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	require.NoError(t, repo1.LoadSubject(t.Context()))
+	articleLink := fmt.Sprintf("/article/%s/%s", user2.Name, repo1.SubjectRelation.Name)
+
+	latestChangeLink := func(t *testing.T) string {
+		resp := MakeRequest(t, NewRequest(t, "GET", articleLink), http.StatusOK)
+		href, _ := NewHTMLParser(t, resp.Body).Find(`a[data-role="article-latest-change"]`).Attr("href")
+		return href
+	}
+
+	t.Run("InitialCommit", func(t *testing.T) {
+		// The README of repo1 was only committed once, so the whole README is the latest change
+		assert.Equal(t, "/user2/repo1/src/commit/65f1bf27bc3bf70f64657658635e66094edbcb4d/README.md", latestChangeLink(t))
+	})
+
+	t.Run("MultipleCommits", func(t *testing.T) {
+		require.NoError(t, createOrReplaceFileInBranch(user2, repo1, "README.md", repo1.DefaultBranch, "# Edited by user2\n"))
+
+		gitRepo, err := gitrepo.OpenRepository(t.Context(), repo1)
+		require.NoError(t, err)
+		defer gitRepo.Close()
+		lastCommit, err := gitRepo.GetCommitByPath("README.md")
+		require.NoError(t, err)
+		parentID, err := lastCommit.ParentID(0)
+		require.NoError(t, err)
+
+		assert.Equal(t, "/user2/repo1/compare/"+parentID.String()+"..."+lastCommit.ID.String(), latestChangeLink(t))
+	})
+}
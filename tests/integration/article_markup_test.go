@@ -0,0 +1,56 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestArticleMarkupOverride tests that the front matter of a README can choose the markup of the article
+func TestArticleMarkupOverride(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})       // owner of repo1
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1}) // root article of subject 1
+	require.NoError(t, repo1.LoadSubject(t.Context()))
+	articleLink := fmt.Sprintf("/article/%s/%s", user2.Name, repo1.SubjectRelation.Name)
+
+	t.Run("OrgMode", func(t *testing.T) {
+		require.NoError(t, createOrReplaceFileInBranch(user2, repo1, "README.md", repo1.DefaultBranch, "---\nformat: orgmode\n---\nThe Nile +once+ flows north.\n"))
+
+		resp := MakeRequest(t, NewRequest(t, "GET", articleLink), http.StatusOK)
+		fileView := NewHTMLParser(t, resp.Body).Find(".file-view.markup.orgmode")
+		require.Equal(t, 1, fileView.Length())
+		assert.Equal(t, "once", fileView.Find("del").Text())
+		assert.NotContains(t, fileView.Text(), "format:")
+
+		resp = MakeRequest(t, NewRequest(t, "GET", fmt.Sprintf("/api/v1/repos/%s/%s/article/rendered", user2.Name, repo1.Name)), http.StatusOK)
+		var article api.RenderedArticle
+		DecodeJSON(t, resp, &article)
+		assert.Equal(t, "orgmode", article.MarkupType)
+		assert.Equal(t, map[string]any{"format": "orgmode"}, article.FrontMatter)
+		assert.Contains(t, article.HTML, "<del>once</del>")
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		// An unknown markup falls back to the markup detected from the file name
+		require.NoError(t, createOrReplaceFileInBranch(user2, repo1, "README.md", repo1.DefaultBranch, "---\nformat: no-such-markup\n---\nThe Nile +once+ flows north.\n"))
+
+		resp := MakeRequest(t, NewRequest(t, "GET", articleLink), http.StatusOK)
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		assert.Equal(t, 1, htmlDoc.Find(".file-view.markup.markdown").Length())
+		assert.Equal(t, 0, htmlDoc.Find(".file-view del").Length())
+	})
+}
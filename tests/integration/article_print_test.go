@@ -0,0 +1,60 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestArticlePrintView tests the print-friendly article view, including printing older versions
+func TestArticlePrintView(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1}) // root article of subject 1, owned by user2
+	require.NoError(t, repo1.LoadSubject(t.Context()))
+	articleLink := "/article/user2/" + repo1.SubjectRelation.Name
+
+	require.NoError(t, createOrReplaceFileInBranch(user2, repo1, "README.md", repo1.DefaultBranch, "# Old version\n\n## First section\n\nOld text\n"))
+	gitRepo, err := gitrepo.OpenRepository(t.Context(), repo1)
+	require.NoError(t, err)
+	oldCommitID, err := gitRepo.GetBranchCommitID(repo1.DefaultBranch)
+	gitRepo.Close()
+	require.NoError(t, err)
+	require.NoError(t, createOrReplaceFileInBranch(user2, repo1, "README.md", repo1.DefaultBranch, "# New version\n\nNew text\n"))
+
+	session := loginUser(t, user2.Name)
+
+	t.Run("Latest", func(t *testing.T) {
+		resp := session.MakeRequest(t, NewRequest(t, "GET", articleLink+"?print=1&mode=edit"), http.StatusOK)
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		AssertHTMLElement(t, htmlDoc, "#article-print", true)
+		// Neither the site chrome nor the edit controls are rendered
+		AssertHTMLElement(t, htmlDoc, "#navbar", false)
+		AssertHTMLElement(t, htmlDoc, "#article-tabs", false)
+		AssertHTMLElement(t, htmlDoc, "#submit-changes-button", false)
+		assert.Contains(t, htmlDoc.Find("#article-print .file-view").Text(), "New text")
+	})
+
+	t.Run("Version", func(t *testing.T) {
+		resp := session.MakeRequest(t, NewRequest(t, "GET", articleLink+"?print=1&version="+oldCommitID), http.StatusOK)
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		content := htmlDoc.Find("#article-print .file-view").Text()
+		assert.Contains(t, content, "Old text")
+		assert.NotContains(t, content, "New text")
+		AssertHTMLElement(t, htmlDoc, "#article-print-toc", true)
+		canonical, _ := htmlDoc.Find(`link[rel="canonical"]`).Attr("href")
+		assert.Contains(t, canonical, "?version="+oldCommitID)
+	})
+}
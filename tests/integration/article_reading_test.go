@@ -0,0 +1,60 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/gitrepo"
+	repo_service "code.gitea.io/gitea/services/repository"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestArticleReadingMetadata tests that the reading metadata of an article is precomputed when its README is pushed
+func TestArticleReadingMetadata(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})       // owner of repo1
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1}) // root article of subject 1
+	require.NoError(t, repo1.LoadSubject(t.Context()))
+
+	readme := "---\ntitle: Rivers\n---\n# Rivers\n\nRivers carry water " + strings.Repeat("downstream to the sea ", 60) + "\n\n## The Nile\n\nThe Nile flows north.\n"
+	require.NoError(t, createOrReplaceFileInBranch(user2, repo1, "README.md", repo1.DefaultBranch, readme))
+	commitID, err := gitrepo.GetBranchCommitID(t.Context(), repo1, repo1.DefaultBranch)
+	require.NoError(t, err)
+
+	stored, has, err := repo_model.GetArticleReadingMetadata(t.Context(), repo1.ID, commitID)
+	require.NoError(t, err)
+	require.True(t, has)
+	live := repo_service.ComputeArticleReadingMetadata("README.md", []byte(readme))
+	assert.Equal(t, live.WordCount, stored.WordCount)
+	assert.Equal(t, live.ReadingMinutes, stored.ReadingMinutes)
+	assert.Equal(t, live.Outline, stored.Outline)
+	assert.Equal(t, 2, stored.ReadingMinutes)
+
+	// A commit which doesn't change the README reuses its metadata, replacing the metadata of the previous commit
+	require.NoError(t, createOrReplaceFileInBranch(user2, repo1, "notes.txt", repo1.DefaultBranch, "Not part of the article\n"))
+	newCommitID, err := gitrepo.GetBranchCommitID(t.Context(), repo1, repo1.DefaultBranch)
+	require.NoError(t, err)
+	reused, has, err := repo_model.GetArticleReadingMetadata(t.Context(), repo1.ID, newCommitID)
+	require.NoError(t, err)
+	require.True(t, has)
+	assert.Equal(t, stored.ReadmeBlobID, reused.ReadmeBlobID)
+	assert.Equal(t, stored.WordCount, reused.WordCount)
+	unittest.AssertNotExistsBean(t, &repo_model.ArticleReadingMetadata{RepoID: repo1.ID, CommitID: commitID})
+
+	resp := MakeRequest(t, NewRequest(t, "GET", fmt.Sprintf("/article/%s/%s", user2.Name, repo1.SubjectRelation.Name)), http.StatusOK)
+	htmlDoc := NewHTMLParser(t, resp.Body)
+	assert.Contains(t, htmlDoc.Find("#article-reading").Text(), fmt.Sprintf("%d words", stored.WordCount))
+	assert.Equal(t, 2, htmlDoc.Find("#article-outline li").Length())
+}
@@ -0,0 +1,37 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestArticleMaxRenderSize tests that articles are rendered up to their own size limit rather than the one of code files
+func TestArticleMaxRenderSize(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	// The README of repo1 is 30 bytes
+	defer test.MockVariableValue(&setting.UI.MaxDisplayFileSize, 16)()
+
+	t.Run("CodeLimit", func(t *testing.T) {
+		defer test.MockVariableValue(&setting.Repository.Article.MaxRenderSize, 0)()
+		resp := MakeRequest(t, NewRequest(t, "GET", "/article/user2/example-subject"), http.StatusOK)
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		AssertHTMLElement(t, htmlDoc, ".file-view", false)
+		assert.Contains(t, htmlDoc.Find(".ui.error.message").Text(), "too large")
+	})
+
+	t.Run("ArticleLimit", func(t *testing.T) {
+		defer test.MockVariableValue(&setting.Repository.Article.MaxRenderSize, 1024)()
+		resp := MakeRequest(t, NewRequest(t, "GET", "/article/user2/example-subject"), http.StatusOK)
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		assert.Contains(t, htmlDoc.Find(".file-view").Text(), "Description for repo1")
+	})
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRevertArticleVersion tests restoring an article to a version of its history, directly
+// by its owner and through a change request by others
+func TestRevertArticleVersion(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})       // owner of repo1
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1}) // root article of subject 1
+	require.NoError(t, repo1.LoadSubject(t.Context()))
+	articleLink := fmt.Sprintf("/article/%s/%s", user2.Name, repo1.SubjectRelation.Name)
+
+	readmeAt := func(t *testing.T, branch string) (commitID, content string) {
+		gitRepo, err := gitrepo.OpenRepository(t.Context(), repo1)
+		require.NoError(t, err)
+		defer gitRepo.Close()
+		commit, err := gitRepo.GetBranchCommit(branch)
+		require.NoError(t, err)
+		blob, err := commit.GetBlobByPath("README.md")
+		require.NoError(t, err)
+		content, err = blob.GetBlobContent(blob.Size())
+		require.NoError(t, err)
+		return commit.ID.String(), content
+	}
+
+	postRevert := func(t *testing.T, session *TestSession, version string, expectedStatus int) *httptest.ResponseRecorder {
+		req := NewRequestWithValues(t, "POST", articleLink+"/revert?version="+version, map[string]string{
+			"_csrf": GetUserCSRFToken(t, session),
+		})
+		return session.MakeRequest(t, req, expectedStatus)
+	}
+
+	originalVersion, originalContent := readmeAt(t, repo1.DefaultBranch)
+	require.NoError(t, createOrReplaceFileInBranch(user2, repo1, "README.md", repo1.DefaultBranch, "# Vandalized\n"))
+
+	t.Run("HistoryOffersRevert", func(t *testing.T) {
+		session := loginUser(t, user2.Name)
+		resp := session.MakeRequest(t, NewRequest(t, "GET", articleLink+"?mode=history"), http.StatusOK)
+		AssertHTMLElement(t, NewHTMLParser(t, resp.Body), fmt.Sprintf(`button[data-url^="%s/revert?version="]`, articleLink), true)
+	})
+
+	t.Run("ChangeRequest", func(t *testing.T) {
+		session := loginUser(t, "user4")
+		resp := postRevert(t, session, originalVersion, http.StatusOK)
+		assert.Contains(t, test.RedirectURL(resp), "/pulls/")
+
+		pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{BaseRepoID: repo1.ID, HeadBranch: "user4-patch-1"})
+		_, content := readmeAt(t, pr.HeadBranch)
+		assert.Equal(t, originalContent, content)
+		_, content = readmeAt(t, repo1.DefaultBranch)
+		assert.Equal(t, "# Vandalized\n", content)
+	})
+
+	t.Run("Owner", func(t *testing.T) {
+		session := loginUser(t, user2.Name)
+		resp := postRevert(t, session, originalVersion, http.StatusOK)
+		assert.Equal(t, articleLink, test.RedirectURL(resp))
+
+		_, content := readmeAt(t, repo1.DefaultBranch)
+		assert.Equal(t, originalContent, content)
+
+		// The article already has the content of this version
+		postRevert(t, session, originalVersion, http.StatusBadRequest)
+	})
+
+	t.Run("NoReadme", func(t *testing.T) {
+		// Replacing the README deletes it in a commit of its own before adding it again
+		require.NoError(t, createOrReplaceFileInBranch(user2, repo1, "README.md", repo1.DefaultBranch, "# Replaced\n"))
+		gitRepo, err := gitrepo.OpenRepository(t.Context(), repo1)
+		require.NoError(t, err)
+		defer gitRepo.Close()
+		head, err := gitRepo.GetBranchCommit(repo1.DefaultBranch)
+		require.NoError(t, err)
+		deletedVersion, err := head.ParentID(0)
+		require.NoError(t, err)
+
+		session := loginUser(t, user2.Name)
+		resp := postRevert(t, session, deletedVersion.String(), http.StatusBadRequest)
+		assert.Equal(t, "The article didn't exist in this version, so it can't be restored.", test.ParseJSONError(resp.Body.Bytes()).ErrorMessage)
+	})
+
+	t.Run("UnknownVersion", func(t *testing.T) {
+		session := loginUser(t, user2.Name)
+		postRevert(t, session, "0000000000000000000000000000000000000000", http.StatusNotFound)
+	})
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/gitrepo"
+	repo_service "code.gitea.io/gitea/services/repository"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPullArticleUpstream tests that the owner of an article fork can pull the latest
+// changes of the original article into the fork from the edit page
+func TestPullArticleUpstream(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})       // owner of repo1
+	user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})       // forks repo1
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1}) // root article of subject 1
+	require.NoError(t, repo1.LoadSubject(t.Context()))
+	subjectName := repo1.SubjectRelation.Name
+
+	forkRepo1 := func(t *testing.T) *repo_model.Repository {
+		fork, err := repo_service.ForkRepository(t.Context(), user4, user4, repo_service.ForkRepoOptions{
+			BaseRepo: repo1,
+			Name:     "upstream-fork",
+		})
+		require.NoError(t, err)
+		return fork
+	}
+
+	branchCommitID := func(t *testing.T, repo *repo_model.Repository) string {
+		gitRepo, err := gitrepo.OpenRepository(t.Context(), repo)
+		require.NoError(t, err)
+		defer gitRepo.Close()
+		commitID, err := gitRepo.GetBranchCommitID(repo.DefaultBranch)
+		require.NoError(t, err)
+		return commitID
+	}
+
+	editLink := fmt.Sprintf("/article/%s/%s?mode=edit", user4.Name, subjectName)
+	pullLink := fmt.Sprintf("/article/%s/%s/pull-upstream", user4.Name, subjectName)
+
+	t.Run("BannerAndFastForward", func(t *testing.T) {
+		fork := forkRepo1(t)
+		defer func() {
+			require.NoError(t, repo_service.DeleteRepositoryDirectly(t.Context(), fork.ID))
+		}()
+
+		session := loginUser(t, user4.Name)
+		resp := session.MakeRequest(t, NewRequest(t, "GET", editLink), http.StatusOK)
+		AssertHTMLElement(t, NewHTMLParser(t, resp.Body), "#article-upstream-banner", false)
+
+		require.NoError(t, createOrReplaceFileInBranch(user2, repo1, "README.md", repo1.DefaultBranch, "# Updated by the original author\n"))
+
+		resp = session.MakeRequest(t, NewRequest(t, "GET", editLink), http.StatusOK)
+		AssertHTMLElement(t, NewHTMLParser(t, resp.Body), "#article-upstream-banner", true)
+
+		// Only the owner of the fork can pull into it
+		ownerSession := loginUser(t, user2.Name)
+		req := NewRequestWithValues(t, "POST", pullLink, map[string]string{
+			"_csrf": GetUserCSRFToken(t, ownerSession),
+		})
+		ownerSession.MakeRequest(t, req, http.StatusNotFound)
+
+		req = NewRequestWithValues(t, "POST", pullLink, map[string]string{
+			"_csrf": GetUserCSRFToken(t, session),
+		})
+		session.MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, branchCommitID(t, repo1), branchCommitID(t, fork))
+
+		resp = session.MakeRequest(t, NewRequest(t, "GET", editLink), http.StatusOK)
+		AssertHTMLElement(t, NewHTMLParser(t, resp.Body), "#article-upstream-banner", false)
+	})
+
+	t.Run("ConflictOpensCompare", func(t *testing.T) {
+		fork := forkRepo1(t)
+		defer func() {
+			require.NoError(t, repo_service.DeleteRepositoryDirectly(t.Context(), fork.ID))
+		}()
+
+		require.NoError(t, createOrReplaceFileInBranch(user4, fork, "README.md", fork.DefaultBranch, "# The fork's version\n"))
+		require.NoError(t, createOrReplaceFileInBranch(user2, repo1, "README.md", repo1.DefaultBranch, "# The original's version\n"))
+		forkCommitID := branchCommitID(t, fork)
+
+		session := loginUser(t, user4.Name)
+		req := NewRequestWithValues(t, "POST", pullLink, map[string]string{
+			"_csrf": GetUserCSRFToken(t, session),
+		})
+		resp := session.MakeRequest(t, req, http.StatusOK)
+		result := map[string]string{}
+		DecodeJSON(t, resp, &result)
+		assert.Contains(t, result["redirect"], fork.Link()+"/compare/")
+		assert.Equal(t, forkCommitID, branchCommitID(t, fork))
+	})
+}
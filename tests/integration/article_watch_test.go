@@ -0,0 +1,64 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestArticleWatch tests that the watchers of an article are only notified of the pushes changing its README
+func TestArticleWatch(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})       // owner of repo1
+	user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})       // watches the article of repo1
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1}) // root article of subject 1
+	require.NoError(t, repo1.LoadSubject(t.Context()))
+	articleLink := fmt.Sprintf("/article/%s/%s", user2.Name, repo1.SubjectRelation.Name)
+
+	session := loginUser(t, user4.Name)
+	req := NewRequestWithValues(t, "POST", articleLink+"/watch", map[string]string{
+		"_csrf": GetUserCSRFToken(t, session),
+	})
+	session.MakeRequest(t, req, http.StatusOK)
+	assert.True(t, repo_model.IsWatchingArticle(t.Context(), user4.ID, repo1.ID))
+
+	resp := session.MakeRequest(t, NewRequest(t, "GET", articleLink), http.StatusOK)
+	action, _ := NewHTMLParser(t, resp.Body).Find("#article-watch-button").Parent().Attr("action")
+	assert.Equal(t, articleLink+"/unwatch", action)
+
+	articleNotifications := func() int {
+		return unittest.GetCount(t, &activities_model.Notification{
+			UserID: user4.ID,
+			RepoID: repo1.ID,
+			Source: activities_model.NotificationSourceCommit,
+		})
+	}
+
+	require.NoError(t, createOrReplaceFileInBranch(user2, repo1, "notes.txt", repo1.DefaultBranch, "Not part of the article\n"))
+	assert.Equal(t, 0, articleNotifications())
+
+	require.NoError(t, createOrReplaceFileInBranch(user2, repo1, "README.md", repo1.DefaultBranch, "# A new version of the article\n"))
+	assert.Equal(t, 1, articleNotifications())
+
+	req = NewRequestWithValues(t, "POST", articleLink+"/unwatch", map[string]string{
+		"_csrf": GetUserCSRFToken(t, session),
+	})
+	session.MakeRequest(t, req, http.StatusOK)
+	assert.False(t, repo_model.IsWatchingArticle(t.Context(), user4.ID, repo1.ID))
+
+	require.NoError(t, createOrReplaceFileInBranch(user2, repo1, "README.md", repo1.DefaultBranch, "# Another version of the article\n"))
+	assert.Equal(t, 1, articleNotifications())
+}
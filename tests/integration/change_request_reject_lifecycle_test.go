@@ -168,3 +168,42 @@ func TestCloseChangeRequestWithoutFork(t *testing.T) {
 		assert.Equal(t, int64(0), pr.ForkedRepoID, "ForkedRepoID should remain 0")
 	})
 }
+
+// TestForkOpenChangeRequest tests that the author of an open change request can keep
+// their version as a fork, which closes the change request.
+func TestForkOpenChangeRequest(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		nonOwner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+		repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+		sessionNonOwner := loginUser(t, nonOwner.Name)
+
+		content := "# Open change request\n\nThe owner disagrees with this version.\n"
+		prIndex := submitChangeRequestAndGetPR(t, sessionNonOwner, owner, repo, content)
+
+		// The author is offered to fork the open change request
+		prPageURL := path.Join(owner.Name, repo.Name, "pulls", strconv.FormatInt(prIndex, 10))
+		resp := sessionNonOwner.MakeRequest(t, NewRequest(t, "GET", prPageURL), http.StatusOK)
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		forkURL := fmt.Sprintf("/%s/%s/pulls/%d/fork_rejected_changes", owner.Name, repo.Name, prIndex)
+		assert.Equal(t, 1, htmlDoc.Find(`form[action="`+forkURL+`"]`).Length())
+
+		req := NewRequestWithValues(t, "POST", forkURL, map[string]string{"_csrf": htmlDoc.GetCSRF()})
+		sessionNonOwner.MakeRequest(t, req, http.StatusOK)
+
+		// The change request is closed and its content landed on the default branch of the fork
+		pr, err := issues_model.GetPullRequestByIndex(t.Context(), repo.ID, prIndex)
+		require.NoError(t, err)
+		require.True(t, pr.IsForked)
+		pr.Issue = unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: pr.IssueID})
+		assert.True(t, pr.Issue.IsClosed, "CR should be closed once forked")
+		assert.False(t, pr.HasMerged)
+
+		forkedRepo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: pr.ForkedRepoID})
+		assert.Equal(t, nonOwner.ID, forkedRepo.OwnerID)
+		rawURL := path.Join(nonOwner.Name, forkedRepo.Name, "raw/branch", forkedRepo.DefaultBranch, "README.md")
+		resp = sessionNonOwner.MakeRequest(t, NewRequest(t, "GET", rawURL), http.StatusOK)
+		assert.Equal(t, content, resp.Body.String())
+	})
+}
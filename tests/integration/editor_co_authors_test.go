@@ -0,0 +1,116 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"testing"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEditorCoAuthors tests crediting co-authors of article edits with commit message trailers
+func TestEditorCoAuthors(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	editURL := "/" + path.Join("user2", repo.Name, "_edit", repo.DefaultBranch, "README.md")
+
+	postEdit := func(t *testing.T, session *TestSession, query string, values url.Values, expectedStatus int) *httptest.ResponseRecorder {
+		resp := session.MakeRequest(t, NewRequest(t, "GET", editURL+query), http.StatusOK)
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		values.Set("_csrf", htmlDoc.GetCSRF())
+		values.Set("last_commit", htmlDoc.GetInputValueByName("last_commit"))
+		values.Set("tree_path", "README.md")
+		values.Set("commit_choice", "direct")
+		return session.MakeRequest(t, NewRequestWithURLValues(t, "POST", editURL, values), expectedStatus)
+	}
+
+	branchCommitMessage := func(t *testing.T, branch string) string {
+		gitRepo, err := gitrepo.OpenRepository(t.Context(), repo)
+		require.NoError(t, err)
+		defer gitRepo.Close()
+		commit, err := gitRepo.GetBranchCommit(branch)
+		require.NoError(t, err)
+		return commit.Message()
+	}
+
+	t.Run("DirectEdit", func(t *testing.T) {
+		session := loginUser(t, "user2")
+		postEdit(t, session, "", url.Values{
+			"content":    {"# repo1\n\nWith suggestions\n"},
+			"co_authors": {"Jane Doe <jane@example.com>", "John Smith <john@example.com>\njane doe <JANE@example.com>"},
+		}, http.StatusOK)
+
+		message := branchCommitMessage(t, repo.DefaultBranch)
+		assert.Contains(t, message, "\n\nCo-authored-by: Jane Doe <jane@example.com>\nCo-authored-by: John Smith <john@example.com>")
+		assert.NotContains(t, message, "JANE@example.com")
+	})
+
+	t.Run("ChangeRequest", func(t *testing.T) {
+		session := loginUser(t, "user4")
+		resp := postEdit(t, session, "?submit_change_request=true", url.Values{
+			"content":               {"# repo1\n\nChange request with suggestions\n"},
+			"submit_change_request": {"true"},
+			"change_request_title":  {"Include suggestions"},
+			"co_authors":            {"Jane Doe <jane@example.com>"},
+		}, http.StatusOK)
+		require.Contains(t, test.RedirectURL(resp), "/pulls/")
+
+		pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{BaseRepoID: repo.ID, HeadBranch: "user4-patch-1"})
+		message := branchCommitMessage(t, pr.HeadBranch)
+		assert.Contains(t, message, "Include suggestions\n\nCo-authored-by: Jane Doe <jane@example.com>")
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		session := loginUser(t, "user2")
+		resp := postEdit(t, session, "", url.Values{
+			"content":    {"# repo1\n\nInvalid co-author\n"},
+			"co_authors": {"jane@example.com"},
+		}, http.StatusBadRequest)
+		assert.Equal(t, `Co-author "jane@example.com" must be given as "Name &lt;email&gt;".`, test.ParseJSONError(resp.Body.Bytes()).ErrorMessage)
+	})
+
+	t.Run("InvalidBeforeBranchPush", func(t *testing.T) {
+		// user13/repo11 is a fork of user12/repo10, editing it from the base branch pushes that branch first
+		fork := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 11})
+		forkEditURL := "/" + path.Join("user13", fork.Name, "_edit", fork.DefaultBranch, "README.md")
+		session := loginUser(t, "user13")
+		resp := session.MakeRequest(t, NewRequest(t, "GET", forkEditURL), http.StatusOK)
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		resp = session.MakeRequest(t, NewRequestWithURLValues(t, "POST", forkEditURL+"?from_base_branch=master", url.Values{
+			"_csrf":           {htmlDoc.GetCSRF()},
+			"last_commit":     {htmlDoc.GetInputValueByName("last_commit")},
+			"tree_path":       {"README.md"},
+			"content":         {"# repo11\n\nInvalid co-author\n"},
+			"commit_choice":   {"commit-to-new-branch"},
+			"new_branch_name": {"invalid-co-author"},
+			"co_authors":      {"jane@example.com"},
+		}), http.StatusBadRequest)
+		assert.Equal(t, `Co-author "jane@example.com" must be given as "Name &lt;email&gt;".`, test.ParseJSONError(resp.Body.Bytes()).ErrorMessage)
+		assert.False(t, gitrepo.IsBranchExist(t.Context(), fork, "invalid-co-author"))
+	})
+
+	t.Run("TooMany", func(t *testing.T) {
+		session := loginUser(t, "user2")
+		coAuthors := url.Values{"content": {"# repo1\n\nToo many co-authors\n"}}
+		for i := range 11 {
+			coAuthors.Add("co_authors", fmt.Sprintf("User %d <user%d@example.com>", i, i))
+		}
+		resp := postEdit(t, session, "", coAuthors, http.StatusBadRequest)
+		assert.Equal(t, "At most 10 co-authors can be credited in one commit.", test.ParseJSONError(resp.Body.Bytes()).ErrorMessage)
+	})
+}
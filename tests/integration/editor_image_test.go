@@ -0,0 +1,146 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestArticleEditorImageUpload tests that images pasted into the article editor by other users
+// are committed to their fork or change request branch, never to the article itself
+func TestArticleEditorImageUpload(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1}) // root article of subject 1, owned by user2
+	require.NoError(t, repo1.LoadSubject(t.Context()))
+	uploadLink := "/article/user2/" + repo1.SubjectRelation.Name + "/_image/" + repo1.DefaultBranch + "/README.md"
+
+	var pngData bytes.Buffer
+	require.NoError(t, png.Encode(&pngData, image.NewRGBA(image.Rect(0, 0, 8, 8))))
+
+	uploadImage := func(t *testing.T, userName, fileName string, content []byte, fields map[string]string, expectedStatus int) map[string]string {
+		session := loginUser(t, userName)
+		body := &bytes.Buffer{}
+		form := multipart.NewWriter(body)
+		file, err := form.CreateFormFile("file", fileName)
+		require.NoError(t, err)
+		_, err = file.Write(content)
+		require.NoError(t, err)
+		require.NoError(t, form.WriteField("_csrf", GetUserCSRFToken(t, session)))
+		for k, v := range fields {
+			require.NoError(t, form.WriteField(k, v))
+		}
+		require.NoError(t, form.Close())
+
+		req := NewRequestWithBody(t, "POST", uploadLink, body)
+		req.Header.Add("Content-Type", form.FormDataContentType())
+		resp := session.MakeRequest(t, req, expectedStatus)
+		result := map[string]string{}
+		if expectedStatus == http.StatusOK {
+			DecodeJSON(t, resp, &result)
+		}
+		return result
+	}
+
+	hasFile := func(t *testing.T, repo *repo_model.Repository, branch, treePath string) bool {
+		gitRepo, err := gitrepo.OpenRepository(t.Context(), repo)
+		require.NoError(t, err)
+		defer gitRepo.Close()
+		commit, err := gitRepo.GetBranchCommit(branch)
+		require.NoError(t, err)
+		has, _ := commit.HasFile(treePath)
+		return has
+	}
+
+	t.Run("ForkOnEdit", func(t *testing.T) {
+		result := uploadImage(t, "user4", "pasted.png", pngData.Bytes(), map[string]string{"fork_and_edit": "true"}, http.StatusOK)
+		assert.Equal(t, "images/pasted.png", result["path"])
+		assert.Equal(t, "![pasted](images/pasted.png)", result["markdown"])
+		assert.Empty(t, result["patch_branch"])
+
+		fork, err := repo_model.GetUserFork(t.Context(), repo1.ID, 4)
+		require.NoError(t, err)
+		require.NotNil(t, fork)
+		assert.True(t, hasFile(t, fork, fork.DefaultBranch, "images/pasted.png"))
+		assert.False(t, hasFile(t, repo1, repo1.DefaultBranch, "images/pasted.png"))
+	})
+
+	t.Run("ChangeRequest", func(t *testing.T) {
+		user5 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+
+		// The editor gives the patch branch of the editing session
+		session := loginUser(t, user5.Name)
+		resp := session.MakeRequest(t, NewRequest(t, "GET", "/article/user2/"+repo1.SubjectRelation.Name+"?mode=edit"), http.StatusOK)
+		patchBranch := NewHTMLParser(t, resp.Body).GetInputValueByName("patch_branch")
+		assert.Equal(t, user5.LowerName+"-patch-1", patchBranch)
+
+		// The first image creates the branch
+		fields := map[string]string{"submit_change_request": "true", "patch_branch": patchBranch}
+		result := uploadImage(t, user5.Name, "pasted.png", pngData.Bytes(), fields, http.StatusOK)
+		assert.Equal(t, patchBranch, result["patch_branch"])
+		assert.Equal(t, "images/pasted.png", result["path"])
+
+		// The next image of the session goes to the same branch, without overwriting the first
+		result = uploadImage(t, user5.Name, "pasted.png", pngData.Bytes(), fields, http.StatusOK)
+		assert.Equal(t, patchBranch, result["patch_branch"])
+		assert.Equal(t, "images/pasted-1.png", result["path"])
+
+		assert.True(t, hasFile(t, repo1, patchBranch, "images/pasted.png"))
+		assert.True(t, hasFile(t, repo1, patchBranch, "images/pasted-1.png"))
+		assert.False(t, hasFile(t, repo1, repo1.DefaultBranch, "images/pasted.png"))
+
+		// Uploads without the branch of the session are rejected
+		delete(fields, "patch_branch")
+		uploadImage(t, user5.Name, "pasted.png", pngData.Bytes(), fields, http.StatusBadRequest)
+
+		// Branches of other users can't be written to
+		fields["patch_branch"] = repo1.DefaultBranch
+		uploadImage(t, user5.Name, "pasted.png", pngData.Bytes(), fields, http.StatusBadRequest)
+
+		// The change request is made from the branch holding the images
+		editLink := "/user2/" + repo1.Name + "/_edit/" + repo1.DefaultBranch + "/README.md"
+		req := NewRequestWithValues(t, "POST", editLink, map[string]string{
+			"_csrf":                 GetUserCSRFToken(t, session),
+			"tree_path":             "README.md",
+			"content":               "# With images\n\n![pasted](images/pasted.png)\n",
+			"commit_choice":         "direct",
+			"submit_change_request": "true",
+			"patch_branch":          patchBranch,
+		})
+		session.MakeRequest(t, req, http.StatusOK)
+		pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{BaseRepoID: repo1.ID, HeadBranch: patchBranch})
+		assert.Equal(t, repo1.ID, pr.HeadRepoID)
+		assert.True(t, hasFile(t, repo1, patchBranch, "images/pasted.png"))
+
+		// Uploads are change request submissions, so they are rate limited as well
+		defer test.MockVariableValue(&setting.Repository.PullRequest.ChangeRequestRateLimit, 1)()
+		fields["patch_branch"] = user5.LowerName + "-patch-2"
+		uploadImage(t, user5.Name, "pasted.png", pngData.Bytes(), fields, http.StatusBadRequest)
+	})
+
+	t.Run("NotAnImage", func(t *testing.T) {
+		uploadImage(t, "user4", "notes.png", []byte("just some text"), map[string]string{"fork_and_edit": "true"}, http.StatusBadRequest)
+	})
+
+	t.Run("NoWorkflowRequiresWriteAccess", func(t *testing.T) {
+		uploadImage(t, "user4", "pasted.png", pngData.Bytes(), nil, http.StatusNotFound)
+	})
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"net/url"
+	"path"
+	"testing"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	repo_service "code.gitea.io/gitea/services/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEditorRootPromotionMinCommits tests that the fork created to contribute the README of a root
+// article without one counts its commits towards ROOT_PROMOTION_MIN_COMMITS, the one committed by
+// the editor and the later ones
+func TestEditorRootPromotionMinCommits(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+		// createRootWithoutReadme creates an article of user2 which has content, but no README
+		createRootWithoutReadme := func(t *testing.T, name string) *repo_model.Repository {
+			rootRepo, err := repo_service.CreateRepository(t.Context(), user2, user2, repo_service.CreateRepoOptions{
+				Name:     name,
+				Subject:  name,
+				AutoInit: true,
+				Readme:   "Default",
+				License:  "MIT",
+			})
+			require.NoError(t, err)
+			_, err = deleteFileInBranch(user2, rootRepo, "README.md", rootRepo.DefaultBranch)
+			require.NoError(t, err)
+			return unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: rootRepo.ID})
+		}
+
+		// editReadme commits a README with the editor of the repository, which forks it if needed
+		editReadme := func(t *testing.T, session *TestSession, repo *repo_model.Repository, editorAction, content string) {
+			editURL := "/" + path.Join(repo.OwnerName, repo.Name, editorAction, repo.DefaultBranch, "README.md")
+			resp := session.MakeRequest(t, NewRequest(t, "GET", editURL), http.StatusOK)
+			htmlDoc := NewHTMLParser(t, resp.Body)
+			session.MakeRequest(t, NewRequestWithURLValues(t, "POST", editURL, url.Values{
+				"_csrf":         {htmlDoc.GetCSRF()},
+				"last_commit":   {htmlDoc.GetInputValueByName("last_commit")},
+				"tree_path":     {"README.md"},
+				"content":       {content},
+				"commit_choice": {"direct"},
+			}), http.StatusOK)
+		}
+
+		t.Run("PromotedOnceCommitted", func(t *testing.T) {
+			defer test.MockVariableValue(&setting.Repository.Article.RootPromotionMinCommits, 1)()
+			rootRepo := createRootWithoutReadme(t, "promotion-once-committed")
+
+			editReadme(t, loginUser(t, "user4"), rootRepo, "_new", "# Promotion\n\nThe first README.\n")
+
+			fork := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{OwnerName: "user4", LowerName: rootRepo.LowerName})
+			assert.False(t, fork.IsFork)
+			rootRepo = unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: rootRepo.ID})
+			assert.True(t, rootRepo.IsFork)
+			assert.Equal(t, fork.ID, rootRepo.ForkID)
+		})
+
+		t.Run("PromotedByLaterPush", func(t *testing.T) {
+			defer test.MockVariableValue(&setting.Repository.Article.RootPromotionMinCommits, 2)()
+			rootRepo := createRootWithoutReadme(t, "promotion-later-push")
+			session := loginUser(t, "user4")
+
+			// A single commit isn't enough, the root is kept
+			editReadme(t, session, rootRepo, "_new", "# Promotion\n\nThe first README.\n")
+			fork := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{OwnerName: "user4", LowerName: rootRepo.LowerName})
+			assert.True(t, fork.IsFork)
+			assert.Equal(t, rootRepo.ID, fork.ForkID)
+
+			// The next commit to the default branch of the fork makes it eligible
+			editReadme(t, session, fork, "_edit", "# Promotion\n\nThe first README, revised.\n")
+			fork = unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: fork.ID})
+			assert.False(t, fork.IsFork)
+			rootRepo = unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: rootRepo.ID})
+			assert.True(t, rootRepo.IsFork)
+			assert.Equal(t, fork.ID, rootRepo.ForkID)
+		})
+	})
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	git_model "code.gitea.io/gitea/models/git"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+// TestEditorSignedCommits tests that article edits are signed following the signing policy, and
+// that the doer can only leave them unsigned when neither the policy nor the branch requires signing
+func TestEditorSignedCommits(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	tmpDir := t.TempDir()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	sshPubKey, err := ssh.NewPublicKey(pub)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "id_ed25519.pub"), ssh.MarshalAuthorizedKey(sshPubKey), 0o600))
+	block, err := ssh.MarshalPrivateKey(priv, "")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "id_ed25519"), pem.EncodeToMemory(block), 0o600))
+
+	defer test.MockVariableValue(&setting.Repository.Signing.SigningKey, filepath.Join(tmpDir, "id_ed25519.pub"))()
+	defer test.MockVariableValue(&setting.Repository.Signing.SigningName, "gitea")()
+	defer test.MockVariableValue(&setting.Repository.Signing.SigningEmail, "gitea@fake.local")()
+	defer test.MockVariableValue(&setting.Repository.Signing.SigningFormat, "ssh")()
+	defer test.MockVariableValue(&setting.Repository.Signing.CRUDActions, []string{"always"})()
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	editURL := "/" + path.Join("user2", repo.Name, "_edit", repo.DefaultBranch, "README.md")
+	session := loginUser(t, "user2")
+
+	postEdit := func(t *testing.T, values url.Values) {
+		resp := session.MakeRequest(t, NewRequest(t, "GET", editURL), http.StatusOK)
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		values.Set("_csrf", htmlDoc.GetCSRF())
+		values.Set("last_commit", htmlDoc.GetInputValueByName("last_commit"))
+		values.Set("tree_path", "README.md")
+		values.Set("commit_choice", "direct")
+		session.MakeRequest(t, NewRequestWithURLValues(t, "POST", editURL, values), http.StatusOK)
+	}
+
+	isHeadSigned := func(t *testing.T) bool {
+		gitRepo, err := gitrepo.OpenRepository(t.Context(), repo)
+		require.NoError(t, err)
+		defer gitRepo.Close()
+		commit, err := gitRepo.GetBranchCommit(repo.DefaultBranch)
+		require.NoError(t, err)
+		return commit.Signature != nil
+	}
+
+	t.Run("SignedByDefault", func(t *testing.T) {
+		postEdit(t, url.Values{"content": {"# repo1\n\nSigned edit\n"}})
+		assert.True(t, isHeadSigned(t))
+	})
+
+	t.Run("PolicyAlwaysSigns", func(t *testing.T) {
+		postEdit(t, url.Values{"content": {"# repo1\n\nPolicy signed edit\n"}, "skip_signing": {"true"}})
+		assert.True(t, isHeadSigned(t))
+	})
+
+	// The parent commit is signed, so this policy signs unless the doer opts out
+	defer test.MockVariableValue(&setting.Repository.Signing.CRUDActions, []string{"parentsigned"})()
+
+	t.Run("RequiredByBranch", func(t *testing.T) {
+		protectedBranch := &git_model.ProtectedBranch{
+			RepoID:               repo.ID,
+			RuleName:             repo.DefaultBranch,
+			CanPush:              true,
+			RequireSignedCommits: true,
+		}
+		require.NoError(t, git_model.UpdateProtectBranch(t.Context(), repo, protectedBranch, git_model.WhitelistOptions{}))
+		defer func() {
+			require.NoError(t, git_model.DeleteProtectedBranch(t.Context(), repo, protectedBranch.ID))
+		}()
+
+		postEdit(t, url.Values{"content": {"# repo1\n\nRequired signed edit\n"}, "skip_signing": {"true"}})
+		assert.True(t, isHeadSigned(t))
+	})
+
+	t.Run("OptedOut", func(t *testing.T) {
+		postEdit(t, url.Values{"content": {"# repo1\n\nUnsigned edit\n"}, "skip_signing": {"true"}})
+		assert.False(t, isHeadSigned(t))
+	})
+}
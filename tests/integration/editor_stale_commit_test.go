@@ -0,0 +1,82 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"path"
+	"testing"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEditorStaleLastCommit tests that submitting an edit of a file that was changed since the
+// editor was opened fails with a prompt to reload the editor
+func TestEditorStaleLastCommit(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	editURL := "/" + path.Join(owner.Name, repo.Name, "_edit", repo.DefaultBranch, "README.md")
+
+	// openEditor returns the form the editor would submit, with the last commit when it was opened
+	openEditor := func(t *testing.T, session *TestSession, query, content string) map[string]string {
+		resp := session.MakeRequest(t, NewRequest(t, "GET", editURL+query), http.StatusOK)
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		lastCommit := htmlDoc.GetInputValueByName("last_commit")
+		require.NotEmpty(t, lastCommit)
+		return map[string]string{
+			"_csrf":         htmlDoc.GetCSRF(),
+			"last_commit":   lastCommit,
+			"tree_path":     "README.md",
+			"content":       content,
+			"commit_choice": "direct",
+		}
+	}
+
+	assertStale := func(t *testing.T, session *TestSession, form map[string]string) {
+		resp := session.MakeRequest(t, NewRequestWithValues(t, "POST", editURL, form), http.StatusBadRequest)
+		errorMessage := test.ParseJSONError(resp.Body.Bytes()).ErrorMessage
+		assert.Contains(t, errorMessage, "was changed by someone else since you opened the editor")
+		assert.Contains(t, errorMessage, `href="`+editURL)
+	}
+
+	t.Run("DirectEdit", func(t *testing.T) {
+		session := loginUser(t, owner.Name)
+		form := openEditor(t, session, "", "# repo1\n\nMy edit\n")
+
+		require.NoError(t, createOrReplaceFileInBranch(owner, repo, "README.md", repo.DefaultBranch, "# repo1\n\nAnother edit\n"))
+
+		assertStale(t, session, form)
+		resp := MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/raw/branch/master/README.md"), http.StatusOK)
+		assert.Equal(t, "# repo1\n\nAnother edit\n", resp.Body.String())
+	})
+
+	t.Run("OtherFileChanged", func(t *testing.T) {
+		session := loginUser(t, owner.Name)
+		form := openEditor(t, session, "", "# repo1\n\nMy edit\n")
+
+		require.NoError(t, createOrReplaceFileInBranch(owner, repo, "other.md", repo.DefaultBranch, "other\n"))
+
+		resp := session.MakeRequest(t, NewRequestWithValues(t, "POST", editURL, form), http.StatusOK)
+		assert.NotEmpty(t, test.RedirectURL(resp))
+	})
+
+	t.Run("ChangeRequest", func(t *testing.T) {
+		session := loginUser(t, "user4")
+		form := openEditor(t, session, "?submit_change_request=true", "# repo1\n\nMy change request\n")
+		form["submit_change_request"] = "true"
+
+		require.NoError(t, createOrReplaceFileInBranch(owner, repo, "README.md", repo.DefaultBranch, "# repo1\n\nYet another edit\n"))
+
+		assertStale(t, session, form)
+	})
+}
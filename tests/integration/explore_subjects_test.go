@@ -5,9 +5,13 @@ package integration
 
 import (
 	"net/http"
+	"net/url"
 	"testing"
+	"time"
 
 	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/tests"
 
 	"github.com/stretchr/testify/assert"
@@ -46,6 +50,37 @@ func TestExploreSubjects(t *testing.T) {
 	req = NewRequest(t, "GET", "/explore/articles?page=1")
 	resp = MakeRequest(t, req, http.StatusOK)
 	assert.Equal(t, http.StatusOK, resp.Code)
+
+	// Test minimum repository count filter: the new subjects have no repositories
+	req = NewRequest(t, "GET", "/explore/subjects")
+	resp = MakeRequest(t, req, http.StatusOK)
+	assert.Contains(t, resp.Body.String(), subject1.Name)
+
+	req = NewRequest(t, "GET", "/explore/subjects?min_repos=1")
+	resp = MakeRequest(t, req, http.StatusOK)
+	respStr = resp.Body.String()
+	assert.NotContains(t, respStr, subject1.Name)
+	assert.NotContains(t, respStr, subject2.Name)
+	assert.Contains(t, respStr, `name="min_repos" value="1"`)
+}
+
+func TestExploreSubjectsHasForks(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	// Subject 1 has a root article and a fork, subject 2 a single article
+	fork := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 11})
+	fork.SubjectID = 1
+	assert.NoError(t, repo_model.UpdateRepositoryColsNoAutoTime(t.Context(), fork, "subject_id"))
+	single := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 4})
+	single.SubjectID = 2
+	assert.NoError(t, repo_model.UpdateRepositoryColsNoAutoTime(t.Context(), single, "subject_id"))
+
+	req := NewRequest(t, "GET", "/explore/subjects?has_forks=true")
+	resp := MakeRequest(t, req, http.StatusOK)
+	respStr := resp.Body.String()
+	assert.Contains(t, respStr, "example-subject")
+	assert.NotContains(t, respStr, "another-subject")
+	assert.Contains(t, respStr, `name="has_forks" checked value="true"`)
 }
 
 func TestExploreSubjectsSorting(t *testing.T) {
@@ -67,3 +102,73 @@ func TestExploreSubjectsSorting(t *testing.T) {
 		assert.Equal(t, http.StatusOK, resp.Code, "Sort type %s should work", sortType)
 	}
 }
+
+func TestPushTouchesSubject(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		// repo1 belongs to subject 1, whose fixture timestamp is far in the past
+		before := unittest.AssertExistsAndLoadBean(t, &repo_model.Subject{ID: 1})
+
+		dstPath := t.TempDir()
+		u.Path = "user2/repo1.git"
+		u.User = url.UserPassword("user2", userPassword)
+		t.Run("Clone", doGitClone(dstPath, u))
+		doCommitAndPush(t, 64, dstPath, "subject-activity-")
+
+		// the pushed refs are processed by the push queue
+		assert.Eventually(t, func() bool {
+			after := unittest.AssertExistsAndLoadBean(t, &repo_model.Subject{ID: 1})
+			return after.UpdatedUnix > before.UpdatedUnix
+		}, 10*time.Second, 100*time.Millisecond)
+	})
+}
+
+func TestOpenSearch(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	req := NewRequest(t, "GET", "/opensearch.xml")
+	resp := MakeRequest(t, req, http.StatusOK)
+	assert.Contains(t, resp.Header().Get("Content-Type"), "application/opensearchdescription+xml")
+	body := resp.Body.String()
+	assert.Contains(t, body, `template="`+setting.AppURL+`explore/subjects?q={searchTerms}"`)
+	assert.Contains(t, body, `template="`+setting.AppURL+`explore/subjects/search?q={searchTerms}"`)
+
+	// Pages advertise the description document
+	req = NewRequest(t, "GET", "/explore/subjects")
+	resp = MakeRequest(t, req, http.StatusOK)
+	assert.Contains(t, resp.Body.String(), `href="`+setting.AppSubURL+`/opensearch.xml"`)
+
+	// Suggestions are returned in the OpenSearch suggestions format
+	req = NewRequest(t, "GET", "/explore/subjects/search?q=subject")
+	resp = MakeRequest(t, req, http.StatusOK)
+	var suggestions []any
+	DecodeJSON(t, resp, &suggestions)
+	if assert.Len(t, suggestions, 2) {
+		assert.Equal(t, "subject", suggestions[0])
+		assert.ElementsMatch(t, []any{"another-subject", "example-subject"}, suggestions[1])
+	}
+
+	req = NewRequest(t, "GET", "/explore/subjects/search?q=")
+	resp = MakeRequest(t, req, http.StatusOK)
+	DecodeJSON(t, resp, &suggestions)
+	assert.Equal(t, []any{"", []any{}}, suggestions)
+}
+
+func TestExploreSubjectsSitemap(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	// The sitemap index links to the pages of the subjects sitemap
+	req := NewRequest(t, "GET", "/sitemap.xml")
+	resp := MakeRequest(t, req, http.StatusOK)
+	assert.Contains(t, resp.Body.String(), "<loc>"+setting.AppURL+"explore/subjects/sitemap-1.xml</loc>")
+
+	// Only the subjects with a public non-empty repository are listed, another-subject has none
+	req = NewRequest(t, "GET", "/explore/subjects/sitemap-1.xml")
+	resp = MakeRequest(t, req, http.StatusOK)
+	respStr := resp.Body.String()
+	assert.Contains(t, respStr, "<loc>"+setting.AppURL+"subject/example-subject</loc>")
+	assert.NotContains(t, respStr, "another-subject")
+
+	req = NewRequest(t, "GET", "/explore/subjects/sitemap-2.xml")
+	resp = MakeRequest(t, req, http.StatusOK)
+	assert.NotContains(t, resp.Body.String(), "<loc>")
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	repo_service "code.gitea.io/gitea/services/repository"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAbandonFork tests that the owner of an article fork can detach or delete it
+func TestAbandonFork(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})       // owner of repo1
+	user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})       // forks repo1
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1}) // root article of subject 1
+	require.NoError(t, repo1.LoadSubject(t.Context()))
+	subjectName := repo1.SubjectRelation.Name
+
+	forkRepo1 := func(t *testing.T) *repo_model.Repository {
+		fork, err := repo_service.ForkRepository(t.Context(), user4, user4, repo_service.ForkRepoOptions{
+			BaseRepo: repo1,
+			Name:     "abandon-fork",
+		})
+		require.NoError(t, err)
+		require.True(t, fork.IsFork)
+		require.Equal(t, repo1.SubjectID, fork.SubjectID)
+		return fork
+	}
+
+	abandonLink := fmt.Sprintf("/article/%s/%s/abandon", user4.Name, subjectName)
+
+	t.Run("NonOwnerGetsNotFound", func(t *testing.T) {
+		fork := forkRepo1(t)
+		defer func() {
+			require.NoError(t, repo_service.DeleteRepositoryDirectly(t.Context(), fork.ID))
+		}()
+
+		session := loginUser(t, user2.Name)
+		req := NewRequestWithValues(t, "POST", abandonLink, map[string]string{
+			"_csrf":  GetUserCSRFToken(t, session),
+			"action": "delete",
+		})
+		session.MakeRequest(t, req, http.StatusNotFound)
+		unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: fork.ID})
+	})
+
+	t.Run("Detach", func(t *testing.T) {
+		fork := forkRepo1(t)
+		defer func() {
+			require.NoError(t, repo_service.DeleteRepositoryDirectly(t.Context(), fork.ID))
+		}()
+
+		session := loginUser(t, user4.Name)
+		req := NewRequestWithValues(t, "POST", abandonLink, map[string]string{
+			"_csrf":         GetUserCSRFToken(t, session),
+			"action":        "detach",
+			"leave_subject": "true",
+		})
+		session.MakeRequest(t, req, http.StatusOK)
+
+		fork = unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: fork.ID})
+		assert.False(t, fork.IsFork)
+		assert.Zero(t, fork.ForkID)
+		assert.Zero(t, fork.SubjectID)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		fork := forkRepo1(t)
+
+		session := loginUser(t, user4.Name)
+		req := NewRequestWithValues(t, "POST", abandonLink, map[string]string{
+			"_csrf":  GetUserCSRFToken(t, session),
+			"action": "delete",
+		})
+		session.MakeRequest(t, req, http.StatusOK)
+
+		unittest.AssertNotExistsBean(t, &repo_model.Repository{ID: fork.ID})
+	})
+
+	t.Run("BlockedByOpenChangeRequests", func(t *testing.T) {
+		fork := forkRepo1(t)
+		defer func() {
+			require.NoError(t, repo_service.DeleteRepositoryDirectly(t.Context(), fork.ID))
+		}()
+
+		// someone else has proposed a change to the fork
+		require.NoError(t, db.Insert(t.Context(), &issues_model.Issue{
+			RepoID:   fork.ID,
+			Index:    1,
+			PosterID: user2.ID,
+			Title:    "change request against the fork",
+			IsPull:   true,
+		}))
+
+		session := loginUser(t, user4.Name)
+		for _, action := range []string{"detach", "delete"} {
+			req := NewRequestWithValues(t, "POST", abandonLink, map[string]string{
+				"_csrf":  GetUserCSRFToken(t, session),
+				"action": action,
+			})
+			session.MakeRequest(t, req, http.StatusOK)
+
+			fork = unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: fork.ID})
+			assert.True(t, fork.IsFork)
+			assert.Equal(t, repo1.SubjectID, fork.SubjectID)
+		}
+	})
+}
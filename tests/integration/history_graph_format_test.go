@@ -0,0 +1,84 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"testing"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	repo_service "code.gitea.io/gitea/services/repository"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRepoHistoryGraphFormats tests requesting the fork graph from the history view
+func TestRepoHistoryGraphFormats(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	require.NoError(t, repo1.LoadSubject(t.Context()))
+	historyLink := "/subject/" + url.PathEscape(repo1.SubjectRelation.Name)
+
+	t.Run("HTMLByDefault", func(t *testing.T) {
+		req := NewRequest(t, "GET", historyLink)
+		req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Header().Get("Content-Type"), "text/html")
+		assert.Contains(t, resp.Header().Values("Vary"), "Accept")
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		resp := MakeRequest(t, NewRequest(t, "GET", historyLink+"?format=json"), http.StatusOK)
+		var graph repo_service.ForkGraphResponse
+		DecodeJSON(t, resp, &graph)
+		require.NotNil(t, graph.Root)
+		assert.Equal(t, "repo_1", graph.Root.ID)
+		assert.Equal(t, "user2/repo1", graph.Root.Repository.FullName)
+	})
+
+	t.Run("DOT", func(t *testing.T) {
+		req := NewRequest(t, "GET", historyLink)
+		req.Header.Set("Accept", "text/vnd.graphviz")
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Header().Get("Content-Type"), "text/vnd.graphviz")
+		assert.Contains(t, resp.Header().Values("Vary"), "Accept")
+		assert.Contains(t, resp.Body.String(), "digraph forks {")
+		assert.Contains(t, resp.Body.String(), `"repo_1" [label="user2/repo1", repo_id=1, level=0];`)
+	})
+
+	t.Run("GraphML", func(t *testing.T) {
+		resp := MakeRequest(t, NewRequest(t, "GET", historyLink+"?format=graphml"), http.StatusOK)
+		assert.Contains(t, resp.Header().Get("Content-Type"), "application/graphml+xml")
+		var doc struct {
+			Nodes []struct {
+				ID string `xml:"id,attr"`
+			} `xml:"graph>node"`
+		}
+		require.NoError(t, xml.Unmarshal(resp.Body.Bytes(), &doc))
+		require.NotEmpty(t, doc.Nodes)
+		assert.Equal(t, "repo_1", doc.Nodes[0].ID)
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		MakeRequest(t, NewRequest(t, "GET", historyLink+"?format=png"), http.StatusNotAcceptable)
+	})
+
+	t.Run("UnsupportedAccept", func(t *testing.T) {
+		req := NewRequest(t, "GET", historyLink)
+		req.Header.Set("Accept", "image/png")
+		MakeRequest(t, req, http.StatusNotAcceptable)
+
+		// Wildcards still get the HTML page
+		req = NewRequest(t, "GET", historyLink)
+		req.Header.Set("Accept", "image/png, */*;q=0.1")
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Header().Get("Content-Type"), "text/html")
+	})
+}
@@ -0,0 +1,54 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	repo_service "code.gitea.io/gitea/services/repository"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHistoryTableCommitsSinceFork tests that the history table counts the README commits of each fork since it was forked
+func TestHistoryTableCommitsSinceFork(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	fork, err := repo_service.ForkRepository(t.Context(), user4, user4, repo_service.ForkRepoOptions{
+		BaseRepo: repo1,
+		Name:     "commits-fork",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, createOrReplaceFileInBranch(user4, fork, "README.md", fork.DefaultBranch, "# The fork's version\n"))
+	require.NoError(t, createOrReplaceFileInBranch(user4, fork, "README.md", fork.DefaultBranch, "# The fork's version, again\n"))
+
+	resp := MakeRequest(t, NewRequest(t, "GET", "/article/repo/user2/repo1?view=table"), http.StatusOK)
+	htmlDoc := NewHTMLParser(t, resp.Body)
+
+	// The root counts all the commits to its README
+	root := htmlDoc.Find(`.article-row[data-repo="repo1"]`)
+	require.Equal(t, 1, root.Length())
+	rootCommits, _ := root.Attr("data-commits")
+	count, err := strconv.Atoi(rootCommits)
+	require.NoError(t, err)
+	assert.Positive(t, count)
+
+	// The fork only counts its own edits, not the history it was forked with
+	row := htmlDoc.Find(`.article-row[data-repo="commits-fork"]`)
+	require.Equal(t, 1, row.Length())
+	forkCommits, _ := row.Attr("data-commits")
+	assert.Equal(t, "2", forkCommits)
+	assert.Equal(t, 1, row.Find(".history-table-commits").Length())
+}
@@ -0,0 +1,54 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	repo_service "code.gitea.io/gitea/services/repository"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHistoryTableDivergence tests that the history table shows how far each fork diverged from the root
+func TestHistoryTableDivergence(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	fork, err := repo_service.ForkRepository(t.Context(), user4, user4, repo_service.ForkRepoOptions{
+		BaseRepo: repo1,
+		Name:     "divergence-fork",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, createOrReplaceFileInBranch(user4, fork, "README.md", fork.DefaultBranch, "# The fork's version\n"))
+	require.NoError(t, createOrReplaceFileInBranch(user2, repo1, "README.md", repo1.DefaultBranch, "# The original's version\n"))
+	require.NoError(t, createOrReplaceFileInBranch(user2, repo1, "README.md", repo1.DefaultBranch, "# The original's version, again\n"))
+
+	resp := MakeRequest(t, NewRequest(t, "GET", "/article/repo/user2/repo1?view=table"), http.StatusOK)
+	htmlDoc := NewHTMLParser(t, resp.Body)
+
+	// The root has nothing to diverge from
+	root := htmlDoc.Find(`.article-row[data-repo="repo1"]`)
+	require.Equal(t, 1, root.Length())
+	_, hasAhead := root.Attr("data-ahead")
+	assert.False(t, hasAhead)
+
+	row := htmlDoc.Find(`.article-row[data-repo="divergence-fork"]`)
+	require.Equal(t, 1, row.Length())
+	ahead, _ := row.Attr("data-ahead")
+	behind, _ := row.Attr("data-behind")
+	assert.Equal(t, "1", ahead)
+	assert.Equal(t, "2", behind)
+	assert.False(t, row.HasClass("tw-opacity-60"))
+}
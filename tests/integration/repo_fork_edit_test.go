@@ -4,6 +4,7 @@
 package integration
 
 import (
+	"fmt"
 	"net/http"
 	"path"
 	"testing"
@@ -11,6 +12,8 @@ import (
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
 	repo_service "code.gitea.io/gitea/services/repository"
 	"code.gitea.io/gitea/tests"
 
@@ -563,3 +566,60 @@ func TestSubmitChangeRequestMiddlewareBypass(t *testing.T) {
 			"submit_change_request=true should NOT bypass CanWriteToBranch for _new action")
 	})
 }
+
+// TestForkAndEditDisabled tests that with fork-on-edit disabled, non-owners can't fork
+// an article when editing it but can still submit change requests
+func TestForkAndEditDisabled(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	defer test.MockVariableValue(&setting.Repository.DisableForkOnEdit, true)()
+
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	nonOwner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	require.NoError(t, repo.LoadSubject(t.Context()))
+
+	sessionNonOwner := loginUser(t, nonOwner.Name)
+
+	t.Run("ArticleOffersOnlyChangeRequest", func(t *testing.T) {
+		articleURL := fmt.Sprintf("/article/%s/%s?mode=edit", owner.Name, repo.SubjectRelation.Name)
+		resp := sessionNonOwner.MakeRequest(t, NewRequest(t, "GET", articleURL), http.StatusOK)
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		AssertHTMLElement(t, htmlDoc, "#fork-article-button", false)
+		AssertHTMLElement(t, htmlDoc, "#pre-submit-changes-button", true)
+	})
+
+	editURL := path.Join(owner.Name, repo.Name, "_edit", repo.DefaultBranch, "README.md")
+
+	t.Run("ForkAndEditIsRejected", func(t *testing.T) {
+		resp := sessionNonOwner.MakeRequest(t, NewRequest(t, "GET", editURL), http.StatusOK)
+		htmlDoc := NewHTMLParser(t, resp.Body)
+
+		req := NewRequestWithValues(t, "POST", editURL, map[string]string{
+			"_csrf":         htmlDoc.GetCSRF(),
+			"last_commit":   htmlDoc.GetInputValueByName("last_commit"),
+			"tree_path":     "README.md",
+			"content":       "Test content with fork_and_edit",
+			"commit_choice": "direct",
+			"fork_and_edit": "true",
+		})
+		sessionNonOwner.MakeRequest(t, req, http.StatusBadRequest)
+
+		unittest.AssertNotExistsBean(t, &repo_model.Repository{OwnerID: nonOwner.ID, ForkID: repo.ID})
+	})
+
+	t.Run("ChangeRequestStillWorks", func(t *testing.T) {
+		resp := sessionNonOwner.MakeRequest(t, NewRequest(t, "GET", editURL+"?submit_change_request=true"), http.StatusOK)
+		htmlDoc := NewHTMLParser(t, resp.Body)
+
+		req := NewRequestWithValues(t, "POST", editURL, map[string]string{
+			"_csrf":                 htmlDoc.GetCSRF(),
+			"last_commit":           htmlDoc.GetInputValueByName("last_commit"),
+			"tree_path":             "README.md",
+			"content":               "# Updated while fork-on-edit is disabled\n",
+			"commit_choice":         "direct",
+			"submit_change_request": "true",
+		})
+		resp = sessionNonOwner.MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, test.RedirectURL(resp), "/pulls/")
+	})
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 okTurtles Foundation. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	repo_service "code.gitea.io/gitea/services/repository"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSubjectArticleSearch tests searching the text of all articles of a subject
+func TestSubjectArticleSearch(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	// The repo indexer updates asynchronously, search with git grep
+	defer test.MockVariableValue(&setting.Indexer.RepoIndexerEnabled, false)()
+
+	user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	require.NoError(t, repo1.LoadSubject(t.Context()))
+
+	fork, err := repo_service.ForkRepository(t.Context(), user4, user4, repo_service.ForkRepoOptions{
+		BaseRepo: repo1,
+		Name:     "search-fork",
+	})
+	require.NoError(t, err)
+	require.NoError(t, createOrReplaceFileInBranch(user4, fork, "README.md", fork.DefaultBranch, "# repo1\n\nThe quasar viewpoint\n"))
+
+	searchLink := "/subject/" + url.PathEscape(repo1.SubjectRelation.Name) + "/search?q="
+
+	t.Run("SearchBox", func(t *testing.T) {
+		resp := MakeRequest(t, NewRequest(t, "GET", "/subject/"+url.PathEscape(repo1.SubjectRelation.Name)), http.StatusOK)
+		AssertHTMLElement(t, NewHTMLParser(t, resp.Body), "#subject-article-search", true)
+	})
+
+	t.Run("OnlyMatchingFork", func(t *testing.T) {
+		resp := MakeRequest(t, NewRequest(t, "GET", searchLink+"Quasar"), http.StatusOK)
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		results := htmlDoc.Find("#subject-search-results .item")
+		require.Equal(t, 1, results.Length())
+		assert.Contains(t, results.Find("a").Text(), "user4/search-fork")
+		assert.Contains(t, results.Find(".subject-search-snippet").Text(), "The quasar viewpoint")
+		AssertHTMLElement(t, htmlDoc, "#subject-search-truncated", false)
+	})
+
+	t.Run("AllArticles", func(t *testing.T) {
+		resp := MakeRequest(t, NewRequest(t, "GET", searchLink+"repo1"), http.StatusOK)
+		assert.Equal(t, 2, NewHTMLParser(t, resp.Body).Find("#subject-search-results .item").Length())
+	})
+
+	t.Run("NoResults", func(t *testing.T) {
+		resp := MakeRequest(t, NewRequest(t, "GET", searchLink+"nebula"), http.StatusOK)
+		AssertHTMLElement(t, NewHTMLParser(t, resp.Body), "#subject-search-empty", true)
+	})
+}
+
+// TestSubjectArticleSearchIndexer tests that text pushed to an article becomes searchable
+// through the repo indexer
+func TestSubjectArticleSearchIndexer(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	require.True(t, setting.Indexer.RepoIndexerEnabled)
+
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	require.NoError(t, repo1.LoadSubject(t.Context()))
+
+	// The push hooks queue repo1 for reindexing
+	require.NoError(t, createOrReplaceFileInBranch(user2, repo1, "README.md", repo1.DefaultBranch, "# repo1\n\nThe pulsar lighthouse\n"))
+
+	searchLink := "/subject/" + url.PathEscape(repo1.SubjectRelation.Name) + "/search?q=pulsar"
+	assert.Eventually(t, func() bool {
+		resp := MakeRequest(t, NewRequest(t, "GET", searchLink), http.StatusOK)
+		results := NewHTMLParser(t, resp.Body).Find("#subject-search-results .item")
+		return results.Length() == 1 && strings.Contains(results.Find("a").Text(), "user2/repo1")
+	}, 10*time.Second, 100*time.Millisecond)
+}
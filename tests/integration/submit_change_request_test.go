@@ -7,6 +7,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"path"
 	"slices"
@@ -22,7 +23,9 @@ import (
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/test"
+	repo_service "code.gitea.io/gitea/services/repository"
 	"code.gitea.io/gitea/tests"
 
 	"github.com/stretchr/testify/assert"
@@ -237,6 +240,82 @@ func TestSubmitChangeRequestErrorCases(t *testing.T) {
 	})
 }
 
+// TestSubmitChangeRequestToSpecificFork tests that a change request can be routed to a
+// fork of the same subject via target_repo_id instead of the repository being viewed.
+func TestSubmitChangeRequestToSpecificFork(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	// user2 owns repo1 (subject_id: 1); user5 forks it; user4 submits to user5's fork
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	forkOwner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+	nonOwner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	fork, err := repo_service.ForkRepository(t.Context(), forkOwner, forkOwner, repo_service.ForkRepoOptions{
+		BaseRepo: repo,
+		Name:     "repo1-viewpoint",
+	})
+	require.NoError(t, err)
+	require.Equal(t, repo.SubjectID, fork.SubjectID)
+
+	sessionNonOwner := loginUser(t, nonOwner.Name)
+	editURL := path.Join(owner.Name, repo.Name, "_edit", repo.DefaultBranch, "README.md")
+
+	submit := func(t *testing.T, targetRepoID int64) *httptest.ResponseRecorder {
+		req := NewRequest(t, "GET", editURL+"?submit_change_request=true")
+		resp := sessionNonOwner.MakeRequest(t, req, http.StatusOK)
+		htmlDoc := NewHTMLParser(t, resp.Body)
+
+		form := map[string]string{
+			"_csrf":                 htmlDoc.GetCSRF(),
+			"last_commit":           htmlDoc.GetInputValueByName("last_commit"),
+			"tree_path":             "README.md",
+			"content":               "# Proposed for a specific viewpoint\n",
+			"commit_choice":         "direct",
+			"submit_change_request": "true",
+			"target_repo_id":        strconv.FormatInt(targetRepoID, 10),
+		}
+		req = NewRequestWithValues(t, "POST", editURL, form)
+		return sessionNonOwner.MakeRequest(t, req, NoExpectedStatus)
+	}
+
+	t.Run("RoutesToTargetFork", func(t *testing.T) {
+		resp := submit(t, fork.ID)
+		require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+
+		redirectURL := test.RedirectURL(resp)
+		assert.Contains(t, redirectURL, fork.Link()+"/pulls/")
+
+		parts := strings.Split(redirectURL, "/pulls/")
+		require.Len(t, parts, 2)
+		prIndex, err := strconv.ParseInt(strings.TrimSuffix(parts[1], "/"), 10, 64)
+		require.NoError(t, err)
+
+		// Same-repo CR inside the fork, nothing created in the viewed repo
+		pr, err := issues_model.GetPullRequestByIndex(t.Context(), fork.ID, prIndex)
+		require.NoError(t, err)
+		assert.Equal(t, fork.ID, pr.HeadRepoID)
+		assert.Equal(t, fork.ID, pr.BaseRepoID)
+		assert.Equal(t, fork.DefaultBranch, pr.BaseBranch)
+		assert.Contains(t, pr.HeadBranch, nonOwner.LowerName+"-patch-")
+		exist, err := git_model.IsBranchExist(t.Context(), fork.ID, pr.HeadBranch)
+		require.NoError(t, err)
+		assert.True(t, exist, "patch branch should live in the target fork")
+	})
+
+	t.Run("RejectsRepoOfOtherSubject", func(t *testing.T) {
+		// repo2 has no subject, so it can never be a target for repo1's subject
+		other := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 2})
+		resp := submit(t, other.ID)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+
+	t.Run("RejectsMissingRepo", func(t *testing.T) {
+		resp := submit(t, 999999)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+}
+
 // TestSubmitChangeRequestWhitespaceOnlyContent tests that submitting content containing only
 // whitespace characters is rejected with HTTP 400 Bad Request (SCR-001 fix verification).
 func TestSubmitChangeRequestWhitespaceOnlyContent(t *testing.T) {
@@ -542,7 +621,7 @@ func TestArticleIssueTitleUpdate(t *testing.T) {
 
 // TestSubmitChangeRequestConcurrentBranchCollision tests that concurrent change request
 // submissions from multiple users correctly generate unique branch names without collisions.
-// This verifies that getUniquePatchBranchName() handles the scenario where multiple users
+// This verifies that repo_service.GetUniquePatchBranchName() handles the scenario where multiple users
 // simultaneously submit change requests that would generate similar branch name patterns.
 // Note: The submit-change-request workflow creates branches directly in the target repository
 // and creates same-repo PRs (head and base both point to the target repository).
@@ -1248,3 +1327,143 @@ func TestSubmitConflictResolutionAuth(t *testing.T) {
 		assert.Contains(t, resp.Body.String(), "pull request has no conflicts")
 	})
 }
+
+// TestOwnerEditChangeRequestThenMerge verifies that the target repository owner
+// can revise a same-repo change request's content before merging it, that other
+// users cannot, and that the merged result contains the owner's revision.
+func TestOwnerEditChangeRequestThenMerge(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		nonOwner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+		repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+		sessionOwner := loginUser(t, owner.Name)
+		sessionNonOwner := loginUser(t, nonOwner.Name)
+
+		// Non-owner submits a change request
+		editURL := path.Join(owner.Name, repo.Name, "_edit", repo.DefaultBranch, "README.md")
+		req := NewRequest(t, "GET", editURL+"?submit_change_request=true")
+		resp := sessionNonOwner.MakeRequest(t, req, http.StatusOK)
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		req = NewRequestWithValues(t, "POST", editURL+"?submit_change_request=true", map[string]string{
+			"_csrf":                 htmlDoc.GetCSRF(),
+			"last_commit":           htmlDoc.GetInputValueByName("last_commit"),
+			"tree_path":             "README.md",
+			"content":               "# Proposed\n\nTeh contributor's wording.\n",
+			"commit_choice":         "direct",
+			"submit_change_request": "true",
+		})
+		resp = sessionNonOwner.MakeRequest(t, req, http.StatusOK)
+
+		parts := strings.Split(test.RedirectURL(resp), "/pulls/")
+		require.Len(t, parts, 2)
+		prIndex, err := strconv.ParseInt(strings.TrimSuffix(parts[1], "/"), 10, 64)
+		require.NoError(t, err)
+		pr, err := issues_model.GetPullRequestByIndex(t.Context(), repo.ID, prIndex)
+		require.NoError(t, err)
+
+		gitRepo, err := gitrepo.OpenRepository(t.Context(), repo)
+		require.NoError(t, err)
+		defer gitRepo.Close()
+		firstCommitSHA, err := gitRepo.GetBranchCommitID(pr.HeadBranch)
+		require.NoError(t, err)
+
+		ownerEditURL := path.Join(owner.Name, repo.Name, "pulls", strconv.FormatInt(prIndex, 10), "owner_edit")
+
+		t.Run("NonOwnerCannotOwnerEdit", func(t *testing.T) {
+			req := NewRequest(t, "GET", ownerEditURL)
+			sessionNonOwner.MakeRequest(t, req, http.StatusNotFound)
+		})
+
+		// Owner fixes the wording on the patch branch
+		req = NewRequest(t, "GET", ownerEditURL)
+		resp = sessionOwner.MakeRequest(t, req, http.StatusOK)
+		htmlDoc = NewHTMLParser(t, resp.Body)
+		assert.Equal(t, firstCommitSHA, htmlDoc.GetInputValueByName("last_commit"))
+
+		revised := "# Proposed\n\nThe contributor's wording.\n"
+		req = NewRequestWithValues(t, "POST", ownerEditURL, map[string]string{
+			"_csrf":          htmlDoc.GetCSRF(),
+			"last_commit":    firstCommitSHA,
+			"content":        revised,
+			"commit_summary": "Fix typo",
+		})
+		sessionOwner.MakeRequest(t, req, http.StatusOK)
+
+		headSHA, err := gitRepo.GetBranchCommitID(pr.HeadBranch)
+		require.NoError(t, err)
+		assert.NotEqual(t, firstCommitSHA, headSHA, "owner edit should add a commit to the patch branch")
+		pullRefSHA, err := gitRepo.GetRefCommitID(pr.GetGitHeadRefName())
+		require.NoError(t, err)
+		assert.Equal(t, headSHA, pullRefSHA, "PR head ref should follow the owner's commit")
+
+		// Merge and check the owner's revision landed on the default branch
+		req = NewRequest(t, "GET", path.Join(owner.Name, repo.Name, "pulls", strconv.FormatInt(prIndex, 10)))
+		resp = sessionOwner.MakeRequest(t, req, http.StatusOK)
+		htmlDoc = NewHTMLParser(t, resp.Body)
+		req = NewRequestWithValues(t, "POST", path.Join(owner.Name, repo.Name, "pulls", strconv.FormatInt(prIndex, 10), "merge"), map[string]string{
+			"_csrf": htmlDoc.GetCSRF(),
+			"do":    string(repo_model.MergeStyleMerge),
+		})
+		sessionOwner.MakeRequest(t, req, http.StatusOK)
+
+		pr = unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: pr.ID})
+		assert.True(t, pr.HasMerged)
+
+		commit, err := gitRepo.GetBranchCommit(repo.DefaultBranch)
+		require.NoError(t, err)
+		content, err := commit.GetFileContent("README.md", 1024)
+		require.NoError(t, err)
+		assert.Equal(t, revised, content)
+
+		t.Run("NoOwnerEditAfterMerge", func(t *testing.T) {
+			req := NewRequest(t, "GET", ownerEditURL)
+			sessionOwner.MakeRequest(t, req, http.StatusNotFound)
+		})
+	})
+}
+
+// TestSubmitChangeRequestRateLimit verifies that a user cannot open more change requests
+// against the same article than CHANGE_REQUEST_RATE_LIMIT allows within the window.
+func TestSubmitChangeRequestRateLimit(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	defer test.MockVariableValue(&setting.Repository.PullRequest.ChangeRequestRateLimit, 2)()
+
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	nonOwner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	sessionNonOwner := loginUser(t, nonOwner.Name)
+	editURL := path.Join(owner.Name, repo.Name, "_edit", repo.DefaultBranch, "README.md")
+
+	submit := func(t *testing.T, content string, expectedStatus int) *httptest.ResponseRecorder {
+		req := NewRequest(t, "GET", editURL+"?submit_change_request=true")
+		resp := sessionNonOwner.MakeRequest(t, req, http.StatusOK)
+		htmlDoc := NewHTMLParser(t, resp.Body)
+
+		req = NewRequestWithValues(t, "POST", editURL+"?submit_change_request=true", map[string]string{
+			"_csrf":                 htmlDoc.GetCSRF(),
+			"last_commit":           htmlDoc.GetInputValueByName("last_commit"),
+			"tree_path":             "README.md",
+			"content":               content,
+			"commit_choice":         "direct",
+			"submit_change_request": "true",
+		})
+		return sessionNonOwner.MakeRequest(t, req, expectedStatus)
+	}
+
+	submit(t, "# Rate limit 1\n", http.StatusOK)
+	submit(t, "# Rate limit 2\n", http.StatusOK)
+
+	resp := submit(t, "# Rate limit 3\n", http.StatusBadRequest)
+	assert.Contains(t, resp.Body.String(), "too many change requests")
+
+	count, err := issues_model.CountOpenPullRequestsByPosterSince(t.Context(), repo.ID, nonOwner.ID, 0)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, count)
+
+	t.Run("DisabledWhenZero", func(t *testing.T) {
+		defer test.MockVariableValue(&setting.Repository.PullRequest.ChangeRequestRateLimit, 0)()
+		submit(t, "# Rate limit disabled\n", http.StatusOK)
+	})
+}
@@ -1,5 +1,7 @@
 import {createToastEditor} from './toast-editor.ts';
+import type {UploadedImage} from './toast-editor.ts';
 import {submitFormFetchAction} from './common-fetch-action.ts';
+import {POST} from '../modules/fetch.ts';
 import {fomanticQuery} from '../modules/fomantic/base.ts';
 import {createElementFromHTML} from '../utils/dom.ts';
 import {svg} from '../svg.ts';
@@ -54,6 +56,32 @@ function showForkConfirmModal(title: string, body: string, body2: string, confir
   });
 }
 
+// Create the image uploader of the editor. Images are committed where the edit will end up:
+// users who can edit directly commit to the article, other users commit to a patch branch
+// that the change request (or, when forking instead, the fork) picks up on submit.
+function createImageUploader(editForm: HTMLFormElement): ((blob: Blob) => Promise<UploadedImage>) | undefined {
+  const uploadUrl = editForm.getAttribute('data-image-upload-url');
+  if (!uploadUrl) return undefined;
+
+  const canEditDirectly = editForm.getAttribute('data-can-edit-directly') === 'true';
+  const patchBranchField = editForm.querySelector<HTMLInputElement>('#patch_branch');
+  const targetRepoField = editForm.querySelector<HTMLInputElement>('#target_repo_id');
+  return async (blob: Blob): Promise<UploadedImage> => {
+    const formData = new FormData();
+    formData.append('file', blob, (blob as File).name || 'image');
+    if (!canEditDirectly) {
+      formData.append('submit_change_request', 'true');
+      formData.append('target_repo_id', targetRepoField?.value || '');
+      formData.append('patch_branch', patchBranchField?.value || '');
+    }
+    const resp = await POST(uploadUrl, {data: formData});
+    const json = await resp.json();
+    if (!resp.ok) throw new Error(json.errorMessage);
+    if (json.patch_branch && patchBranchField) patchBranchField.value = json.patch_branch;
+    return {link: json.link, rawUrl: json.raw_url};
+  };
+}
+
 export function initArticleEditor() {
   const editForm = document.querySelector<HTMLFormElement>('#article-edit-form');
   if (!editForm) return;
@@ -69,6 +97,7 @@ export function initArticleEditor() {
       previewStyle: 'vertical',
       usageStatistics: false,
       hideModeSwitch: false,  // Allow mode switching
+      uploadImage: createImageUploader(editForm),
     });
 
     // Handle Fork Article button (fork and edit in user's own fork)
@@ -110,11 +139,13 @@ export function initArticleEditor() {
       const $modal = fomanticQuery(submitCRModal);
       const modalTitleInput = submitCRModal.querySelector<HTMLInputElement>('#modal-cr-title');
       const modalDescriptionInput = submitCRModal.querySelector<HTMLTextAreaElement>('#modal-cr-description');
+      const modalCoAuthorsInput = submitCRModal.querySelector<HTMLTextAreaElement>('#modal-cr-co-authors');
 
       preSubmitChangesButton.addEventListener('click', () => {
         // Clear previous values when opening modal
         if (modalTitleInput) modalTitleInput.value = '';
         if (modalDescriptionInput) modalDescriptionInput.value = '';
+        if (modalCoAuthorsInput) modalCoAuthorsInput.value = '';
 
         // Show the modal
         $modal.modal({
@@ -130,17 +161,20 @@ export function initArticleEditor() {
 
             // Get values from modal
             const description = modalDescriptionInput?.value.trim() || '';
+            const coAuthors = modalCoAuthorsInput?.value.trim() || '';
 
             // Set form hidden fields
             const forkAndEditField = document.querySelector<HTMLInputElement>('#fork_and_edit');
             const submitChangeRequestField = document.querySelector<HTMLInputElement>('#submit_change_request');
             const changeRequestTitleField = document.querySelector<HTMLInputElement>('#change_request_title');
             const changeRequestDescriptionField = document.querySelector<HTMLInputElement>('#change_request_description');
+            const coAuthorsField = document.querySelector<HTMLInputElement>('#co_authors');
 
             if (forkAndEditField) forkAndEditField.value = 'false';
             if (submitChangeRequestField) submitChangeRequestField.value = 'true';
             if (changeRequestTitleField) changeRequestTitleField.value = title;
             if (changeRequestDescriptionField) changeRequestDescriptionField.value = description;
+            if (coAuthorsField) coAuthorsField.value = coAuthors;
 
             // Update textarea with editor content before submission
             textarea.value = editor.getMarkdown();
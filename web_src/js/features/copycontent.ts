@@ -59,3 +59,10 @@ export function initCompareModeToggle() {
     window.dispatchEvent(new CustomEvent('repo:compare-mode-toggle'));
   });
 }
+
+export function initGraphFlattenToggle() {
+  registerGlobalEventFunc('click', 'onGraphFlattenToggle', (btn: HTMLElement) => {
+    btn.classList.toggle('primary');
+    window.dispatchEvent(new CustomEvent('repo:graph-flatten-toggle'));
+  });
+}
@@ -2,6 +2,7 @@ import {nextTick, reactive, ref, watch} from 'vue';
 import {initRepoBubbleView} from './repo-bubble-view.ts';
 import {initArticleEditor} from './article-editor.ts';
 import {GET} from '../modules/fetch.ts';
+import {initMarkupAnchorsElement} from '../markup/anchors.ts';
 
 type ViewKey = 'bubble' | 'table' | 'article';
 
@@ -459,7 +460,8 @@ export function initRepoHistory() {
     showArticleContent();
     const url = buildArticleUrl(articleBase, selection, mode);
     try {
-      const response = await GET(url);
+      // Request a fragment, its heading ids are scoped to the fork so they don't collide with the ids of this page
+      const response = await GET(`${url}&fragment=1`);
       if (!response.ok) throw new Error(`Failed with status ${response.status}`);
       const html = await response.text();
       if (articleRequestToken.value !== currentToken) return;
@@ -468,6 +470,9 @@ export function initRepoHistory() {
       const newSection = doc.querySelector('.history-view-section--article');
       if (newSection && articleSection) {
         articleSection.innerHTML = newSection.innerHTML;
+        for (const markupEl of articleSection.querySelectorAll('.markup')) {
+          initMarkupAnchorsElement(markupEl);
+        }
         collectArticleRefs();
         showArticleContent();
         const newMode = articleSection.querySelector<HTMLElement>('#article-view-root')?.getAttribute('data-article-mode');
@@ -12,6 +12,14 @@ export type ToastEditorOptions = {
   usageStatistics?: boolean;
   hideModeSwitch?: boolean;
   toolbarItems?: string[][];
+  // uploadImage stores a pasted or dropped image and resolves to where it can be found.
+  // Without it, images are embedded into the markdown as data URIs.
+  uploadImage?: (blob: Blob) => Promise<UploadedImage>;
+};
+
+export type UploadedImage = {
+  link: string; // the link written into the markdown
+  rawUrl: string; // where the image can be displayed from while editing
 };
 
 // resolveRelativeSrc resolves a relative image path (e.g. "./img/a.png", "../b.png")
@@ -45,6 +53,7 @@ export async function createToastEditor(
       ['image', 'table'],
     ],
   } = options;
+  const {uploadImage} = options;
 
   // Use the existing container from the template
   let container = document.querySelector<HTMLElement>('#toast-editor-container');
@@ -61,6 +70,9 @@ export async function createToastEditor(
 
   // Server-provided raw URL of the file being edited, used to resolve relative image paths.
   const rawFileUrl = textarea.getAttribute('data-raw-file-url') || '';
+  // Uploaded images may live on another branch or in a fork until the edit is submitted,
+  // so they are displayed from the URL returned by the upload instead of rawFileUrl.
+  const uploadedImageUrls = new Map<string, string>();
 
   // Initialize Toast UI Editor
   // eslint-disable-next-line @typescript-eslint/no-redundant-type-constituents -- Editor type has issues
@@ -90,6 +102,18 @@ export async function createToastEditor(
           showFileTooLargeError((blob as File).name || 'image');
           return;
         }
+        if (uploadImage) {
+          (async () => {
+            try {
+              const uploaded = await uploadImage(blob);
+              uploadedImageUrls.set(uploaded.link, uploaded.rawUrl);
+              callback(uploaded.link, (blob as File).name || 'image');
+            } catch (err) {
+              showErrorToast(err instanceof Error && err.message ? err.message : window.config.i18n.editor_image_upload_failed);
+            }
+          })();
+          return;
+        }
         const reader = new FileReader();
         reader.addEventListener('load', () => {
           callback(reader.result as string, (blob as File).name || 'image');
@@ -106,7 +130,9 @@ export async function createToastEditor(
         skipChildren();
         const altText = getChildrenText(node);
         let src = node.destination;
-        if (src && !src.startsWith('data:') && !src.startsWith('http:') && !src.startsWith('https:') && !src.startsWith('/') && !src.startsWith('#')) {
+        if (uploadedImageUrls.has(src)) {
+          src = uploadedImageUrls.get(src);
+        } else if (src && !src.startsWith('data:') && !src.startsWith('http:') && !src.startsWith('https:') && !src.startsWith('/') && !src.startsWith('#')) {
           src = resolveRelativeSrc(src, rawFileUrl);
         }
         return [
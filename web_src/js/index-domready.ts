@@ -44,7 +44,7 @@ import {initRepoBranchButton} from './features/repo-branch.ts';
 import {initCommonOrganization} from './features/common-organization.ts';
 import {initRepoWikiForm} from './features/repo-wiki.ts';
 import {initRepository, initBranchSelectorTabs} from './features/repo-legacy.ts';
-import {initCopyContent, initCompareModeToggle} from './features/copycontent.ts';
+import {initCopyContent, initCompareModeToggle, initGraphFlattenToggle} from './features/copycontent.ts';
 import {initCaptcha} from './features/captcha.ts';
 import {initRepositoryActionView} from './features/repo-actions.ts';
 import {initGlobalTooltips} from './modules/tippy.ts';
@@ -111,6 +111,7 @@ const initPerformanceTracer = callInitFunctions([
   initFindFileInRepo,
   initCopyContent,
   initCompareModeToggle,
+  initGraphFlattenToggle,
 
   initAdminCommon,
   initAdminUserListSearchForm,
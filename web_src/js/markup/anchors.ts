@@ -27,40 +27,45 @@ function scrollToAnchor(encodedId?: string): void {
   el?.scrollIntoView();
 }
 
+// set up the anchors of a markup element, markup swapped into the page later needs this too
+export function initMarkupAnchorsElement(markupEl: Element): void {
+  // create link icons for markup headings, the resulting link href will remove `user-content-`
+  for (const heading of markupEl.querySelectorAll('h1, h2, h3, h4, h5, h6')) {
+    const a = document.createElement('a');
+    a.classList.add('anchor');
+    a.setAttribute('href', `#${encodeURIComponent(removePrefix(heading.id))}`);
+    a.innerHTML = svg('octicon-link');
+    heading.prepend(a);
+  }
+
+  // remove `user-content-` prefix from links so they don't show in url bar when clicked
+  for (const a of markupEl.querySelectorAll<HTMLAnchorElement>('a[href^="#"]')) {
+    const href = a.getAttribute('href');
+    if (!href.startsWith('#user-content-')) continue;
+    a.setAttribute('href', `#${removePrefix(href.substring(1))}`);
+  }
+
+  // add `user-content-` prefix to user-generated `a[name]` link targets
+  // TODO: this prefix should be added in backend instead
+  for (const a of markupEl.querySelectorAll<HTMLAnchorElement>('a[name]')) {
+    const name = a.getAttribute('name');
+    if (!name) continue;
+    a.setAttribute('name', addPrefix(name));
+  }
+
+  for (const a of markupEl.querySelectorAll<HTMLAnchorElement>('a[href^="#"]')) {
+    a.addEventListener('click', (e) => {
+      scrollToAnchor((e.currentTarget as HTMLAnchorElement).getAttribute('href')?.substring(1));
+    });
+  }
+}
+
 export function initMarkupAnchors(): void {
   const markupEls = document.querySelectorAll('.markup');
   if (!markupEls.length) return;
 
   for (const markupEl of markupEls) {
-    // create link icons for markup headings, the resulting link href will remove `user-content-`
-    for (const heading of markupEl.querySelectorAll('h1, h2, h3, h4, h5, h6')) {
-      const a = document.createElement('a');
-      a.classList.add('anchor');
-      a.setAttribute('href', `#${encodeURIComponent(removePrefix(heading.id))}`);
-      a.innerHTML = svg('octicon-link');
-      heading.prepend(a);
-    }
-
-    // remove `user-content-` prefix from links so they don't show in url bar when clicked
-    for (const a of markupEl.querySelectorAll<HTMLAnchorElement>('a[href^="#"]')) {
-      const href = a.getAttribute('href');
-      if (!href.startsWith('#user-content-')) continue;
-      a.setAttribute('href', `#${removePrefix(href.substring(1))}`);
-    }
-
-    // add `user-content-` prefix to user-generated `a[name]` link targets
-    // TODO: this prefix should be added in backend instead
-    for (const a of markupEl.querySelectorAll<HTMLAnchorElement>('a[name]')) {
-      const name = a.getAttribute('name');
-      if (!name) continue;
-      a.setAttribute('name', addPrefix(name));
-    }
-
-    for (const a of markupEl.querySelectorAll<HTMLAnchorElement>('a[href^="#"]')) {
-      a.addEventListener('click', (e) => {
-        scrollToAnchor((e.currentTarget as HTMLAnchorElement).getAttribute('href')?.substring(1));
-      });
-    }
+    initMarkupAnchorsElement(markupEl);
   }
 
   // scroll to anchor unless the browser has already scrolled somewhere during page load